@@ -12,6 +12,7 @@ import (
 	"github.com/GlebMoskalev/go-todo-api/internal/app"
 	"log/slog"
 	"os"
+	_ "time/tzdata"
 )
 
 var flagConfig = flag.String("config", "./config/local.yaml", "path to the config file")
@@ -15,9 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
+        "/auth/app-passwords": {
             "post": {
-                "description": "Authenticates a user and returns access and refresh tokens.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a password for a client that signs in with HTTP Basic auth, such as a CalDAV app. The account password is not accepted there.\nThe password is only returned here. It is listed and revoked as a session, and logging out everywhere or changing the password ends it too.",
                 "consumes": [
                     "application/json"
                 ],
@@ -27,33 +32,33 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "User login",
+                "summary": "Create app password",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "user",
+                        "description": "Client name",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.UserRequest"
+                            "$ref": "#/definitions/swagger.AppPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Login successful",
+                    "201": {
+                        "description": "Successfully create",
                         "schema": {
-                            "$ref": "#/definitions/swagger.LoginResponse"
+                            "$ref": "#/definitions/swagger.CreateAppPasswordResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid request data or validation error",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "User not authenticated or invalid token",
                         "schema": {
                             "$ref": "#/definitions/swagger.UnauthorizedResponse"
                         }
@@ -67,9 +72,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
+        "/auth/login": {
             "post": {
-                "description": "Refreshes tokens using a valid refresh token.",
+                "description": "Authenticates a user and returns access and refresh tokens.",
                 "consumes": [
                     "application/json"
                 ],
@@ -79,23 +84,23 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Refresh access and refresh tokens",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "refresh_token",
+                        "description": "User login credentials",
+                        "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.RefreshRequest"
+                            "$ref": "#/definitions/swagger.UserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tokens refreshed",
+                        "description": "Login successful",
                         "schema": {
-                            "$ref": "#/definitions/swagger.RefreshResponse"
+                            "$ref": "#/definitions/swagger.LoginResponse"
                         }
                     },
                     "400": {
@@ -105,17 +110,23 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Invalid refresh token",
+                        "description": "Invalid credentials",
                         "schema": {
                             "$ref": "#/definitions/swagger.UnauthorizedResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/auth/register": {
+        "/auth/logout": {
             "post": {
-                "description": "Creates a new user with the provided username and password.",
+                "description": "Revokes the refresh token. The access token sent in the Authorization header, if any, is revoked too.",
                 "consumes": [
                     "application/json"
                 ],
@@ -125,35 +136,35 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Register a new user",
+                "summary": "Log out",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "user",
+                        "description": "Refresh token",
+                        "name": "refresh_token",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.UserRequest"
+                            "$ref": "#/definitions/swagger.RefreshRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User successfully created",
+                    "200": {
+                        "description": "Successfully logout",
                         "schema": {
-                            "$ref": "#/definitions/swagger.SuccessRegisterResponse"
+                            "$ref": "#/definitions/swagger.LogoutResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data or validation error",
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Username already exists",
+                    "401": {
+                        "description": "Invalid refresh token",
                         "schema": {
-                            "$ref": "#/definitions/swagger.ConflictResponse"
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
                         }
                     },
                     "500": {
@@ -165,63 +176,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/todos": {
-            "get": {
+        "/auth/logout-all": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a paginated list of todos for the authenticated user with optional filters.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Revokes every refresh and access token of the authenticated user, including the one used for this request.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
-                ],
-                "summary": "Get all todos",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by due date (YYYY-MM-DD)",
-                        "name": "due_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by tags (comma-separated)",
-                        "name": "tags",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Log out everywhere",
                 "responses": {
                     "200": {
-                        "description": "Todos successfully retrieved",
+                        "description": "Successfully logout",
                         "schema": {
-                            "$ref": "#/definitions/swagger.ListTodoResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid query parameters",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ErrorResponse"
+                            "$ref": "#/definitions/swagger.LogoutResponse"
                         }
                     },
                     "401": {
@@ -231,20 +205,22 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Something went wrong, please try again later",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/swagger.ServerErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/password": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing todo for the authenticated user.",
+                "description": "Sets a new password after confirming the current one. Every other session of the user is logged out and pending password reset links stop working.",
                 "consumes": [
                     "application/json"
                 ],
@@ -252,25 +228,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "auth"
                 ],
-                "summary": "Update a todo",
+                "summary": "Change password",
                 "parameters": [
                     {
-                        "description": "Updated todo data",
-                        "name": "todo",
+                        "description": "Current and new password",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.TodoRequest"
+                            "$ref": "#/definitions/swagger.PasswordChangeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Todo successfully updated",
+                        "description": "Password successfully changed",
                         "schema": {
-                            "$ref": "#/definitions/swagger.UpdateResponse"
+                            "$ref": "#/definitions/swagger.PasswordChangeResponse"
                         }
                     },
                     "400": {
@@ -285,8 +261,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.UnauthorizedResponse"
                         }
                     },
+                    "403": {
+                        "description": "Wrong password",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Todo not found",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/swagger.NotFoundResponse"
                         }
@@ -298,14 +280,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/password/forgot": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Creates a new todo for the authenticated user.",
+                "description": "Mails a single-use password reset link to the email address of the account. The response is the same whether or not the account exists or has an email address.",
                 "consumes": [
                     "application/json"
                 ],
@@ -313,25 +292,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "auth"
                 ],
-                "summary": "Create a todo",
+                "summary": "Request password reset",
                 "parameters": [
                     {
-                        "description": "Todo data",
-                        "name": "todo",
+                        "description": "Username",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swagger.TodoRequest"
+                            "$ref": "#/definitions/swagger.PasswordForgotRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Todo successfully created",
+                    "200": {
+                        "description": "Reset link sent if the account exists",
                         "schema": {
-                            "$ref": "#/definitions/swagger.CreateTodoResponse"
+                            "$ref": "#/definitions/swagger.PasswordForgotResponse"
                         }
                     },
                     "400": {
@@ -340,12 +319,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "User not authenticated or invalid token",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -355,14 +328,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/todos/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves a todo by its ID for the authenticated user.",
+        "/auth/password/reset": {
+            "post": {
+                "description": "Sets a new password with the token from a password reset link. The token works once; every session of the user is logged out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -370,41 +338,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "auth"
                 ],
-                "summary": "Get",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Todo ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.PasswordResetRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully create",
+                        "description": "Password successfully reset",
                         "schema": {
-                            "$ref": "#/definitions/swagger.GetTodoResponse"
+                            "$ref": "#/definitions/swagger.PasswordResetResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.InvalidIDResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "User not authenticated or invalid token",
+                        "description": "Invalid request data, validation error or invalid reset token",
                         "schema": {
-                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Todo not found",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                            "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "500": {
@@ -414,14 +372,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Deletes a todo by its ID for the authenticated user.",
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Refreshes tokens using a valid refresh token.",
                 "consumes": [
                     "application/json"
                 ],
@@ -429,411 +384,8503 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "auth"
                 ],
-                "summary": "Delete a todo",
+                "summary": "Refresh access and refresh tokens",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Todo ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully delete",
+                        "description": "Tokens refreshed",
                         "schema": {
-                            "$ref": "#/definitions/swagger.DeleteResponse"
+                            "$ref": "#/definitions/swagger.RefreshResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Invalid request data",
                         "schema": {
-                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                            "$ref": "#/definitions/swagger.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "User not authenticated or invalid token",
+                        "description": "Invalid refresh token",
                         "schema": {
                             "$ref": "#/definitions/swagger.UnauthorizedResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Todo not found",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.NotFoundResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/swagger.ServerErrorResponse"
-                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "swagger.ConflictResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 409
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Username already exists"
-                }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Creates a new user with the provided username and password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.SuccessRegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Username already exists",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ConflictResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the sessions of the authenticated user, one per login, the most recently used first. The session of the access token used is marked current.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List sessions",
+                "responses": {
+                    "200": {
+                        "description": "Sessions successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListSessionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logs the authenticated user out of one session, revoking its refresh and access tokens.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session successfully revoked",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.RevokeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dispatches each sub-request to the API as if it was sent on its own, with the caller's authorization. Paths are relative to the API base path.\nSub-requests run concurrently, so one must not depend on another. The responses are returned in the order of the requests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "batch"
+                ],
+                "summary": "Batch requests",
+                "parameters": [
+                    {
+                        "description": "Sub-requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully batch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the authenticated user's calendar feeds. Feed tokens are only shown when a feed is created.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feed"
+                ],
+                "summary": "List calendar feeds",
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListCalendarFeedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a secret URL serving your personal todos as iCalendar, which calendar clients can subscribe to without a Bearer token.\nThe token and URL are only returned here; delete the feed to revoke them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feed"
+                ],
+                "summary": "Create a calendar feed",
+                "parameters": [
+                    {
+                        "description": "Feed name",
+                        "name": "feed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CalendarFeedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateCalendarFeedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a calendar feed; its URL stops working immediately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feed"
+                ],
+                "summary": "Delete a calendar feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Feed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/{token}.ics": {
+            "get": {
+                "description": "Returns the personal todos of the feed's owner as RFC 5545 VTODO components. The token in the URL authenticates the request.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "feed"
+                ],
+                "summary": "Get a calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{token}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Joins the workspace an invitation token belongs to, with the role the invitation grants. Each token can be used once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Accept an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully accept",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AcceptInvitationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invitation not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already a member",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the authenticated user's profile, including the time zone used for agendas and stats.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the authenticated user after confirming their password. Every refresh token is revoked. Todos and projects in workspaces with other members are handed to the admin who joined first; the last admin of such a workspace must appoint another admin first. The user's other todos, projects, tags, templates, comments, time entries, calendar feeds, attachments and the history of their todos are removed. Changes the user made to other users' todos stay in those todos' history without naming them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Delete account",
+                "parameters": [
+                    {
+                        "description": "Password confirmation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AccountDeletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account successfully deleted",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Wrong password",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is the last admin of a workspace",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the authenticated user's profile. Fields left out are not changed. Changing the email address requires the current password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Update profile",
+                "parameters": [
+                    {
+                        "description": "Profile changes",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ProfileUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile successfully updated",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Wrong current password",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a ZIP archive with the profile, every owned todo, the refresh tokens (without their values), the comments written, the history of the owned todos and of the changes made by the user, the sessions with their device, user agent and IP address, the tracked time entries, the templates and the tags with their metadata, each as a JSON file.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Export account data",
+                "responses": {
+                    "200": {
+                        "description": "ZIP archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a paginated list of the authenticated user's notifications, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Get notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only list unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListNotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks one of the authenticated user's notifications as read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a paginated list of the authenticated user's personal projects, or of the projects in the active workspace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Get all projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the projects of this workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Projects successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong, please try again later",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a project the authenticated user can edit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Update a project",
+                "parameters": [
+                    {
+                        "description": "Updated project data",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project successfully updated",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new project owned by the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Create a project",
+                "parameters": [
+                    {
+                        "description": "Project data",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ProjectRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Create the project in this workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Project successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No write access to the workspace",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/shared-with-me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves projects shared with the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Get projects shared with me",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Projects successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListSharedProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong, please try again later",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a project the authenticated user owns or has been shared.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Get a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GetProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a project. Todos in the project are kept and detached from it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Delete a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/shares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the users a project is shared with and their permission levels.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "List project shares",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Shares a project, and every todo in it, with another user as viewer, editor or owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Share a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Share data",
+                        "name": "share",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully share",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/shares/{userID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a user's access to a project. Takes effect immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Revoke a project share",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully revoke",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.RevokeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Project or share not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/time": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sums the time the authenticated user logged in entries started between from and to, both inclusive, per todo, tag or project.\nfrom defaults to the first day of the current month and to defaults to today. A todo with several tags counts towards each of them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time"
+                ],
+                "summary": "Get a time report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "todo",
+                        "description": "Group by todo, tag or project",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TimeReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Counts the todos the authenticated user created and completed per day or week, with open and overdue counts, the average hours from creation to completion, a per tag breakdown and completion streaks.\nWithout from and to the statistics cover the current and the previous three weeks. Days are days in the user's time zone and weeks start on Monday.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "week",
+                        "description": "Bucket size, day or week",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.StatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the tags used on the authenticated user's todos, and those they set metadata for, with the number of todos carrying each.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Get tags",
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListTagResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces each source tag with the target tag on all of the authenticated user's todos. The target keeps its metadata; the metadata of the sources is removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Merge tags",
+                "parameters": [
+                    {
+                        "description": "Tags to merge",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TagMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{name}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renames a tag on all of the authenticated user's todos and/or sets its color and description. Renaming to an existing tag is refused; merge the tags instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Update a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New name and metadata",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TagUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tag already exists",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a paginated list of the authenticated user's templates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Get templates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces one of the authenticated user's templates. Todos created from it earlier are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Update a template",
+                "parameters": [
+                    {
+                        "description": "Updated template data",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a template: a todo with subtasks and checklists whose text may use {{variables}} and whose due dates are days after the start date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Create a template",
+                "parameters": [
+                    {
+                        "description": "Template data",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves one of the authenticated user's templates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Get a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GetTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes one of the authenticated user's templates. Todos created from it are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Delete a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/instantiate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates the todo of a template with all of its subtasks and checklists in one transaction. {{variables}} are replaced with the given values and due dates count from start_date, today by default.\nThe returned undo token deletes every created todo again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "template"
+                ],
+                "summary": "Instantiate a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variables, start date and project",
+                        "name": "instantiation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InstantiateRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Create the todos in this workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InstantiateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or missing variables",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No write access to the project or workspace",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template, project or workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timer": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the authenticated user's running timer, if any.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time"
+                ],
+                "summary": "Get the running timer",
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TimeEntryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No timer is running",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a paginated list of todos for the authenticated user with optional filters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get all todos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by due date (YYYY-MM-DD)",
+                        "name": "due_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the todos of a project instead of your own",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the subtasks of a todo instead of your own todos",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assignee ID, or 'me' for every todo assigned to you",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the todos of this workspace instead of your personal ones",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todos successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo, project or workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong, please try again later",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing todo for the authenticated user. The returned undo token restores the previous values.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Update a todo",
+                "parameters": [
+                    {
+                        "description": "Updated todo data",
+                        "name": "todo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TodoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todo successfully updated",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new todo for the authenticated user, or a subtask when parent_id is set. The returned undo token deletes it again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Create a todo",
+                "parameters": [
+                    {
+                        "description": "Todo data",
+                        "name": "todo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TodoRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Create the todo in this workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Todo successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No write access to the parent todo, project or workspace",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Parent todo, project or workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the todos matching the filters as RFC 5545 VTODO components, for calendar clients such as Thunderbird or Apple Calendar. Tags become CATEGORIES and recurring todos carry an RRULE.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Export todos as iCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by due date (YYYY-MM-DD)",
+                        "name": "due_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export the todos of a project instead of your own",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export the subtasks of a todo instead of your own todos",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assignee ID, or 'me' for every todo assigned to you",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export the todos of this workspace instead of your personal ones",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo, project or workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong, please try again later",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/agenda": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists todos per day from 'from' to 'to', both inclusive, in the user's time zone. Days without todos are included, recurring todos are listed on each occurrence and open todos due before today are listed on today as overdue. Without 'from' the agenda starts today, without 'to' it covers a week; it may cover at most 92 days.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get agenda",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the todos of a project instead of your own",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the subtasks of a todo instead of your own todos",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assignee ID, or 'me' for every todo assigned to you",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "List the todos of this workspace instead of your personal ones",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Agenda successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AgendaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo, project or workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong, please try again later",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies create, update, delete, complete, retag and move operations in order, in a single transaction.\nIn atomic mode (the default) either every operation is applied or none is; in best_effort mode failed operations are skipped.\nEvery operation gets its own status. The returned undo token reverts all applied operations at once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Bulk operations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace for created todos",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Operations to apply",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.BulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully apply",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Partially apply",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Bulk operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.BulkFailedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams every todo matching the filters as JSON, NDJSON, CSV, a Markdown checklist or todo.txt, without pagination. The JSON, CSV and todo.txt files can be imported again with POST /todos/import.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Export todos as a file",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "ndjson",
+                            "csv",
+                            "markdown",
+                            "todotxt"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by due date (YYYY-MM-DD)",
+                        "name": "due_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tags (comma-separated)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export the todos of a project instead of your own",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export the subtasks of a todo instead of your own todos",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by assignee ID, or 'me' for every todo assigned to you",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export the todos of this workspace instead of your personal ones",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo, project or workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong, please try again later",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates todos from CSV, JSON as returned by GET /todos, todo.txt or a Markdown checklist sent as the request body.\nCSV needs a header row; columns are matched to the fields title, description, tags, due_date, completed and project by name unless mapped with the columns parameter.\ntodo.txt lines keep +project, @contexts as tags, (A) priorities as priority:A tags and due:YYYY-MM-DD. Indented Markdown items become subtasks.\nTodos without a due date are due today. Every line is validated first; if any line has errors nothing is imported and the errors are returned per line.\nThe returned undo token removes every imported todo at once.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Import todos",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "todotxt",
+                            "markdown"
+                        ],
+                        "type": "string",
+                        "description": "File format, taken from the Content-Type when omitted",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "title:Task,due_date:Due date",
+                        "description": "CSV column mapping as field:column pairs",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Import into this workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "File to import",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully validate",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ImportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Successfully import",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unreadable file or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No write access to the workspace",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Import failed",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ImportFailedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/quick": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reads a todo from free text such as \"Pay rent every month on the 1st #finance !high\" and creates it. #tag adds a tag and !high, !medium or !low a priority:\u003clevel\u003e tag.\nDue dates such as today, tomorrow, friday, next friday, in 3 days, next week, on the 1st, april 30 or 2025-04-30 are relative to the user's time zone; without one the todo is due today.\nRecurrences such as every day, every 2 weeks, every other month, every monday or monthly may end with until and a date. The remaining words are the title and description.\nWith preview=true the parsed todo is only returned, not saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Quick-add a todo",
+                "parameters": [
+                    {
+                        "description": "Text to parse",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.QuickAddRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only parse the text",
+                        "name": "preview",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Create the todo in this workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully parse",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.QuickAddResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.QuickAddResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or the parsed todo does not validate",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No write access to the workspace",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/shared-with-me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves todos shared with the authenticated user directly or through a shared project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get todos shared with me",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Todos successfully retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListSharedTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Something went wrong, please try again later",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a todo by its ID for the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GetTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a todo by its ID for the authenticated user. The returned undo token brings the todo back, without its comments, shares and attachments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Delete a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/assignee": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns a todo to a user who can see it, or unassigns it when assignee_id is null. The new assignee is notified. The returned undo token restores the previous assignee.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Assign a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assignee",
+                        "name": "assignee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.AssigneeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or the assignee cannot see the todo",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the metadata of all files attached to a todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Get todo attachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListAttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads a file as multipart/form-data in the \"file\" field. The content type is detected from the file and must be one of the allowed types.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Upload an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateAttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or form data",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File is too large",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/attachments/{attachmentID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the attached file. The ETag is the SHA-256 checksum of the content.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Download an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes an attachment and its stored file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Delete an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the comments of a todo, oldest first. Pass the next_cursor of a page as cursor to get the next one. Deleted comments keep their place with an empty body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Get todo comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of comments per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a markdown comment to a todo. Users mentioned as @username who can see the todo are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Comment on a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/comments/{commentID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edits a comment. Only its author can edit it. Users newly mentioned by the edit are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Edit a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a comment. Authors can delete their own comments and todo owners can delete any comment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/completion": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a todo as done, or reopens it when completed is false. The returned undo token restores the previous state.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Complete a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Completion state",
+                        "name": "completion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CompletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateTodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves who changed a todo, when, and the before and after value of every changed field, oldest first. Pass the next_cursor of a page as cursor to get the next one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get todo history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of entries per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListTodoHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/shares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the users a todo is shared with and their permission levels.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "List todo shares",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Shares a todo with another user as viewer, editor or owner. Sharing again changes the permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Share a todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Share data",
+                        "name": "share",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully share",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/shares/{userID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a user's access to a todo. Takes effect immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Revoke a todo share",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully revoke",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.RevokeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or share not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/time-entries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the time everyone logged on a todo, most recent first. Running timers have no ended_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time"
+                ],
+                "summary": "Get time entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListTimeEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logs time spent on a todo without a timer. Entries must have ended and be at most 24 hours long.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time"
+                ],
+                "summary": "Log time",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time entry data",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TimeEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateTimeEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No write access to the todo",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/time-entries/{entryID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes one of the authenticated user's time entries. Deleting a running timer discards it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time"
+                ],
+                "summary": "Delete a time entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time entry ID",
+                        "name": "entryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Time entry of another user",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo or time entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/timer/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts tracking time on a todo. Each user can have only one running timer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time"
+                ],
+                "summary": "Start a timer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully start",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TimeEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No write access to the todo",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Todo not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another timer is already running",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ConflictResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/timer/stop": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops the authenticated user's running timer on a todo and returns the finished time entry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "time"
+                ],
+                "summary": "Stop a timer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully stop",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.TimeEntryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No timer is running on the todo",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/undo/{token}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reverts the change an undo token was returned for. Each token works once, only for a short time after the change, and only if the todo was not changed again since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "undo"
+                ],
+                "summary": "Undo a todo change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Undo token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully undo",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UndoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid undo token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Todo was changed since",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Undo token expired or already used",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the workspaces the authenticated user is a member of, with their role in each.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "List workspaces",
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListWorkspaceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new workspace. The authenticated user becomes its first admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Create a workspace",
+                "parameters": [
+                    {
+                        "description": "Workspace data",
+                        "name": "workspace",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.WorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Workspace successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateWorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspaceID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a workspace the authenticated user is a member of.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Get a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.GetWorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a workspace together with all of its todos, projects, members and invitations. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Delete a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.DeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspaceID}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the pending, unexpired invitations of a workspace. Tokens are not included. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "List invitations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a single-use invitation token granting the given role. The token is only returned once. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Invite to a workspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation data",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully create",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.CreateInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspaceID}/invitations/{invitationID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a pending invitation so its token can no longer be accepted. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Revoke an invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "invitationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully revoke",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.RevokeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or invitation not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspaceID}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the members of a workspace and their roles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "List workspace members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetch",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ListMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.InvalidIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspaceID}/members/{userID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the role of a workspace member. Admins only. The last admin cannot be demoted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Change a member's role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swagger.MemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, validation error or last admin",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a member from a workspace. Admins can remove anyone; every member can remove themselves to leave. The last admin cannot leave.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Remove a member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workspace ID",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully remove",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.RemoveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or last admin",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.UnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/swagger.ServerErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "swagger.AcceptInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.WorkspaceResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully accept"
+                }
+            }
+        },
+        "swagger.AccountDeletionRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "Secret123"
+                }
+            }
+        },
+        "swagger.AgendaData": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.AgendaDay"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-04-07"
+                },
+                "today": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                }
+            }
+        },
+        "swagger.AgendaDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "todos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.AgendaItem"
+                    }
+                }
+            }
+        },
+        "swagger.AgendaItem": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                },
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.ChecklistItem"
+                    }
+                },
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-28T09:15:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Get milk, bread, and eggs"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "occurrence": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "overdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/swagger.Recurrence"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shopping",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Buy groceries"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "swagger.AgendaResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.AgendaData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.AppPassword": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "DAVx5 on Pixel 8"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "kq3B8cWz1yXo0p7Tn2LrVf5aHs9dJm4E"
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77"
+                }
+            }
+        },
+        "swagger.AppPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "DAVx5 on Pixel 8"
+                }
+            }
+        },
+        "swagger.AssigneeRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                }
+            }
+        },
+        "swagger.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "receipt.pdf"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "uploader_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                }
+            }
+        },
+        "swagger.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.SubRequest"
+                    }
+                }
+            }
+        },
+        "swagger.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.SubResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully batch"
+                }
+            }
+        },
+        "swagger.BulkData": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.BulkResult"
+                    }
+                },
+                "undo_expires_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:30Z"
+                },
+                "undo_token": {
+                    "type": "string",
+                    "example": "6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"
+                }
+            }
+        },
+        "swagger.BulkFailedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 422
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.BulkData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bulk operation failed"
+                }
+            }
+        },
+        "swagger.BulkOperation": {
+            "type": "object",
+            "properties": {
+                "add_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "urgent"
+                    ]
+                },
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "complete",
+                        "retag",
+                        "move"
+                    ],
+                    "example": "retag"
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "remove_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "someday"
+                    ]
+                },
+                "todo": {
+                    "$ref": "#/definitions/swagger.TodoRequest"
+                }
+            }
+        },
+        "swagger.BulkRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ],
+                    "example": "atomic"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.BulkOperation"
+                    }
+                }
+            }
+        },
+        "swagger.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.BulkData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully apply"
+                }
+            }
+        },
+        "swagger.BulkResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "op": {
+                    "type": "string",
+                    "example": "retag"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "swagger.CalendarFeed": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-04-02T06:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Thunderbird"
+                }
+            }
+        },
+        "swagger.CalendarFeedRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Thunderbird"
+                }
+            }
+        },
+        "swagger.ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Tag the release"
+                }
+            }
+        },
+        "swagger.CommentRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Can you pick up oat milk too, @jane_doe?"
+                }
+            }
+        },
+        "swagger.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "john_doe"
+                },
+                "author_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Can you pick up oat milk too, @jane_doe?"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "edited_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:05:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "swagger.CompletionRequest": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "swagger.ConflictResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Username already exists"
+                }
+            }
+        },
+        "swagger.CreateAppPasswordResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.AppPassword"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateAttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.AttachmentResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateCalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.CreatedCalendarFeed"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateCommentResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.CommentResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.createdInvitationData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateProjectResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.createResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.createResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateTimeEntryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.TimeEntryData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateTodoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.createTodoResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreateWorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.createResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.CreatedCalendarFeed": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-04-02T06:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Thunderbird"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "kQ2m7Zr0c1xW5bT9yV3nH8pL4sD6fA1gJ0eU2iO7qRk"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://todo.example.com/api/v1/feeds/kQ2m7Zr0c1xW5bT9yV3nH8pL4sD6fA1gJ0eU2iO7qRk.ics"
+                }
+            }
+        },
+        "swagger.DeleteResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully delete"
+                }
+            }
+        },
+        "swagger.DeleteTodoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.UndoData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully delete"
+                }
+            }
+        },
+        "swagger.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid request data"
+                }
+            }
+        },
+        "swagger.FieldChangeResponse": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string",
+                    "example": "2025-04-08"
+                },
+                "before": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "due_date"
+                }
+            }
+        },
+        "swagger.ForbiddenResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 403
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Permission denied"
+                }
+            }
+        },
+        "swagger.GetProjectResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.ProjectResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.GetTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.TemplateResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.GetTodoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.TodoResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.GetWorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.WorkspaceResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ImportData": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.ImportLineError"
+                    }
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        41,
+                        42
+                    ]
+                },
+                "imported": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "undo_expires_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:30Z"
+                },
+                "undo_token": {
+                    "type": "string",
+                    "example": "6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"
+                }
+            }
+        },
+        "swagger.ImportFailedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 422
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.ImportData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Import failed"
+                }
+            }
+        },
+        "swagger.ImportLineError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Field 'title' must be at least 3 characters"
+                    ]
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "swagger.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.ImportData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully import"
+                }
+            }
+        },
+        "swagger.InstantiateRequest": {
+            "type": "object",
+            "properties": {
+                "project_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "swagger.InstantiateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.instantiateData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.InvalidIDResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid ID"
+                }
+            }
+        },
+        "swagger.InvitationRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in_hours": {
+                    "type": "integer",
+                    "example": 72
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member",
+                        "guest"
+                    ],
+                    "example": "member"
+                }
+            }
+        },
+        "swagger.ListAttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.AttachmentResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListCalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.CalendarFeed"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListCommentResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.commentPage"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.invitationData"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListMemberResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.memberData"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListNotificationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.NotificationResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swagger.ListProjectResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.ProjectResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swagger.ListSessionResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.SessionResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListShareResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.shareData"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListSharedProjectResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.SharedProjectResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swagger.ListSharedTodoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.SharedTodoResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swagger.ListTagResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TagData"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TemplateResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swagger.ListTimeEntryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TimeEntryData"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swagger.ListTodoHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.todoHistoryPage"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ListTodoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TodoResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swagger.ListWorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.WorkspaceResponse"
+                    }
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.tokenResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Login successful"
+                }
+            }
+        },
+        "swagger.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully logout"
+                }
+            }
+        },
+        "swagger.MemberRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member",
+                        "guest"
+                    ],
+                    "example": "member"
+                }
+            }
+        },
+        "swagger.NotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Todo not found"
+                }
+            }
+        },
+        "swagger.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "message": {
+                    "type": "string",
+                    "example": "You were assigned to \"Buy groceries\""
+                },
+                "read": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "type": {
+                    "type": "string",
+                    "example": "assigned"
+                }
+            }
+        },
+        "swagger.PasswordChangeRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "Secret123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "Better456"
+                }
+            }
+        },
+        "swagger.PasswordChangeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Password successfully changed"
+                }
+            }
+        },
+        "swagger.PasswordForgotRequest": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
+        },
+        "swagger.PasswordForgotResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "If the account exists and has an email address, a password reset link has been sent"
+                }
+            }
+        },
+        "swagger.PasswordResetRequest": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "example": "Better456"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "q3v9Xc0bTn8uRk2mW5yLzA1sD4fG7hJ6eP0oI9uY8tR"
+                }
+            }
+        },
+        "swagger.PasswordResetResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Password successfully reset"
+                }
+            }
+        },
+        "swagger.Profile": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
+        },
+        "swagger.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.Profile"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.ProfileUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "Secret123"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                }
+            }
+        },
+        "swagger.ProjectRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Everything needed for the next release"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Release 2.0"
+                }
+            }
+        },
+        "swagger.ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Everything needed for the next release"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Release 2.0"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "swagger.QuickAddData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "preview": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "todo": {
+                    "$ref": "#/definitions/swagger.TodoResponse"
+                },
+                "undo_expires_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:30Z"
+                },
+                "undo_token": {
+                    "type": "string",
+                    "example": "6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"
+                }
+            }
+        },
+        "swagger.QuickAddRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Pay rent every month on the 1st #finance !high"
+                }
+            }
+        },
+        "swagger.QuickAddResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.QuickAddData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully create"
+                }
+            }
+        },
+        "swagger.Recurrence": {
+            "type": "object",
+            "properties": {
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ],
+                    "example": "weekly"
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                },
+                "until": {
+                    "type": "string",
+                    "example": "2025-12-31"
+                }
+            }
+        },
+        "swagger.RefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "swagger.RefreshResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.tokenResponse"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Tokens refreshed"
+                }
+            }
+        },
+        "swagger.RemoveResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully remove"
+                }
+            }
+        },
+        "swagger.RevokeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully revoke"
+                }
+            }
+        },
+        "swagger.ServerErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Something went wrong, please try again later"
+                }
+            }
+        },
+        "swagger.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "app_password": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-04-01T09:12:44Z"
+                },
+                "current": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "device_name": {
+                    "type": "string",
+                    "example": "Firefox on Windows"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-04-02T17:03:10Z"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0"
+                }
+            }
+        },
+        "swagger.ShareRequest": {
+            "type": "object",
+            "properties": {
+                "permission": {
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "editor",
+                        "owner"
+                    ],
+                    "example": "editor"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "jane_doe"
+                }
+            }
+        },
+        "swagger.ShareResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.shareData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully share"
+                }
+            }
+        },
+        "swagger.SharedProjectResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Everything needed for the next release"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Release 2.0"
+                },
+                "owner": {
+                    "type": "string",
+                    "example": "jane_doe"
+                },
+                "permission": {
+                    "type": "string",
+                    "example": "editor"
+                }
+            }
+        },
+        "swagger.SharedTodoResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Get milk, bread, and eggs"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "owner": {
+                    "type": "string",
+                    "example": "jane_doe"
+                },
+                "permission": {
+                    "type": "string",
+                    "example": "viewer"
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shopping",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Buy groceries"
+                }
+            }
+        },
+        "swagger.StatsBucket": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2025-03-31"
+                }
+            }
+        },
+        "swagger.StatsData": {
+            "type": "object",
+            "properties": {
+                "average_lead_time_hours": {
+                    "type": "number",
+                    "example": 31.5
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.StatsBucket"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-03-10"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "week"
+                },
+                "open": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "overdue": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "streak": {
+                    "$ref": "#/definitions/swagger.Streak"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TagStats"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-04-02"
+                }
+            }
+        },
+        "swagger.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.StatsData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.Streak": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "longest": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "swagger.SubRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "POST",
+                        "PUT",
+                        "PATCH",
+                        "DELETE"
+                    ],
+                    "example": "PUT"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/todos/12/completion"
+                }
+            }
+        },
+        "swagger.SubResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "swagger.SuccessRegisterResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.UserData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User successfully created"
+                }
+            }
+        },
+        "swagger.TagData": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#1e88e5"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Anything for the day job"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "work"
+                }
+            }
+        },
+        "swagger.TagMergeRequest": {
+            "type": "object",
+            "properties": {
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "wrk",
+                        "job"
+                    ]
+                },
+                "target": {
+                    "type": "string",
+                    "example": "work"
+                }
+            }
+        },
+        "swagger.TagResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.TagData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully update"
+                }
+            }
+        },
+        "swagger.TagStats": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "overdue": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "work"
+                }
+            }
+        },
+        "swagger.TagUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#1e88e5"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Anything for the day job"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "work"
+                }
+            }
+        },
+        "swagger.TemplateRequest": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Bump version to {{version}}",
+                        "Update changelog"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Ship version {{version}} to production"
+                },
+                "due_offset": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Release checklist"
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TemplateTask"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "release"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Release {{version}}"
+                }
             }
         },
-        "swagger.CreateTodoResponse": {
+        "swagger.TemplateResponse": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Bump version to {{version}}",
+                        "Update changelog"
+                    ]
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-01T09:30:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Ship version {{version}} to production"
+                },
+                "due_offset": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Release checklist"
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TemplateTask"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "release"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Release {{version}}"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-02T10:00:00Z"
+                }
+            }
+        },
+        "swagger.TemplateTask": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Bump version to {{version}}",
+                        "Update changelog"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Ship version {{version}} to production"
+                },
+                "due_offset": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TemplateTask"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "release"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Release {{version}}"
+                }
+            }
+        },
+        "swagger.TimeEntryData": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "type": "string",
+                    "example": "2025-04-01T10:30:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Call with the client"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-04-01T09:00:00Z"
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                }
+            }
+        },
+        "swagger.TimeEntryRequest": {
+            "type": "object",
+            "properties": {
+                "ended_at": {
+                    "type": "string",
+                    "example": "2025-04-01T10:30:00Z"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Call with the client"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-04-01T09:00:00Z"
+                }
+            }
+        },
+        "swagger.TimeEntryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.TimeEntryData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully stop"
+                }
+            }
+        },
+        "swagger.TimeReportData": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "project"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TimeReportRow"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-04-30"
+                },
+                "total_minutes": {
+                    "type": "integer",
+                    "example": 540
+                }
+            }
+        },
+        "swagger.TimeReportResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 201
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/swagger.TimeReportData"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Successfully fetch"
+                }
+            }
+        },
+        "swagger.TimeReportRow": {
+            "type": "object",
+            "properties": {
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 600
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "minutes": {
+                    "type": "integer",
+                    "example": 540
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Website redesign"
+                }
+            }
+        },
+        "swagger.TodoHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "john_doe"
+                },
+                "actor_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.FieldChangeResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "operation": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "assigned",
+                        "deleted"
+                    ],
+                    "example": "updated"
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "swagger.TodoRequest": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.ChecklistItem"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Get milk, bread, and eggs"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "project_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "recurrence": {
+                    "$ref": "#/definitions/swagger.Recurrence"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shopping",
+                        "urgent"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Buy groceries"
+                }
+            }
+        },
+        "swagger.TodoResponse": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                },
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.ChecklistItem"
+                    }
+                },
+                "completed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-28T09:15:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Get milk, bread, and eggs"
+                },
+                "due_date": {
+                    "type": "string",
+                    "example": "2025-04-01"
+                },
+                "estimate_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "data": {
-                    "$ref": "#/definitions/swagger.createResponse"
+                "parent_id": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "error": {
-                    "type": "boolean",
-                    "example": false
+                "project_id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "message": {
+                "recurrence": {
+                    "$ref": "#/definitions/swagger.Recurrence"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shopping",
+                        "urgent"
+                    ]
+                },
+                "title": {
                     "type": "string",
-                    "example": "Successfully create"
+                    "example": "Buy groceries"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 5
                 }
             }
         },
-        "swagger.DeleteResponse": {
+        "swagger.UnauthorizedResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 200
+                    "example": 401
                 },
                 "error": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
                 },
                 "message": {
                     "type": "string",
-                    "example": "Successfully delete"
+                    "example": "Invalid credentials"
                 }
             }
         },
-        "swagger.ErrorResponse": {
+        "swagger.UndoData": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
-                },
-                "error": {
-                    "type": "boolean",
-                    "example": true
+                "undo_expires_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:30Z"
                 },
-                "message": {
+                "undo_token": {
                     "type": "string",
-                    "example": "Invalid request data"
+                    "example": "6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"
                 }
             }
         },
-        "swagger.GetTodoResponse": {
+        "swagger.UndoResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
                     "example": 200
                 },
-                "data": {
-                    "$ref": "#/definitions/swagger.TodoResponse"
-                },
                 "error": {
                     "type": "boolean",
                     "example": false
                 },
                 "message": {
                     "type": "string",
-                    "example": "Successfully fetch"
+                    "example": "Successfully undo"
                 }
             }
         },
-        "swagger.InvalidIDResponse": {
+        "swagger.UpdateResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 400
+                    "example": 200
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
                 },
                 "message": {
                     "type": "string",
-                    "example": "Invalid ID"
+                    "example": "Successfully update"
                 }
             }
         },
-        "swagger.ListTodoResponse": {
+        "swagger.UpdateTodoResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
                     "example": 200
                 },
-                "count": {
-                    "type": "integer",
-                    "example": 1
-                },
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/swagger.TodoResponse"
-                    }
+                    "$ref": "#/definitions/swagger.UndoData"
                 },
                 "error": {
                     "type": "boolean",
                     "example": false
                 },
-                "limit": {
-                    "type": "integer",
-                    "example": 20
-                },
                 "message": {
                     "type": "string",
-                    "example": "Successfully fetch"
-                },
-                "offset": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "Successfully update"
                 }
             }
         },
-        "swagger.LoginResponse": {
+        "swagger.UserData": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 200
-                },
-                "data": {
-                    "$ref": "#/definitions/swagger.tokenResponse"
-                },
-                "error": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "message": {
+                "username": {
                     "type": "string",
-                    "example": "Login successful"
+                    "example": "john_doe"
                 }
             }
         },
-        "swagger.NotFoundResponse": {
+        "swagger.UserRequest": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 404
+                "password": {
+                    "type": "string",
+                    "example": "password123"
                 },
-                "message": {
+                "username": {
                     "type": "string",
-                    "example": "Todo not found"
+                    "example": "john_doe"
                 }
             }
         },
-        "swagger.RefreshRequest": {
+        "swagger.WorkspaceRequest": {
             "type": "object",
             "properties": {
-                "refresh_token": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "Acme Inc."
                 }
             }
         },
-        "swagger.RefreshResponse": {
+        "swagger.WorkspaceResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "id": {
                     "type": "integer",
-                    "example": 200
+                    "example": 5
                 },
-                "data": {
-                    "$ref": "#/definitions/swagger.tokenResponse"
-                },
-                "error": {
-                    "type": "boolean",
-                    "example": false
+                "name": {
+                    "type": "string",
+                    "example": "Acme Inc."
                 },
-                "message": {
+                "role": {
                     "type": "string",
-                    "example": "Tokens refreshed"
+                    "enum": [
+                        "admin",
+                        "member",
+                        "guest"
+                    ],
+                    "example": "admin"
                 }
             }
         },
-        "swagger.ServerErrorResponse": {
+        "swagger.commentPage": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 500
-                },
-                "error": {
-                    "type": "boolean",
-                    "example": true
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.CommentResponse"
+                    }
                 },
-                "message": {
+                "next_cursor": {
                     "type": "string",
-                    "example": "Something went wrong, please try again later"
+                    "example": "MzE"
                 }
             }
         },
-        "swagger.SuccessRegisterResponse": {
+        "swagger.createResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "id": {
                     "type": "integer",
-                    "example": 201
-                },
-                "data": {
-                    "$ref": "#/definitions/swagger.UserData"
-                },
-                "error": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "message": {
-                    "type": "string",
-                    "example": "User successfully created"
+                    "example": 12
                 }
             }
         },
-        "swagger.TodoRequest": {
+        "swagger.createTodoResponse": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "Get milk, bread, and eggs"
+                "id": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "due_date": {
+                "undo_expires_at": {
                     "type": "string",
-                    "example": "2025-04-01"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "shopping",
-                        "urgent"
-                    ]
+                    "example": "2025-04-01T12:00:30Z"
                 },
-                "title": {
+                "undo_token": {
                     "type": "string",
-                    "example": "Buy groceries"
+                    "example": "6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"
                 }
             }
         },
-        "swagger.TodoResponse": {
+        "swagger.createdInvitationData": {
             "type": "object",
             "properties": {
-                "description": {
+                "expires_at": {
                     "type": "string",
-                    "example": "Get milk, bread, and eggs"
+                    "example": "2025-04-04T12:00:00Z"
                 },
-                "due_date": {
+                "id": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "role": {
                     "type": "string",
-                    "example": "2025-04-01"
+                    "example": "member"
                 },
+                "token": {
+                    "type": "string",
+                    "example": "k3Jx9aP2vQ8mZr7LwN4tYb6HcE1sDf0Ug5Ki2Oa8WqM"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "swagger.instantiateData": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "integer",
                     "example": 12
                 },
-                "tags": {
+                "ids": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     },
                     "example": [
-                        "shopping",
-                        "urgent"
+                        12,
+                        13,
+                        14
                     ]
                 },
-                "title": {
+                "undo_expires_at": {
                     "type": "string",
-                    "example": "Buy groceries"
+                    "example": "2025-04-01T12:00:30Z"
+                },
+                "undo_token": {
+                    "type": "string",
+                    "example": "6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"
                 }
             }
         },
-        "swagger.UnauthorizedResponse": {
+        "swagger.invitationData": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 401
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-04-04T12:00:00Z"
                 },
-                "error": {
-                    "type": "boolean",
-                    "example": true
+                "id": {
+                    "type": "integer",
+                    "example": 9
                 },
-                "message": {
+                "role": {
                     "type": "string",
-                    "example": "Invalid credentials"
+                    "example": "member"
+                },
+                "workspace_id": {
+                    "type": "integer",
+                    "example": 5
                 }
             }
         },
-        "swagger.UpdateResponse": {
+        "swagger.memberData": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 200
+                "joined_at": {
+                    "type": "string",
+                    "example": "2025-04-01T12:00:00Z"
                 },
-                "error": {
-                    "type": "boolean",
-                    "example": false
+                "role": {
+                    "type": "string",
+                    "example": "member"
                 },
-                "message": {
+                "user_id": {
                     "type": "string",
-                    "example": "Successfully update"
-                }
-            }
-        },
-        "swagger.UserData": {
-            "type": "object",
-            "properties": {
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
+                },
                 "username": {
                     "type": "string",
-                    "example": "john_doe"
+                    "example": "jane_doe"
                 }
             }
         },
-        "swagger.UserRequest": {
+        "swagger.shareData": {
             "type": "object",
             "properties": {
-                "password": {
+                "permission": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "editor"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "818bdf4c-0b94-4dcb-96be-12a31f073ac2"
                 },
                 "username": {
                     "type": "string",
-                    "example": "john_doe"
+                    "example": "jane_doe"
                 }
             }
         },
-        "swagger.createResponse": {
+        "swagger.todoHistoryPage": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 12
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swagger.TodoHistoryResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "NDI"
                 }
             }
         },
@@ -1,21 +1,43 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	_ "github.com/GlebMoskalev/go-todo-api/docs"
 	"github.com/GlebMoskalev/go-todo-api/internal/config"
+	account2 "github.com/GlebMoskalev/go-todo-api/internal/controller/account"
+	attachment2 "github.com/GlebMoskalev/go-todo-api/internal/controller/attachment"
 	auth2 "github.com/GlebMoskalev/go-todo-api/internal/controller/auth"
+	batch2 "github.com/GlebMoskalev/go-todo-api/internal/controller/batch"
+	caldav2 "github.com/GlebMoskalev/go-todo-api/internal/controller/caldav"
+	comment2 "github.com/GlebMoskalev/go-todo-api/internal/controller/comment"
+	feed2 "github.com/GlebMoskalev/go-todo-api/internal/controller/feed"
+	notification2 "github.com/GlebMoskalev/go-todo-api/internal/controller/notification"
+	password2 "github.com/GlebMoskalev/go-todo-api/internal/controller/password"
+	project2 "github.com/GlebMoskalev/go-todo-api/internal/controller/project"
+	share2 "github.com/GlebMoskalev/go-todo-api/internal/controller/share"
+	stats2 "github.com/GlebMoskalev/go-todo-api/internal/controller/stats"
+	tag2 "github.com/GlebMoskalev/go-todo-api/internal/controller/tag"
+	template2 "github.com/GlebMoskalev/go-todo-api/internal/controller/template"
+	timeentry2 "github.com/GlebMoskalev/go-todo-api/internal/controller/timeentry"
 	todo2 "github.com/GlebMoskalev/go-todo-api/internal/controller/todo"
+	undo2 "github.com/GlebMoskalev/go-todo-api/internal/controller/undo"
+	workspace2 "github.com/GlebMoskalev/go-todo-api/internal/controller/workspace"
 	"github.com/GlebMoskalev/go-todo-api/internal/database"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/mailer"
 	"github.com/GlebMoskalev/go-todo-api/internal/middleware"
 	"github.com/GlebMoskalev/go-todo-api/internal/repository"
 	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/storage"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 )
 
@@ -50,52 +72,289 @@ func Run(configPath string) error {
 		}
 	}(db)
 
-	router := setupRouter(logger, db, cfg)
+	blobs, err := storage.New(cfg)
+	if err != nil {
+		logger.Error("Failed initialization blob storage", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if cfg.Token.CleanupInterval > 0 {
+		cleaner := service.NewTokenCleaner(repository.NewTokenRepository(db, logger),
+			time.Duration(cfg.Token.CleanupInterval)*time.Minute, logger)
+		go cleaner.Run(ctx)
+	}
+
+	mail, err := mailer.New(cfg, logger)
+	if err != nil {
+		logger.Error("Failed initialization mailer", "error", err)
+		os.Exit(1)
+	}
+
+	router, passwordService := setupRouter(logger, db, blobs, mail, cfg)
 	server := &http.Server{
 		Addr:         cfg.Server.Address,
 		Handler:      router,
 		ReadTimeout:  time.Duration(cfg.Server.Timeout) * time.Second,
 		WriteTimeout: time.Duration(cfg.Server.Timeout) * time.Second,
 	}
-	return server.ListenAndServe()
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		passwordService.Run(workersCtx)
+		close(workersDone)
+	}()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	signals, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case err := <-serverErr:
+		return err
+	case <-signals.Done():
+	}
+
+	// Requests in flight finish first, as they may still queue work.
+	logger.Info("Shutting down server")
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(),
+		time.Duration(cfg.Server.ShutdownTimeout)*time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to shut down server", "error", err)
+	}
+	stopWorkers()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		logger.Warn("Queued password reset links were not sent before shutdown")
+	}
+	return nil
 }
 
-func setupRouter(logger *slog.Logger, db *sql.DB, cfg config.Config) *chi.Mux {
+// setupRouter builds the API. The password service is returned so that the caller
+// runs its background work.
+func setupRouter(logger *slog.Logger, db *sql.DB, blobs storage.BlobStore, mail mailer.Mailer,
+	cfg config.Config) (*chi.Mux, service.PasswordService) {
 	userRepo := repository.NewUserRepository(db, logger)
 	tokenRepo := repository.NewTokenRepository(db, logger)
 	todoRepo := repository.NewTodoRepository(db, logger)
+	projectRepo := repository.NewProjectRepository(db, logger)
+	shareRepo := repository.NewShareRepository(db, logger)
+	workspaceRepo := repository.NewWorkspaceRepository(db, logger)
+	notificationRepo := repository.NewNotificationRepository(db, logger)
+	commentRepo := repository.NewCommentRepository(db, logger)
+	attachmentRepo := repository.NewAttachmentRepository(db, logger)
+	historyRepo := repository.NewHistoryRepository(db, logger)
+	undoRepo := repository.NewUndoRepository(db, logger)
+	tagRepo := repository.NewTagRepository(db, logger)
+	templateRepo := repository.NewTemplateRepository(db, logger)
+	feedRepo := repository.NewCalendarFeedRepository(db, logger)
+	timeEntryRepo := repository.NewTimeEntryRepository(db, logger)
+	statsRepo := repository.NewStatsRepository(db, logger)
+	caldavRepo := repository.NewCalDAVRepository(db, logger)
+	accountRepo := repository.NewAccountRepository(db, logger)
+	passwordResetRepo := repository.NewPasswordResetRepository(db, logger)
+	transactor := repository.NewTransactor(db, logger)
 
-	userService := service.NewUserService(userRepo, logger)
-	tokenService := service.NewTokenService(userRepo, tokenRepo, cfg, logger)
-	todoService := service.NewTodoService(todoRepo)
+	userService := service.NewUserService(userRepo, transactor, logger)
+	tokenService := service.NewTokenService(userRepo, tokenRepo, transactor, cfg, logger)
+	todoService := service.NewTodoService(todoRepo, projectRepo, userRepo, shareRepo, workspaceRepo, notificationRepo,
+		attachmentRepo, historyRepo, undoRepo, transactor, blobs, time.Duration(cfg.Undo.Window)*time.Second, logger)
+	projectService := service.NewProjectService(projectRepo, shareRepo, workspaceRepo)
+	shareService := service.NewShareService(shareRepo, userRepo, workspaceRepo, logger)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, logger)
+	notificationService := service.NewNotificationService(notificationRepo)
+	commentService := service.NewCommentService(commentRepo, todoRepo, userRepo, shareRepo, workspaceRepo, notificationRepo, logger)
+	undoService := service.NewUndoService(undoRepo, todoRepo, shareRepo, workspaceRepo, historyRepo, transactor)
+	tagService := service.NewTagService(tagRepo, historyRepo, transactor)
+	templateService := service.NewTemplateService(templateRepo, todoService)
+	feedService := service.NewCalendarFeedService(feedRepo, todoService)
+	timeService := service.NewTimeService(timeEntryRepo, shareRepo, workspaceRepo)
+	statsService := service.NewStatsService(statsRepo, userRepo)
+	caldavService := service.NewCalDAVService(caldavRepo, todoService, transactor)
+	accountService := service.NewAccountService(accountRepo, userRepo, tokenRepo, transactor, blobs, logger)
+	passwordService := service.NewPasswordService(userRepo, passwordResetRepo, tokenService, transactor, mail,
+		service.PasswordResetConfig{
+			Expire:    time.Duration(cfg.PasswordReset.Expire) * time.Minute,
+			URL:       cfg.PasswordReset.URL,
+			MaxActive: cfg.PasswordReset.MaxActive,
+			Workers:   cfg.PasswordReset.Workers,
+			QueueSize: cfg.PasswordReset.QueueSize,
+		}, logger)
+	attachmentService := service.NewAttachmentService(attachmentRepo, blobs, shareRepo, workspaceRepo, entity.AttachmentLimits{
+		MaxSize:             cfg.Storage.MaxAttachmentSize,
+		AllowedContentTypes: cfg.Storage.AllowedContentTypes,
+	}, logger)
 
 	todoHandler := todo2.NewHandler(todoService, logger)
 	authHandler := auth2.NewHandler(userService, tokenService, logger)
+	projectHandler := project2.NewHandler(projectService, logger)
+	shareHandler := share2.NewHandler(shareService, logger)
+	workspaceHandler := workspace2.NewHandler(workspaceService, logger)
+	notificationHandler := notification2.NewHandler(notificationService, logger)
+	commentHandler := comment2.NewHandler(commentService, logger)
+	attachmentHandler := attachment2.NewHandler(attachmentService, cfg.Storage.MaxAttachmentSize, logger)
+	undoHandler := undo2.NewHandler(undoService, logger)
+	tagHandler := tag2.NewHandler(tagService, logger)
+	templateHandler := template2.NewHandler(templateService, logger)
+	feedHandler := feed2.NewHandler(feedService, logger)
+	timeHandler := timeentry2.NewHandler(timeService, logger)
+	statsHandler := stats2.NewHandler(statsService, logger)
+	caldavHandler := caldav2.NewHandler(caldavService, "/api/"+version+"/caldav", logger)
+	accountHandler := account2.NewHandler(accountService, logger)
+	passwordHandler := password2.NewHandler(passwordService, logger)
 
 	r := chi.NewRouter()
+	batchHandler := batch2.NewHandler(r, "/api/"+version, cfg.Batch.MaxRequests, cfg.Batch.Concurrency, logger)
 
 	r.Use(chiMiddleware.RequestID)
 	r.Use(middleware.RequestIdHeader)
+	r.Use(chiMiddleware.Recoverer)
 
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://"+cfg.Server.Address+"/swagger/doc.json"),
 	))
 
+	// CalDAV clients look the server up here when only given the host.
+	r.HandleFunc("/.well-known/caldav", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/api/"+version+"/caldav/", http.StatusMovedPermanently)
+	})
+
 	r.Route("/api/"+version, func(r chi.Router) {
 		r.Route("/auth", func(r chi.Router) {
 			auth2.RegisterRoutes(r, authHandler)
+			password2.RegisterRoutes(r, passwordHandler)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.AuthMiddleware(tokenService))
+				auth2.RegisterSessionRoutes(r, authHandler)
+				password2.RegisterProtectedRoutes(r, passwordHandler)
+			})
+		})
+
+		r.Route("/me", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			auth2.RegisterProfileRoutes(r, authHandler)
+			account2.RegisterRoutes(r, accountHandler)
 		})
 
 		r.Route("/todos", func(r chi.Router) {
 			r.Group(func(r chi.Router) {
 				r.Use(middleware.AuthMiddleware(tokenService))
+				r.Use(middleware.WorkspaceMiddleware(workspaceService))
 				todo2.RegisterRoutes(r, todoHandler)
+				share2.RegisterTodoRoutes(r, shareHandler)
+				comment2.RegisterRoutes(r, commentHandler)
+				attachment2.RegisterRoutes(r, attachmentHandler)
+				timeentry2.RegisterRoutes(r, timeHandler)
 			})
 		})
+
+		r.With(
+			middleware.AuthMiddleware(tokenService),
+			middleware.WorkspaceMiddleware(workspaceService),
+		).Get("/todos.ics", todoHandler.Export)
+
+		r.Route("/feeds", func(r chi.Router) {
+			feed2.RegisterCalendarRoutes(r, feedHandler)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.AuthMiddleware(tokenService))
+				feed2.RegisterRoutes(r, feedHandler)
+			})
+		})
+
+		r.Route("/caldav", func(r chi.Router) {
+			r.Use(middleware.BasicAuthMiddleware(tokenService, "go-todo-api"))
+			caldav2.RegisterRoutes(r, caldavHandler)
+		})
+
+		r.Route("/projects", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.AuthMiddleware(tokenService))
+				r.Use(middleware.WorkspaceMiddleware(workspaceService))
+				project2.RegisterRoutes(r, projectHandler)
+				share2.RegisterProjectRoutes(r, shareHandler)
+			})
+		})
+
+		r.Route("/workspaces", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			workspace2.RegisterRoutes(r, workspaceHandler)
+
+			r.Route("/{workspaceID}", func(r chi.Router) {
+				workspace2.RegisterWorkspaceRoutes(r, workspaceHandler)
+
+				r.Route("/todos", func(r chi.Router) {
+					r.Use(middleware.WorkspaceMiddleware(workspaceService))
+					todo2.RegisterRoutes(r, todoHandler)
+					share2.RegisterTodoRoutes(r, shareHandler)
+					comment2.RegisterRoutes(r, commentHandler)
+					attachment2.RegisterRoutes(r, attachmentHandler)
+					timeentry2.RegisterRoutes(r, timeHandler)
+				})
+
+				r.Route("/projects", func(r chi.Router) {
+					r.Use(middleware.WorkspaceMiddleware(workspaceService))
+					project2.RegisterRoutes(r, projectHandler)
+					share2.RegisterProjectRoutes(r, shareHandler)
+				})
+			})
+		})
+
+		r.Route("/invitations", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			workspace2.RegisterInvitationRoutes(r, workspaceHandler)
+		})
+
+		r.Route("/tags", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			tag2.RegisterRoutes(r, tagHandler)
+		})
+
+		r.Route("/templates", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			r.Use(middleware.WorkspaceMiddleware(workspaceService))
+			template2.RegisterRoutes(r, templateHandler)
+		})
+
+		r.Route("/timer", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			timeentry2.RegisterTimerRoutes(r, timeHandler)
+		})
+
+		r.Route("/reports", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			timeentry2.RegisterReportRoutes(r, timeHandler)
+		})
+
+		r.Route("/stats", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			stats2.RegisterRoutes(r, statsHandler)
+		})
+
+		r.Route("/undo", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			undo2.RegisterRoutes(r, undoHandler)
+		})
+
+		r.Route("/notifications", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			notification2.RegisterRoutes(r, notificationHandler)
+		})
+
+		r.Route("/batch", func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(tokenService))
+			batch2.RegisterRoutes(r, batchHandler)
+		})
 	})
 
 	logger.Info("Starting server", "address", cfg.Server.Address)
-	return r
+	return r, passwordService
 }
 
 func setupLogger(env string) *slog.Logger {
@@ -9,8 +9,9 @@ import (
 type Config struct {
 	Env    string `yaml:"env"`
 	Server struct {
-		Address string `yaml:"address"`
-		Timeout int    `yaml:"timeout"`
+		Address         string `yaml:"address"`
+		Timeout         int    `yaml:"timeout"`
+		ShutdownTimeout int    `yaml:"shutdownTimeout"`
 	} `yaml:"server"`
 	Database struct {
 		Host     string `yaml:"host"`
@@ -24,7 +25,47 @@ type Config struct {
 		RefreshTokenSecret string `yaml:"refreshTokenSecret"`
 		AccessTokenExpire  int    `yaml:"accessTokenExpire"`
 		RefreshTokenExpire int    `yaml:"refreshTokenExpire"`
+		CleanupInterval    int    `yaml:"cleanupInterval"`
 	} `yaml:"token"`
+	Storage struct {
+		Driver string `yaml:"driver"`
+		Local  struct {
+			Path string `yaml:"path"`
+		} `yaml:"local"`
+		S3 struct {
+			Endpoint  string `yaml:"endpoint"`
+			Region    string `yaml:"region"`
+			Bucket    string `yaml:"bucket"`
+			AccessKey string `yaml:"accessKey"`
+			SecretKey string `yaml:"secretKey"`
+		} `yaml:"s3"`
+		MaxAttachmentSize   int64    `yaml:"maxAttachmentSize"`
+		AllowedContentTypes []string `yaml:"allowedContentTypes"`
+	} `yaml:"storage"`
+	Undo struct {
+		Window int `yaml:"window"`
+	} `yaml:"undo"`
+	Batch struct {
+		MaxRequests int `yaml:"maxRequests"`
+		Concurrency int `yaml:"concurrency"`
+	} `yaml:"batch"`
+	Mail struct {
+		Driver string `yaml:"driver"`
+		From   string `yaml:"from"`
+		SMTP   struct {
+			Host     string `yaml:"host"`
+			Port     string `yaml:"port"`
+			Username string `yaml:"username"`
+			Password string `yaml:"password"`
+		} `yaml:"smtp"`
+	} `yaml:"mail"`
+	PasswordReset struct {
+		Expire    int    `yaml:"expire"`
+		URL       string `yaml:"url"`
+		MaxActive int    `yaml:"maxActive"`
+		Workers   int    `yaml:"workers"`
+		QueueSize int    `yaml:"queueSize"`
+	} `yaml:"passwordReset"`
 }
 
 func Load(file string) (Config, error) {
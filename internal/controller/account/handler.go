@@ -0,0 +1,167 @@
+package account
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Handler struct {
+	service service.AccountService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.AccountService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Export downloads everything stored about the caller
+// @Summary Export account data
+// @Description Returns a ZIP archive with the profile, every owned todo, the refresh tokens (without their values), the comments written, the history of the owned todos and of the changes made by the user, the sessions with their device, user agent and IP address, the tracked time entries, the templates and the tags with their metadata, each as a JSON file.
+// @Tags account
+// @Produce application/zip
+// @Security BearerAuth
+// @Success 200 {file} file "ZIP archive"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "User not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /me/export [post]
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "account_handler", "Export")
+	logger.Debug("Attempting to export account")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	export, err := h.service.Export(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			logger.Warn("User not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "User not found", nil)
+			return
+		}
+		logger.Error("Failed to export account", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%s-%s.zip"`,
+		export.Profile.Username, export.ExportedAt.Format(time.DateOnly)))
+	if err := writeArchive(w, export); err != nil {
+		logger.Error("Failed to write archive", "error", err)
+		return
+	}
+	logger.Info("Successfully exported account", "todos", len(export.Todos))
+}
+
+// writeArchive writes the export as a ZIP archive of JSON files.
+func writeArchive(w http.ResponseWriter, export entity.AccountExport) error {
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", export.Profile},
+		{"todos.json", export.Todos},
+		{"refresh_tokens.json", export.RefreshTokens},
+		{"comments.json", export.Comments},
+		{"history.json", export.History},
+		{"sessions.json", export.Sessions},
+		{"time_entries.json", export.TimeEntries},
+		{"templates.json", export.Templates},
+		{"tags.json", export.Tags},
+	}
+	for _, file := range files {
+		out, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// Delete removes the caller's account
+// @Summary Delete account
+// @Description Deletes the authenticated user after confirming their password. Every refresh token is revoked. Todos and projects in workspaces with other members are handed to the admin who joined first; the last admin of such a workspace must appoint another admin first. The user's other todos, projects, tags, templates, comments, time entries, calendar feeds, attachments and the history of their todos are removed. Changes the user made to other users' todos stay in those todos' history without naming them.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param request body swagger.AccountDeletionRequest true "Password confirmation"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Account successfully deleted"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Wrong password"
+// @Failure 404 {object} swagger.NotFoundResponse "User not found"
+// @Failure 409 {object} swagger.ErrorResponse "User is the last admin of a workspace"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /me [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "account_handler", "Delete")
+	logger.Debug("Attempting to delete account")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var deletion entity.AccountDeletion
+	if err := utils.DecodeJSONStruct(r, &deletion); err != nil {
+		logger.Warn("Failed to decode JSON", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := deletion.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	err := h.service.Delete(r.Context(), userID, deletion.Password)
+	if err != nil {
+		if errors.Is(err, entity.ErrWrongPassword) {
+			logger.Warn("Wrong password")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Wrong password", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrUserNotFound) {
+			logger.Warn("User not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "User not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrLastAdmin) {
+			logger.Warn("User is the last admin of a workspace")
+			entity.SendResponse[any](w, http.StatusConflict, true, "Appoint another workspace admin before deleting the account", nil)
+			return
+		}
+		logger.Error("Failed to delete account", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted account")
+}
@@ -0,0 +1,202 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRouter(handler *Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/me", func(r chi.Router) {
+		RegisterRoutes(r, handler)
+	})
+	return r
+}
+
+func TestExport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	sessionID := uuid.New()
+	exportedAt := time.Date(2025, 4, 2, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                  string
+		prepareAccountService func(serviceMock *mocks.AccountService)
+		expectedHTTPStatus    int
+		expectedFiles         map[string]string
+		expectedResponse      string
+	}{
+		{
+			name: "successful export",
+			prepareAccountService: func(serviceMock *mocks.AccountService) {
+				serviceMock.On("Export", mock.Anything, userID).Return(entity.AccountExport{
+					Profile:       entity.Profile{ID: userID, Username: "john_doe", Timezone: "UTC"},
+					Todos:         []entity.Todo{{ID: 7, Title: "Pay rent", Tags: []string{}}},
+					RefreshTokens: []entity.RefreshTokenInfo{{ID: 3, CreatedAt: exportedAt, ExpiresAt: exportedAt.Add(time.Hour)}},
+					Comments:      []entity.Comment{},
+					History:       []entity.TodoHistory{},
+					Sessions:      []entity.Session{{ID: sessionID, DeviceName: "Laptop", UserAgent: "Firefox", IP: "203.0.113.7"}},
+					TimeEntries:   []entity.TimeEntry{{ID: 4, TodoID: 7, UserID: userID, StartedAt: exportedAt, Minutes: 25}},
+					Templates:     []entity.Template{{ID: 2, Name: "Weekly review"}},
+					Tags:          []entity.Tag{{Name: "home", Description: "Chores", Count: 1}},
+					ExportedAt:    exportedAt,
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedFiles: map[string]string{
+				"profile.json":        `"username": "john_doe"`,
+				"todos.json":          `"title": "Pay rent"`,
+				"refresh_tokens.json": `"expires_at": "2025-04-02T11:00:00Z"`,
+				"comments.json":       "[]",
+				"history.json":        "[]",
+				"sessions.json":       `"ip": "203.0.113.7"`,
+				"time_entries.json":   `"minutes": 25`,
+				"templates.json":      `"name": "Weekly review"`,
+				"tags.json":           `"description": "Chores"`,
+			},
+		},
+		{
+			name: "service error",
+			prepareAccountService: func(serviceMock *mocks.AccountService) {
+				serviceMock.On("Export", mock.Anything, userID).Return(entity.AccountExport{}, errors.New("db down"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			accountServiceMock := mocks.NewAccountService(t)
+			if tc.prepareAccountService != nil {
+				tc.prepareAccountService(accountServiceMock)
+			}
+
+			handler := NewHandler(accountServiceMock, logger)
+
+			req, err := http.NewRequest("POST", "/me/export", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			newRouter(handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+				return
+			}
+
+			assert.Equal(t, "application/zip", rr.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="account-john_doe-2025-04-02.zip"`, rr.Header().Get("Content-Disposition"))
+			archive, err := zip.NewReader(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+			assert.NoError(t, err)
+			assert.Len(t, archive.File, len(tc.expectedFiles))
+			for _, file := range archive.File {
+				f, err := file.Open()
+				assert.NoError(t, err)
+				data, err := io.ReadAll(f)
+				assert.NoError(t, err)
+				assert.Contains(t, string(data), tc.expectedFiles[file.Name], file.Name)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                  string
+		inputRequest          string
+		prepareAccountService func(serviceMock *mocks.AccountService)
+		expectedHTTPStatus    int
+		expectedResponse      string
+	}{
+		{
+			name:         "successful deletion",
+			inputRequest: `{"password":"Secret123"}`,
+			prepareAccountService: func(serviceMock *mocks.AccountService) {
+				serviceMock.On("Delete", mock.Anything, userID, "Secret123").Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully delete"}`,
+		},
+		{
+			name:               "missing password",
+			inputRequest:       `{}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'password' is required"}`,
+		},
+		{
+			name:         "wrong password",
+			inputRequest: `{"password":"Guess1234"}`,
+			prepareAccountService: func(serviceMock *mocks.AccountService) {
+				serviceMock.On("Delete", mock.Anything, userID, "Guess1234").Return(entity.ErrWrongPassword)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Wrong password"}`,
+		},
+		{
+			name:         "last workspace admin",
+			inputRequest: `{"password":"Secret123"}`,
+			prepareAccountService: func(serviceMock *mocks.AccountService) {
+				serviceMock.On("Delete", mock.Anything, userID, "Secret123").Return(entity.ErrLastAdmin)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   `{"code":409,"error":true,"message":"Appoint another workspace admin before deleting the account"}`,
+		},
+		{
+			name:         "service error",
+			inputRequest: `{"password":"Secret123"}`,
+			prepareAccountService: func(serviceMock *mocks.AccountService) {
+				serviceMock.On("Delete", mock.Anything, userID, "Secret123").Return(errors.New("db down"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			accountServiceMock := mocks.NewAccountService(t)
+			if tc.prepareAccountService != nil {
+				tc.prepareAccountService(accountServiceMock)
+			}
+
+			handler := NewHandler(accountServiceMock, logger)
+
+			req, err := http.NewRequest("DELETE", "/me", strings.NewReader(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			newRouter(handler).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
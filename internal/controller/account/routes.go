@@ -0,0 +1,9 @@
+package account
+
+import "github.com/go-chi/chi/v5"
+
+// RegisterRoutes mounts the data export and deletion of the authenticated user.
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Post("/export", h.Export)
+	r.Delete("/", h.Delete)
+}
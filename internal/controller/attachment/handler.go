@@ -0,0 +1,287 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// formOverhead leaves room for multipart boundaries and headers on top of the file itself.
+const formOverhead = 1 << 20
+
+type Handler struct {
+	service service.AttachmentService
+	maxSize int64
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.AttachmentService, maxSize int64, logger *slog.Logger) *Handler {
+	return &Handler{service: service, maxSize: maxSize, logger: logger}
+}
+
+// GetAll retrieves the attachments of a todo
+// @Summary Get todo attachments
+// @Description Retrieves the metadata of all files attached to a todo
+// @Tags attachment
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListAttachmentResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/attachments [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "attachment_handler", "GetAll")
+	logger.Debug("Attempting to fetch attachments")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID)
+	attachments, err := h.service.GetAll(r.Context(), userID, todoID)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", attachments)
+	logger.Info("Successfully fetched attachments", "count", len(attachments))
+}
+
+// Upload attaches a file to a todo
+// @Summary Upload an attachment
+// @Description Uploads a file as multipart/form-data in the "file" field. The content type is detected from the file and must be one of the allowed types.
+// @Tags attachment
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param file formData file true "File to attach"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateAttachmentResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid ID or form data"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 413 {object} swagger.ErrorResponse "File is too large"
+// @Failure 415 {object} swagger.ErrorResponse "Unsupported file type"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/attachments [post]
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "attachment_handler", "Upload")
+	logger.Debug("Attempting to upload attachment")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+formOverhead)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			logger.Warn("Request body is too large")
+			entity.SendResponse[any](w, http.StatusRequestEntityTooLarge, true, "File is too large", nil)
+			return
+		}
+		logger.Warn("Failed to read file from form", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Field 'file' is required", nil)
+		return
+	}
+	defer file.Close()
+	defer r.MultipartForm.RemoveAll()
+
+	logger = logger.With("todo_id", todoID)
+	attachment, err := h.service.Upload(r.Context(), userID, todoID, entity.AttachmentUpload{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Body:     file,
+	})
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", attachment)
+	logger.Info("Successfully uploaded attachment", "attachment_id", attachment.ID)
+}
+
+// Download returns the content of an attachment
+// @Summary Download an attachment
+// @Description Streams the attached file. The ETag is the SHA-256 checksum of the content.
+// @Tags attachment
+// @Produce octet-stream
+// @Param id path int true "Todo ID"
+// @Param attachmentID path int true "Attachment ID"
+// @Security BearerAuth
+// @Success 200 {file} file "File content"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo or attachment not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/attachments/{attachmentID} [get]
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "attachment_handler", "Download")
+	logger.Debug("Attempting to download attachment")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	attachmentStr := chi.URLParam(r, "attachmentID")
+	attachmentID, err := strconv.Atoi(attachmentStr)
+	if err != nil {
+		logger.Warn("Invalid attachment id", "attachment_id", attachmentStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID, "attachment_id", attachmentID)
+	attachment, body, err := h.service.Download(r.Context(), userID, todoID, attachmentID)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+	defer body.Close()
+
+	etag := `"` + attachment.Checksum + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		logger.Info("Attachment not modified")
+		return
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, body); err != nil {
+		logger.Error("Failed to stream attachment", "error", err)
+		return
+	}
+	logger.Info("Successfully downloaded attachment")
+}
+
+// Delete removes an attachment
+// @Summary Delete an attachment
+// @Description Deletes an attachment and its stored file
+// @Tags attachment
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param attachmentID path int true "Attachment ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo or attachment not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/attachments/{attachmentID} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "attachment_handler", "Delete")
+	logger.Debug("Attempting to delete attachment")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	attachmentStr := chi.URLParam(r, "attachmentID")
+	attachmentID, err := strconv.Atoi(attachmentStr)
+	if err != nil {
+		logger.Warn("Invalid attachment id", "attachment_id", attachmentStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID, "attachment_id", attachmentID)
+	if err := h.service.Delete(r.Context(), userID, todoID, attachmentID); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted attachment")
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrTodoNotFound):
+		logger.Warn("Todo not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+	case errors.Is(err, entity.ErrAttachmentNotFound):
+		logger.Warn("Attachment not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Attachment not found", nil)
+	case errors.Is(err, entity.ErrForbidden):
+		logger.Warn("Permission denied")
+		entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+	case errors.Is(err, entity.ErrAttachmentTooLarge):
+		logger.Warn("Attachment is too large")
+		entity.SendResponse[any](w, http.StatusRequestEntityTooLarge, true,
+			fmt.Sprintf("File is too large. Maximum size is %d bytes", h.maxSize), nil)
+	case errors.Is(err, entity.ErrUnsupportedContentType):
+		logger.Warn("Unsupported attachment content type")
+		entity.SendResponse[any](w, http.StatusUnsupportedMediaType, true, "Unsupported file type", nil)
+	default:
+		logger.Error("Failed to process attachment request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
@@ -0,0 +1,251 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestUpload(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+
+	testCases := []struct {
+		name                     string
+		fieldName                string
+		content                  []byte
+		prepareAttachmentService func(serviceMock *mocks.AttachmentService)
+		expectedHTTPStatus       int
+		expectedResponse         string
+	}{
+		{
+			name:      "successful upload",
+			fieldName: "file",
+			content:   []byte("hello"),
+			prepareAttachmentService: func(serviceMock *mocks.AttachmentService) {
+				serviceMock.On("Upload", mock.Anything, userID, 12, mock.MatchedBy(func(upload entity.AttachmentUpload) bool {
+					body, _ := io.ReadAll(upload.Body)
+					return upload.FileName == "notes.txt" && upload.Size == 5 && string(body) == "hello"
+				})).Return(entity.Attachment{
+					ID:          7,
+					TodoID:      12,
+					UploaderID:  &userID,
+					FileName:    "notes.txt",
+					ContentType: "text/plain",
+					Size:        5,
+					Checksum:    "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+					CreatedAt:   time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully create","data":{"id":7,"todo_id":12,
+				"uploader_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","file_name":"notes.txt","content_type":"text/plain",
+				"size":5,"checksum":"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+				"created_at":"2025-04-01T12:00:00Z"}}`,
+		},
+		{
+			name:               "missing file field",
+			fieldName:          "document",
+			content:            []byte("hello"),
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Field 'file' is required"}`,
+		},
+		{
+			name:               "request body over limit",
+			fieldName:          "file",
+			content:            bytes.Repeat([]byte("a"), 64+formOverhead),
+			expectedHTTPStatus: http.StatusRequestEntityTooLarge,
+			expectedResponse:   `{"code":413,"error":true,"message":"File is too large"}`,
+		},
+		{
+			name:      "file over limit",
+			fieldName: "file",
+			content:   bytes.Repeat([]byte("a"), 65),
+			prepareAttachmentService: func(serviceMock *mocks.AttachmentService) {
+				serviceMock.On("Upload", mock.Anything, userID, 12, mock.Anything).
+					Return(entity.Attachment{}, entity.ErrAttachmentTooLarge)
+			},
+			expectedHTTPStatus: http.StatusRequestEntityTooLarge,
+			expectedResponse:   `{"code":413,"error":true,"message":"File is too large. Maximum size is 64 bytes"}`,
+		},
+		{
+			name:      "unsupported content type",
+			fieldName: "file",
+			content:   []byte("MZ\x90\x00"),
+			prepareAttachmentService: func(serviceMock *mocks.AttachmentService) {
+				serviceMock.On("Upload", mock.Anything, userID, 12, mock.Anything).
+					Return(entity.Attachment{}, entity.ErrUnsupportedContentType)
+			},
+			expectedHTTPStatus: http.StatusUnsupportedMediaType,
+			expectedResponse:   `{"code":415,"error":true,"message":"Unsupported file type"}`,
+		},
+		{
+			name:      "viewer cannot upload",
+			fieldName: "file",
+			content:   []byte("hello"),
+			prepareAttachmentService: func(serviceMock *mocks.AttachmentService) {
+				serviceMock.On("Upload", mock.Anything, userID, 12, mock.Anything).
+					Return(entity.Attachment{}, entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attachmentServiceMock := mocks.NewAttachmentService(t)
+			if tc.prepareAttachmentService != nil {
+				tc.prepareAttachmentService(attachmentServiceMock)
+			}
+
+			handler := NewHandler(attachmentServiceMock, 64, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, err := writer.CreateFormFile(tc.fieldName, "notes.txt")
+			if err != nil {
+				t.Fatalf("Failed to create form file: %v", err)
+			}
+			part.Write(tc.content)
+			writer.Close()
+
+			req, err := http.NewRequest("POST", "/todos/12/attachments", &body)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	checksum := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	attachment := entity.Attachment{
+		ID:          7,
+		TodoID:      12,
+		FileName:    "notes.txt",
+		ContentType: "text/plain",
+		Size:        5,
+		Checksum:    checksum,
+	}
+
+	testCases := []struct {
+		name                     string
+		inputAttachmentID        string
+		ifNoneMatch              string
+		prepareAttachmentService func(serviceMock *mocks.AttachmentService)
+		expectedHTTPStatus       int
+		expectedHeaders          map[string]string
+		expectedBody             string
+	}{
+		{
+			name:              "successful download",
+			inputAttachmentID: "7",
+			prepareAttachmentService: func(serviceMock *mocks.AttachmentService) {
+				serviceMock.On("Download", mock.Anything, userID, 12, 7).
+					Return(attachment, io.NopCloser(bytes.NewBufferString("hello")), nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Content-Type":        "text/plain",
+				"Content-Length":      "5",
+				"Content-Disposition": `attachment; filename=notes.txt`,
+				"ETag":                `"` + checksum + `"`,
+			},
+			expectedBody: "hello",
+		},
+		{
+			name:              "not modified",
+			inputAttachmentID: "7",
+			ifNoneMatch:       `"` + checksum + `"`,
+			prepareAttachmentService: func(serviceMock *mocks.AttachmentService) {
+				serviceMock.On("Download", mock.Anything, userID, 12, 7).
+					Return(attachment, io.NopCloser(bytes.NewBufferString("hello")), nil)
+			},
+			expectedHTTPStatus: http.StatusNotModified,
+			expectedHeaders:    map[string]string{"ETag": `"` + checksum + `"`},
+		},
+		{
+			name:               "invalid attachment id",
+			inputAttachmentID:  "abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+		{
+			name:              "attachment of another todo",
+			inputAttachmentID: "8",
+			prepareAttachmentService: func(serviceMock *mocks.AttachmentService) {
+				serviceMock.On("Download", mock.Anything, userID, 12, 8).
+					Return(entity.Attachment{}, nil, entity.ErrAttachmentNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedBody:       `{"code":404,"error":true,"message":"Attachment not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attachmentServiceMock := mocks.NewAttachmentService(t)
+			if tc.prepareAttachmentService != nil {
+				tc.prepareAttachmentService(attachmentServiceMock)
+			}
+
+			handler := NewHandler(attachmentServiceMock, 64, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("GET", "/todos/12/attachments/"+tc.inputAttachmentID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for name, value := range tc.expectedHeaders {
+				assert.Equal(t, value, rr.Header().Get(name))
+			}
+			if tc.expectedHTTPStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedBody, rr.Body.String())
+			} else if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
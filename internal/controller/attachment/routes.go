@@ -0,0 +1,11 @@
+package attachment
+
+import "github.com/go-chi/chi/v5"
+
+// RegisterRoutes registers the attachment routes on the todos router.
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/{id}/attachments", h.GetAll)
+	r.Post("/{id}/attachments", h.Upload)
+	r.Get("/{id}/attachments/{attachmentID}", h.Download)
+	r.Delete("/{id}/attachments/{attachmentID}", h.Delete)
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/GlebMoskalev/go-todo-api/internal/entity"
 	"github.com/GlebMoskalev/go-todo-api/internal/service"
 	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"log/slog"
+	"net"
 	"net/http"
 	"strings"
 )
@@ -114,7 +117,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.tokenService.GenerateTokenPair(r.Context(), user.ID)
+	accessToken, refreshToken, err := h.tokenService.GenerateTokenPair(r.Context(), user.ID, sessionClient(r))
 	if err != nil {
 		logger.Error("Failed to generate tokens", "error", err)
 		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
@@ -165,3 +168,311 @@ func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
 	})
 	logger.Info("Successfully refreshed tokens")
 }
+
+// Logout handles logout
+// @Summary Log out
+// @Description Revokes the refresh token. The access token sent in the Authorization header, if any, is revoked too.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh_token body swagger.RefreshRequest true "Refresh token"
+// @Success 200 {object} swagger.LogoutResponse "Successfully logout"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data"
+// @Failure 401 {object} swagger.UnauthorizedResponse "Invalid refresh token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/logout [post]
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "auth_handler", "Logout")
+	logger.Debug("Attempting to logout")
+
+	var logoutRequest struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := utils.DecodeJSONStruct(r, &logoutRequest); err != nil {
+		logger.Warn("Failed to decode JSON", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	accessToken, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if err := h.tokenService.Logout(r.Context(), logoutRequest.RefreshToken, accessToken); err != nil {
+		if errors.Is(err, entity.ErrInvalidToken) || errors.Is(err, entity.ErrTokenReused) {
+			logger.Warn("Invalid refresh token", "error", err)
+			entity.SendResponse[any](w, http.StatusUnauthorized, true, "Invalid refresh token", nil)
+			return
+		}
+		logger.Error("Failed to logout", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully logout", nil)
+	logger.Info("Successfully logged out")
+}
+
+// LogoutAll handles logout from every device
+// @Summary Log out everywhere
+// @Description Revokes every refresh and access token of the authenticated user, including the one used for this request.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} swagger.LogoutResponse "Successfully logout"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/logout-all [post]
+func (h *Handler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "auth_handler", "LogoutAll")
+	logger.Debug("Attempting to logout everywhere")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	if err := h.tokenService.LogoutAll(r.Context(), userID); err != nil {
+		logger.Error("Failed to logout everywhere", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully logout", nil)
+	logger.Info("Successfully logged out everywhere")
+}
+
+// GetSessions lists where the caller is logged in
+// @Summary List sessions
+// @Description Lists the sessions of the authenticated user, one per login, the most recently used first. The session of the access token used is marked current.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListSessionResponse "Sessions successfully retrieved"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/sessions [get]
+func (h *Handler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "auth_handler", "GetSessions")
+	logger.Debug("Attempting to get sessions")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	accessToken, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	sessions, err := h.tokenService.Sessions(r.Context(), userID, accessToken)
+	if err != nil {
+		logger.Error("Failed to get sessions", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", sessions)
+	logger.Info("Successfully fetched sessions", "count", len(sessions))
+}
+
+// DeleteSession revokes one session of the caller
+// @Summary Revoke session
+// @Description Logs the authenticated user out of one session, revoking its refresh and access tokens.
+// @Tags auth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.RevokeResponse "Session successfully revoked"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid session ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Session not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/sessions/{id} [delete]
+func (h *Handler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "auth_handler", "DeleteSession")
+	logger.Debug("Attempting to revoke session")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		logger.Warn("Invalid session ID")
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid session ID", nil)
+		return
+	}
+
+	if err := h.tokenService.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, entity.ErrSessionNotFound) {
+			logger.Warn("Session not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Session not found", nil)
+			return
+		}
+		logger.Error("Failed to revoke session", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully revoke", nil)
+	logger.Info("Successfully revoked session")
+}
+
+// CreateAppPassword creates a password for a CalDAV client
+// @Summary Create app password
+// @Description Creates a password for a client that signs in with HTTP Basic auth, such as a CalDAV app. The account password is not accepted there.
+// @Description The password is only returned here. It is listed and revoked as a session, and logging out everywhere or changing the password ends it too.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body swagger.AppPasswordRequest true "Client name"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateAppPasswordResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/app-passwords [post]
+func (h *Handler) CreateAppPassword(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "auth_handler", "CreateAppPassword")
+	logger.Debug("Attempting to create app password")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var request entity.AppPasswordRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode JSON", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	appPassword, err := h.tokenService.CreateAppPassword(r.Context(), userID, request, sessionClient(r))
+	if err != nil {
+		logger.Error("Failed to create app password", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", appPassword)
+	logger.Info("Successfully created app password", "session_id", appPassword.SessionID)
+}
+
+// GetMe returns the profile of the caller
+// @Summary Get profile
+// @Description Returns the authenticated user's profile, including the time zone used for agendas and stats.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} swagger.ProfileResponse "Profile successfully retrieved"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "User not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /me [get]
+func (h *Handler) GetMe(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "auth_handler", "GetMe")
+	logger.Debug("Attempting to get profile")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	user, err := h.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		h.sendProfileError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", user.Profile())
+	logger.Info("Successfully fetched profile")
+}
+
+// UpdateMe updates the profile of the caller
+// @Summary Update profile
+// @Description Updates the authenticated user's profile. Fields left out are not changed. Changing the email address requires the current password.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param profile body swagger.ProfileUpdateRequest true "Profile changes"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ProfileResponse "Profile successfully updated"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Wrong current password"
+// @Failure 404 {object} swagger.NotFoundResponse "User not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /me [patch]
+func (h *Handler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "auth_handler", "UpdateMe")
+	logger.Debug("Attempting to update profile")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var update entity.ProfileUpdate
+	if err := utils.DecodeJSONStruct(r, &update); err != nil {
+		logger.Warn("Failed to decode JSON", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := update.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(r.Context(), userID, update)
+	if err != nil {
+		h.sendProfileError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully update", user.Profile())
+	logger.Info("Successfully updated profile")
+}
+
+func (h *Handler) sendProfileError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	if errors.Is(err, entity.ErrWrongPassword) {
+		logger.Warn("Wrong password")
+		entity.SendResponse[any](w, http.StatusForbidden, true, "Wrong password", nil)
+		return
+	}
+	if errors.Is(err, entity.ErrUserNotFound) {
+		logger.Warn("User not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "User not found", nil)
+		return
+	}
+	logger.Error("Failed to process profile request", "error", err)
+	entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+}
+
+// sessionClient describes the client of a login. The address is the peer of the
+// connection, as forwarding headers can be set by anyone.
+func sessionClient(r *http.Request) entity.SessionClient {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return entity.SessionClient{UserAgent: r.UserAgent(), IP: ip}
+}
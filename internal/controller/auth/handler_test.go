@@ -6,13 +6,16 @@ import (
 	"errors"
 	"github.com/GlebMoskalev/go-todo-api/internal/entity"
 	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestRegister(t *testing.T) {
@@ -155,7 +158,7 @@ func TestLogin(t *testing.T) {
 					}, nil)
 			},
 			prepareTokenService: func(mock *mocks.TokenService) {
-				mock.On("GenerateTokenPair", context.Background(), userID).
+				mock.On("GenerateTokenPair", context.Background(), userID, entity.SessionClient{}).
 					Return("access_token", "refresh_token", nil)
 			},
 			expectedHTTPStatus: http.StatusOK,
@@ -212,7 +215,7 @@ func TestLogin(t *testing.T) {
 					}, nil)
 			},
 			prepareTokenService: func(mock *mocks.TokenService) {
-				mock.On("GenerateTokenPair", context.Background(), userID).
+				mock.On("GenerateTokenPair", context.Background(), userID, entity.SessionClient{}).
 					Return("", "", errors.New("internal server error"))
 			},
 			expectedHTTPStatus: http.StatusInternalServerError,
@@ -300,6 +303,16 @@ func TestRefresh(t *testing.T) {
 			expectedHTTPStatus: http.StatusUnauthorized,
 			expectedResponse:   `{"code":401,"error":true,"message":"Invalid refresh token"}`,
 		},
+		{
+			name:         "reused refresh token",
+			inputRequest: `{"refresh_token": "refresh_token"}`,
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("RefreshTokens", context.Background(), "refresh_token").
+					Return("", "", entity.ErrTokenReused)
+			},
+			expectedHTTPStatus: http.StatusUnauthorized,
+			expectedResponse:   `{"code":401,"error":true,"message":"Invalid refresh token"}`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -326,3 +339,470 @@ func TestRefresh(t *testing.T) {
 		})
 	}
 }
+
+func TestLogout(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	testCases := []struct {
+		name                string
+		inputRequest        string
+		authorization       string
+		prepareTokenService func(mock *mocks.TokenService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name:          "successful logout",
+			inputRequest:  `{"refresh_token": "refresh_token"}`,
+			authorization: "Bearer access_token",
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("Logout", context.Background(), "refresh_token", "access_token").Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully logout"}`,
+		},
+		{
+			name:         "without access token",
+			inputRequest: `{"refresh_token": "refresh_token"}`,
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("Logout", context.Background(), "refresh_token", "").Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully logout"}`,
+		},
+		{
+			name:         "invalid refresh token",
+			inputRequest: `{"refresh_token": "refresh_token"}`,
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("Logout", context.Background(), "refresh_token", "").Return(entity.ErrInvalidToken)
+			},
+			expectedHTTPStatus: http.StatusUnauthorized,
+			expectedResponse:   `{"code":401,"error":true,"message":"Invalid refresh token"}`,
+		},
+		{
+			name:         "reused refresh token",
+			inputRequest: `{"refresh_token": "refresh_token"}`,
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("Logout", context.Background(), "refresh_token", "").Return(entity.ErrTokenReused)
+			},
+			expectedHTTPStatus: http.StatusUnauthorized,
+			expectedResponse:   `{"code":401,"error":true,"message":"Invalid refresh token"}`,
+		},
+		{
+			name:         "service error",
+			inputRequest: `{"refresh_token": "refresh_token"}`,
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("Logout", context.Background(), "refresh_token", "").Return(errors.New("db error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+		{
+			name:               "invalid json",
+			inputRequest:       `{"refresh_token":`,
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userServiceMock := mocks.NewUserService(t)
+			tokenServiceMock := mocks.NewTokenService(t)
+
+			if tc.prepareTokenService != nil {
+				tc.prepareTokenService(tokenServiceMock)
+			}
+
+			handler := NewHandler(userServiceMock, tokenServiceMock, logger)
+			req, err := http.NewRequest("POST", "auth/logout", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rr := httptest.NewRecorder()
+			handler.Logout(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+
+	testCases := []struct {
+		name                string
+		prepareTokenService func(mock *mocks.TokenService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name: "successful logout",
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("LogoutAll", context.WithValue(context.Background(), "id", userID), userID).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully logout"}`,
+		},
+		{
+			name: "service error",
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("LogoutAll", context.WithValue(context.Background(), "id", userID), userID).
+					Return(errors.New("db error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userServiceMock := mocks.NewUserService(t)
+			tokenServiceMock := mocks.NewTokenService(t)
+
+			if tc.prepareTokenService != nil {
+				tc.prepareTokenService(tokenServiceMock)
+			}
+
+			handler := NewHandler(userServiceMock, tokenServiceMock, logger)
+			req, err := http.NewRequest("POST", "auth/logout-all", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+			handler.LogoutAll(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestGetSessions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	sessionID := uuid.MustParse("5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77")
+	createdAt := time.Date(2025, 4, 1, 9, 12, 44, 0, time.UTC)
+	lastUsedAt := time.Date(2025, 4, 2, 17, 3, 10, 0, time.UTC)
+
+	testCases := []struct {
+		name                string
+		prepareTokenService func(mock *mocks.TokenService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name: "successful fetch",
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("Sessions", context.WithValue(context.Background(), "id", userID), userID, "access_token").
+					Return([]entity.Session{{
+						ID:         sessionID,
+						DeviceName: "Firefox on Windows",
+						UserAgent:  "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+						IP:         "203.0.113.7",
+						CreatedAt:  createdAt,
+						LastUsedAt: lastUsedAt,
+						Current:    true,
+					}}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":[{
+				"id":"5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77","device_name":"Firefox on Windows",
+				"user_agent":"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+				"ip":"203.0.113.7","app_password":false,"created_at":"2025-04-01T09:12:44Z",
+				"last_used_at":"2025-04-02T17:03:10Z","current":true}]}`,
+		},
+		{
+			name: "service error",
+			prepareTokenService: func(mock *mocks.TokenService) {
+				mock.On("Sessions", context.WithValue(context.Background(), "id", userID), userID, "access_token").
+					Return(nil, errors.New("db error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userServiceMock := mocks.NewUserService(t)
+			tokenServiceMock := mocks.NewTokenService(t)
+
+			if tc.prepareTokenService != nil {
+				tc.prepareTokenService(tokenServiceMock)
+			}
+
+			handler := NewHandler(userServiceMock, tokenServiceMock, logger)
+			req, err := http.NewRequest("GET", "/auth/sessions", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer access_token")
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+			handler.GetSessions(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestDeleteSession(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	sessionID := uuid.MustParse("5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77")
+
+	testCases := []struct {
+		name                string
+		inputID             string
+		prepareTokenService func(serviceMock *mocks.TokenService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name:    "successful revoke",
+			inputID: sessionID.String(),
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("RevokeSession", mock.Anything, userID, sessionID).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully revoke"}`,
+		},
+		{
+			name:               "invalid id",
+			inputID:            "not-a-session",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid session ID"}`,
+		},
+		{
+			name:    "session not found",
+			inputID: sessionID.String(),
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("RevokeSession", mock.Anything, userID, sessionID).Return(entity.ErrSessionNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Session not found"}`,
+		},
+		{
+			name:    "service error",
+			inputID: sessionID.String(),
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("RevokeSession", mock.Anything, userID, sessionID).Return(errors.New("db error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userServiceMock := mocks.NewUserService(t)
+			tokenServiceMock := mocks.NewTokenService(t)
+
+			if tc.prepareTokenService != nil {
+				tc.prepareTokenService(tokenServiceMock)
+			}
+
+			handler := NewHandler(userServiceMock, tokenServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/auth", func(r chi.Router) {
+				RegisterSessionRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("DELETE", "/auth/sessions/"+tc.inputID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestCreateAppPassword(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	sessionID := uuid.MustParse("5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77")
+
+	testCases := []struct {
+		name                string
+		inputRequest        string
+		prepareTokenService func(serviceMock *mocks.TokenService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name:         "successful create",
+			inputRequest: `{"name":"DAVx5 on Pixel 8"}`,
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("CreateAppPassword", mock.Anything, userID,
+					entity.AppPasswordRequest{Name: "DAVx5 on Pixel 8"}, entity.SessionClient{}).
+					Return(entity.AppPassword{SessionID: sessionID, Name: "DAVx5 on Pixel 8", Password: "app_password"}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully create","data":{
+				"session_id":"5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77","name":"DAVx5 on Pixel 8","password":"app_password"}}`,
+		},
+		{
+			name:               "missing name",
+			inputRequest:       `{}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'name' is required"}`,
+		},
+		{
+			name:         "service error",
+			inputRequest: `{"name":"DAVx5 on Pixel 8"}`,
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("CreateAppPassword", mock.Anything, userID,
+					entity.AppPasswordRequest{Name: "DAVx5 on Pixel 8"}, entity.SessionClient{}).
+					Return(entity.AppPassword{}, errors.New("db error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userServiceMock := mocks.NewUserService(t)
+			tokenServiceMock := mocks.NewTokenService(t)
+
+			if tc.prepareTokenService != nil {
+				tc.prepareTokenService(tokenServiceMock)
+			}
+
+			handler := NewHandler(userServiceMock, tokenServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/auth", func(r chi.Router) {
+				RegisterSessionRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/auth/app-passwords", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUpdateMe(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	timezone := "Europe/Berlin"
+	email := "john@example.com"
+
+	testCases := []struct {
+		name               string
+		inputRequest       string
+		prepareUserService func(mock *mocks.UserService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful update",
+			inputRequest: `{"timezone":"Europe/Berlin"}`,
+			prepareUserService: func(mock *mocks.UserService) {
+				mock.On("UpdateProfile", context.WithValue(context.Background(), "id", userID), userID,
+					entity.ProfileUpdate{Timezone: &timezone}).
+					Return(entity.User{ID: userID, Username: "john_doe", Timezone: timezone}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully update",
+				"data":{"id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","username":"john_doe","timezone":"Europe/Berlin"}}`,
+		},
+		{
+			name:         "set email",
+			inputRequest: `{"email":"john@example.com","current_password":"Secret123"}`,
+			prepareUserService: func(mock *mocks.UserService) {
+				mock.On("UpdateProfile", context.WithValue(context.Background(), "id", userID), userID,
+					entity.ProfileUpdate{Email: &email, CurrentPassword: "Secret123"}).
+					Return(entity.User{ID: userID, Username: "john_doe", Timezone: "UTC", Email: email}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully update",
+				"data":{"id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","username":"john_doe","timezone":"UTC","email":"john@example.com"}}`,
+		},
+		{
+			name:               "email without current password",
+			inputRequest:       `{"email":"john@example.com"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'current_password' is required"}`,
+		},
+		{
+			name:         "email with wrong password",
+			inputRequest: `{"email":"john@example.com","current_password":"Wrong123"}`,
+			prepareUserService: func(mock *mocks.UserService) {
+				mock.On("UpdateProfile", context.WithValue(context.Background(), "id", userID), userID,
+					entity.ProfileUpdate{Email: &email, CurrentPassword: "Wrong123"}).
+					Return(entity.User{}, entity.ErrWrongPassword)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Wrong password"}`,
+		},
+		{
+			name:               "invalid email",
+			inputRequest:       `{"email":"john at example","current_password":"Secret123"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'email' must be an email address"}`,
+		},
+		{
+			name:               "unknown time zone",
+			inputRequest:       `{"timezone":"Mars/Olympus"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'timezone' must be an IANA time zone such as Europe/Berlin"}`,
+		},
+		{
+			name:         "user not found",
+			inputRequest: `{"timezone":"Europe/Berlin"}`,
+			prepareUserService: func(mock *mocks.UserService) {
+				mock.On("UpdateProfile", context.WithValue(context.Background(), "id", userID), userID,
+					entity.ProfileUpdate{Timezone: &timezone}).
+					Return(entity.User{}, entity.ErrUserNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"User not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userServiceMock := mocks.NewUserService(t)
+			tokenServiceMock := mocks.NewTokenService(t)
+
+			if tc.prepareUserService != nil {
+				tc.prepareUserService(userServiceMock)
+			}
+
+			handler := NewHandler(userServiceMock, tokenServiceMock, logger)
+			req, err := http.NewRequest("PATCH", "/me", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+			handler.UpdateMe(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
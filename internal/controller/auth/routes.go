@@ -6,4 +6,19 @@ func RegisterRoutes(r chi.Router, h *Handler) {
 	r.Post("/register", h.Register)
 	r.Post("/login", h.Login)
 	r.Post("/refresh", h.Refresh)
+	r.Post("/logout", h.Logout)
+}
+
+// RegisterSessionRoutes mounts the auth routes that need an authenticated user.
+func RegisterSessionRoutes(r chi.Router, h *Handler) {
+	r.Post("/logout-all", h.LogoutAll)
+	r.Get("/sessions", h.GetSessions)
+	r.Delete("/sessions/{id}", h.DeleteSession)
+	r.Post("/app-passwords", h.CreateAppPassword)
+}
+
+// RegisterProfileRoutes mounts the profile of the authenticated user.
+func RegisterProfileRoutes(r chi.Router, h *Handler) {
+	r.Get("/", h.GetMe)
+	r.Patch("/", h.UpdateMe)
 }
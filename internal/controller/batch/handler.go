@@ -0,0 +1,192 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Handler dispatches the calls of a batch to the API router in-process.
+type Handler struct {
+	router      http.Handler
+	basePath    string
+	maxRequests int
+	concurrency int
+	logger      *slog.Logger
+}
+
+// NewHandler returns a handler that serves sub-requests with router. Their paths are
+// resolved against basePath, and at most concurrency of them run at the same time.
+func NewHandler(router http.Handler, basePath string, maxRequests, concurrency int, logger *slog.Logger) *Handler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Handler{
+		router:      router,
+		basePath:    strings.TrimRight(basePath, "/"),
+		maxRequests: maxRequests,
+		concurrency: concurrency,
+		logger:      logger,
+	}
+}
+
+// Batch runs several API calls in one request
+// @Summary Batch requests
+// @Description Dispatches each sub-request to the API as if it was sent on its own, with the caller's authorization. Paths are relative to the API base path.
+// @Description Sub-requests run concurrently, so one must not depend on another. The responses are returned in the order of the requests.
+// @Tags batch
+// @Accept json
+// @Produce json
+// @Param request body swagger.BatchRequest true "Sub-requests"
+// @Security BearerAuth
+// @Success 200 {object} swagger.BatchResponse "Successfully batch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "batch_handler", "Batch")
+	logger.Debug("Attempting to run batch")
+
+	var request entity.BatchRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if msg := h.validate(request); msg != "" {
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("requests", len(request.Requests))
+	responses := make([]entity.SubResponse, len(request.Requests))
+	semaphore := make(chan struct{}, h.concurrency)
+	var wg sync.WaitGroup
+	for i, sub := range request.Requests {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			responses[i] = h.dispatch(r, sub)
+		}()
+	}
+	wg.Wait()
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully batch", responses)
+	logger.Info("Successfully ran batch")
+}
+
+// validate returns a client-facing message describing what is wrong with the batch,
+// or an empty string if it may run.
+func (h *Handler) validate(request entity.BatchRequest) string {
+	if validationErrors := request.Validate(); validationErrors != nil {
+		return fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+	}
+	if h.maxRequests > 0 && len(request.Requests) > h.maxRequests {
+		return fmt.Sprintf("Validation error: Field 'requests' must not exceed %d", h.maxRequests)
+	}
+	for i, sub := range request.Requests {
+		if validationErrors := sub.Validate(); validationErrors != nil {
+			return fmt.Sprintf("Validation error: requests[%d]: %s", i, strings.Join(validationErrors, ";"))
+		}
+		u, err := url.Parse(sub.Path)
+		if err != nil || u.Host != "" || strings.ContainsRune(sub.Path, '#') {
+			return fmt.Sprintf("Validation error: requests[%d]: invalid path", i)
+		}
+		if p := path.Clean(u.Path); p == "/batch" || strings.HasPrefix(p, "/batch/") {
+			return fmt.Sprintf("Validation error: requests[%d]: batches cannot be nested", i)
+		}
+	}
+	return ""
+}
+
+// dispatch serves one sub-request and records its response. The sub-request gets a
+// fresh routing context but keeps the caller's authorization and cancellation.
+func (h *Handler) dispatch(r *http.Request, sub entity.SubRequest) entity.SubResponse {
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, nil)
+	req, err := http.NewRequestWithContext(ctx, sub.Method, h.basePath+sub.Path, bytes.NewReader(sub.Body))
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "Invalid request path")
+	}
+	for name, value := range sub.Headers {
+		req.Header.Set(name, value)
+	}
+	if len(sub.Body) > 0 && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", r.Header.Get("Authorization"))
+	req.RemoteAddr = r.RemoteAddr
+
+	recorder := newResponseRecorder()
+	h.router.ServeHTTP(recorder, req)
+	return recorder.result()
+}
+
+func errorResponse(status int, message string) entity.SubResponse {
+	body, _ := json.Marshal(entity.Response[any]{Code: status, Error: true, Message: message})
+	return entity.SubResponse{Status: status, Body: body}
+}
+
+// responseRecorder buffers the response of a sub-request.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: make(http.Header)}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) result() entity.SubResponse {
+	response := entity.SubResponse{Status: r.status}
+	if response.Status == 0 {
+		response.Status = http.StatusOK
+	}
+
+	for name := range r.header {
+		if response.Headers == nil {
+			response.Headers = make(map[string]string)
+		}
+		response.Headers[name] = r.header.Get(name)
+	}
+
+	body := bytes.TrimSpace(r.body.Bytes())
+	switch {
+	case len(body) == 0:
+	case json.Valid(body):
+		response.Body = body
+	default:
+		response.Body, _ = json.Marshal(string(body))
+	}
+	return response
+}
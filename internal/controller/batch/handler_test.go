@@ -0,0 +1,210 @@
+package batch
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestRouter builds an API with a few endpoints and the batch endpoint under /api/v2.
+// Only requests carrying "Bearer good" are authorized. Panics are recovered as in the
+// application router.
+func newTestRouter(maxRequests, concurrency int, logger *slog.Logger) (*chi.Mux, *atomic.Int32) {
+	var peak, running atomic.Int32
+	r := chi.NewRouter()
+	r.Use(chiMiddleware.Recoverer)
+	h := NewHandler(r, "/api/v2", maxRequests, concurrency, logger)
+
+	r.Route("/api/v2", func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer good" {
+					entity.SendResponse[any](w, http.StatusUnauthorized, true, "Invalid or expired token", nil)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.Get("/todos/{id}", func(w http.ResponseWriter, r *http.Request) {
+			entity.SendResponse(w, http.StatusOK, false, "Successfully fetch",
+				map[string]string{"id": chi.URLParam(r, "id"), "workspace": r.Header.Get("X-Workspace-ID"), "limit": r.URL.Query().Get("limit")})
+		})
+		r.Post("/todos", func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			if err := utils.DecodeJSONStruct(r, &body); err != nil {
+				entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+				return
+			}
+			entity.SendResponse(w, http.StatusCreated, false, "Successfully create", body)
+		})
+		r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})
+		r.Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+			current := running.Add(1)
+			for {
+				old := peak.Load()
+				if current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "done")
+		})
+		r.Route("/batch", func(r chi.Router) {
+			RegisterRoutes(r, h)
+		})
+	})
+	return r, &peak
+}
+
+func TestBatch(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	testCases := []struct {
+		name               string
+		inputRequest       string
+		authorization      string
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name: "successful batch",
+			inputRequest: `{"requests":[` +
+				`{"method":"GET","path":"/todos/12?limit=5","headers":{"X-Workspace-ID":"3"}},` +
+				`{"method":"POST","path":"/todos","body":{"title":"Buy milk"}},` +
+				`{"method":"GET","path":"/unknown"}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully batch","data":[` +
+				`{"status":200,"headers":{"Content-Type":"application/json"},"body":{"code":200,"error":false,` +
+				`"message":"Successfully fetch","data":{"id":"12","workspace":"3","limit":"5"}}},` +
+				`{"status":201,"headers":{"Content-Type":"application/json"},"body":{"code":201,"error":false,` +
+				`"message":"Successfully create","data":{"title":"Buy milk"}}},` +
+				`{"status":404,"headers":{"Content-Type":"text/plain; charset=utf-8","X-Content-Type-Options":"nosniff"},` +
+				`"body":"404 page not found"}]}`,
+		},
+		{
+			name:               "panicking sub-request",
+			inputRequest:       `{"requests":[{"method":"GET","path":"/panic"},{"method":"GET","path":"/todos/12"}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully batch","data":[` +
+				`{"status":500},` +
+				`{"status":200,"headers":{"Content-Type":"application/json"},"body":{"code":200,"error":false,` +
+				`"message":"Successfully fetch","data":{"id":"12","workspace":"","limit":""}}}]}`,
+		},
+		{
+			name:               "sub-requests share the caller's authorization",
+			inputRequest:       `{"requests":[{"method":"GET","path":"/todos/12","headers":{"Authorization":"Bearer good"}}]}`,
+			authorization:      "Bearer bad",
+			expectedHTTPStatus: http.StatusUnauthorized,
+			expectedResponse:   `{"code":401,"error":true,"message":"Invalid or expired token"}`,
+		},
+		{
+			name:               "sub-request cannot override authorization",
+			inputRequest:       `{"requests":[{"method":"GET","path":"/todos/12","headers":{"Authorization":"Bearer bad"}}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully batch","data":[` +
+				`{"status":200,"headers":{"Content-Type":"application/json"},"body":{"code":200,"error":false,` +
+				`"message":"Successfully fetch","data":{"id":"12","workspace":"","limit":""}}}]}`,
+		},
+		{
+			name:               "no requests",
+			inputRequest:       `{"requests":[]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'requests' must be at least 1"}`,
+		},
+		{
+			name: "too many requests",
+			inputRequest: `{"requests":[{"method":"GET","path":"/todos/1"},{"method":"GET","path":"/todos/2"},` +
+				`{"method":"GET","path":"/todos/3"},{"method":"GET","path":"/todos/4"}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'requests' must not exceed 3"}`,
+		},
+		{
+			name:               "invalid method",
+			inputRequest:       `{"requests":[{"method":"GET","path":"/todos/1"},{"method":"TRACE","path":"/todos/2"}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse: `{"code":400,"error":true,"message":"Validation error: requests[1]: ` +
+				`Field 'method' must be one of: GET POST PUT PATCH DELETE"}`,
+		},
+		{
+			name:               "nested batch",
+			inputRequest:       `{"requests":[{"method":"POST","path":"/batch","body":{"requests":[]}}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: requests[0]: batches cannot be nested"}`,
+		},
+		{
+			name:               "nested batch behind a dot segment",
+			inputRequest:       `{"requests":[{"method":"POST","path":"/todos/../batch","body":{"requests":[]}}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: requests[0]: batches cannot be nested"}`,
+		},
+		{
+			name:               "path with a fragment",
+			inputRequest:       `{"requests":[{"method":"POST","path":"/batch#x","body":{"requests":[]}}]}`,
+			authorization:      "Bearer good",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: requests[0]: invalid path"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := newTestRouter(3, 2, logger)
+
+			req, err := http.NewRequest("POST", "/api/v2/batch", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", tc.authorization)
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestBatchConcurrency(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	r, peak := newTestRouter(10, 2, logger)
+
+	body := `{"requests":[` +
+		`{"method":"GET","path":"/slow"},{"method":"GET","path":"/slow"},{"method":"GET","path":"/slow"},` +
+		`{"method":"GET","path":"/slow"},{"method":"GET","path":"/slow"},{"method":"GET","path":"/slow"}]}`
+	req, err := http.NewRequest("POST", "/api/v2/batch", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer good")
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `{"status":200,"headers":{"Content-Type":"text/plain"},"body":"done"}`)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
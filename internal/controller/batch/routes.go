@@ -0,0 +1,7 @@
+package batch
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Post("/", h.Batch)
+}
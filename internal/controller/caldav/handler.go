@@ -0,0 +1,465 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/ical"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxBodyBytes   = 1 << 20
+	collectionName = "todos"
+	objectType     = "text/calendar; charset=utf-8; component=vtodo"
+)
+
+func init() {
+	chi.RegisterMethod("PROPFIND")
+	chi.RegisterMethod("REPORT")
+}
+
+// Handler serves the personal todos of a user as a CalDAV calendar: the principal
+// and calendar home live at the prefix and the calendar at <prefix>/todos/.
+type Handler struct {
+	service service.CalDAVService
+	prefix  string
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.CalDAVService, prefix string, logger *slog.Logger) *Handler {
+	return &Handler{service: service, prefix: strings.TrimSuffix(prefix, "/"), logger: logger}
+}
+
+func (h *Handler) homePath() string {
+	return h.prefix + "/"
+}
+
+func (h *Handler) collectionPath() string {
+	return h.prefix + "/" + collectionName + "/"
+}
+
+func (h *Handler) objectPath(name string) string {
+	return h.collectionPath() + url.PathEscape(name)
+}
+
+// Options advertises the DAV classes and methods supported.
+func (h *Handler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+	w.WriteHeader(http.StatusOK)
+}
+
+// PropfindHome describes the principal of the caller, which is also its calendar
+// home. With Depth 1 the calendar is listed as well.
+func (h *Handler) PropfindHome(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "caldav_handler", "PropfindHome")
+	logger.Debug("Attempting to describe calendar home")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	names, ok := h.propfindNames(w, r, logger)
+	if !ok {
+		return
+	}
+
+	var ms multistatus
+	ms.add(h.homePath(), h.homeProps(), names)
+	if depth(r) != "0" {
+		collection, err := h.service.Collection(r.Context(), userID)
+		if err != nil {
+			h.sendError(w, logger, err)
+			return
+		}
+		ms.add(h.collectionPath(), h.collectionProps(collection), names)
+	}
+	ms.write(w)
+	logger.Info("Successfully described calendar home")
+}
+
+// PropfindCollection describes the calendar and, with Depth 1, its todos.
+func (h *Handler) PropfindCollection(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "caldav_handler", "PropfindCollection")
+	logger.Debug("Attempting to describe calendar")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	names, ok := h.propfindNames(w, r, logger)
+	if !ok {
+		return
+	}
+
+	collection, err := h.service.Collection(r.Context(), userID)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	var ms multistatus
+	ms.add(h.collectionPath(), h.collectionProps(collection), names)
+	if depth(r) != "0" {
+		for _, resource := range collection.Resources {
+			ms.add(h.objectPath(resource.Name), objectProps(resource, names), names)
+		}
+	}
+	ms.write(w)
+	logger.Info("Successfully described calendar", "count", len(collection.Resources))
+}
+
+// PropfindObject describes a single todo.
+func (h *Handler) PropfindObject(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "caldav_handler", "PropfindObject")
+	logger.Debug("Attempting to describe calendar object")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	names, ok := h.propfindNames(w, r, logger)
+	if !ok {
+		return
+	}
+
+	resource, err := h.service.Get(r.Context(), userID, chi.URLParam(r, "name"))
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	var ms multistatus
+	ms.add(h.objectPath(resource.Name), objectProps(resource, names), names)
+	ms.write(w)
+	logger.Info("Successfully described calendar object", "todo_id", resource.Todo.ID)
+}
+
+// Report answers the calendar-query, calendar-multiget and sync-collection reports
+// on the calendar.
+func (h *Handler) Report(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "caldav_handler", "Report")
+	logger.Debug("Attempting to run report")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var request reportRequest
+	if err := decodeXML(r.Body, &request); err != nil {
+		logger.Warn("Failed to decode xml", "error", err)
+		http.Error(w, "Invalid XML body", http.StatusBadRequest)
+		return
+	}
+	var names []xml.Name
+	if request.Prop != nil {
+		names = request.Prop.names()
+	}
+
+	var ms multistatus
+	switch request.XMLName {
+	case calDAVName("calendar-query"):
+		collection, err := h.service.Collection(r.Context(), userID)
+		if err != nil {
+			h.sendError(w, logger, err)
+			return
+		}
+		for _, resource := range collection.Resources {
+			ms.add(h.objectPath(resource.Name), objectProps(resource, names), names)
+		}
+	case calDAVName("calendar-multiget"):
+		for _, ref := range request.Hrefs {
+			name, ok := h.objectName(ref)
+			if !ok {
+				ms.addStatus(ref, http.StatusNotFound)
+				continue
+			}
+			resource, err := h.service.Get(r.Context(), userID, name)
+			if errors.Is(err, entity.ErrCalDAVObjectNotFound) {
+				ms.addStatus(h.objectPath(name), http.StatusNotFound)
+				continue
+			}
+			if err != nil {
+				h.sendError(w, logger, err)
+				return
+			}
+			ms.add(h.objectPath(resource.Name), objectProps(resource, names), names)
+		}
+	case davName("sync-collection"):
+		var since entity.SyncToken
+		if strings.TrimSpace(request.SyncToken) != "" {
+			token, err := entity.ParseSyncToken(request.SyncToken)
+			if err != nil {
+				h.sendError(w, logger, err)
+				return
+			}
+			since = token
+		}
+		sync, err := h.service.Sync(r.Context(), userID, since)
+		if err != nil {
+			h.sendError(w, logger, err)
+			return
+		}
+		for _, resource := range sync.Changed {
+			ms.add(h.objectPath(resource.Name), objectProps(resource, names), names)
+		}
+		for _, name := range sync.Deleted {
+			ms.addStatus(h.objectPath(name), http.StatusNotFound)
+		}
+		ms.addSyncToken(sync.SyncToken.String())
+	default:
+		logger.Warn("Unsupported report", "report", request.XMLName.Local)
+		writeError(w, http.StatusForbidden, davName("supported-report"))
+		return
+	}
+
+	ms.write(w)
+	logger.Info("Successfully ran report", "report", request.XMLName.Local)
+}
+
+// Get returns a todo as an iCalendar object.
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "caldav_handler", "Get")
+	logger.Debug("Attempting to fetch calendar object")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	resource, err := h.service.Get(r.Context(), userID, chi.URLParam(r, "name"))
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ical.WriteObject(&buf, resource.Todo, resource.UID, time.Now()); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", objectType)
+	w.Header().Set("ETag", resource.ETag())
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = buf.WriteTo(w)
+	logger.Info("Successfully fetched calendar object", "todo_id", resource.Todo.ID)
+}
+
+// Put creates or replaces a todo from the VTODO in the body.
+func (h *Handler) Put(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "caldav_handler", "Put")
+	logger.Debug("Attempting to write calendar object")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		logger.Warn("Failed to read body", "error", err)
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(data) > maxBodyBytes {
+		logger.Warn("Calendar object too large")
+		writeError(w, http.StatusRequestEntityTooLarge, calDAVName("max-resource-size"))
+		return
+	}
+
+	todo, uid, err := ical.ParseObject(data)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(todo.Title)) < 3 {
+		logger.Warn("Summary too short")
+		writeError(w, http.StatusForbidden, calDAVName("valid-calendar-object-resource"))
+		return
+	}
+
+	resource, created, err := h.service.Put(r.Context(), userID, chi.URLParam(r, "name"), todo, uid, preconditions(r))
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	w.Header().Set("ETag", resource.ETag())
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	logger.Info("Successfully wrote calendar object", "todo_id", resource.Todo.ID, "created", created)
+}
+
+// Delete deletes a todo.
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "caldav_handler", "Delete")
+	logger.Debug("Attempting to delete calendar object")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		http.Error(w, "User not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userID, chi.URLParam(r, "name"), preconditions(r)); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	logger.Info("Successfully deleted calendar object")
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrCalDAVObjectNotFound):
+		logger.Warn("Calendar object not found")
+		http.Error(w, "Calendar object not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrPreconditionFailed):
+		logger.Warn("Precondition failed")
+		http.Error(w, "Precondition failed", http.StatusPreconditionFailed)
+	case errors.Is(err, entity.ErrInvalidSyncToken):
+		logger.Warn("Invalid sync token")
+		writeError(w, http.StatusForbidden, davName("valid-sync-token"))
+	case errors.Is(err, entity.ErrInvalidCalendarData):
+		logger.Warn("Invalid calendar data", "error", err)
+		writeError(w, http.StatusForbidden, calDAVName("valid-calendar-data"))
+	case errors.Is(err, entity.ErrForbidden):
+		logger.Warn("Forbidden")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	default:
+		logger.Error("Internal server error", "error", err)
+		http.Error(w, "Something went wrong, please try again later", http.StatusInternalServerError)
+	}
+}
+
+// propfindNames returns the properties asked for, or nil for all of them.
+func (h *Handler) propfindNames(w http.ResponseWriter, r *http.Request, logger *slog.Logger) ([]xml.Name, bool) {
+	var request propfindRequest
+	if err := decodeXML(r.Body, &request); err != nil {
+		logger.Warn("Failed to decode xml", "error", err)
+		http.Error(w, "Invalid XML body", http.StatusBadRequest)
+		return nil, false
+	}
+	if request.Prop == nil || request.AllProp != nil {
+		return nil, true
+	}
+	return request.Prop.names(), true
+}
+
+// objectName returns the name of the todo an href of the calendar points to.
+func (h *Handler) objectName(ref string) (string, bool) {
+	if u, err := url.Parse(ref); err == nil {
+		ref = u.Path
+	}
+	dir, name := path.Split(ref)
+	if dir != h.collectionPath() || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+func (h *Handler) homeProps() []prop {
+	return []prop{
+		{name: davName("resourcetype"), value: "<d:collection/><d:principal/>"},
+		{name: davName("displayname"), value: "go-todo-api"},
+		{name: davName("current-user-principal"), value: href(h.homePath())},
+		{name: davName("principal-URL"), value: href(h.homePath())},
+		{name: calDAVName("calendar-home-set"), value: href(h.homePath())},
+	}
+}
+
+func (h *Handler) collectionProps(collection entity.CalDAVCollection) []prop {
+	token := escape(collection.SyncToken.String())
+	return []prop{
+		{name: davName("resourcetype"), value: "<d:collection/><c:calendar/>"},
+		{name: davName("displayname"), value: "Todos"},
+		{name: davName("owner"), value: href(h.homePath())},
+		{name: davName("current-user-principal"), value: href(h.homePath())},
+		{name: davName("current-user-privilege-set"), value: "<d:privilege><d:read/></d:privilege>" +
+			"<d:privilege><d:write/></d:privilege><d:privilege><d:write-content/></d:privilege>" +
+			"<d:privilege><d:bind/></d:privilege><d:privilege><d:unbind/></d:privilege>"},
+		{name: davName("supported-report-set"), value: "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><d:sync-collection/></d:report></d:supported-report>"},
+		{name: calDAVName("supported-calendar-component-set"), value: `<c:comp name="VTODO"/>`},
+		{name: xml.Name{Space: nsCalServer, Local: "getctag"}, value: token},
+		{name: davName("sync-token"), value: token},
+	}
+}
+
+// objectProps only renders calendar-data when it is asked for.
+func objectProps(resource entity.CalDAVResource, names []xml.Name) []prop {
+	props := []prop{
+		{name: davName("resourcetype")},
+		{name: davName("getetag"), value: escape(resource.ETag())},
+		{name: davName("getcontenttype"), value: escape(objectType)},
+	}
+	if resource.Todo.UpdatedAt != nil {
+		props = append(props, prop{name: davName("getlastmodified"), value: resource.Todo.UpdatedAt.UTC().Format(http.TimeFormat)})
+	}
+	for _, name := range names {
+		if name == calDAVName("calendar-data") {
+			var buf bytes.Buffer
+			if err := ical.WriteObject(&buf, resource.Todo, resource.UID, time.Now()); err == nil {
+				props = append(props, prop{name: name, value: escape(buf.String())})
+			}
+		}
+	}
+	return props
+}
+
+func (p *propNames) names() []xml.Name {
+	names := make([]xml.Name, 0, len(p.Names))
+	for _, element := range p.Names {
+		names = append(names, element.XMLName)
+	}
+	return names
+}
+
+func depth(r *http.Request) string {
+	if value := r.Header.Get("Depth"); value != "" {
+		return value
+	}
+	return "infinity"
+}
+
+func preconditions(r *http.Request) entity.Preconditions {
+	return entity.Preconditions{IfMatch: r.Header.Get("If-Match"), IfNoneMatch: r.Header.Get("If-None-Match")}
+}
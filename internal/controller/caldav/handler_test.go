@@ -0,0 +1,302 @@
+package caldav
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRouter(handler *Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/api/v1/caldav", func(r chi.Router) {
+		RegisterRoutes(r, handler)
+	})
+	return r
+}
+
+func TestPropfindCollection(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	updatedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	collection := entity.CalDAVCollection{
+		SyncToken: 12,
+		Resources: []entity.CalDAVResource{
+			{Name: "7.ics", UID: "todo-7@go-todo-api", Todo: entity.Todo{ID: 7, Title: "Pay rent", UpdatedAt: &updatedAt}},
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		depth                string
+		requestBody          string
+		prepareCalDAVService func(serviceMock *mocks.CalDAVService)
+		expectedHTTPStatus   int
+		expectedContains     []string
+		expectedNotContains  []string
+	}{
+		{
+			name:  "depth 1 lists todos",
+			depth: "1",
+			requestBody: `<?xml version="1.0"?><d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">` +
+				`<d:prop><d:resourcetype/><d:getetag/><cs:getctag/><d:quota-used-bytes/></d:prop></d:propfind>`,
+			prepareCalDAVService: func(serviceMock *mocks.CalDAVService) {
+				serviceMock.On("Collection", mock.Anything, userID).Return(collection, nil)
+			},
+			expectedHTTPStatus: http.StatusMultiStatus,
+			expectedContains: []string{
+				`<d:response><d:href>/api/v1/caldav/todos/</d:href><d:propstat><d:prop>` +
+					`<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>` +
+					`<cs:getctag>http://go-todo-api/ns/sync/12</cs:getctag></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>` +
+					`<d:propstat><d:prop><d:getetag/><d:quota-used-bytes/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat></d:response>`,
+				`<d:href>/api/v1/caldav/todos/7.ics</d:href><d:propstat><d:prop><d:resourcetype/>` +
+					`<d:getetag>&#34;7-1743508800000000000&#34;</d:getetag>`,
+			},
+		},
+		{
+			name:        "depth 0 without body",
+			depth:       "0",
+			requestBody: "",
+			prepareCalDAVService: func(serviceMock *mocks.CalDAVService) {
+				serviceMock.On("Collection", mock.Anything, userID).Return(collection, nil)
+			},
+			expectedHTTPStatus:  http.StatusMultiStatus,
+			expectedContains:    []string{`<c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set>`},
+			expectedNotContains: []string{"7.ics"},
+		},
+		{
+			name:               "invalid xml",
+			depth:              "1",
+			requestBody:        `<d:propfind xmlns:d="DAV:">`,
+			expectedHTTPStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calDAVServiceMock := mocks.NewCalDAVService(t)
+			if tc.prepareCalDAVService != nil {
+				tc.prepareCalDAVService(calDAVServiceMock)
+			}
+
+			r := newRouter(NewHandler(calDAVServiceMock, "/api/v1/caldav", logger))
+
+			req, err := http.NewRequest("PROPFIND", "/api/v1/caldav/todos/", strings.NewReader(tc.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Depth", tc.depth)
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, rr.Body.String(), expected)
+			}
+			for _, unexpected := range tc.expectedNotContains {
+				assert.NotContains(t, rr.Body.String(), unexpected)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	updatedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	resource := entity.CalDAVResource{
+		Name: "client-1.ics",
+		UID:  "client-1",
+		Todo: entity.Todo{ID: 9, Title: "Call mom", Tags: []string{}, UpdatedAt: &updatedAt},
+	}
+
+	testCases := []struct {
+		name                 string
+		requestBody          string
+		prepareCalDAVService func(serviceMock *mocks.CalDAVService)
+		expectedHTTPStatus   int
+		expectedContains     []string
+	}{
+		{
+			name: "sync collection",
+			requestBody: `<d:sync-collection xmlns:d="DAV:"><d:sync-token>http://go-todo-api/ns/sync/3</d:sync-token>` +
+				`<d:sync-level>1</d:sync-level><d:prop><d:getetag/></d:prop></d:sync-collection>`,
+			prepareCalDAVService: func(serviceMock *mocks.CalDAVService) {
+				serviceMock.On("Sync", mock.Anything, userID, entity.SyncToken(3)).Return(entity.CalDAVSync{
+					SyncToken: 5,
+					Changed:   []entity.CalDAVResource{resource},
+					Deleted:   []string{"4.ics"},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusMultiStatus,
+			expectedContains: []string{
+				`<d:href>/api/v1/caldav/todos/client-1.ics</d:href><d:propstat><d:prop><d:getetag>&#34;9-1743508800000000000&#34;</d:getetag>`,
+				`<d:response><d:href>/api/v1/caldav/todos/4.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status></d:response>`,
+				`<d:sync-token>http://go-todo-api/ns/sync/5</d:sync-token></d:multistatus>`,
+			},
+		},
+		{
+			name:               "invalid sync token",
+			requestBody:        `<d:sync-collection xmlns:d="DAV:"><d:sync-token>garbage</d:sync-token></d:sync-collection>`,
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedContains:   []string{"<d:valid-sync-token/>"},
+		},
+		{
+			name: "calendar multiget",
+			requestBody: `<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+				`<d:prop><c:calendar-data/></d:prop><d:href>/api/v1/caldav/todos/client-1.ics</d:href>` +
+				`<d:href>/api/v1/caldav/todos/gone.ics</d:href></c:calendar-multiget>`,
+			prepareCalDAVService: func(serviceMock *mocks.CalDAVService) {
+				serviceMock.On("Get", mock.Anything, userID, "client-1.ics").Return(resource, nil)
+				serviceMock.On("Get", mock.Anything, userID, "gone.ics").Return(entity.CalDAVResource{}, entity.ErrCalDAVObjectNotFound)
+			},
+			expectedHTTPStatus: http.StatusMultiStatus,
+			expectedContains: []string{
+				`<c:calendar-data>BEGIN:VCALENDAR`,
+				`UID:client-1`,
+				`<d:response><d:href>/api/v1/caldav/todos/gone.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status></d:response>`,
+			},
+		},
+		{
+			name:               "unsupported report",
+			requestBody:        `<d:expand-property xmlns:d="DAV:"/>`,
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedContains:   []string{"<d:supported-report/>"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calDAVServiceMock := mocks.NewCalDAVService(t)
+			if tc.prepareCalDAVService != nil {
+				tc.prepareCalDAVService(calDAVServiceMock)
+			}
+
+			r := newRouter(NewHandler(calDAVServiceMock, "/api/v1/caldav", logger))
+
+			req, err := http.NewRequest("REPORT", "/api/v1/caldav/todos/", strings.NewReader(tc.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Depth", "1")
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, rr.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestPut(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	updatedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	object := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTODO",
+		"UID:client-1",
+		"SUMMARY:Call mom",
+		"DUE;VALUE=DATE:20250402",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+	parsed := entity.Todo{
+		Title:   "Call mom",
+		Tags:    []string{},
+		DueDate: &entity.Date{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	testCases := []struct {
+		name                 string
+		requestBody          string
+		ifMatch              string
+		prepareCalDAVService func(serviceMock *mocks.CalDAVService)
+		expectedHTTPStatus   int
+		expectedETag         string
+	}{
+		{
+			name:        "successful create",
+			requestBody: object,
+			prepareCalDAVService: func(serviceMock *mocks.CalDAVService) {
+				serviceMock.On("Put", mock.Anything, userID, "client-1.ics", parsed, "client-1", entity.Preconditions{}).
+					Return(entity.CalDAVResource{Name: "client-1.ics", UID: "client-1", Todo: entity.Todo{ID: 9, UpdatedAt: &updatedAt}}, true, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedETag:       `"9-1743508800000000000"`,
+		},
+		{
+			name:        "stale etag",
+			requestBody: object,
+			ifMatch:     `"9-1"`,
+			prepareCalDAVService: func(serviceMock *mocks.CalDAVService) {
+				serviceMock.On("Put", mock.Anything, userID, "client-1.ics", parsed, "client-1", entity.Preconditions{IfMatch: `"9-1"`}).
+					Return(entity.CalDAVResource{}, false, entity.ErrPreconditionFailed)
+			},
+			expectedHTTPStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:               "event instead of todo",
+			requestBody:        "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			expectedHTTPStatus: http.StatusForbidden,
+		},
+		{
+			name:               "summary too short",
+			requestBody:        strings.Replace(object, "Call mom", "Go", 1),
+			expectedHTTPStatus: http.StatusForbidden,
+		},
+		{
+			name:        "internal server error",
+			requestBody: object,
+			prepareCalDAVService: func(serviceMock *mocks.CalDAVService) {
+				serviceMock.On("Put", mock.Anything, userID, "client-1.ics", parsed, "client-1", entity.Preconditions{}).
+					Return(entity.CalDAVResource{}, false, errors.New("unexpected error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calDAVServiceMock := mocks.NewCalDAVService(t)
+			if tc.prepareCalDAVService != nil {
+				tc.prepareCalDAVService(calDAVServiceMock)
+			}
+
+			r := newRouter(NewHandler(calDAVServiceMock, "/api/v1/caldav", logger))
+
+			req, err := http.NewRequest("PUT", "/api/v1/caldav/todos/client-1.ics", strings.NewReader(tc.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.Equal(t, tc.expectedETag, rr.Header().Get("ETag"))
+		})
+	}
+}
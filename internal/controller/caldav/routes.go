@@ -0,0 +1,27 @@
+package caldav
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+// RegisterRoutes mounts the calendar home, the calendar and its objects. The
+// calendar answers with and without a trailing slash, as clients differ.
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Options("/", h.Options)
+	r.Method("PROPFIND", "/", http.HandlerFunc(h.PropfindHome))
+
+	for _, pattern := range []string{"/" + collectionName, "/" + collectionName + "/"} {
+		r.Options(pattern, h.Options)
+		r.Method("PROPFIND", pattern, http.HandlerFunc(h.PropfindCollection))
+		r.Method("REPORT", pattern, http.HandlerFunc(h.Report))
+	}
+
+	object := "/" + collectionName + "/{name}"
+	r.Options(object, h.Options)
+	r.Method("PROPFIND", object, http.HandlerFunc(h.PropfindObject))
+	r.Get(object, h.Get)
+	r.Head(object, h.Get)
+	r.Put(object, h.Put)
+	r.Delete(object, h.Delete)
+}
@@ -0,0 +1,153 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	nsDAV       = "DAV:"
+	nsCalDAV    = "urn:ietf:params:xml:ns:caldav"
+	nsCalServer = "http://calendarserver.org/ns/"
+)
+
+var prefixes = map[string]string{nsDAV: "d", nsCalDAV: "c", nsCalServer: "cs"}
+
+// element is an XML element of a request whose name is all that matters.
+type element struct {
+	XMLName xml.Name
+}
+
+type propNames struct {
+	Names []element `xml:",any"`
+}
+
+type propfindRequest struct {
+	XMLName xml.Name   `xml:"DAV: propfind"`
+	AllProp *struct{}  `xml:"DAV: allprop"`
+	Prop    *propNames `xml:"DAV: prop"`
+}
+
+// reportRequest holds the parts of the calendar-query, calendar-multiget and
+// sync-collection reports that are read. Filters of calendar-query are not applied,
+// as the collection only holds todos.
+type reportRequest struct {
+	XMLName   xml.Name
+	Prop      *propNames `xml:"DAV: prop"`
+	Hrefs     []string   `xml:"DAV: href"`
+	SyncToken string     `xml:"DAV: sync-token"`
+}
+
+func decodeXML(body io.Reader, v any) error {
+	err := xml.NewDecoder(io.LimitReader(body, maxBodyBytes)).Decode(v)
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// prop is a property of a resource. value is its content as XML.
+type prop struct {
+	name  xml.Name
+	value string
+}
+
+func davName(local string) xml.Name    { return xml.Name{Space: nsDAV, Local: local} }
+func calDAVName(local string) xml.Name { return xml.Name{Space: nsCalDAV, Local: local} }
+
+func href(path string) string {
+	return "<d:href>" + escape(path) + "</d:href>"
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// multistatus builds a 207 Multi-Status response.
+type multistatus struct {
+	b strings.Builder
+}
+
+// add writes the response for a resource. Properties asked for that the resource
+// does not have are listed as not found; without names every property is returned.
+func (m *multistatus) add(path string, props []prop, names []xml.Name) {
+	var found, missing strings.Builder
+	if names == nil {
+		for _, p := range props {
+			writeProp(&found, p.name, p.value)
+		}
+	} else {
+		for _, name := range names {
+			if p, ok := lookup(props, name); ok {
+				writeProp(&found, name, p.value)
+			} else {
+				writeProp(&missing, name, "")
+			}
+		}
+	}
+
+	m.b.WriteString("<d:response>" + href(path))
+	if found.Len() > 0 {
+		m.b.WriteString("<d:propstat><d:prop>" + found.String() + "</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>")
+	}
+	if missing.Len() > 0 {
+		m.b.WriteString("<d:propstat><d:prop>" + missing.String() + "</d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>")
+	}
+	m.b.WriteString("</d:response>")
+}
+
+// addStatus writes a response that only carries a status, such as a resource
+// removed since the last sync.
+func (m *multistatus) addStatus(path string, status int) {
+	m.b.WriteString(fmt.Sprintf("<d:response>%s<d:status>HTTP/1.1 %d %s</d:status></d:response>",
+		href(path), status, http.StatusText(status)))
+}
+
+func (m *multistatus) addSyncToken(token string) {
+	m.b.WriteString("<d:sync-token>" + escape(token) + "</d:sync-token>")
+}
+
+func (m *multistatus) write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, xml.Header+`<d:multistatus xmlns:d="DAV:" xmlns:c="`+nsCalDAV+`" xmlns:cs="`+nsCalServer+`">`+
+		m.b.String()+"</d:multistatus>")
+}
+
+func lookup(props []prop, name xml.Name) (prop, bool) {
+	for _, p := range props {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return prop{}, false
+}
+
+func writeProp(b *strings.Builder, name xml.Name, value string) {
+	tag := name.Local
+	attr := ""
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		attr = ` xmlns="` + escape(name.Space) + `"`
+	}
+	if value == "" {
+		b.WriteString("<" + tag + attr + "/>")
+		return
+	}
+	b.WriteString("<" + tag + attr + ">" + value + "</" + tag + ">")
+}
+
+// writeError answers with a DAV error naming the precondition that failed.
+func writeError(w http.ResponseWriter, status int, condition xml.Name) {
+	var b strings.Builder
+	writeProp(&b, condition, "")
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header+`<d:error xmlns:d="DAV:" xmlns:c="`+nsCalDAV+`">`+b.String()+"</d:error>")
+}
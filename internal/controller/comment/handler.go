@@ -0,0 +1,274 @@
+package comment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service service.CommentService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.CommentService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// GetAll retrieves the comments of a todo
+// @Summary Get todo comments
+// @Description Retrieves the comments of a todo, oldest first. Pass the next_cursor of a page as cursor to get the next one. Deleted comments keep their place with an empty body.
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param limit query int false "Number of comments per page" default(20)
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListCommentResponse "Successfully fetch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid ID or query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/comments [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "comment_handler", "GetAll")
+	logger.Debug("Attempting to fetch comments")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	cursor, err := utils.ParseCursor(r.URL.Query())
+	if err != nil {
+		logger.Warn("Invalid cursor parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID)
+	page, err := h.service.GetAll(r.Context(), userID, todoID, cursor)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", page)
+	logger.Info("Successfully fetched comments", "count", len(page.Items))
+}
+
+// Create adds a comment to a todo
+// @Summary Comment on a todo
+// @Description Adds a markdown comment to a todo. Users mentioned as @username who can see the todo are notified.
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param comment body swagger.CommentRequest true "Comment data"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateCommentResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/comments [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "comment_handler", "Create")
+	logger.Debug("Attempting to create comment")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	var request entity.CommentRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID)
+	comment, err := h.service.Create(r.Context(), userID, todoID, request)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", comment)
+	logger.Info("Successfully created comment", "comment_id", comment.ID)
+}
+
+// Update edits a comment
+// @Summary Edit a comment
+// @Description Edits a comment. Only its author can edit it. Users newly mentioned by the edit are notified.
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param commentID path int true "Comment ID"
+// @Param comment body swagger.CommentRequest true "Comment data"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UpdateResponse "Successfully update"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo or comment not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/comments/{commentID} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "comment_handler", "Update")
+	logger.Debug("Attempting to update comment")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	commentStr := chi.URLParam(r, "commentID")
+	commentID, err := strconv.Atoi(commentStr)
+	if err != nil {
+		logger.Warn("Invalid comment id", "comment_id", commentStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	var request entity.CommentRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID, "comment_id", commentID)
+	if err := h.service.Update(r.Context(), userID, todoID, commentID, request); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully update", nil)
+	logger.Info("Successfully updated comment")
+}
+
+// Delete removes a comment
+// @Summary Delete a comment
+// @Description Deletes a comment. Authors can delete their own comments and todo owners can delete any comment.
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param commentID path int true "Comment ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo or comment not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/comments/{commentID} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "comment_handler", "Delete")
+	logger.Debug("Attempting to delete comment")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	commentStr := chi.URLParam(r, "commentID")
+	commentID, err := strconv.Atoi(commentStr)
+	if err != nil {
+		logger.Warn("Invalid comment id", "comment_id", commentStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID, "comment_id", commentID)
+	if err := h.service.Delete(r.Context(), userID, todoID, commentID); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted comment")
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrTodoNotFound):
+		logger.Warn("Todo not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+	case errors.Is(err, entity.ErrCommentNotFound):
+		logger.Warn("Comment not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Comment not found", nil)
+	case errors.Is(err, entity.ErrForbidden):
+		logger.Warn("Permission denied")
+		entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+	default:
+		logger.Error("Failed to process comment request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
@@ -0,0 +1,181 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetAll(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	authorID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+
+	testCases := []struct {
+		name                  string
+		queryParams           string
+		prepareCommentService func(serviceMock *mocks.CommentService)
+		expectedHTTPStatus    int
+		expectedResponse      string
+	}{
+		{
+			name:        "successful get with cursor",
+			queryParams: "?limit=1&cursor=MzA",
+			prepareCommentService: func(serviceMock *mocks.CommentService) {
+				serviceMock.On("GetAll", mock.Anything, userID, 12, entity.Cursor{After: 30, Limit: 1}).
+					Return(entity.CursorPage[entity.Comment]{
+						Items: []entity.Comment{
+							{
+								ID:        31,
+								TodoID:    12,
+								AuthorID:  authorID,
+								Author:    "jane_doe",
+								Body:      "On it",
+								CreatedAt: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+							},
+						},
+						NextCursor: "MzE",
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":{"items":[{"id":31,"todo_id":12,
+				"author_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","author":"jane_doe","body":"On it",
+				"created_at":"2025-04-01T12:00:00Z"}],"next_cursor":"MzE"}}`,
+		},
+		{
+			name:               "invalid cursor",
+			queryParams:        "?cursor=not-a-cursor",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid cursor parameter"}`,
+		},
+		{
+			name: "todo not visible",
+			prepareCommentService: func(serviceMock *mocks.CommentService) {
+				serviceMock.On("GetAll", mock.Anything, userID, 12, entity.Cursor{Limit: 20}).
+					Return(entity.CursorPage[entity.Comment]{}, entity.ErrTodoNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Todo not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			commentServiceMock := mocks.NewCommentService(t)
+			if tc.prepareCommentService != nil {
+				tc.prepareCommentService(commentServiceMock)
+			}
+
+			handler := NewHandler(commentServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("GET", "/todos/12/comments"+tc.queryParams, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                  string
+		inputCommentID        string
+		inputRequest          string
+		prepareCommentService func(serviceMock *mocks.CommentService)
+		expectedHTTPStatus    int
+		expectedResponse      string
+	}{
+		{
+			name:           "successful update",
+			inputCommentID: "31",
+			inputRequest:   `{"body":"Done, thanks @jane_doe"}`,
+			prepareCommentService: func(serviceMock *mocks.CommentService) {
+				serviceMock.On("Update", mock.Anything, userID, 12, 31,
+					entity.CommentRequest{Body: "Done, thanks @jane_doe"}).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update"}`,
+		},
+		{
+			name:               "empty body",
+			inputCommentID:     "31",
+			inputRequest:       `{"body":""}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'body' is required"}`,
+		},
+		{
+			name:           "not the author",
+			inputCommentID: "31",
+			inputRequest:   `{"body":"Edited"}`,
+			prepareCommentService: func(serviceMock *mocks.CommentService) {
+				serviceMock.On("Update", mock.Anything, userID, 12, 31, mock.Anything).Return(entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+		{
+			name:           "deleted comment",
+			inputCommentID: "31",
+			inputRequest:   `{"body":"Edited"}`,
+			prepareCommentService: func(serviceMock *mocks.CommentService) {
+				serviceMock.On("Update", mock.Anything, userID, 12, 31, mock.Anything).Return(entity.ErrCommentNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Comment not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			commentServiceMock := mocks.NewCommentService(t)
+			if tc.prepareCommentService != nil {
+				tc.prepareCommentService(commentServiceMock)
+			}
+
+			handler := NewHandler(commentServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("PUT", "/todos/12/comments/"+tc.inputCommentID, bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
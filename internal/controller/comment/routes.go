@@ -0,0 +1,11 @@
+package comment
+
+import "github.com/go-chi/chi/v5"
+
+// RegisterRoutes registers the comment routes on the todos router.
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/{id}/comments", h.GetAll)
+	r.Post("/{id}/comments", h.Create)
+	r.Put("/{id}/comments/{commentID}", h.Update)
+	r.Delete("/{id}/comments/{commentID}", h.Delete)
+}
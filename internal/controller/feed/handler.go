@@ -0,0 +1,201 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/ical"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Handler struct {
+	service service.CalendarFeedService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.CalendarFeedService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// GetAll lists the calendar feeds of the caller
+// @Summary List calendar feeds
+// @Description Lists the authenticated user's calendar feeds. Feed tokens are only shown when a feed is created.
+// @Tags feed
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListCalendarFeedResponse "Successfully fetch"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /feeds [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "feed_handler", "GetAll")
+	logger.Debug("Attempting to fetch calendar feeds")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	feeds, err := h.service.GetAll(r.Context(), userID)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", feeds)
+	logger.Info("Successfully fetched calendar feeds")
+}
+
+// Create creates a calendar feed
+// @Summary Create a calendar feed
+// @Description Creates a secret URL serving your personal todos as iCalendar, which calendar clients can subscribe to without a Bearer token.
+// @Description The token and URL are only returned here; delete the feed to revoke them.
+// @Tags feed
+// @Accept json
+// @Produce json
+// @Param feed body swagger.CalendarFeedRequest true "Feed name"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateCalendarFeedResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /feeds [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "feed_handler", "Create")
+	logger.Debug("Attempting to create calendar feed")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var request entity.CalendarFeedRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	feed, err := h.service.Create(r.Context(), userID, request)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+	feed.URL = feedURL(r, feed.Token)
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", feed)
+	logger.Info("Successfully created calendar feed", "feed_id", feed.ID)
+}
+
+// Delete revokes a calendar feed
+// @Summary Delete a calendar feed
+// @Description Revokes a calendar feed; its URL stops working immediately.
+// @Tags feed
+// @Produce json
+// @Param id path int true "Feed ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Calendar feed not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /feeds/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "feed_handler", "Delete")
+	logger.Debug("Attempting to delete calendar feed")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "feed_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("feed_id", id)
+	if err := h.service.Delete(r.Context(), userID, id); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted calendar feed")
+}
+
+// Calendar serves a calendar feed
+// @Summary Get a calendar feed
+// @Description Returns the personal todos of the feed's owner as RFC 5545 VTODO components. The token in the URL authenticates the request.
+// @Tags feed
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Success 200 {string} string "iCalendar file"
+// @Failure 404 {object} swagger.NotFoundResponse "Calendar feed not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /feeds/{token}.ics [get]
+func (h *Handler) Calendar(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "feed_handler", "Calendar")
+	logger.Debug("Attempting to serve calendar feed")
+
+	todos, err := h.service.Todos(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	if err := ical.WriteCalendar(w, "Todos", todos, time.Now()); err != nil {
+		logger.Error("Failed to write calendar", "error", err)
+		return
+	}
+	logger.Info("Successfully served calendar feed", "count", len(todos))
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrCalendarFeedNotFound):
+		logger.Warn("Calendar feed not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Calendar feed not found", nil)
+	default:
+		logger.Error("Failed to process calendar feed request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
+
+// feedURL builds the absolute URL of the feed with the token from the request that
+// created it, so it can be pasted into a calendar client as is.
+func feedURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s%s/%s.ics", scheme, r.Host, strings.TrimSuffix(r.URL.Path, "/"), token)
+}
@@ -0,0 +1,222 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func newRouter(handler *Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/api/v1/feeds", func(r chi.Router) {
+		RegisterCalendarRoutes(r, handler)
+		RegisterRoutes(r, handler)
+	})
+	return r
+}
+
+func TestCreate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	createdAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		requestBody        string
+		prepareFeedService func(serviceMock *mocks.CalendarFeedService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:        "successful create",
+			requestBody: `{"name":"Thunderbird"}`,
+			prepareFeedService: func(serviceMock *mocks.CalendarFeedService) {
+				serviceMock.On("Create", mock.Anything, userID, entity.CalendarFeedRequest{Name: "Thunderbird"}).
+					Return(entity.CalendarFeed{ID: 4, Name: "Thunderbird", Token: "secret", CreatedAt: createdAt}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully create","data":{"id":4,"name":"Thunderbird",` +
+				`"token":"secret","url":"http://todo.example.com/api/v1/feeds/secret.ics","created_at":"2025-04-01T12:00:00Z"}}`,
+		},
+		{
+			name:               "name too long",
+			requestBody:        `{"name":"` + string(bytes.Repeat([]byte("a"), 101)) + `"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'name' must not exceed 100 characters"}`,
+		},
+		{
+			name:        "internal server error",
+			requestBody: `{}`,
+			prepareFeedService: func(serviceMock *mocks.CalendarFeedService) {
+				serviceMock.On("Create", mock.Anything, userID, entity.CalendarFeedRequest{}).
+					Return(entity.CalendarFeed{}, errors.New("unexpected error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			feedServiceMock := mocks.NewCalendarFeedService(t)
+			if tc.prepareFeedService != nil {
+				tc.prepareFeedService(feedServiceMock)
+			}
+
+			r := newRouter(NewHandler(feedServiceMock, logger))
+
+			req, err := http.NewRequest("POST", "http://todo.example.com/api/v1/feeds", bytes.NewBufferString(tc.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		feedID             string
+		prepareFeedService func(serviceMock *mocks.CalendarFeedService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:   "successful delete",
+			feedID: "4",
+			prepareFeedService: func(serviceMock *mocks.CalendarFeedService) {
+				serviceMock.On("Delete", mock.Anything, userID, 4).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully delete"}`,
+		},
+		{
+			name:               "invalid id",
+			feedID:             "abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+		{
+			name:   "feed not found",
+			feedID: "5",
+			prepareFeedService: func(serviceMock *mocks.CalendarFeedService) {
+				serviceMock.On("Delete", mock.Anything, userID, 5).Return(entity.ErrCalendarFeedNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Calendar feed not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			feedServiceMock := mocks.NewCalendarFeedService(t)
+			if tc.prepareFeedService != nil {
+				tc.prepareFeedService(feedServiceMock)
+			}
+
+			r := newRouter(NewHandler(feedServiceMock, logger))
+
+			req, err := http.NewRequest("DELETE", "/api/v1/feeds/"+tc.feedID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	testCases := []struct {
+		name                string
+		prepareFeedService  func(serviceMock *mocks.CalendarFeedService)
+		expectedHTTPStatus  int
+		expectedContentType string
+		expectedBody        []string
+	}{
+		{
+			name: "successful calendar",
+			prepareFeedService: func(serviceMock *mocks.CalendarFeedService) {
+				serviceMock.On("Todos", mock.Anything, "secret").Return([]entity.Todo{
+					{
+						ID:      7,
+						Title:   "Water plants",
+						Tags:    []string{"home"},
+						DueDate: &entity.Date{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+					},
+				}, nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "text/calendar; charset=utf-8",
+			expectedBody: []string{
+				"BEGIN:VCALENDAR\r\n",
+				"UID:todo-7@go-todo-api\r\n",
+				"DUE;VALUE=DATE:20250402\r\n",
+				"CATEGORIES:home\r\n",
+			},
+		},
+		{
+			name: "revoked feed",
+			prepareFeedService: func(serviceMock *mocks.CalendarFeedService) {
+				serviceMock.On("Todos", mock.Anything, "secret").Return(nil, entity.ErrCalendarFeedNotFound)
+			},
+			expectedHTTPStatus:  http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedBody:        []string{`"message":"Calendar feed not found"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			feedServiceMock := mocks.NewCalendarFeedService(t)
+			if tc.prepareFeedService != nil {
+				tc.prepareFeedService(feedServiceMock)
+			}
+
+			r := newRouter(NewHandler(feedServiceMock, logger))
+
+			req, err := http.NewRequest("GET", "/api/v1/feeds/secret.ics", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.Contains(t, rr.Header().Get("Content-Type"), tc.expectedContentType)
+			for _, part := range tc.expectedBody {
+				assert.Contains(t, rr.Body.String(), part)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package feed
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/", h.GetAll)
+	r.Post("/", h.Create)
+	r.Delete("/{id}", h.Delete)
+}
+
+// RegisterCalendarRoutes mounts the feeds themselves, which are authenticated by
+// the token in their URL instead of a Bearer header.
+func RegisterCalendarRoutes(r chi.Router, h *Handler) {
+	r.Get("/{token}.ics", h.Calendar)
+}
@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	service service.NotificationService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.NotificationService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// GetAll retrieves the notifications of the user
+// @Summary Get notifications
+// @Description Retrieves a paginated list of the authenticated user's notifications, newest first.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of items per page" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param unread query bool false "Only list unread notifications"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListNotificationResponse "Successfully fetch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /notifications [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "notification_handler", "GetAll")
+	logger.Debug("Attempting to fetch notifications")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	pagination, err := utils.ParsePagination(query)
+	if err != nil {
+		logger.Warn("Invalid pagination parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	var unreadOnly bool
+	if unreadStr := query.Get("unread"); unreadStr != "" {
+		unreadOnly, err = strconv.ParseBool(unreadStr)
+		if err != nil {
+			logger.Warn("Invalid unread parameter", "unread", unreadStr)
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid unread parameter", nil)
+			return
+		}
+	}
+
+	notifications, total, err := h.service.GetAll(r.Context(), userID, unreadOnly, pagination)
+	if err != nil {
+		logger.Error("Failed to fetch notifications", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, notifications)
+	logger.Info("Successfully fetched notifications")
+}
+
+// MarkRead marks a notification as read
+// @Summary Mark a notification as read
+// @Description Marks one of the authenticated user's notifications as read.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UpdateResponse "Successfully update"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Notification not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /notifications/{id}/read [post]
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "notification_handler", "MarkRead")
+	logger.Debug("Attempting to mark notification as read")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "notification_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("notification_id", id)
+	if err := h.service.MarkRead(r.Context(), userID, id); err != nil {
+		if errors.Is(err, entity.ErrNotificationNotFound) {
+			logger.Warn("Notification not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Notification not found", nil)
+			return
+		}
+		logger.Error("Failed to mark notification as read", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully update", nil)
+	logger.Info("Successfully marked notification as read")
+}
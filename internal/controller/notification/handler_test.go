@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetAll(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	actorID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	todoID := 12
+
+	testCases := []struct {
+		name                       string
+		queryParams                string
+		prepareNotificationService func(serviceMock *mocks.NotificationService)
+		expectedHTTPStatus         int
+		expectedResponse           string
+	}{
+		{
+			name:        "successful get unread notifications",
+			queryParams: "?unread=true",
+			prepareNotificationService: func(serviceMock *mocks.NotificationService) {
+				serviceMock.On("GetAll", mock.Anything, userID, true, entity.Pagination{Offset: 0, Limit: 20}).
+					Return([]entity.Notification{
+						{
+							ID:        42,
+							Type:      entity.NotificationAssigned,
+							TodoID:    &todoID,
+							ActorID:   &actorID,
+							Message:   `You were assigned to "Buy groceries"`,
+							CreatedAt: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+						},
+					}, 1, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","offset":0,"limit":20,"count":1,"total":1,
+				"data":[{"id":42,"type":"assigned","todo_id":12,"actor_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2",
+				"message":"You were assigned to \"Buy groceries\"","read":false,"created_at":"2025-04-01T12:00:00Z"}]}`,
+		},
+		{
+			name:               "invalid unread parameter",
+			queryParams:        "?unread=maybe",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid unread parameter"}`,
+		},
+		{
+			name: "internal server error",
+			prepareNotificationService: func(serviceMock *mocks.NotificationService) {
+				serviceMock.On("GetAll", mock.Anything, userID, false, entity.Pagination{Offset: 0, Limit: 20}).
+					Return(nil, 0, errors.New("unexpected error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notificationServiceMock := mocks.NewNotificationService(t)
+			if tc.prepareNotificationService != nil {
+				tc.prepareNotificationService(notificationServiceMock)
+			}
+
+			handler := NewHandler(notificationServiceMock, logger)
+
+			req, err := http.NewRequest("GET", "/notifications"+tc.queryParams, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			handler.GetAll(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                       string
+		inputID                    string
+		prepareNotificationService func(serviceMock *mocks.NotificationService)
+		expectedHTTPStatus         int
+		expectedResponse           string
+	}{
+		{
+			name:    "successful mark read",
+			inputID: "42",
+			prepareNotificationService: func(serviceMock *mocks.NotificationService) {
+				serviceMock.On("MarkRead", mock.Anything, userID, 42).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update"}`,
+		},
+		{
+			name:               "invalid id",
+			inputID:            "abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+		{
+			name:    "notification of another user",
+			inputID: "42",
+			prepareNotificationService: func(serviceMock *mocks.NotificationService) {
+				serviceMock.On("MarkRead", mock.Anything, userID, 42).Return(entity.ErrNotificationNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Notification not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notificationServiceMock := mocks.NewNotificationService(t)
+			if tc.prepareNotificationService != nil {
+				tc.prepareNotificationService(notificationServiceMock)
+			}
+
+			handler := NewHandler(notificationServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/notifications", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/notifications/"+tc.inputID+"/read", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package notification
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/", h.GetAll)
+	r.Post("/{id}/read", h.MarkRead)
+}
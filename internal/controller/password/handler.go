@@ -0,0 +1,169 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// forgotMessage is the answer to every well-formed reset request, so that it does
+// not tell whether the username exists.
+const forgotMessage = "If the account exists and has an email address, a password reset link has been sent"
+
+type Handler struct {
+	service service.PasswordService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.PasswordService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Change sets a new password for the caller
+// @Summary Change password
+// @Description Sets a new password after confirming the current one. Every other session of the user is logged out and pending password reset links stop working.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body swagger.PasswordChangeRequest true "Current and new password"
+// @Security BearerAuth
+// @Success 200 {object} swagger.PasswordChangeResponse "Password successfully changed"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Wrong password"
+// @Failure 404 {object} swagger.NotFoundResponse "User not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/password [post]
+func (h *Handler) Change(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "password_handler", "Change")
+	logger.Debug("Attempting to change password")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var change entity.PasswordChange
+	if err := utils.DecodeJSONStruct(r, &change); err != nil {
+		logger.Warn("Failed to decode JSON", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := change.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	accessToken, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if err := h.service.Change(r.Context(), userID, change, accessToken); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrWrongPassword):
+			logger.Warn("Wrong password")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Wrong password", nil)
+		case errors.Is(err, entity.ErrUserNotFound):
+			logger.Warn("User not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "User not found", nil)
+		default:
+			logger.Error("Failed to change password", "error", err)
+			entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		}
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Password successfully changed", nil)
+	logger.Info("Successfully changed password")
+}
+
+// Forgot sends a password reset link
+// @Summary Request password reset
+// @Description Mails a single-use password reset link to the email address of the account. The response is the same whether or not the account exists or has an email address.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body swagger.PasswordForgotRequest true "Username"
+// @Success 200 {object} swagger.PasswordForgotResponse "Reset link sent if the account exists"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/password/forgot [post]
+func (h *Handler) Forgot(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "password_handler", "Forgot")
+	logger.Debug("Attempting to request password reset")
+
+	var request entity.PasswordForgot
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode JSON", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	if err := h.service.Forgot(r.Context(), request); err != nil {
+		logger.Error("Failed to request password reset", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, forgotMessage, nil)
+	logger.Info("Successfully handled password reset request")
+}
+
+// Reset sets a new password with a reset token
+// @Summary Reset password
+// @Description Sets a new password with the token from a password reset link. The token works once; every session of the user is logged out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body swagger.PasswordResetRequest true "Reset token and new password"
+// @Success 200 {object} swagger.PasswordResetResponse "Password successfully reset"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data, validation error or invalid reset token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /auth/password/reset [post]
+func (h *Handler) Reset(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "password_handler", "Reset")
+	logger.Debug("Attempting to reset password")
+
+	var request entity.PasswordReset
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode JSON", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	if err := h.service.Reset(r.Context(), request); err != nil {
+		if errors.Is(err, entity.ErrInvalidToken) {
+			logger.Warn("Invalid or expired reset token")
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid or expired reset token", nil)
+			return
+		}
+		logger.Error("Failed to reset password", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Password successfully reset", nil)
+	logger.Info("Successfully reset password")
+}
@@ -0,0 +1,225 @@
+package password
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestChange(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	change := entity.PasswordChange{CurrentPassword: "Secret123", NewPassword: "Better456"}
+
+	testCases := []struct {
+		name                   string
+		inputRequest           string
+		preparePasswordService func(serviceMock *mocks.PasswordService)
+		expectedHTTPStatus     int
+		expectedResponse       string
+	}{
+		{
+			name:         "successful change",
+			inputRequest: `{"current_password":"Secret123","new_password":"Better456"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Change", mock.Anything, userID, change, "access_token").Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Password successfully changed"}`,
+		},
+		{
+			name:               "weak new password",
+			inputRequest:       `{"current_password":"Secret123","new_password":"password"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'new_password' must contain at least one letter and one digit"}`,
+		},
+		{
+			name:               "missing current password",
+			inputRequest:       `{"new_password":"Better456"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'current_password' is required"}`,
+		},
+		{
+			name:         "wrong current password",
+			inputRequest: `{"current_password":"Secret123","new_password":"Better456"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Change", mock.Anything, userID, change, "access_token").Return(entity.ErrWrongPassword)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Wrong password"}`,
+		},
+		{
+			name:         "internal server error",
+			inputRequest: `{"current_password":"Secret123","new_password":"Better456"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Change", mock.Anything, userID, change, "access_token").Return(errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			passwordServiceMock := mocks.NewPasswordService(t)
+			if tc.preparePasswordService != nil {
+				tc.preparePasswordService(passwordServiceMock)
+			}
+
+			handler := NewHandler(passwordServiceMock, logger)
+			req, err := http.NewRequest("POST", "/auth/password", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer access_token")
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+			handler.Change(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestForgot(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	testCases := []struct {
+		name                   string
+		inputRequest           string
+		preparePasswordService func(serviceMock *mocks.PasswordService)
+		expectedHTTPStatus     int
+		expectedResponse       string
+	}{
+		{
+			name:         "request accepted",
+			inputRequest: `{"username":"john_doe"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Forgot", mock.Anything, entity.PasswordForgot{Username: "john_doe"}).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,
+				"message":"If the account exists and has an email address, a password reset link has been sent"}`,
+		},
+		{
+			name:               "missing username",
+			inputRequest:       `{}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'username' is required"}`,
+		},
+		{
+			name:         "internal server error",
+			inputRequest: `{"username":"john_doe"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Forgot", mock.Anything, entity.PasswordForgot{Username: "john_doe"}).
+					Return(errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			passwordServiceMock := mocks.NewPasswordService(t)
+			if tc.preparePasswordService != nil {
+				tc.preparePasswordService(passwordServiceMock)
+			}
+
+			handler := NewHandler(passwordServiceMock, logger)
+			req, err := http.NewRequest("POST", "/auth/password/forgot", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			rr := httptest.NewRecorder()
+			handler.Forgot(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reset := entity.PasswordReset{Token: "reset_token", NewPassword: "Better456"}
+
+	testCases := []struct {
+		name                   string
+		inputRequest           string
+		preparePasswordService func(serviceMock *mocks.PasswordService)
+		expectedHTTPStatus     int
+		expectedResponse       string
+	}{
+		{
+			name:         "successful reset",
+			inputRequest: `{"token":"reset_token","new_password":"Better456"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Reset", mock.Anything, reset).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Password successfully reset"}`,
+		},
+		{
+			name:         "invalid or used token",
+			inputRequest: `{"token":"reset_token","new_password":"Better456"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Reset", mock.Anything, reset).Return(entity.ErrInvalidToken)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid or expired reset token"}`,
+		},
+		{
+			name:               "short new password",
+			inputRequest:       `{"token":"reset_token","new_password":"abc1"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'new_password' must be at least 8 characters"}`,
+		},
+		{
+			name:               "unknown field",
+			inputRequest:       `{"token":"reset_token","password":"Better456"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Unknown field: password"}`,
+		},
+		{
+			name:         "internal server error",
+			inputRequest: `{"token":"reset_token","new_password":"Better456"}`,
+			preparePasswordService: func(serviceMock *mocks.PasswordService) {
+				serviceMock.On("Reset", mock.Anything, reset).Return(errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			passwordServiceMock := mocks.NewPasswordService(t)
+			if tc.preparePasswordService != nil {
+				tc.preparePasswordService(passwordServiceMock)
+			}
+
+			handler := NewHandler(passwordServiceMock, logger)
+			req, err := http.NewRequest("POST", "/auth/password/reset", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			rr := httptest.NewRecorder()
+			handler.Reset(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package password
+
+import "github.com/go-chi/chi/v5"
+
+// RegisterRoutes mounts the password recovery routes, which need no authentication.
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Post("/password/forgot", h.Forgot)
+	r.Post("/password/reset", h.Reset)
+}
+
+// RegisterProtectedRoutes mounts the password change of the authenticated user.
+func RegisterProtectedRoutes(r chi.Router, h *Handler) {
+	r.Post("/password", h.Change)
+}
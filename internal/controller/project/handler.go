@@ -0,0 +1,351 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils/contextutils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service service.ProjectService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.ProjectService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Get retrieves a project by ID
+// @Summary Get a project
+// @Description Retrieves a project the authenticated user owns or has been shared.
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.GetProjectResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Project not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /projects/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "project_handler", "Get")
+	logger.Debug("Attempting to fetch project")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "project_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("project_id", id)
+	project, err := h.service.Get(r.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrProjectNotFound) {
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+			return
+		}
+		logger.Error("Failed to get project", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", project)
+	logger.Info("Successfully fetched project")
+}
+
+// Create adds a new project
+// @Summary Create a project
+// @Description Creates a new project owned by the authenticated user.
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param project body swagger.ProjectRequest true "Project data"
+// @Param X-Workspace-ID header int false "Create the project in this workspace"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateProjectResponse "Project successfully created"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "No write access to the workspace"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /projects [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "project_handler", "Create")
+	logger.Debug("Attempting to create project")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var project entity.Project
+	if err := utils.DecodeJSONStruct(r, &project); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := project.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	project.WorkspaceID = contextutils.GetWorkspaceID(r.Context())
+
+	id, err := h.service.Create(r.Context(), userID, project)
+	if err != nil {
+		if errors.Is(err, entity.ErrWorkspaceNotFound) {
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
+		logger.Error("Failed to create project", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", map[string]int{
+		"id": id,
+	})
+	logger.Info("Successfully created project")
+}
+
+// Update modifies an existing project
+// @Summary Update a project
+// @Description Updates a project the authenticated user can edit.
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param project body swagger.ProjectRequest true "Updated project data"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UpdateResponse "Project successfully updated"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Project not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /projects [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "project_handler", "Update")
+	logger.Debug("Attempting to update project")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var project entity.Project
+	if err := utils.DecodeJSONStruct(r, &project); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := project.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	err := h.service.Update(r.Context(), userID, project)
+	if err != nil {
+		if errors.Is(err, entity.ErrProjectNotFound) {
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
+		logger.Error("Failed to update project", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully update", nil)
+	logger.Info("Successfully updated project")
+}
+
+// Delete removes a project by ID
+// @Summary Delete a project
+// @Description Deletes a project. Todos in the project are kept and detached from it.
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Project not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /projects/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "project_handler", "Delete")
+	logger.Debug("Attempting to delete project")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "project_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("project_id", id)
+	err = h.service.Delete(r.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrProjectNotFound) {
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
+		logger.Error("Failed to delete project", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted project")
+}
+
+// GetAll retrieves the caller's projects
+// @Summary Get all projects
+// @Description Retrieves a paginated list of the authenticated user's personal projects, or of the projects in the active workspace.
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of items per page" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param X-Workspace-ID header int false "List the projects of this workspace"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListProjectResponse "Projects successfully retrieved"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Something went wrong, please try again later"
+// @Router /projects [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "project_handler", "GetAll")
+	logger.Debug("Attempting to get projects")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	pagination, err := utils.ParsePagination(r.URL.Query())
+	if err != nil {
+		logger.Warn("Invalid pagination parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	projects, total, err := h.service.GetAll(r.Context(), userID, contextutils.GetWorkspaceID(r.Context()), pagination)
+	if err != nil {
+		if errors.Is(err, entity.ErrWorkspaceNotFound) {
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+			return
+		}
+		logger.Error("Failed to fetch projects", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, projects)
+	logger.Info("Successfully fetched projects")
+}
+
+// GetSharedWithMe retrieves projects other users have shared with the caller
+// @Summary Get projects shared with me
+// @Description Retrieves projects shared with the authenticated user.
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of items per page" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListSharedProjectResponse "Projects successfully retrieved"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Something went wrong, please try again later"
+// @Router /projects/shared-with-me [get]
+func (h *Handler) GetSharedWithMe(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "project_handler", "GetSharedWithMe")
+	logger.Debug("Attempting to get shared projects")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	pagination, err := utils.ParsePagination(r.URL.Query())
+	if err != nil {
+		logger.Warn("Invalid pagination parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	projects, total, err := h.service.GetSharedWithMe(r.Context(), userID, pagination)
+	if err != nil {
+		logger.Error("Failed to fetch shared projects", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, projects)
+	logger.Info("Successfully fetched shared projects")
+}
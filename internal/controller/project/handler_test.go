@@ -0,0 +1,258 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/middleware"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                  string
+		inputID               string
+		inputToken            string
+		prepareProjectService func(serviceMock *mocks.ProjectService)
+		prepareTokenService   func(serviceMock *mocks.TokenService)
+		expectedHTTPStatus    int
+		expectedResponse      string
+	}{
+		{
+			name:       "successful get",
+			inputID:    "3",
+			inputToken: "valid_token",
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Get", mock.Anything, userID, 3).
+					Return(entity.Project{ID: 3, Name: "Release", Description: "Next release"}, nil)
+			},
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").Return(userID, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully fetch","data":{"id":3,"name":"Release","description":"Next release"}}`,
+		},
+		{
+			name:       "invalid id",
+			inputID:    "abc",
+			inputToken: "valid_token",
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").Return(userID, nil)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+		{
+			name:       "project not found",
+			inputID:    "3",
+			inputToken: "valid_token",
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Get", mock.Anything, userID, 3).
+					Return(entity.Project{}, entity.ErrProjectNotFound)
+			},
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").Return(userID, nil)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Project not found"}`,
+		},
+		{
+			name:       "internal server error",
+			inputID:    "3",
+			inputToken: "valid_token",
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Get", mock.Anything, userID, 3).
+					Return(entity.Project{}, errors.New("unexpected error"))
+			},
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").Return(userID, nil)
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectServiceMock := mocks.NewProjectService(t)
+			tokenServiceMock := mocks.NewTokenService(t)
+
+			if tc.prepareProjectService != nil {
+				tc.prepareProjectService(projectServiceMock)
+			}
+			if tc.prepareTokenService != nil {
+				tc.prepareTokenService(tokenServiceMock)
+			}
+
+			handler := NewHandler(projectServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Use(middleware.AuthMiddleware(tokenServiceMock))
+			r.Get("/projects/{id}", handler.Get)
+
+			req, err := http.NewRequest("GET", "/projects/"+tc.inputID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+tc.inputToken)
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                  string
+		inputRequest          string
+		prepareProjectService func(serviceMock *mocks.ProjectService)
+		expectedHTTPStatus    int
+		expectedResponse      string
+	}{
+		{
+			name:         "successful creation",
+			inputRequest: `{"name":"Release","description":"Next release"}`,
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Create", mock.MatchedBy(func(ctx context.Context) bool {
+					_, ok := ctx.Value("id").(uuid.UUID)
+					return ok
+				}), userID, entity.Project{Name: "Release", Description: "Next release"}).
+					Return(3, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   `{"code":201,"error":false,"message":"Successfully create","data":{"id":3}}`,
+		},
+		{
+			name:               "missing name",
+			inputRequest:       `{"description":"Next release"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'name' is required"}`,
+		},
+		{
+			name:               "unknown field",
+			inputRequest:       `{"name":"Release","color":"red"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Unknown field: color"}`,
+		},
+		{
+			name:         "internal server error",
+			inputRequest: `{"name":"Release"}`,
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Create", mock.Anything, userID, entity.Project{Name: "Release"}).
+					Return(0, errors.New("unexpected error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectServiceMock := mocks.NewProjectService(t)
+			if tc.prepareProjectService != nil {
+				tc.prepareProjectService(projectServiceMock)
+			}
+
+			handler := NewHandler(projectServiceMock, logger)
+
+			req, err := http.NewRequest("POST", "/projects", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			handler.Create(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                  string
+		inputID               string
+		prepareProjectService func(serviceMock *mocks.ProjectService)
+		expectedHTTPStatus    int
+		expectedResponse      string
+	}{
+		{
+			name:    "successful delete",
+			inputID: "3",
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Delete", mock.Anything, userID, 3).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully delete"}`,
+		},
+		{
+			name:    "not the owner",
+			inputID: "3",
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Delete", mock.Anything, userID, 3).Return(entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+		{
+			name:    "project not found",
+			inputID: "3",
+			prepareProjectService: func(serviceMock *mocks.ProjectService) {
+				serviceMock.On("Delete", mock.Anything, userID, 3).Return(entity.ErrProjectNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Project not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectServiceMock := mocks.NewProjectService(t)
+			if tc.prepareProjectService != nil {
+				tc.prepareProjectService(projectServiceMock)
+			}
+
+			handler := NewHandler(projectServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Delete("/projects/{id}", handler.Delete)
+
+			req, err := http.NewRequest("DELETE", "/projects/"+tc.inputID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package project
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/shared-with-me", h.GetSharedWithMe)
+	r.Get("/{id}", h.Get)
+	r.Get("/", h.GetAll)
+	r.Delete("/{id}", h.Delete)
+	r.Post("/", h.Create)
+	r.Put("/", h.Update)
+}
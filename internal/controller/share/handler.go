@@ -0,0 +1,277 @@
+package share
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service service.ShareService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.ShareService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// GetTodoShares lists who a todo is shared with
+// @Summary List todo shares
+// @Description Lists the users a todo is shared with and their permission levels.
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListShareResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/shares [get]
+func (h *Handler) GetTodoShares(w http.ResponseWriter, r *http.Request) {
+	h.getShares(w, r, entity.ShareResourceTodo)
+}
+
+// ShareTodo grants another user access to a todo
+// @Summary Share a todo
+// @Description Shares a todo with another user as viewer, editor or owner. Sharing again changes the permission.
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param share body swagger.ShareRequest true "Share data"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ShareResponse "Successfully share"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo or user not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/shares [post]
+func (h *Handler) ShareTodo(w http.ResponseWriter, r *http.Request) {
+	h.share(w, r, entity.ShareResourceTodo)
+}
+
+// RevokeTodoShare removes a user's access to a todo
+// @Summary Revoke a todo share
+// @Description Revokes a user's access to a todo. Takes effect immediately.
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param userID path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.RevokeResponse "Successfully revoke"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo or share not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/shares/{userID} [delete]
+func (h *Handler) RevokeTodoShare(w http.ResponseWriter, r *http.Request) {
+	h.revoke(w, r, entity.ShareResourceTodo)
+}
+
+// GetProjectShares lists who a project is shared with
+// @Summary List project shares
+// @Description Lists the users a project is shared with and their permission levels.
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListShareResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Project not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /projects/{id}/shares [get]
+func (h *Handler) GetProjectShares(w http.ResponseWriter, r *http.Request) {
+	h.getShares(w, r, entity.ShareResourceProject)
+}
+
+// ShareProject grants another user access to a project and all of its todos
+// @Summary Share a project
+// @Description Shares a project, and every todo in it, with another user as viewer, editor or owner.
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param share body swagger.ShareRequest true "Share data"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ShareResponse "Successfully share"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Project or user not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /projects/{id}/shares [post]
+func (h *Handler) ShareProject(w http.ResponseWriter, r *http.Request) {
+	h.share(w, r, entity.ShareResourceProject)
+}
+
+// RevokeProjectShare removes a user's access to a project
+// @Summary Revoke a project share
+// @Description Revokes a user's access to a project. Takes effect immediately.
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param userID path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.RevokeResponse "Successfully revoke"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Project or share not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /projects/{id}/shares/{userID} [delete]
+func (h *Handler) RevokeProjectShare(w http.ResponseWriter, r *http.Request) {
+	h.revoke(w, r, entity.ShareResourceProject)
+}
+
+func (h *Handler) getShares(w http.ResponseWriter, r *http.Request, resource entity.ShareResource) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "share_handler", "GetShares", "resource", resource)
+	logger.Debug("Attempting to fetch shares")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "resource_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("resource_id", id)
+	shares, err := h.service.GetShares(r.Context(), userID, resource, id)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", shares)
+	logger.Info("Successfully fetched shares")
+}
+
+func (h *Handler) share(w http.ResponseWriter, r *http.Request, resource entity.ShareResource) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "share_handler", "Share", "resource", resource)
+	logger.Debug("Attempting to share")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "resource_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	var request entity.ShareRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("resource_id", id)
+	share, err := h.service.Share(r.Context(), userID, resource, id, request)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully share", share)
+	logger.Info("Successfully shared")
+}
+
+func (h *Handler) revoke(w http.ResponseWriter, r *http.Request, resource entity.ShareResource) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "share_handler", "Revoke", "resource", resource)
+	logger.Debug("Attempting to revoke share")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "resource_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	targetStr := chi.URLParam(r, "userID")
+	targetID, err := uuid.Parse(targetStr)
+	if err != nil {
+		logger.Warn("Invalid user id", "user_id", targetStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid user ID", nil)
+		return
+	}
+
+	logger = logger.With("resource_id", id)
+	if err := h.service.Revoke(r.Context(), userID, resource, id, targetID); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully revoke", nil)
+	logger.Info("Successfully revoked share")
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrTodoNotFound):
+		logger.Warn("Todo not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+	case errors.Is(err, entity.ErrProjectNotFound):
+		logger.Warn("Project not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+	case errors.Is(err, entity.ErrUserNotFound):
+		logger.Warn("User not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "User not found", nil)
+	case errors.Is(err, entity.ErrShareNotFound):
+		logger.Warn("Share not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Share not found", nil)
+	case errors.Is(err, entity.ErrInvalidShareTarget):
+		logger.Warn("Attempt to share with the owner")
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Cannot share with the owner", nil)
+	case errors.Is(err, entity.ErrForbidden):
+		logger.Warn("Permission denied")
+		entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+	default:
+		logger.Error("Failed to process share request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
@@ -0,0 +1,181 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestShareTodo(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	targetID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+
+	testCases := []struct {
+		name                string
+		inputID             string
+		inputRequest        string
+		prepareShareService func(serviceMock *mocks.ShareService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name:         "successful share",
+			inputID:      "12",
+			inputRequest: `{"username":"jane_doe","permission":"editor"}`,
+			prepareShareService: func(serviceMock *mocks.ShareService) {
+				serviceMock.On("Share", mock.Anything, userID, entity.ShareResourceTodo, 12,
+					entity.ShareRequest{Username: "jane_doe", Permission: entity.PermissionEditor}).
+					Return(entity.Share{UserID: targetID, Username: "jane_doe", Permission: entity.PermissionEditor}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully share","data":{"user_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","username":"jane_doe","permission":"editor"}}`,
+		},
+		{
+			name:               "invalid permission",
+			inputID:            "12",
+			inputRequest:       `{"username":"jane_doe","permission":"admin"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'permission' must be one of: viewer editor owner"}`,
+		},
+		{
+			name:         "user not found",
+			inputID:      "12",
+			inputRequest: `{"username":"ghost","permission":"viewer"}`,
+			prepareShareService: func(serviceMock *mocks.ShareService) {
+				serviceMock.On("Share", mock.Anything, userID, entity.ShareResourceTodo, 12, mock.Anything).
+					Return(entity.Share{}, entity.ErrUserNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"User not found"}`,
+		},
+		{
+			name:         "caller is only an editor",
+			inputID:      "12",
+			inputRequest: `{"username":"jane_doe","permission":"viewer"}`,
+			prepareShareService: func(serviceMock *mocks.ShareService) {
+				serviceMock.On("Share", mock.Anything, userID, entity.ShareResourceTodo, 12, mock.Anything).
+					Return(entity.Share{}, entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+		{
+			name:         "share with owner",
+			inputID:      "12",
+			inputRequest: `{"username":"john_doe","permission":"viewer"}`,
+			prepareShareService: func(serviceMock *mocks.ShareService) {
+				serviceMock.On("Share", mock.Anything, userID, entity.ShareResourceTodo, 12, mock.Anything).
+					Return(entity.Share{}, entity.ErrInvalidShareTarget)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Cannot share with the owner"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shareServiceMock := mocks.NewShareService(t)
+			if tc.prepareShareService != nil {
+				tc.prepareShareService(shareServiceMock)
+			}
+
+			handler := NewHandler(shareServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterTodoRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/todos/"+tc.inputID+"/shares", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestRevokeProjectShare(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	testCases := []struct {
+		name                string
+		inputUserID         string
+		prepareShareService func(serviceMock *mocks.ShareService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name:        "successful revoke",
+			inputUserID: targetID.String(),
+			prepareShareService: func(serviceMock *mocks.ShareService) {
+				serviceMock.On("Revoke", mock.Anything, userID, entity.ShareResourceProject, 3, targetID).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully revoke"}`,
+		},
+		{
+			name:               "invalid user id",
+			inputUserID:        "not-a-uuid",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid user ID"}`,
+		},
+		{
+			name:        "share not found",
+			inputUserID: targetID.String(),
+			prepareShareService: func(serviceMock *mocks.ShareService) {
+				serviceMock.On("Revoke", mock.Anything, userID, entity.ShareResourceProject, 3, targetID).
+					Return(entity.ErrShareNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Share not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shareServiceMock := mocks.NewShareService(t)
+			if tc.prepareShareService != nil {
+				tc.prepareShareService(shareServiceMock)
+			}
+
+			handler := NewHandler(shareServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/projects", func(r chi.Router) {
+				RegisterProjectRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("DELETE", "/projects/3/shares/"+tc.inputUserID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
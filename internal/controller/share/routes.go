@@ -0,0 +1,15 @@
+package share
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterTodoRoutes(r chi.Router, h *Handler) {
+	r.Get("/{id}/shares", h.GetTodoShares)
+	r.Post("/{id}/shares", h.ShareTodo)
+	r.Delete("/{id}/shares/{userID}", h.RevokeTodoShare)
+}
+
+func RegisterProjectRoutes(r chi.Router, h *Handler) {
+	r.Get("/{id}/shares", h.GetProjectShares)
+	r.Post("/{id}/shares", h.ShareProject)
+	r.Delete("/{id}/shares/{userID}", h.RevokeProjectShare)
+}
@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultWeeks is how many weeks the statistics cover when no range is given.
+const defaultWeeks = 4
+
+type Handler struct {
+	service service.StatsService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.StatsService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Get retrieves productivity statistics
+// @Summary Get statistics
+// @Description Counts the todos the authenticated user created and completed per day or week, with open and overdue counts, the average hours from creation to completion, a per tag breakdown and completion streaks.
+// @Description Without from and to the statistics cover the current and the previous three weeks. Days are days in the user's time zone and weeks start on Monday.
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param interval query string false "Bucket size, day or week" default(week)
+// @Security BearerAuth
+// @Success 200 {object} swagger.StatsResponse "Successfully fetch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /stats [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "stats_handler", "Get")
+	logger.Debug("Attempting to fetch statistics")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	request := entity.StatsRequest{
+		From:     entity.Date{Time: monday.AddDate(0, 0, -7*(defaultWeeks-1))},
+		To:       entity.Date{Time: today},
+		Interval: entity.StatsByWeek,
+	}
+	for _, param := range []struct {
+		name string
+		date *entity.Date
+	}{{"from", &request.From}, {"to", &request.To}} {
+		name, date := param.name, param.date
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				logger.Warn("Invalid date parameter", name, value)
+				entity.SendResponse[any](w, http.StatusBadRequest, true,
+					fmt.Sprintf("Invalid %s format. Use YYYY-MM-DD", name), nil)
+				return
+			}
+			*date = entity.Date{Time: parsed}
+		}
+	}
+	if interval := query.Get("interval"); interval != "" {
+		request.Interval = entity.StatsInterval(interval)
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	stats, err := h.service.Get(r.Context(), userID, request)
+	if err != nil {
+		logger.Error("Failed to fetch statistics", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", stats)
+	logger.Info("Successfully fetched statistics")
+}
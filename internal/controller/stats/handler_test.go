@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func newRouter(handler *Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/stats", func(r chi.Router) {
+		RegisterRoutes(r, handler)
+	})
+	return r
+}
+
+func TestGet(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	from := entity.Date{Time: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}
+	to := entity.Date{Time: time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC)}
+	leadTime := 31.5
+
+	testCases := []struct {
+		name                string
+		query               string
+		prepareStatsService func(serviceMock *mocks.StatsService)
+		expectedHTTPStatus  int
+		expectedResponse    string
+	}{
+		{
+			name:  "weekly statistics",
+			query: "?from=2025-03-31&to=2025-04-13&interval=week",
+			prepareStatsService: func(serviceMock *mocks.StatsService) {
+				serviceMock.On("Get", mock.Anything, userID, entity.StatsRequest{
+					From: from, To: to, Interval: entity.StatsByWeek,
+				}).Return(entity.Stats{
+					From: from, To: to, Interval: entity.StatsByWeek,
+					StatsSummary: entity.StatsSummary{Open: 14, Overdue: 2, AverageLeadTimeHours: &leadTime},
+					Buckets: []entity.StatsBucket{
+						{Start: from, Created: 9, Completed: 7},
+						{Start: entity.Date{Time: from.AddDate(0, 0, 7)}, Created: 3, Completed: 5},
+					},
+					Tags:   []entity.TagStats{{Tag: "work", Created: 5, Completed: 4, Overdue: 1}},
+					Streak: entity.Streak{Current: 3, Longest: 12},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":{"from":"2025-03-31",` +
+				`"to":"2025-04-13","interval":"week","open":14,"overdue":2,"average_lead_time_hours":31.5,` +
+				`"buckets":[{"start":"2025-03-31","created":9,"completed":7},{"start":"2025-04-07","created":3,"completed":5}],` +
+				`"tags":[{"tag":"work","created":5,"completed":4,"overdue":1}],"streak":{"current":3,"longest":12}}}`,
+		},
+		{
+			name:  "default range covers four weeks",
+			query: "",
+			prepareStatsService: func(serviceMock *mocks.StatsService) {
+				serviceMock.On("Get", mock.Anything, userID, mock.MatchedBy(func(request entity.StatsRequest) bool {
+					return request.Interval == entity.StatsByWeek && request.From.Weekday() == time.Monday &&
+						request.To.Sub(request.From.Time) >= 21*24*time.Hour &&
+						request.To.Sub(request.From.Time) < 28*24*time.Hour
+				})).Return(entity.Stats{Interval: entity.StatsByWeek}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":{"from":"0001-01-01",` +
+				`"to":"0001-01-01","interval":"week","open":0,"overdue":0,"average_lead_time_hours":null,` +
+				`"buckets":null,"tags":null,"streak":{"current":0,"longest":0}}}`,
+		},
+		{
+			name:               "unknown interval",
+			query:              "?interval=month",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'interval' must be one of: day week"}`,
+		},
+		{
+			name:               "invalid date",
+			query:              "?to=yesterday",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid to format. Use YYYY-MM-DD"}`,
+		},
+		{
+			name:               "range too long",
+			query:              "?from=2024-01-01&to=2025-04-01&interval=day",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Statistics must not cover more than 366 days"}`,
+		},
+		{
+			name:  "service error",
+			query: "?from=2025-03-31&to=2025-04-13",
+			prepareStatsService: func(serviceMock *mocks.StatsService) {
+				serviceMock.On("Get", mock.Anything, userID, mock.Anything).Return(entity.Stats{}, errors.New("connection refused"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"` + entity.ServerFailureMessage + `"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			statsServiceMock := mocks.NewStatsService(t)
+			if tc.prepareStatsService != nil {
+				tc.prepareStatsService(statsServiceMock)
+			}
+
+			r := newRouter(NewHandler(statsServiceMock, logger))
+
+			req, err := http.NewRequest("GET", "/stats"+tc.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Handler struct {
+	service service.TagService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.TagService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// GetAll lists the tags of the user
+// @Summary Get tags
+// @Description Lists the tags used on the authenticated user's todos, and those they set metadata for, with the number of todos carrying each.
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListTagResponse "Successfully fetch"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /tags [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "tag_handler", "GetAll")
+	logger.Debug("Attempting to fetch tags")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	tags, err := h.service.GetAll(r.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to fetch tags", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", tags)
+	logger.Info("Successfully fetched tags")
+}
+
+// Update renames a tag or changes its metadata
+// @Summary Update a tag
+// @Description Renames a tag on all of the authenticated user's todos and/or sets its color and description. Renaming to an existing tag is refused; merge the tags instead.
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param name path string true "Tag name"
+// @Param tag body swagger.TagUpdateRequest true "New name and metadata"
+// @Security BearerAuth
+// @Success 200 {object} swagger.TagResponse "Successfully update"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Tag not found"
+// @Failure 409 {object} swagger.ErrorResponse "Tag already exists"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /tags/{name} [patch]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "tag_handler", "Update")
+	logger.Debug("Attempting to update tag")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	name, err := url.PathUnescape(chi.URLParam(r, "name"))
+	if err != nil || name == "" {
+		logger.Warn("Invalid tag name", "tag", chi.URLParam(r, "name"))
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid tag name", nil)
+		return
+	}
+
+	var update entity.TagUpdate
+	if err := utils.DecodeJSONStruct(r, &update); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := update.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("tag", name)
+	tag, err := h.service.Update(r.Context(), userID, name, update)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully update", tag)
+	logger.Info("Successfully updated tag")
+}
+
+// Merge merges tags into one
+// @Summary Merge tags
+// @Description Replaces each source tag with the target tag on all of the authenticated user's todos. The target keeps its metadata; the metadata of the sources is removed.
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param merge body swagger.TagMergeRequest true "Tags to merge"
+// @Security BearerAuth
+// @Success 200 {object} swagger.TagResponse "Successfully update"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Tag not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /tags/merge [post]
+func (h *Handler) Merge(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "tag_handler", "Merge")
+	logger.Debug("Attempting to merge tags")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var merge entity.TagMerge
+	if err := utils.DecodeJSONStruct(r, &merge); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := merge.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("sources", merge.Sources, "target", merge.Target)
+	tag, err := h.service.Merge(r.Context(), userID, merge)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully update", tag)
+	logger.Info("Successfully merged tags")
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrTagNotFound):
+		logger.Warn("Tag not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Tag not found", nil)
+	case errors.Is(err, entity.ErrTagExists):
+		logger.Warn("Tag already exists")
+		entity.SendResponse[any](w, http.StatusConflict, true, "Tag already exists, merge the tags instead", nil)
+	default:
+		logger.Error("Failed to process tag request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
@@ -0,0 +1,259 @@
+package tag
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func newRouter(handler *Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/tags", func(r chi.Router) {
+		RegisterRoutes(r, handler)
+	})
+	return r
+}
+
+func TestGetAll(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	color := "#1e88e5"
+
+	testCases := []struct {
+		name               string
+		prepareTagService  func(serviceMock *mocks.TagService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name: "successful fetch",
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("GetAll", mock.Anything, userID).Return([]entity.Tag{
+					{Name: "home", Count: 2},
+					{Name: "work", Color: &color, Description: "Day job", Count: 14},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":[` +
+				`{"name":"home","color":null,"description":"","count":2},` +
+				`{"name":"work","color":"#1e88e5","description":"Day job","count":14}]}`,
+		},
+		{
+			name: "no tags",
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("GetAll", mock.Anything, userID).Return([]entity.Tag{}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully fetch"}`,
+		},
+		{
+			name: "service error",
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("GetAll", mock.Anything, userID).Return(nil, errors.New("connection refused"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"` + entity.ServerFailureMessage + `"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tagServiceMock := mocks.NewTagService(t)
+			if tc.prepareTagService != nil {
+				tc.prepareTagService(tagServiceMock)
+			}
+
+			r := newRouter(NewHandler(tagServiceMock, logger))
+
+			req, err := http.NewRequest("GET", "/tags", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	newName := "work"
+	color := "#1e88e5"
+
+	testCases := []struct {
+		name               string
+		inputPath          string
+		inputRequest       string
+		prepareTagService  func(serviceMock *mocks.TagService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful rename",
+			inputPath:    "/tags/wrk",
+			inputRequest: `{"name":"work"}`,
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("Update", mock.Anything, userID, "wrk", entity.TagUpdate{Name: &newName}).
+					Return(entity.Tag{Name: "work", Count: 3}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully update",` +
+				`"data":{"name":"work","color":null,"description":"","count":3}}`,
+		},
+		{
+			name:         "successful color of escaped name",
+			inputPath:    "/tags/to%20do",
+			inputRequest: `{"color":"#1e88e5"}`,
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("Update", mock.Anything, userID, "to do", entity.TagUpdate{Color: &color}).
+					Return(entity.Tag{Name: "to do", Color: &color, Count: 1}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully update",` +
+				`"data":{"name":"to do","color":"#1e88e5","description":"","count":1}}`,
+		},
+		{
+			name:               "invalid color",
+			inputPath:          "/tags/work",
+			inputRequest:       `{"color":"blue"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field color failed validation on hexcolor"}`,
+		},
+		{
+			name:               "name too long",
+			inputPath:          "/tags/work",
+			inputRequest:       `{"name":"` + string(bytes.Repeat([]byte("a"), 51)) + `"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'name' must not exceed 50 characters"}`,
+		},
+		{
+			name:         "tag not found",
+			inputPath:    "/tags/wrk",
+			inputRequest: `{"name":"work"}`,
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("Update", mock.Anything, userID, "wrk", mock.Anything).Return(entity.Tag{}, entity.ErrTagNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Tag not found"}`,
+		},
+		{
+			name:         "target exists",
+			inputPath:    "/tags/wrk",
+			inputRequest: `{"name":"work"}`,
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("Update", mock.Anything, userID, "wrk", mock.Anything).Return(entity.Tag{}, entity.ErrTagExists)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   `{"code":409,"error":true,"message":"Tag already exists, merge the tags instead"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tagServiceMock := mocks.NewTagService(t)
+			if tc.prepareTagService != nil {
+				tc.prepareTagService(tagServiceMock)
+			}
+
+			r := newRouter(NewHandler(tagServiceMock, logger))
+
+			req, err := http.NewRequest("PATCH", tc.inputPath, bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		inputRequest       string
+		prepareTagService  func(serviceMock *mocks.TagService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful merge",
+			inputRequest: `{"sources":["wrk","job"],"target":"work"}`,
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("Merge", mock.Anything, userID, entity.TagMerge{Sources: []string{"wrk", "job"}, Target: "work"}).
+					Return(entity.Tag{Name: "work", Count: 9}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully update",` +
+				`"data":{"name":"work","color":null,"description":"","count":9}}`,
+		},
+		{
+			name:               "missing sources",
+			inputRequest:       `{"target":"work"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'sources' is required"}`,
+		},
+		{
+			name:               "empty source",
+			inputRequest:       `{"sources":[""],"target":"work"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'sources[0]' is required"}`,
+		},
+		{
+			name:         "source not found",
+			inputRequest: `{"sources":["wrk"],"target":"work"}`,
+			prepareTagService: func(serviceMock *mocks.TagService) {
+				serviceMock.On("Merge", mock.Anything, userID, mock.Anything).Return(entity.Tag{}, entity.ErrTagNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Tag not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tagServiceMock := mocks.NewTagService(t)
+			if tc.prepareTagService != nil {
+				tc.prepareTagService(tagServiceMock)
+			}
+
+			r := newRouter(NewHandler(tagServiceMock, logger))
+
+			req, err := http.NewRequest("POST", "/tags/merge", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package tag
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/", h.GetAll)
+	r.Post("/merge", h.Merge)
+	r.Patch("/{name}", h.Update)
+}
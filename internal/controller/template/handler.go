@@ -0,0 +1,351 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils/contextutils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// instantiateResponse lists the todos created from a template, the root todo first.
+type instantiateResponse struct {
+	ID  int   `json:"id"`
+	IDs []int `json:"ids"`
+	entity.Undo
+}
+
+type Handler struct {
+	service service.TemplateService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.TemplateService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Get retrieves a template by ID
+// @Summary Get a template
+// @Description Retrieves one of the authenticated user's templates.
+// @Tags template
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.GetTemplateResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Template not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /templates/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "template_handler", "Get")
+	logger.Debug("Attempting to fetch template")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	id, ok := h.parseID(w, r, logger)
+	if !ok {
+		return
+	}
+
+	logger = logger.With("template_id", id)
+	template, err := h.service.Get(r.Context(), userID, id)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", template)
+	logger.Info("Successfully fetched template")
+}
+
+// GetAll retrieves the templates of the user
+// @Summary Get templates
+// @Description Retrieves a paginated list of the authenticated user's templates.
+// @Tags template
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of items per page" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListTemplateResponse "Successfully fetch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /templates [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "template_handler", "GetAll")
+	logger.Debug("Attempting to fetch templates")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	pagination, err := utils.ParsePagination(r.URL.Query())
+	if err != nil {
+		logger.Warn("Invalid pagination parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	templates, total, err := h.service.GetAll(r.Context(), userID, pagination)
+	if err != nil {
+		logger.Error("Failed to fetch templates", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, templates)
+	logger.Info("Successfully fetched templates")
+}
+
+// Create adds a new template
+// @Summary Create a template
+// @Description Creates a template: a todo with subtasks and checklists whose text may use {{variables}} and whose due dates are days after the start date.
+// @Tags template
+// @Accept json
+// @Produce json
+// @Param template body swagger.TemplateRequest true "Template data"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateTemplateResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /templates [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "template_handler", "Create")
+	logger.Debug("Attempting to create template")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	template, ok := h.decodeTemplate(w, r, logger)
+	if !ok {
+		return
+	}
+
+	id, err := h.service.Create(r.Context(), userID, template)
+	if err != nil {
+		logger.Error("Failed to create template", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", map[string]int{
+		"id": id,
+	})
+	logger.Info("Successfully created template", "template_id", id)
+}
+
+// Update modifies an existing template
+// @Summary Update a template
+// @Description Replaces one of the authenticated user's templates. Todos created from it earlier are not changed.
+// @Tags template
+// @Accept json
+// @Produce json
+// @Param template body swagger.TemplateRequest true "Updated template data"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UpdateResponse "Successfully update"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Template not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /templates [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "template_handler", "Update")
+	logger.Debug("Attempting to update template")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	template, ok := h.decodeTemplate(w, r, logger)
+	if !ok {
+		return
+	}
+
+	logger = logger.With("template_id", template.ID)
+	if err := h.service.Update(r.Context(), userID, template); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully update", nil)
+	logger.Info("Successfully updated template")
+}
+
+// Delete removes a template
+// @Summary Delete a template
+// @Description Deletes one of the authenticated user's templates. Todos created from it are kept.
+// @Tags template
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Template not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /templates/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "template_handler", "Delete")
+	logger.Debug("Attempting to delete template")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	id, ok := h.parseID(w, r, logger)
+	if !ok {
+		return
+	}
+
+	logger = logger.With("template_id", id)
+	if err := h.service.Delete(r.Context(), userID, id); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted template")
+}
+
+// Instantiate creates todos from a template
+// @Summary Instantiate a template
+// @Description Creates the todo of a template with all of its subtasks and checklists in one transaction. {{variables}} are replaced with the given values and due dates count from start_date, today by default.
+// @Description The returned undo token deletes every created todo again.
+// @Tags template
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param instantiation body swagger.InstantiateRequest true "Variables, start date and project"
+// @Param X-Workspace-ID header int false "Create the todos in this workspace"
+// @Security BearerAuth
+// @Success 201 {object} swagger.InstantiateResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or missing variables"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "No write access to the project or workspace"
+// @Failure 404 {object} swagger.NotFoundResponse "Template, project or workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /templates/{id}/instantiate [post]
+func (h *Handler) Instantiate(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "template_handler", "Instantiate")
+	logger.Debug("Attempting to instantiate template")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	id, ok := h.parseID(w, r, logger)
+	if !ok {
+		return
+	}
+
+	var instantiation entity.Instantiation
+	if err := utils.DecodeJSONStruct(r, &instantiation); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+	instantiation.WorkspaceID = contextutils.GetWorkspaceID(r.Context())
+
+	logger = logger.With("template_id", id)
+	ids, undo, err := h.service.Instantiate(r.Context(), userID, id, instantiation)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", instantiateResponse{
+		ID:   ids[0],
+		IDs:  ids,
+		Undo: undo,
+	})
+	logger.Info("Successfully instantiated template", "todos", len(ids))
+}
+
+func (h *Handler) parseID(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (int, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "template_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) decodeTemplate(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (entity.Template, bool) {
+	var template entity.Template
+	if err := utils.DecodeJSONStruct(r, &template); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return entity.Template{}, false
+	}
+
+	if validationErrors := template.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return entity.Template{}, false
+	}
+	return template, true
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	var templateErr *entity.TemplateError
+	switch {
+	case errors.As(err, &templateErr):
+		msg := fmt.Sprintf("Validation error: %s", templateErr.Error())
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+	case errors.Is(err, entity.ErrTemplateNotFound):
+		logger.Warn("Template not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Template not found", nil)
+	case errors.Is(err, entity.ErrProjectNotFound):
+		logger.Warn("Project not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+	case errors.Is(err, entity.ErrWorkspaceNotFound):
+		logger.Warn("Workspace not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+	case errors.Is(err, entity.ErrWorkspaceMismatch):
+		logger.Warn("Project belongs to a different workspace")
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Project belongs to a different workspace", nil)
+	case errors.Is(err, entity.ErrForbidden):
+		logger.Warn("Permission denied")
+		entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+	default:
+		logger.Error("Failed to process template request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
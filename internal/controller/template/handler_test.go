@@ -0,0 +1,185 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func newRouter(handler *Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/templates", func(r chi.Router) {
+		RegisterRoutes(r, handler)
+	})
+	return r
+}
+
+func TestCreate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                   string
+		requestBody            string
+		prepareTemplateService func(serviceMock *mocks.TemplateService)
+		expectedHTTPStatus     int
+		expectedResponse       string
+	}{
+		{
+			name: "successful create",
+			requestBody: `{"name":"Release","title":"Release {{version}}","description":"Ship {{version}}",` +
+				`"checklist":["Update changelog"],"due_offset":7,` +
+				`"subtasks":[{"title":"Tag {{version}}","description":"Push the tag","due_offset":6}]}`,
+			prepareTemplateService: func(serviceMock *mocks.TemplateService) {
+				serviceMock.On("Create", mock.Anything, userID, mock.MatchedBy(func(template entity.Template) bool {
+					return template.Name == "Release" && template.Title == "Release {{version}}" &&
+						len(template.Subtasks) == 1 && template.Subtasks[0].DueOffset == 6
+				})).Return(4, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse:   `{"code":201,"error":false,"message":"Successfully create","data":{"id":4}}`,
+		},
+		{
+			name:               "missing name",
+			requestBody:        `{"title":"Release","description":"Ship it"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'name' is required"}`,
+		},
+		{
+			name: "subtasks nested too deep",
+			requestBody: `{"name":"Deep","title":"a","description":"a","subtasks":[{"title":"b","description":"b",` +
+				`"subtasks":[{"title":"c","description":"c","subtasks":[{"title":"d","description":"d"}]}]}]}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse: `{"code":400,"error":true,` +
+				`"message":"Validation error: Subtasks must not be nested more than 2 levels deep"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			templateServiceMock := mocks.NewTemplateService(t)
+			if tc.prepareTemplateService != nil {
+				tc.prepareTemplateService(templateServiceMock)
+			}
+
+			r := newRouter(NewHandler(templateServiceMock, logger))
+
+			req, err := http.NewRequest("POST", "/templates", bytes.NewBufferString(tc.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	token := uuid.MustParse("6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10")
+	expiresAt := time.Date(2025, 4, 1, 12, 0, 30, 0, time.UTC)
+
+	testCases := []struct {
+		name                   string
+		templateID             string
+		requestBody            string
+		prepareTemplateService func(serviceMock *mocks.TemplateService)
+		expectedHTTPStatus     int
+		expectedResponse       string
+	}{
+		{
+			name:        "successful instantiate",
+			templateID:  "4",
+			requestBody: `{"variables":{"version":"1.2.0"},"start_date":"2025-04-01"}`,
+			prepareTemplateService: func(serviceMock *mocks.TemplateService) {
+				serviceMock.On("Instantiate", mock.Anything, userID, 4, mock.MatchedBy(func(inst entity.Instantiation) bool {
+					return inst.Variables["version"] == "1.2.0" && inst.StartDate.Format(time.DateOnly) == "2025-04-01"
+				})).Return([]int{12, 13, 14}, entity.Undo{Token: token, ExpiresAt: expiresAt}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully create","data":{"id":12,"ids":[12,13,14],` +
+				`"undo_token":"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10","undo_expires_at":"2025-04-01T12:00:30Z"}}`,
+		},
+		{
+			name:        "missing variable",
+			templateID:  "4",
+			requestBody: `{}`,
+			prepareTemplateService: func(serviceMock *mocks.TemplateService) {
+				serviceMock.On("Instantiate", mock.Anything, userID, 4, mock.Anything).
+					Return(nil, entity.Undo{}, &entity.TemplateError{Messages: []string{"Variable 'version' is not set"}})
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Variable 'version' is not set"}`,
+		},
+		{
+			name:        "template not found",
+			templateID:  "9",
+			requestBody: `{}`,
+			prepareTemplateService: func(serviceMock *mocks.TemplateService) {
+				serviceMock.On("Instantiate", mock.Anything, userID, 9, mock.Anything).
+					Return(nil, entity.Undo{}, entity.ErrTemplateNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Template not found"}`,
+		},
+		{
+			name:        "project not writable",
+			templateID:  "4",
+			requestBody: `{"variables":{"version":"1.2.0"},"project_id":3}`,
+			prepareTemplateService: func(serviceMock *mocks.TemplateService) {
+				serviceMock.On("Instantiate", mock.Anything, userID, 4, mock.Anything).
+					Return(nil, entity.Undo{}, entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+		{
+			name:               "invalid id",
+			templateID:         "abc",
+			requestBody:        `{}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			templateServiceMock := mocks.NewTemplateService(t)
+			if tc.prepareTemplateService != nil {
+				tc.prepareTemplateService(templateServiceMock)
+			}
+
+			r := newRouter(NewHandler(templateServiceMock, logger))
+
+			req, err := http.NewRequest("POST", "/templates/"+tc.templateID+"/instantiate",
+				bytes.NewBufferString(tc.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
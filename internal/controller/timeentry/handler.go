@@ -0,0 +1,408 @@
+package timeentry
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Handler struct {
+	service service.TimeService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.TimeService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Start starts a timer on a todo
+// @Summary Start a timer
+// @Description Starts tracking time on a todo. Each user can have only one running timer.
+// @Tags time
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Security BearerAuth
+// @Success 201 {object} swagger.TimeEntryResponse "Successfully start"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "No write access to the todo"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 409 {object} swagger.ConflictResponse "Another timer is already running"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/timer/start [post]
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "time_handler", "Start")
+	logger.Debug("Attempting to start timer")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID)
+	entry, err := h.service.Start(r.Context(), userID, todoID)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully start", entry)
+	logger.Info("Successfully started timer", "time_entry_id", entry.ID)
+}
+
+// Stop stops the timer on a todo
+// @Summary Stop a timer
+// @Description Stops the authenticated user's running timer on a todo and returns the finished time entry.
+// @Tags time
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.TimeEntryResponse "Successfully stop"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "No timer is running on the todo"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/timer/stop [post]
+func (h *Handler) Stop(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "time_handler", "Stop")
+	logger.Debug("Attempting to stop timer")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID)
+	entry, err := h.service.Stop(r.Context(), userID, todoID)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully stop", entry)
+	logger.Info("Successfully stopped timer", "time_entry_id", entry.ID)
+}
+
+// Running retrieves the running timer
+// @Summary Get the running timer
+// @Description Retrieves the authenticated user's running timer, if any.
+// @Tags time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} swagger.TimeEntryResponse "Successfully fetch"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "No timer is running"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /timer [get]
+func (h *Handler) Running(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "time_handler", "Running")
+	logger.Debug("Attempting to fetch running timer")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	entry, err := h.service.Running(r.Context(), userID)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", entry)
+	logger.Info("Successfully fetched running timer", "time_entry_id", entry.ID)
+}
+
+// GetAll retrieves the time entries of a todo
+// @Summary Get time entries
+// @Description Retrieves the time everyone logged on a todo, most recent first. Running timers have no ended_at.
+// @Tags time
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param limit query int false "Number of items per page" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListTimeEntryResponse "Successfully fetch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid ID or query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/time-entries [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "time_handler", "GetAll")
+	logger.Debug("Attempting to fetch time entries")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	pagination, err := utils.ParsePagination(r.URL.Query())
+	if err != nil {
+		logger.Warn("Invalid pagination parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID)
+	entries, total, err := h.service.GetAll(r.Context(), userID, todoID, pagination)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, entries)
+	logger.Info("Successfully fetched time entries")
+}
+
+// Create logs time on a todo
+// @Summary Log time
+// @Description Logs time spent on a todo without a timer. Entries must have ended and be at most 24 hours long.
+// @Tags time
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param entry body swagger.TimeEntryRequest true "Time entry data"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateTimeEntryResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "No write access to the todo"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/time-entries [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "time_handler", "Create")
+	logger.Debug("Attempting to create time entry")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	var request entity.TimeEntryRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID)
+	entry, err := h.service.Create(r.Context(), userID, todoID, request)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", entry)
+	logger.Info("Successfully created time entry", "time_entry_id", entry.ID)
+}
+
+// Delete removes a time entry
+// @Summary Delete a time entry
+// @Description Deletes one of the authenticated user's time entries. Deleting a running timer discards it.
+// @Tags time
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param entryID path int true "Time entry ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Time entry of another user"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo or time entry not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/time-entries/{entryID} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "time_handler", "Delete")
+	logger.Debug("Attempting to delete time entry")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	todoID, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	entryStr := chi.URLParam(r, "entryID")
+	entryID, err := strconv.Atoi(entryStr)
+	if err != nil {
+		logger.Warn("Invalid time entry id", "time_entry_id", entryStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("todo_id", todoID, "time_entry_id", entryID)
+	if err := h.service.Delete(r.Context(), userID, todoID, entryID); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted time entry")
+}
+
+// Report sums the logged time
+// @Summary Get a time report
+// @Description Sums the time the authenticated user logged in entries started between from and to, both inclusive, per todo, tag or project.
+// @Description from defaults to the first day of the current month and to defaults to today. A todo with several tags counts towards each of them.
+// @Tags time
+// @Accept json
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param group_by query string false "Group by todo, tag or project" default(todo)
+// @Security BearerAuth
+// @Success 200 {object} swagger.TimeReportResponse "Successfully fetch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /reports/time [get]
+func (h *Handler) Report(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "time_handler", "Report")
+	logger.Debug("Attempting to build time report")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	request := entity.TimeReportRequest{
+		From:    entity.Date{Time: today.AddDate(0, 0, 1-today.Day())},
+		To:      entity.Date{Time: today},
+		GroupBy: entity.TimeReportByTodo,
+	}
+	for _, param := range []struct {
+		name string
+		date *entity.Date
+	}{{"from", &request.From}, {"to", &request.To}} {
+		name, date := param.name, param.date
+		if value := query.Get(name); value != "" {
+			parsed, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				logger.Warn("Invalid date parameter", name, value)
+				entity.SendResponse[any](w, http.StatusBadRequest, true,
+					fmt.Sprintf("Invalid %s format. Use YYYY-MM-DD", name), nil)
+				return
+			}
+			*date = entity.Date{Time: parsed}
+		}
+	}
+	if groupBy := query.Get("group_by"); groupBy != "" {
+		request.GroupBy = entity.TimeReportGrouping(groupBy)
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	report, err := h.service.Report(r.Context(), userID, request)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", report)
+	logger.Info("Successfully built time report", "rows", len(report.Rows))
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrTodoNotFound):
+		logger.Warn("Todo not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+	case errors.Is(err, entity.ErrTimeEntryNotFound):
+		logger.Warn("Time entry not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Time entry not found", nil)
+	case errors.Is(err, entity.ErrTimerNotRunning):
+		logger.Warn("No timer is running")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "No timer is running", nil)
+	case errors.Is(err, entity.ErrTimerRunning):
+		logger.Warn("Another timer is already running")
+		entity.SendResponse[any](w, http.StatusConflict, true, "Another timer is already running, stop it first", nil)
+	case errors.Is(err, entity.ErrForbidden):
+		logger.Warn("Permission denied")
+		entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+	default:
+		logger.Error("Failed to process time request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
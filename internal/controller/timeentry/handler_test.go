@@ -0,0 +1,309 @@
+package timeentry
+
+import (
+	"bytes"
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func newRouter(handler *Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/todos", func(r chi.Router) {
+		RegisterRoutes(r, handler)
+	})
+	r.Route("/reports", func(r chi.Router) {
+		RegisterReportRoutes(r, handler)
+	})
+	return r
+}
+
+func TestStart(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	startedAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		todoID             string
+		prepareTimeService func(serviceMock *mocks.TimeService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:   "successful start",
+			todoID: "12",
+			prepareTimeService: func(serviceMock *mocks.TimeService) {
+				serviceMock.On("Start", mock.Anything, userID, 12).Return(entity.TimeEntry{
+					ID: 31, TodoID: 12, UserID: userID, StartedAt: startedAt,
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully start","data":{"id":31,"todo_id":12,` +
+				`"user_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","started_at":"2025-04-01T09:00:00Z","minutes":0}}`,
+		},
+		{
+			name:   "another timer running",
+			todoID: "12",
+			prepareTimeService: func(serviceMock *mocks.TimeService) {
+				serviceMock.On("Start", mock.Anything, userID, 12).Return(entity.TimeEntry{}, entity.ErrTimerRunning)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   `{"code":409,"error":true,"message":"Another timer is already running, stop it first"}`,
+		},
+		{
+			name:   "read only access",
+			todoID: "12",
+			prepareTimeService: func(serviceMock *mocks.TimeService) {
+				serviceMock.On("Start", mock.Anything, userID, 12).Return(entity.TimeEntry{}, entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+		{
+			name:               "invalid id",
+			todoID:             "abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeServiceMock := mocks.NewTimeService(t)
+			if tc.prepareTimeService != nil {
+				tc.prepareTimeService(timeServiceMock)
+			}
+
+			r := newRouter(NewHandler(timeServiceMock, logger))
+
+			req, err := http.NewRequest("POST", "/todos/"+tc.todoID+"/timer/start", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestStop(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	startedAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(90 * time.Minute)
+
+	testCases := []struct {
+		name               string
+		prepareTimeService func(serviceMock *mocks.TimeService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name: "successful stop",
+			prepareTimeService: func(serviceMock *mocks.TimeService) {
+				serviceMock.On("Stop", mock.Anything, userID, 12).Return(entity.TimeEntry{
+					ID: 31, TodoID: 12, UserID: userID, StartedAt: startedAt, EndedAt: &endedAt, Minutes: 90,
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully stop","data":{"id":31,"todo_id":12,` +
+				`"user_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","started_at":"2025-04-01T09:00:00Z",` +
+				`"ended_at":"2025-04-01T10:30:00Z","minutes":90}}`,
+		},
+		{
+			name: "no timer running",
+			prepareTimeService: func(serviceMock *mocks.TimeService) {
+				serviceMock.On("Stop", mock.Anything, userID, 12).Return(entity.TimeEntry{}, entity.ErrTimerNotRunning)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"No timer is running"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeServiceMock := mocks.NewTimeService(t)
+			tc.prepareTimeService(timeServiceMock)
+
+			r := newRouter(NewHandler(timeServiceMock, logger))
+
+			req, err := http.NewRequest("POST", "/todos/12/timer/stop", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+	startedAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(90 * time.Minute)
+
+	testCases := []struct {
+		name               string
+		requestBody        string
+		prepareTimeService func(serviceMock *mocks.TimeService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:        "successful create",
+			requestBody: `{"started_at":"2025-04-01T09:00:00Z","ended_at":"2025-04-01T10:30:00Z","note":"Call"}`,
+			prepareTimeService: func(serviceMock *mocks.TimeService) {
+				serviceMock.On("Create", mock.Anything, userID, 12, entity.TimeEntryRequest{
+					StartedAt: startedAt, EndedAt: endedAt, Note: "Call",
+				}).Return(entity.TimeEntry{
+					ID: 32, TodoID: 12, UserID: userID, StartedAt: startedAt, EndedAt: &endedAt, Minutes: 90, Note: "Call",
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully create","data":{"id":32,"todo_id":12,` +
+				`"user_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","started_at":"2025-04-01T09:00:00Z",` +
+				`"ended_at":"2025-04-01T10:30:00Z","minutes":90,"note":"Call"}}`,
+		},
+		{
+			name:               "ends before it starts",
+			requestBody:        `{"started_at":"2025-04-01T09:00:00Z","ended_at":"2025-04-01T08:00:00Z"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'ended_at' must be after 'started_at'"}`,
+		},
+		{
+			name:               "longer than a day",
+			requestBody:        `{"started_at":"2025-04-01T09:00:00Z","ended_at":"2025-04-02T10:00:00Z"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Time entry must not be longer than 24 hours"}`,
+		},
+		{
+			name:               "missing ended_at",
+			requestBody:        `{"started_at":"2025-04-01T09:00:00Z"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'ended_at' is required"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeServiceMock := mocks.NewTimeService(t)
+			if tc.prepareTimeService != nil {
+				tc.prepareTimeService(timeServiceMock)
+			}
+
+			r := newRouter(NewHandler(timeServiceMock, logger))
+
+			req, err := http.NewRequest("POST", "/todos/12/time-entries", bytes.NewBufferString(tc.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	from := entity.Date{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)}
+	to := entity.Date{Time: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)}
+	projectID := 3
+	estimate := 600
+
+	testCases := []struct {
+		name               string
+		query              string
+		prepareTimeService func(serviceMock *mocks.TimeService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:  "per project",
+			query: "?from=2025-04-01&to=2025-04-30&group_by=project",
+			prepareTimeService: func(serviceMock *mocks.TimeService) {
+				serviceMock.On("Report", mock.Anything, userID, entity.TimeReportRequest{
+					From: from, To: to, GroupBy: entity.TimeReportByProject,
+				}).Return(entity.TimeReport{
+					From: from, To: to, GroupBy: entity.TimeReportByProject, TotalMinutes: 600,
+					Rows: []entity.TimeReportRow{
+						{ID: &projectID, Name: "Website", Minutes: 540, EstimateMinutes: &estimate},
+						{Name: "", Minutes: 60},
+					},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":{"from":"2025-04-01",` +
+				`"to":"2025-04-30","group_by":"project","total_minutes":600,"rows":[` +
+				`{"id":3,"name":"Website","minutes":540,"estimate_minutes":600},{"name":"","minutes":60}]}}`,
+		},
+		{
+			name:               "unknown grouping",
+			query:              "?from=2025-04-01&to=2025-04-30&group_by=user",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'group_by' must be one of: todo tag project"}`,
+		},
+		{
+			name:               "invalid date",
+			query:              "?from=01.04.2025",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid from format. Use YYYY-MM-DD"}`,
+		},
+		{
+			name:               "range reversed",
+			query:              "?from=2025-04-30&to=2025-04-01",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'to' must not be before 'from'"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeServiceMock := mocks.NewTimeService(t)
+			if tc.prepareTimeService != nil {
+				tc.prepareTimeService(timeServiceMock)
+			}
+
+			r := newRouter(NewHandler(timeServiceMock, logger))
+
+			req, err := http.NewRequest("GET", "/reports/time"+tc.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
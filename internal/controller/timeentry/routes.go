@@ -0,0 +1,22 @@
+package timeentry
+
+import "github.com/go-chi/chi/v5"
+
+// RegisterRoutes registers the timer and time entry routes on the todos router.
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Post("/{id}/timer/start", h.Start)
+	r.Post("/{id}/timer/stop", h.Stop)
+	r.Get("/{id}/time-entries", h.GetAll)
+	r.Post("/{id}/time-entries", h.Create)
+	r.Delete("/{id}/time-entries/{entryID}", h.Delete)
+}
+
+// RegisterTimerRoutes registers the route that shows the running timer.
+func RegisterTimerRoutes(r chi.Router, h *Handler) {
+	r.Get("/", h.Running)
+}
+
+// RegisterReportRoutes registers the time report on the reports router.
+func RegisterReportRoutes(r chi.Router, h *Handler) {
+	r.Get("/time", h.Report)
+}
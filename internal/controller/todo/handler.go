@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/ical"
 	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/todoio"
 	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils/contextutils"
 	"github.com/google/uuid"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -16,6 +20,26 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+// createResponse is returned for a new todo.
+type createResponse struct {
+	ID int `json:"id"`
+	entity.Undo
+}
+
+// bulkResult reports the outcome of one operation of a bulk request.
+type bulkResult struct {
+	Index  int                      `json:"index"`
+	Op     entity.BulkOperationType `json:"op"`
+	ID     int                      `json:"id,omitempty"`
+	Status int                      `json:"status"`
+	Error  string                   `json:"error,omitempty"`
+}
+
+type bulkResponse struct {
+	Results []bulkResult `json:"results"`
+	*entity.Undo
+}
+
 type Handler struct {
 	service service.TodoService
 	logger  *slog.Logger
@@ -78,15 +102,16 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 
 // Delete removes a todo by ID
 // @Summary Delete a todo
-// @Description Deletes a todo by its ID for the authenticated user.
+// @Description Deletes a todo by its ID for the authenticated user. The returned undo token brings the todo back, without its comments, shares and attachments.
 // @Tags todo
 // @Accept json
 // @Produce json
 // @Param id path int true "Todo ID"
 // @Security BearerAuth
-// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Success 200 {object} swagger.DeleteTodoResponse "Successfully delete"
 // @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
 // @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
 // @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
 // @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
 // @Router /todos/{id} [delete]
@@ -110,32 +135,39 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger = logger.With("todo_id", id)
-	err = h.service.Delete(r.Context(), userID, id)
+	undo, err := h.service.Delete(r.Context(), userID, id)
 	if err != nil {
 		logger.Error("Failed to delete todo", "error", err)
 		if errors.Is(err, entity.ErrTodoNotFound) {
 			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
 			return
 		}
+		if errors.Is(err, entity.ErrForbidden) {
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
 		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
 		return
 	}
 
-	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	entity.SendResponse(w, http.StatusOK, false, "Successfully delete", undo)
 	logger.Info("Successfully delete todo")
 }
 
 // Create adds a new todo
 // @Summary Create a todo
-// @Description Creates a new todo for the authenticated user.
+// @Description Creates a new todo for the authenticated user, or a subtask when parent_id is set. The returned undo token deletes it again.
 // @Tags todo
 // @Accept json
 // @Produce json
 // @Param todo body swagger.TodoRequest true "Todo data"
+// @Param X-Workspace-ID header int false "Create the todo in this workspace"
 // @Security BearerAuth
 // @Success 201 {object} swagger.CreateTodoResponse "Todo successfully created"
 // @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
 // @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "No write access to the parent todo, project or workspace"
+// @Failure 404 {object} swagger.NotFoundResponse "Parent todo, project or workspace not found"
 // @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
 // @Router /todos [post]
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
@@ -163,31 +195,61 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
 		return
 	}
+	todo.WorkspaceID = contextutils.GetWorkspaceID(r.Context())
 
-	id, err := h.service.Create(r.Context(), userID, todo)
+	id, undo, err := h.service.Create(r.Context(), userID, todo)
 	if err != nil {
-		logger.Error("Failed to create todo")
+		if errors.Is(err, entity.ErrTodoNotFound) {
+			logger.Warn("Parent todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Parent todo not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrParentMismatch) {
+			logger.Warn("Parent todo belongs to a different workspace")
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Parent todo belongs to a different workspace", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrProjectNotFound) {
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrWorkspaceNotFound) {
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrWorkspaceMismatch) {
+			logger.Warn("Project belongs to a different workspace")
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Project belongs to a different workspace", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
+		logger.Error("Failed to create todo", "error", err)
 		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
 		return
 	}
 
-	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", map[string]int{
-		"id": id,
-	})
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", createResponse{ID: id, Undo: undo})
 	logger.Info("Successfully create todo")
 }
 
 // Update modifies an existing todo
 // @Summary Update a todo
-// @Description Updates an existing todo for the authenticated user.
+// @Description Updates an existing todo for the authenticated user. The returned undo token restores the previous values.
 // @Tags todo
 // @Accept json
 // @Produce json
 // @Param todo body swagger.TodoRequest true "Updated todo data"
 // @Security BearerAuth
-// @Success 200 {object} swagger.UpdateResponse "Todo successfully updated"
+// @Success 200 {object} swagger.UpdateTodoResponse "Todo successfully updated"
 // @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
 // @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
 // @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
 // @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
 // @Router /todos [put]
@@ -217,20 +279,35 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.Update(r.Context(), userID, todo)
+	undo, err := h.service.Update(r.Context(), userID, todo)
 	if err != nil {
 		if errors.Is(err, entity.ErrTodoNotFound) {
 			logger.Warn("Todo not found")
 			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
 			return
 		}
+		if errors.Is(err, entity.ErrProjectNotFound) {
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrWorkspaceMismatch) {
+			logger.Warn("Project belongs to a different workspace")
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Project belongs to a different workspace", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
 
 		logger.Error("Failed to update todo")
 		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
 		return
 	}
 
-	entity.SendResponse[any](w, http.StatusOK, false, "Successfully update", nil)
+	entity.SendResponse(w, http.StatusOK, false, "Successfully update", undo)
 	logger.Info("Successfully update todo")
 }
 
@@ -244,10 +321,15 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Param offset query int false "Offset for pagination" default(0)
 // @Param due_date query string false "Filter by due date (YYYY-MM-DD)"
 // @Param tags query string false "Filter by tags (comma-separated)"
+// @Param project_id query int false "List the todos of a project instead of your own"
+// @Param parent_id query int false "List the subtasks of a todo instead of your own todos"
+// @Param assignee query string false "Filter by assignee ID, or 'me' for every todo assigned to you"
+// @Param X-Workspace-ID header int false "List the todos of this workspace instead of your personal ones"
 // @Security BearerAuth
 // @Success 200 {object} swagger.ListTodoResponse "Todos successfully retrieved"
 // @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
 // @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo, project or workspace not found"
 // @Failure 500 {object} swagger.ServerErrorResponse "Something went wrong, please try again later"
 // @Router /todos [get]
 func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
@@ -262,36 +344,899 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := r.URL.Query()
-	limit := entity.DefaultLimit
-	offset := entity.DefaultOffset
+	pagination, err := utils.ParsePagination(query)
+	if err != nil {
+		logger.Warn("Invalid pagination parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	filters, err := parseFilters(r, userID)
+	if err != nil {
+		logger.Warn("Invalid filter parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	todos, total, err := h.service.GetAll(r.Context(), userID, pagination, filters)
+	if err != nil {
+		if errors.Is(err, entity.ErrTodoNotFound) {
+			logger.Warn("Parent todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrProjectNotFound) {
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrWorkspaceNotFound) {
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+			return
+		}
+		logger.Error("Failed to fetch todos", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, todos)
+	logger.Info("Successfully fetched todos")
+}
+
+// GetAgenda retrieves todos per day
+// @Summary Get agenda
+// @Description Lists todos per day from 'from' to 'to', both inclusive, in the user's time zone. Days without todos are included, recurring todos are listed on each occurrence and open todos due before today are listed on today as overdue. Without 'from' the agenda starts today, without 'to' it covers a week; it may cover at most 92 days.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param tags query string false "Filter by tags (comma-separated)"
+// @Param project_id query int false "List the todos of a project instead of your own"
+// @Param parent_id query int false "List the subtasks of a todo instead of your own todos"
+// @Param assignee query string false "Filter by assignee ID, or 'me' for every todo assigned to you"
+// @Param X-Workspace-ID header int false "List the todos of this workspace instead of your personal ones"
+// @Security BearerAuth
+// @Success 200 {object} swagger.AgendaResponse "Agenda successfully retrieved"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo, project or workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Something went wrong, please try again later"
+// @Router /todos/agenda [get]
+func (h *Handler) GetAgenda(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "GetAgenda")
+	logger.Debug("Attempting to get agenda")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	filters, err := parseFilters(r, userID)
+	if err != nil {
+		logger.Warn("Invalid filter parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+	request := entity.AgendaRequest{Filters: filters}
+
+	query := r.URL.Query()
+	for _, param := range []struct {
+		name string
+		date **entity.Date
+	}{{"from", &request.From}, {"to", &request.To}} {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			logger.Warn("Invalid date parameter", "param", param.name, "value", value)
+			entity.SendResponse[any](w, http.StatusBadRequest, true,
+				fmt.Sprintf("Invalid %s format. Use YYYY-MM-DD", param.name), nil)
+			return
+		}
+		*param.date = &entity.Date{Time: date}
+	}
+
+	agenda, err := h.service.GetAgenda(r.Context(), userID, request)
+	if err != nil {
+		var validationErr *entity.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			logger.Warn("Invalid agenda range", "error", err)
+			entity.SendResponse[any](w, http.StatusBadRequest, true,
+				fmt.Sprintf("Validation error: %s", strings.Join(validationErr.Messages, ";")), nil)
+		case errors.Is(err, entity.ErrTodoNotFound):
+			logger.Warn("Parent todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+		case errors.Is(err, entity.ErrProjectNotFound):
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+		case errors.Is(err, entity.ErrWorkspaceNotFound):
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+		default:
+			logger.Error("Failed to fetch agenda", "error", err)
+			entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		}
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", agenda)
+	logger.Info("Successfully fetched agenda")
+}
+
+// Export retrieves todos as an iCalendar file
+// @Summary Export todos as iCalendar
+// @Description Returns the todos matching the filters as RFC 5545 VTODO components, for calendar clients such as Thunderbird or Apple Calendar. Tags become CATEGORIES and recurring todos carry an RRULE.
+// @Tags todo
+// @Produce text/calendar
+// @Param due_date query string false "Filter by due date (YYYY-MM-DD)"
+// @Param tags query string false "Filter by tags (comma-separated)"
+// @Param project_id query int false "Export the todos of a project instead of your own"
+// @Param parent_id query int false "Export the subtasks of a todo instead of your own todos"
+// @Param assignee query string false "Filter by assignee ID, or 'me' for every todo assigned to you"
+// @Param X-Workspace-ID header int false "Export the todos of this workspace instead of your personal ones"
+// @Security BearerAuth
+// @Success 200 {string} string "iCalendar file"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo, project or workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Something went wrong, please try again later"
+// @Router /todos.ics [get]
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "Export")
+	logger.Debug("Attempting to export todos")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	filters, err := parseFilters(r, userID)
+	if err != nil {
+		logger.Warn("Invalid filter parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	todos, err := h.service.Export(r.Context(), userID, filters)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrTodoNotFound):
+			logger.Warn("Parent todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+		case errors.Is(err, entity.ErrProjectNotFound):
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+		case errors.Is(err, entity.ErrWorkspaceNotFound):
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+		default:
+			logger.Error("Failed to fetch todos", "error", err)
+			entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="todos.ics"`)
+	if err := ical.WriteCalendar(w, "Todos", todos, time.Now()); err != nil {
+		logger.Error("Failed to write calendar", "error", err)
+		return
+	}
+	logger.Info("Successfully exported todos", "count", len(todos))
+}
+
+// exportWriter sets the headers of an export on its first write, so that errors
+// raised before any todo is written can still be sent as JSON.
+type exportWriter struct {
+	http.ResponseWriter
+	format   todoio.Format
+	filename string
+	started  bool
+}
+
+func (e *exportWriter) start() {
+	if e.started {
+		return
+	}
+	e.started = true
+	e.Header().Set("Content-Type", e.format.ContentType)
+	e.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, e.filename))
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	e.start()
+	return e.ResponseWriter.Write(p)
+}
+
+// ExportFile streams todos as a file
+// @Summary Export todos as a file
+// @Description Streams every todo matching the filters as JSON, NDJSON, CSV, a Markdown checklist or todo.txt, without pagination. The JSON, CSV and todo.txt files can be imported again with POST /todos/import.
+// @Tags todo
+// @Produce json,plain
+// @Param format query string false "File format" Enums(json, ndjson, csv, markdown, todotxt) default(json)
+// @Param due_date query string false "Filter by due date (YYYY-MM-DD)"
+// @Param tags query string false "Filter by tags (comma-separated)"
+// @Param project_id query int false "Export the todos of a project instead of your own"
+// @Param parent_id query int false "Export the subtasks of a todo instead of your own todos"
+// @Param assignee query string false "Filter by assignee ID, or 'me' for every todo assigned to you"
+// @Param X-Workspace-ID header int false "Export the todos of this workspace instead of your personal ones"
+// @Security BearerAuth
+// @Success 200 {string} string "Exported file"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo, project or workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Something went wrong, please try again later"
+// @Router /todos/export [get]
+func (h *Handler) ExportFile(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "ExportFile")
+	logger.Debug("Attempting to export todos")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	name := entity.ExportJSON
+	if formatStr := r.URL.Query().Get("format"); formatStr != "" {
+		name = entity.ExportFormat(formatStr)
+	}
+	format, ok := todoio.ExportFormats[name]
+	if !ok {
+		logger.Warn("Invalid format parameter", "format", name)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid format parameter", nil)
+		return
+	}
+
+	filters, err := parseFilters(r, userID)
+	if err != nil {
+		logger.Warn("Invalid filter parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	logger = logger.With("format", name)
+	out := &exportWriter{
+		ResponseWriter: w,
+		format:         format,
+		filename:       fmt.Sprintf("todos-%s.%s", time.Now().UTC().Format(time.DateOnly), format.Extension),
+	}
+	writer := format.NewWriter(out)
+	count := 0
+	err = h.service.Stream(r.Context(), userID, filters, func(todo entity.Todo) error {
+		count++
+		return writer.Write(todo)
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		if out.started {
+			// The status is already sent, so all that is left is to cut the file short.
+			logger.Error("Failed to write export", "error", err, "count", count)
+			panic(http.ErrAbortHandler)
+		}
+		switch {
+		case errors.Is(err, entity.ErrTodoNotFound):
+			logger.Warn("Parent todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+		case errors.Is(err, entity.ErrProjectNotFound):
+			logger.Warn("Project not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Project not found", nil)
+		case errors.Is(err, entity.ErrWorkspaceNotFound):
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+		default:
+			logger.Error("Failed to fetch todos", "error", err)
+			entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		}
+		return
+	}
+
+	out.start()
+	logger.Info("Successfully exported todos", "count", count)
+}
+
+// GetSharedWithMe retrieves todos other users have shared with the caller
+// @Summary Get todos shared with me
+// @Description Retrieves todos shared with the authenticated user directly or through a shared project.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of items per page" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListSharedTodoResponse "Todos successfully retrieved"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Something went wrong, please try again later"
+// @Router /todos/shared-with-me [get]
+func (h *Handler) GetSharedWithMe(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "GetSharedWithMe")
+	logger.Debug("Attempting to get shared todos")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	pagination, err := utils.ParsePagination(r.URL.Query())
+	if err != nil {
+		logger.Warn("Invalid pagination parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	todos, total, err := h.service.GetSharedWithMe(r.Context(), userID, pagination)
+	if err != nil {
+		logger.Error("Failed to fetch shared todos", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, todos)
+	logger.Info("Successfully fetched shared todos")
+}
+
+// Assign sets the assignee of a todo
+// @Summary Assign a todo
+// @Description Assigns a todo to a user who can see it, or unassigns it when assignee_id is null. The new assignee is notified. The returned undo token restores the previous assignee.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param assignee body swagger.AssigneeRequest true "Assignee"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UpdateTodoResponse "Successfully update"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or the assignee cannot see the todo"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/assignee [put]
+func (h *Handler) Assign(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "Assign")
+	logger.Debug("Attempting to assign todo")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	var request entity.AssigneeRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	logger = logger.With("todo_id", id)
+	undo, err := h.service.Assign(r.Context(), userID, id, request.AssigneeID)
+	if err != nil {
+		if errors.Is(err, entity.ErrTodoNotFound) {
+			logger.Warn("Todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrInvalidAssignee) {
+			logger.Warn("Assignee cannot see the todo", "assignee_id", request.AssigneeID)
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Assignee must be able to see the todo", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
+		logger.Error("Failed to assign todo", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully update", undo)
+	logger.Info("Successfully assigned todo")
+}
+
+// Complete marks a todo as done or reopens it
+// @Summary Complete a todo
+// @Description Marks a todo as done, or reopens it when completed is false. The returned undo token restores the previous state.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param completion body swagger.CompletionRequest true "Completion state"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UpdateTodoResponse "Successfully update"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/completion [put]
+func (h *Handler) Complete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "Complete")
+	logger.Debug("Attempting to complete todo")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	var request entity.CompletionRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	logger = logger.With("todo_id", id, "completed", request.Completed)
+	undo, err := h.service.Complete(r.Context(), userID, id, request.Completed)
+	if err != nil {
+		if errors.Is(err, entity.ErrTodoNotFound) {
+			logger.Warn("Todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
+		logger.Error("Failed to complete todo", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully update", undo)
+	logger.Info("Successfully completed todo")
+}
+
+// GetHistory retrieves the change log of a todo
+// @Summary Get todo history
+// @Description Retrieves who changed a todo, when, and the before and after value of every changed field, oldest first. Pass the next_cursor of a page as cursor to get the next one.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path int true "Todo ID"
+// @Param limit query int false "Number of entries per page" default(20)
+// @Param cursor query string false "Cursor returned by the previous page"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListTodoHistoryResponse "Successfully fetch"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid ID or query parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Todo not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/{id}/history [get]
+func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "GetHistory")
+	logger.Debug("Attempting to fetch todo history")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "todo_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	cursor, err := utils.ParseCursor(r.URL.Query())
+	if err != nil {
+		logger.Warn("Invalid cursor parameters", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	logger = logger.With("todo_id", id)
+	page, err := h.service.GetHistory(r.Context(), userID, id, cursor)
+	if err != nil {
+		if errors.Is(err, entity.ErrTodoNotFound) {
+			logger.Warn("Todo not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Todo not found", nil)
+			return
+		}
+		logger.Error("Failed to fetch todo history", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", page)
+	logger.Info("Successfully fetched todo history", "count", len(page.Items))
+}
+
+// Bulk applies several todo operations in one request
+// @Summary Bulk operations
+// @Description Applies create, update, delete, complete, retag and move operations in order, in a single transaction.
+// @Description In atomic mode (the default) either every operation is applied or none is; in best_effort mode failed operations are skipped.
+// @Description Every operation gets its own status. The returned undo token reverts all applied operations at once.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header int false "Workspace for created todos"
+// @Param request body swagger.BulkRequest true "Operations to apply"
+// @Security BearerAuth
+// @Success 200 {object} swagger.BulkResponse "Successfully apply"
+// @Success 207 {object} swagger.BulkResponse "Partially apply"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 422 {object} swagger.BulkFailedResponse "Bulk operation failed"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/bulk [post]
+func (h *Handler) Bulk(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "Bulk")
+	logger.Debug("Attempting to apply bulk operations")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var request entity.BulkRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+	if request.Mode == "" {
+		request.Mode = entity.BulkAtomic
+	}
+	workspaceID := contextutils.GetWorkspaceID(r.Context())
+	for _, operation := range request.Operations {
+		if operation.Op == entity.BulkCreate && operation.Todo != nil {
+			operation.Todo.WorkspaceID = workspaceID
+		}
+	}
 
-	if limitStr := query.Get("limit"); limitStr != "" {
-		limitInt, err := strconv.Atoi(limitStr)
+	logger = logger.With("mode", request.Mode, "operations", len(request.Operations))
+	outcomes, undo, err := h.service.Bulk(r.Context(), userID, request)
+	if err != nil {
+		logger.Error("Failed to apply bulk operations", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	response := bulkResponse{Results: make([]bulkResult, len(outcomes)), Undo: undo}
+	failed := 0
+	for i, outcome := range outcomes {
+		status, message := bulkStatus(outcome)
+		if outcome.Err != nil {
+			failed++
+			if status == http.StatusInternalServerError {
+				logger.Error("Failed to apply bulk operation", "index", outcome.Index, "error", outcome.Err)
+			}
+		}
+		response.Results[i] = bulkResult{
+			Index:  outcome.Index,
+			Op:     outcome.Op,
+			ID:     outcome.ID,
+			Status: status,
+			Error:  message,
+		}
+	}
+
+	switch {
+	case failed == 0:
+		entity.SendResponse(w, http.StatusOK, false, "Successfully apply", response)
+		logger.Info("Successfully applied bulk operations")
+	case request.Mode == entity.BulkAtomic:
+		entity.SendResponse(w, http.StatusUnprocessableEntity, true, "Bulk operation failed", response)
+		logger.Warn("Bulk operations rolled back")
+	default:
+		entity.SendResponse(w, http.StatusMultiStatus, false, "Partially apply", response)
+		logger.Info("Partially applied bulk operations", "failed", failed)
+	}
+}
+
+// bulkStatus returns the HTTP status and error message describing one outcome.
+func bulkStatus(outcome entity.BulkOutcome) (int, string) {
+	var invalid *entity.InvalidOperationError
+	switch {
+	case outcome.Err == nil && outcome.Op == entity.BulkCreate:
+		return http.StatusCreated, ""
+	case outcome.Err == nil:
+		return http.StatusOK, ""
+	case errors.As(outcome.Err, &invalid):
+		return http.StatusBadRequest, fmt.Sprintf("Validation error: %s", invalid.Error())
+	case errors.Is(outcome.Err, entity.ErrBulkAborted):
+		return http.StatusFailedDependency, "Not applied because another operation failed"
+	case errors.Is(outcome.Err, entity.ErrTodoNotFound):
+		return http.StatusNotFound, "Todo not found"
+	case errors.Is(outcome.Err, entity.ErrProjectNotFound):
+		return http.StatusNotFound, "Project not found"
+	case errors.Is(outcome.Err, entity.ErrWorkspaceNotFound):
+		return http.StatusNotFound, "Workspace not found"
+	case errors.Is(outcome.Err, entity.ErrWorkspaceMismatch):
+		return http.StatusBadRequest, "Project belongs to a different workspace"
+	case errors.Is(outcome.Err, entity.ErrParentMismatch):
+		return http.StatusBadRequest, "Parent todo belongs to a different workspace"
+	case errors.Is(outcome.Err, entity.ErrForbidden):
+		return http.StatusForbidden, "Permission denied"
+	default:
+		return http.StatusInternalServerError, entity.ServerFailureMessage
+	}
+}
+
+// QuickAdd creates a todo from a line of free text
+// @Summary Quick-add a todo
+// @Description Reads a todo from free text such as "Pay rent every month on the 1st #finance !high" and creates it. #tag adds a tag and !high, !medium or !low a priority:<level> tag.
+// @Description Due dates such as today, tomorrow, friday, next friday, in 3 days, next week, on the 1st, april 30 or 2025-04-30 are relative to the user's time zone; without one the todo is due today.
+// @Description Recurrences such as every day, every 2 weeks, every other month, every monday or monthly may end with until and a date. The remaining words are the title and description.
+// @Description With preview=true the parsed todo is only returned, not saved.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param request body swagger.QuickAddRequest true "Text to parse"
+// @Param preview query bool false "Only parse the text"
+// @Param X-Workspace-ID header int false "Create the todo in this workspace"
+// @Security BearerAuth
+// @Success 200 {object} swagger.QuickAddResponse "Successfully parse"
+// @Success 201 {object} swagger.QuickAddResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or the parsed todo does not validate"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "No write access to the workspace"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/quick [post]
+func (h *Handler) QuickAdd(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "QuickAdd")
+	logger.Debug("Attempting to quick-add todo")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var request entity.QuickAddRequest
+	if previewStr := r.URL.Query().Get("preview"); previewStr != "" {
+		preview, err := strconv.ParseBool(previewStr)
 		if err != nil {
-			logger.Warn("Invalid limit parameter", "limit", limitStr)
-			entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid limit parameter", nil)
+			logger.Warn("Invalid preview parameter", "preview", previewStr)
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid preview parameter", nil)
 			return
 		}
-		limit = limitInt
+		request.Preview = preview
+	}
+
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+	request.WorkspaceID = contextutils.GetWorkspaceID(r.Context())
+
+	result, err := h.service.QuickAdd(r.Context(), userID, request)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidTodo):
+			msg := "Validation error: " + strings.TrimPrefix(err.Error(), entity.ErrInvalidTodo.Error()+": ")
+			logger.Warn(msg)
+			entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		case errors.Is(err, entity.ErrWorkspaceNotFound):
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+		case errors.Is(err, entity.ErrForbidden):
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+		default:
+			logger.Error("Failed to quick-add todo", "error", err)
+			entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		}
+		return
+	}
+
+	if result.Preview {
+		entity.SendResponse(w, http.StatusOK, false, "Successfully parse", result)
+		logger.Info("Successfully parsed todo")
+		return
+	}
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", result)
+	logger.Info("Successfully quick-added todo", "id", result.ID)
+}
+
+// maxImportBytes is the largest import file accepted.
+const maxImportBytes = 5 << 20
+
+// importFormats picks the format of an import from its content type when the
+// format parameter is missing.
+var importFormats = map[string]entity.ImportFormat{
+	"text/csv":         entity.ImportCSV,
+	"application/json": entity.ImportJSON,
+	"text/plain":       entity.ImportTodoTxt,
+	"text/markdown":    entity.ImportMarkdown,
+}
+
+// Import creates todos from a file exported by another tool
+// @Summary Import todos
+// @Description Creates todos from CSV, JSON as returned by GET /todos, todo.txt or a Markdown checklist sent as the request body.
+// @Description CSV needs a header row; columns are matched to the fields title, description, tags, due_date, completed and project by name unless mapped with the columns parameter.
+// @Description todo.txt lines keep +project, @contexts as tags, (A) priorities as priority:A tags and due:YYYY-MM-DD. Indented Markdown items become subtasks.
+// @Description Todos without a due date are due today. Every line is validated first; if any line has errors nothing is imported and the errors are returned per line.
+// @Description The returned undo token removes every imported todo at once.
+// @Tags todo
+// @Accept plain
+// @Produce json
+// @Param format query string false "File format, taken from the Content-Type when omitted" Enums(csv, json, todotxt, markdown)
+// @Param columns query string false "CSV column mapping as field:column pairs" example(title:Task,due_date:Due date)
+// @Param dry_run query bool false "Only validate the file"
+// @Param X-Workspace-ID header int false "Import into this workspace"
+// @Param file body string true "File to import"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ImportResponse "Successfully validate"
+// @Success 201 {object} swagger.ImportResponse "Successfully import"
+// @Failure 400 {object} swagger.ErrorResponse "Unreadable file or invalid parameters"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "No write access to the workspace"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 413 {object} swagger.ErrorResponse "File too large"
+// @Failure 422 {object} swagger.ImportFailedResponse "Import failed"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /todos/import [post]
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "todo_handler", "Import")
+	logger.Debug("Attempting to import todos")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
 	}
-	if offsetStr := query.Get("offset"); offsetStr != "" {
-		offsetInt, err := strconv.Atoi(offsetStr)
+
+	query := r.URL.Query()
+	format := entity.ImportFormat(query.Get("format"))
+	if format == "" {
+		mediaType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";")
+		format = importFormats[strings.TrimSpace(mediaType)]
+	}
+	if format == "" {
+		logger.Warn("Import format missing")
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid format parameter", nil)
+		return
+	}
+
+	var dryRun bool
+	if dryRunStr := query.Get("dry_run"); dryRunStr != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunStr)
 		if err != nil {
-			logger.Warn("Invalid limit parameter", "limit", offsetStr)
-			entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid offset parameter", nil)
+			logger.Warn("Invalid dry_run parameter", "dry_run", dryRunStr)
+			entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid dry_run parameter", nil)
+			return
+		}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			logger.Warn("Import file too large")
+			entity.SendResponse[any](w, http.StatusRequestEntityTooLarge, true, "File too large", nil)
 			return
 		}
-		offset = offsetInt
+		logger.Warn("Failed to read body", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Failed to read body", nil)
+		return
 	}
-	pagination := entity.Pagination{Offset: offset, Limit: limit}
 
+	mapping, err := todoio.ParseMapping(query.Get("columns"))
+	if err != nil {
+		sendImportError(w, logger, err)
+		return
+	}
+	items, lineErrors, err := todoio.Parse(format, data, mapping)
+	if err != nil {
+		sendImportError(w, logger, err)
+		return
+	}
+
+	logger = logger.With("format", format, "dry_run", dryRun)
+	result, err := h.service.Import(r.Context(), userID, entity.ImportRequest{
+		Items:       items,
+		Errors:      lineErrors,
+		WorkspaceID: contextutils.GetWorkspaceID(r.Context()),
+		DryRun:      dryRun,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrWorkspaceNotFound) {
+			logger.Warn("Workspace not found")
+			entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+			return
+		}
+		logger.Error("Failed to import todos", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	switch {
+	case result.DryRun:
+		entity.SendResponse(w, http.StatusOK, false, "Successfully validate", result)
+		logger.Info("Successfully validated import", "total", result.Total, "errors", len(result.Errors))
+	case len(result.Errors) > 0:
+		entity.SendResponse(w, http.StatusUnprocessableEntity, true, "Import failed", result)
+		logger.Warn("Import rejected", "errors", len(result.Errors))
+	default:
+		entity.SendResponse(w, http.StatusCreated, false, "Successfully import", result)
+		logger.Info("Successfully imported todos", "count", result.Imported)
+	}
+}
+
+// sendImportError reports an import file that cannot be read at all.
+func sendImportError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	msg := "Invalid import: " + strings.TrimPrefix(err.Error(), entity.ErrInvalidImport.Error()+": ")
+	logger.Warn(msg)
+	entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+}
+
+// parseFilters reads the todo list filters shared by GetAll and GetAgenda from the
+// query and the workspace header. The error message is meant for the client.
+func parseFilters(r *http.Request, userID uuid.UUID) (entity.Filters, error) {
+	query := r.URL.Query()
 	var filters entity.Filters
 	if dueDateStr := query.Get("due_date"); dueDateStr != "" {
 		dueDate, err := time.Parse(time.DateOnly, dueDateStr)
 		if err != nil {
-			logger.Warn("Invalid due_date parameter", "due_date", dueDateStr)
-			entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid due_date format. Use YYYY-MM-DD", nil)
-			return
+			return filters, errors.New("Invalid due_date format. Use YYYY-MM-DD")
 		}
 		date := entity.Date{Time: dueDate}
 		filters.DueTime = &date
@@ -311,13 +1256,33 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	todos, total, err := h.service.GetAll(r.Context(), userID, pagination, filters)
-	if err != nil {
-		logger.Error("Failed to fetch todos", "error", err)
-		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
-		return
+	if projectIDStr := query.Get("project_id"); projectIDStr != "" {
+		projectID, err := strconv.Atoi(projectIDStr)
+		if err != nil {
+			return filters, errors.New("Invalid project_id parameter")
+		}
+		filters.ProjectID = &projectID
 	}
 
-	entity.SendListResponse(w, http.StatusOK, false, "Successfully fetch", pagination, total, todos)
-	logger.Info("Successfully fetched todos")
+	if parentIDStr := query.Get("parent_id"); parentIDStr != "" {
+		parentID, err := strconv.Atoi(parentIDStr)
+		if err != nil {
+			return filters, errors.New("Invalid parent_id parameter")
+		}
+		filters.ParentID = &parentID
+	}
+
+	if assigneeStr := query.Get("assignee"); assigneeStr != "" {
+		assigneeID := userID
+		if assigneeStr != "me" {
+			parsed, err := uuid.Parse(assigneeStr)
+			if err != nil {
+				return filters, errors.New("Invalid assignee parameter. Use a user ID or 'me'")
+			}
+			assigneeID = parsed
+		}
+		filters.AssigneeID = &assigneeID
+	}
+	filters.WorkspaceID = contextutils.GetWorkspaceID(r.Context())
+	return filters, nil
 }
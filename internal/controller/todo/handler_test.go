@@ -21,6 +21,16 @@ import (
 	"time"
 )
 
+var testUndo = entity.Undo{
+	Token:     uuid.MustParse("6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"),
+	ExpiresAt: time.Date(2025, 4, 1, 12, 0, 30, 0, time.UTC),
+}
+
+const (
+	testUndoFields = `"undo_token":"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10","undo_expires_at":"2025-04-01T12:00:30Z"`
+	testUndoJSON   = `{` + testUndoFields + `}`
+)
+
 func TestGet(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 	userID := uuid.New()
@@ -53,14 +63,14 @@ func TestGet(t *testing.T) {
 					}, nil)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
 				mux.Use(middleware.AuthMiddleware(tokenServiceMock))
 			},
 			expectedHTTPStatus: http.StatusOK,
-			expectedResponse:   `{"code":200,"error":false,"message":"Successfully fetch","data":{"id":12,"title":"test_todo","description":"test_description","due_date":"2025-04-01","tags":["test"]}}`,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully fetch","data":{"id":12,"title":"test_todo","description":"test_description","due_date":"2025-04-01","tags":["test"],"completed":false}}`,
 		},
 		{
 			name:               "id not found in context",
@@ -73,7 +83,7 @@ func TestGet(t *testing.T) {
 			inputID:    "12f",
 			inputToken: "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -94,7 +104,7 @@ func TestGet(t *testing.T) {
 					Return(entity.Todo{}, entity.ErrTodoNotFound)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -115,7 +125,7 @@ func TestGet(t *testing.T) {
 					Return(entity.Todo{}, errors.New("internal user server error"))
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -185,24 +195,24 @@ func TestDelete(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, 12).
-					Return(nil)
+					Return(testUndo, nil)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
 				mux.Use(middleware.AuthMiddleware(tokenServiceMock))
 			},
 			expectedHTTPStatus: http.StatusOK,
-			expectedResponse:   `{"code":200,"error":false,"message":"Successfully delete"}`,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully delete","data":` + testUndoJSON + `}`,
 		},
 		{
 			name:       "invalid id",
 			inputID:    "invalid",
 			inputToken: "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -220,10 +230,10 @@ func TestDelete(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, 12).
-					Return(entity.ErrTodoNotFound)
+					Return(entity.Undo{}, entity.ErrTodoNotFound)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -241,10 +251,10 @@ func TestDelete(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, 12).
-					Return(errors.New("unexpected error"))
+					Return(entity.Undo{}, errors.New("unexpected error"))
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -319,17 +329,17 @@ func TestCreate(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, mock.AnythingOfType("entity.Todo")).
-					Return(12, nil)
+					Return(12, testUndo, nil)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
 				mux.Use(middleware.AuthMiddleware(tokenServiceMock))
 			},
 			expectedHTTPStatus: http.StatusCreated,
-			expectedResponse:   `{"code":201,"error":false,"message":"Successfully create","data":{"id":12}}`,
+			expectedResponse:   `{"code":201,"error":false,"message":"Successfully create","data":{"id":12,` + testUndoFields + `}}`,
 		},
 		{
 			name:               "user not authenticated",
@@ -342,7 +352,7 @@ func TestCreate(t *testing.T) {
 			inputRequest: `{"description":"test","due_date":"2025-04-01","tags":["api","test"]}`,
 			inputToken:   "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -360,10 +370,10 @@ func TestCreate(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, mock.AnythingOfType("entity.Todo")).
-					Return(0, errors.New("database error"))
+					Return(0, entity.Undo{}, errors.New("database error"))
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -377,7 +387,7 @@ func TestCreate(t *testing.T) {
 			inputRequest: `{"title":"test","description":"test","due_date":"2025-04-01","tags":"not_an_array"}`, // Некорректный тип для tags
 			inputToken:   "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -447,17 +457,17 @@ func TestUpdate(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, mock.AnythingOfType("entity.Todo")).
-					Return(nil)
+					Return(testUndo, nil)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
 				mux.Use(middleware.AuthMiddleware(tokenServiceMock))
 			},
 			expectedHTTPStatus: http.StatusOK,
-			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update"}`,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update","data":` + testUndoJSON + `}`,
 		},
 		{
 			name:               "missing authorization",
@@ -470,7 +480,7 @@ func TestUpdate(t *testing.T) {
 			inputRequest: `{"id":12,"description":"updated desc","due_date":"2025-04-02","tags":["updated","test"]}`,
 			inputToken:   "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -488,10 +498,10 @@ func TestUpdate(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, mock.AnythingOfType("entity.Todo")).
-					Return(entity.ErrTodoNotFound)
+					Return(entity.Undo{}, entity.ErrTodoNotFound)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -509,10 +519,10 @@ func TestUpdate(t *testing.T) {
 					_, ok := ctx.Value("id").(uuid.UUID)
 					return ok
 				}), userID, mock.AnythingOfType("entity.Todo")).
-					Return(errors.New("internal server"))
+					Return(entity.Undo{}, errors.New("internal server"))
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -526,7 +536,7 @@ func TestUpdate(t *testing.T) {
 			inputRequest: `{"id":12,"title":"updated","description":"updated desc","due_date":"2025-04-02","tags":"not_an_array"}`,
 			inputToken:   "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -618,7 +628,7 @@ func TestGetAll(t *testing.T) {
 				)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -634,7 +644,8 @@ func TestGetAll(t *testing.T) {
                         "title": "Buy groceries",
                         "description": "Get milk, bread, and eggs",
                         "due_date": "2025-04-01",
-                        "tags": ["shopping", "urgent"]
+                        "tags": ["shopping", "urgent"],
+                        "completed": false
                     }
                 ],
                 "error": false,
@@ -644,6 +655,46 @@ func TestGetAll(t *testing.T) {
                 "total": 1
             }`,
 		},
+		{
+			name: "assigned to me",
+			queryParams: map[string]string{
+				"assignee": "me",
+			},
+			inputToken: "valid_token",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetAll",
+					mock.Anything,
+					userID,
+					entity.Pagination{Offset: 0, Limit: 20},
+					entity.Filters{AssigneeID: &userID},
+				).Return([]entity.Todo{}, 0, nil)
+			},
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
+					Return(userID, nil)
+			},
+			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
+				mux.Use(middleware.AuthMiddleware(tokenServiceMock))
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully fetch","offset":0,"limit":20,"count":0,"total":0,"data":[]}`,
+		},
+		{
+			name: "invalid assignee",
+			queryParams: map[string]string{
+				"assignee": "someone",
+			},
+			inputToken: "valid_token",
+			prepareTokenService: func(serviceMock *mocks.TokenService) {
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
+					Return(userID, nil)
+			},
+			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
+				mux.Use(middleware.AuthMiddleware(tokenServiceMock))
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid assignee parameter. Use a user ID or 'me'"}`,
+		},
 		{
 			name: "invalid query parameters - limit",
 			queryParams: map[string]string{
@@ -652,7 +703,7 @@ func TestGetAll(t *testing.T) {
 			},
 			inputToken: "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -683,7 +734,7 @@ func TestGetAll(t *testing.T) {
 			},
 			inputToken: "valid_token",
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -729,7 +780,7 @@ func TestGetAll(t *testing.T) {
 				)
 			},
 			prepareTokenService: func(serviceMock *mocks.TokenService) {
-				serviceMock.On("ValidateAccessToken", "valid_token").
+				serviceMock.On("ValidateAccessToken", mock.Anything, "valid_token").
 					Return(userID, nil)
 			},
 			setupMiddleware: func(mux *chi.Mux, tokenServiceMock *mocks.TokenService) {
@@ -787,3 +838,987 @@ func TestGetAll(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSharedWithMe(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		queryParams        string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name: "successful get shared todos",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetSharedWithMe", mock.Anything, userID, entity.Pagination{Offset: 0, Limit: 20}).
+					Return([]entity.SharedTodo{
+						{
+							Todo: entity.Todo{
+								ID:          7,
+								Title:       "Plan trip",
+								Description: "Book flights",
+								Tags:        []string{"travel"},
+								DueDate:     &entity.Date{Time: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+							},
+							Owner:      "jane_doe",
+							Permission: entity.PermissionEditor,
+						},
+					}, 1, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","offset":0,"limit":20,"count":1,"total":1,
+				"data":[{"id":7,"title":"Plan trip","description":"Book flights","tags":["travel"],"due_date":"2025-05-01","completed":false,"owner":"jane_doe","permission":"editor"}]}`,
+		},
+		{
+			name:               "invalid limit",
+			queryParams:        "?limit=ten",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid limit parameter"}`,
+		},
+		{
+			name: "internal server error",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetSharedWithMe", mock.Anything, userID, entity.Pagination{Offset: 0, Limit: 20}).
+					Return(nil, 0, errors.New("unexpected error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			req, err := http.NewRequest("GET", "/todos/shared-with-me"+tc.queryParams, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			handler.GetSharedWithMe(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	assigneeID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+
+	testCases := []struct {
+		name               string
+		inputID            string
+		inputRequest       string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful assign",
+			inputID:      "12",
+			inputRequest: `{"assignee_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2"}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Assign", mock.Anything, userID, 12, &assigneeID).Return(testUndo, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update","data":` + testUndoJSON + `}`,
+		},
+		{
+			name:         "successful unassign",
+			inputID:      "12",
+			inputRequest: `{"assignee_id":null}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Assign", mock.Anything, userID, 12, (*uuid.UUID)(nil)).Return(testUndo, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update","data":` + testUndoJSON + `}`,
+		},
+		{
+			name:               "invalid assignee id",
+			inputID:            "12",
+			inputRequest:       `{"assignee_id":"jane"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid json format"}`,
+		},
+		{
+			name:         "assignee cannot see the todo",
+			inputID:      "12",
+			inputRequest: `{"assignee_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2"}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Assign", mock.Anything, userID, 12, &assigneeID).Return(entity.Undo{}, entity.ErrInvalidAssignee)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Assignee must be able to see the todo"}`,
+		},
+		{
+			name:         "caller is only a viewer",
+			inputID:      "12",
+			inputRequest: `{"assignee_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2"}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Assign", mock.Anything, userID, 12, &assigneeID).Return(entity.Undo{}, entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("PUT", "/todos/"+tc.inputID+"/assignee", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.MustParse("818bdf4c-0b94-4dcb-96be-12a31f073ac2")
+
+	testCases := []struct {
+		name               string
+		inputID            string
+		queryParams        string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:        "successful get",
+			inputID:     "12",
+			queryParams: "?limit=1",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetHistory", mock.Anything, userID, 12, entity.Cursor{Limit: 1}).
+					Return(entity.CursorPage[entity.TodoHistory]{
+						Items: []entity.TodoHistory{
+							{
+								ID:        42,
+								TodoID:    12,
+								ActorID:   &userID,
+								Actor:     "john_doe",
+								Operation: entity.HistoryUpdated,
+								Changes: []entity.FieldChange{
+									{Field: "due_date", Before: "2025-04-01", After: "2025-04-08"},
+								},
+								CreatedAt: time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+							},
+						},
+						NextCursor: "NDI",
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":{"items":[{"id":42,"todo_id":12,
+				"actor_id":"818bdf4c-0b94-4dcb-96be-12a31f073ac2","actor":"john_doe","operation":"updated",
+				"changes":[{"field":"due_date","before":"2025-04-01","after":"2025-04-08"}],
+				"created_at":"2025-04-01T12:00:00Z"}],"next_cursor":"NDI"}}`,
+		},
+		{
+			name:               "invalid id",
+			inputID:            "abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+		{
+			name:    "todo not visible",
+			inputID: "12",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetHistory", mock.Anything, userID, 12, entity.Cursor{Limit: 20}).
+					Return(entity.CursorPage[entity.TodoHistory]{}, entity.ErrTodoNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Todo not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("GET", "/todos/"+tc.inputID+"/history"+tc.queryParams, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		inputID            string
+		inputRequest       string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful complete",
+			inputID:      "12",
+			inputRequest: `{"completed":true}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Complete", mock.Anything, userID, 12, true).Return(testUndo, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update","data":` + testUndoJSON + `}`,
+		},
+		{
+			name:         "successful reopen",
+			inputID:      "12",
+			inputRequest: `{"completed":false}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Complete", mock.Anything, userID, 12, false).Return(testUndo, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update","data":` + testUndoJSON + `}`,
+		},
+		{
+			name:               "invalid id",
+			inputID:            "abc",
+			inputRequest:       `{"completed":true}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+		{
+			name:         "todo not found",
+			inputID:      "12",
+			inputRequest: `{"completed":true}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Complete", mock.Anything, userID, 12, true).Return(entity.Undo{}, entity.ErrTodoNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Todo not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("PUT", "/todos/"+tc.inputID+"/completion", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestBulk(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	completed := true
+
+	testCases := []struct {
+		name               string
+		inputRequest       string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful atomic",
+			inputRequest: `{"operations":[{"op":"complete","id":12,"completed":true},{"op":"delete","id":13}]}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				request := entity.BulkRequest{
+					Mode: entity.BulkAtomic,
+					Operations: []entity.BulkOperation{
+						{Op: entity.BulkComplete, ID: 12, Completed: &completed},
+						{Op: entity.BulkDelete, ID: 13},
+					},
+				}
+				serviceMock.On("Bulk", mock.Anything, userID, request).Return([]entity.BulkOutcome{
+					{Index: 0, Op: entity.BulkComplete, ID: 12},
+					{Index: 1, Op: entity.BulkDelete, ID: 13},
+				}, &testUndo, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully apply","data":{"results":[` +
+				`{"index":0,"op":"complete","id":12,"status":200},{"index":1,"op":"delete","id":13,"status":200}],` +
+				testUndoFields + `}}`,
+		},
+		{
+			name:         "atomic failure",
+			inputRequest: `{"mode":"atomic","operations":[{"op":"retag","id":12,"add_tags":["urgent"]},{"op":"move","id":13}]}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Bulk", mock.Anything, userID, mock.Anything).Return([]entity.BulkOutcome{
+					{Index: 0, Op: entity.BulkRetag, ID: 12, Err: entity.ErrBulkAborted},
+					{Index: 1, Op: entity.BulkMove, ID: 13, Err: entity.ErrForbidden},
+				}, (*entity.Undo)(nil), nil)
+			},
+			expectedHTTPStatus: http.StatusUnprocessableEntity,
+			expectedResponse: `{"code":422,"error":true,"message":"Bulk operation failed","data":{"results":[` +
+				`{"index":0,"op":"retag","id":12,"status":424,"error":"Not applied because another operation failed"},` +
+				`{"index":1,"op":"move","id":13,"status":403,"error":"Permission denied"}]}}`,
+		},
+		{
+			name: "partial best effort",
+			inputRequest: `{"mode":"best_effort","operations":[{"op":"create","todo":{"title":"Buy milk",` +
+				`"description":"2%","tags":[],"due_date":"2025-04-01"}},{"op":"delete","id":13},{"op":"complete","id":14}]}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Bulk", mock.Anything, userID, mock.Anything).Return([]entity.BulkOutcome{
+					{Index: 0, Op: entity.BulkCreate, ID: 20},
+					{Index: 1, Op: entity.BulkDelete, ID: 13, Err: entity.ErrTodoNotFound},
+					{Index: 2, Op: entity.BulkComplete, ID: 14,
+						Err: &entity.InvalidOperationError{Messages: []string{"Field 'completed' is required"}}},
+				}, &testUndo, nil)
+			},
+			expectedHTTPStatus: http.StatusMultiStatus,
+			expectedResponse: `{"code":207,"error":false,"message":"Partially apply","data":{"results":[` +
+				`{"index":0,"op":"create","id":20,"status":201},` +
+				`{"index":1,"op":"delete","id":13,"status":404,"error":"Todo not found"},` +
+				`{"index":2,"op":"complete","id":14,"status":400,"error":"Validation error: Field 'completed' is required"}],` +
+				testUndoFields + `}}`,
+		},
+		{
+			name:               "invalid mode",
+			inputRequest:       `{"mode":"sometimes","operations":[{"op":"delete","id":13}]}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'mode' must be one of: atomic best_effort"}`,
+		},
+		{
+			name:               "no operations",
+			inputRequest:       `{"operations":[]}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'operations' must be at least 1"}`,
+		},
+		{
+			name:         "service error",
+			inputRequest: `{"operations":[{"op":"delete","id":13}]}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Bulk", mock.Anything, userID, mock.Anything).
+					Return(([]entity.BulkOutcome)(nil), (*entity.Undo)(nil), errors.New("connection refused"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"` + entity.ServerFailureMessage + `"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/todos/bulk", bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	markdown := "- [ ] Pack\n  - [x] Books\n"
+	parent := 0
+	items := []entity.ImportItem{
+		{Line: 1, Todo: entity.Todo{Title: "Pack", Description: "Pack", Tags: []string{}}},
+		{Line: 2, Parent: &parent, Todo: entity.Todo{Title: "Books", Description: "Books", Tags: []string{}, Completed: true}},
+	}
+
+	testCases := []struct {
+		name               string
+		query              string
+		contentType        string
+		inputRequest       string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful import",
+			contentType:  "text/markdown; charset=utf-8",
+			inputRequest: markdown,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Import", mock.Anything, userID, entity.ImportRequest{Items: items}).
+					Return(entity.ImportResult{Total: 2, Imported: 2, IDs: []int{41, 42}, Undo: &testUndo}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully import","data":{"dry_run":false,"total":2,` +
+				`"imported":2,"ids":[41,42],` + testUndoFields + `}}`,
+		},
+		{
+			name:         "dry run",
+			query:        "?format=markdown&dry_run=true",
+			inputRequest: markdown,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Import", mock.Anything, userID, entity.ImportRequest{Items: items, DryRun: true}).
+					Return(entity.ImportResult{DryRun: true, Total: 2}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully validate","data":{"dry_run":true,"total":2,"imported":0}}`,
+		},
+		{
+			name:         "lines with errors",
+			query:        "?format=csv&columns=title:Task",
+			inputRequest: "Task,due_date\nGo,2025-04-30\nPay rent,soon\n",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Import", mock.Anything, userID, mock.Anything).Return(entity.ImportResult{
+					Total: 2,
+					Errors: []entity.ImportLineError{
+						{Line: 2, Errors: []string{"Field 'title' must be at least 3 characters"}},
+						{Line: 3, Errors: []string{"Field 'due_date' must be a date such as 2025-04-30"}},
+					},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusUnprocessableEntity,
+			expectedResponse: `{"code":422,"error":true,"message":"Import failed","data":{"dry_run":false,"total":2,"imported":0,"errors":[` +
+				`{"line":2,"errors":["Field 'title' must be at least 3 characters"]},` +
+				`{"line":3,"errors":["Field 'due_date' must be a date such as 2025-04-30"]}]}}`,
+		},
+		{
+			name:               "missing format",
+			inputRequest:       markdown,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid format parameter"}`,
+		},
+		{
+			name:               "unreadable file",
+			query:              "?format=csv",
+			inputRequest:       "name\nPack\n",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid import: CSV has no title column"}`,
+		},
+		{
+			name:         "service error",
+			query:        "?format=todotxt",
+			inputRequest: "Call mom\n",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Import", mock.Anything, userID, mock.Anything).
+					Return(entity.ImportResult{}, errors.New("connection refused"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"` + entity.ServerFailureMessage + `"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/todos/import"+tc.query, strings.NewReader(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestQuickAdd(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	text := "Pay rent every month on the 1st #finance !high"
+	parsed := entity.Todo{
+		Title:       "Pay rent",
+		Description: "Pay rent",
+		Tags:        []string{"finance", "priority:high"},
+		DueDate:     &entity.Date{Time: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+		Recurrence:  &entity.Recurrence{Frequency: entity.RecurMonthly},
+	}
+	created := parsed
+	created.ID = 42
+	todoJSON := `"title":"Pay rent","description":"Pay rent","tags":["finance","priority:high"],"due_date":"2025-05-01",` +
+		`"recurrence":{"frequency":"monthly"},"completed":false`
+
+	testCases := []struct {
+		name               string
+		query              string
+		inputRequest       string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:         "successful quick-add",
+			inputRequest: `{"text":"` + text + `"}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("QuickAdd", mock.Anything, userID, entity.QuickAddRequest{Text: text}).
+					Return(entity.QuickAddResult{Todo: created, ID: 42, Undo: &testUndo}, nil)
+			},
+			expectedHTTPStatus: http.StatusCreated,
+			expectedResponse: `{"code":201,"error":false,"message":"Successfully create","data":{"preview":false,` +
+				`"todo":{"id":42,` + todoJSON + `},"id":42,` + testUndoFields + `}}`,
+		},
+		{
+			name:         "preview",
+			query:        "?preview=true",
+			inputRequest: `{"text":"` + text + `"}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("QuickAdd", mock.Anything, userID, entity.QuickAddRequest{Text: text, Preview: true}).
+					Return(entity.QuickAddResult{Preview: true, Todo: parsed}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully parse","data":{"preview":true,` +
+				`"todo":{"id":0,` + todoJSON + `}}}`,
+		},
+		{
+			name:               "invalid preview parameter",
+			query:              "?preview=maybe",
+			inputRequest:       `{"text":"` + text + `"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid preview parameter"}`,
+		},
+		{
+			name:               "missing text",
+			inputRequest:       `{}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'text' is required"}`,
+		},
+		{
+			name:         "nothing left for the title",
+			inputRequest: `{"text":"#errands tomorrow"}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("QuickAdd", mock.Anything, userID, entity.QuickAddRequest{Text: "#errands tomorrow"}).
+					Return(entity.QuickAddResult{}, fmt.Errorf("%w: Field 'title' is required;Field 'description' is required",
+						entity.ErrInvalidTodo))
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse: `{"code":400,"error":true,"message":"Validation error: Field 'title' is required;` +
+				`Field 'description' is required"}`,
+		},
+		{
+			name:         "service error",
+			inputRequest: `{"text":"` + text + `"}`,
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("QuickAdd", mock.Anything, userID, mock.Anything).
+					Return(entity.QuickAddResult{}, errors.New("connection refused"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"` + entity.ServerFailureMessage + `"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/todos", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/todos/quick"+tc.query, strings.NewReader(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestGetAgenda(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	day := func(d int) *entity.Date {
+		return &entity.Date{Time: time.Date(2025, 4, d, 0, 0, 0, 0, time.UTC)}
+	}
+	projectID := 3
+
+	testCases := []struct {
+		name               string
+		queryParams        string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:        "successful get agenda",
+			queryParams: "?from=2025-04-01&to=2025-04-02&project_id=3",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetAgenda", mock.Anything, userID, entity.AgendaRequest{
+					From:    day(1),
+					To:      day(2),
+					Filters: entity.Filters{ProjectID: &projectID},
+				}).Return(entity.Agenda{
+					From:     *day(1),
+					To:       *day(2),
+					Today:    *day(1),
+					Timezone: "Europe/Berlin",
+					Days: []entity.AgendaDay{
+						{
+							Date: *day(1),
+							Todos: []entity.AgendaItem{
+								{
+									Todo:       entity.Todo{ID: 7, Title: "Water plants", Tags: []string{}, DueDate: day(1)},
+									Occurrence: *day(1),
+								},
+							},
+						},
+						{Date: *day(2), Todos: []entity.AgendaItem{}},
+					},
+				}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse: `{"code":200,"error":false,"message":"Successfully fetch","data":{"from":"2025-04-01","to":"2025-04-02",
+				"today":"2025-04-01","timezone":"Europe/Berlin","days":[
+				{"date":"2025-04-01","todos":[{"id":7,"title":"Water plants","description":"","tags":[],"due_date":"2025-04-01","completed":false,"occurrence":"2025-04-01"}]},
+				{"date":"2025-04-02","todos":[]}]}}`,
+		},
+		{
+			name:               "invalid from",
+			queryParams:        "?from=01-04-2025",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid from format. Use YYYY-MM-DD"}`,
+		},
+		{
+			name:               "invalid filter",
+			queryParams:        "?project_id=abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid project_id parameter"}`,
+		},
+		{
+			name:        "range too long",
+			queryParams: "?from=2025-01-01&to=2025-12-31",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetAgenda", mock.Anything, userID, mock.Anything).
+					Return(entity.Agenda{}, &entity.ValidationError{Messages: []string{"Agenda must not cover more than 92 days"}})
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Agenda must not cover more than 92 days"}`,
+		},
+		{
+			name:        "project not found",
+			queryParams: "?project_id=3",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetAgenda", mock.Anything, userID, entity.AgendaRequest{Filters: entity.Filters{ProjectID: &projectID}}).
+					Return(entity.Agenda{}, entity.ErrProjectNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Project not found"}`,
+		},
+		{
+			name: "internal server error",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("GetAgenda", mock.Anything, userID, entity.AgendaRequest{}).
+					Return(entity.Agenda{}, errors.New("unexpected error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			req, err := http.NewRequest("GET", "/todos/agenda"+tc.queryParams, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			handler.GetAgenda(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		queryParams        string
+		prepareTodoService func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus int
+		expectedBody       []string
+	}{
+		{
+			name:        "successful export",
+			queryParams: "?tags=home",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Export", mock.Anything, userID, entity.Filters{Tags: []string{"home"}}).
+					Return([]entity.Todo{
+						{
+							ID:        7,
+							Title:     "Water plants",
+							Tags:      []string{"home"},
+							DueDate:   &entity.Date{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+							Completed: true,
+						},
+					}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody: []string{
+				"BEGIN:VTODO\r\n",
+				"SUMMARY:Water plants\r\n",
+				"STATUS:COMPLETED\r\n",
+			},
+		},
+		{
+			name:               "invalid filter",
+			queryParams:        "?parent_id=abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       []string{`"message":"Invalid parent_id parameter"`},
+		},
+		{
+			name: "workspace not found",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Export", mock.Anything, userID, entity.Filters{}).
+					Return(nil, entity.ErrWorkspaceNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedBody:       []string{`"message":"Workspace not found"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			req, err := http.NewRequest("GET", "/todos.ics"+tc.queryParams, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			handler.Export(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for _, part := range tc.expectedBody {
+				assert.Contains(t, rr.Body.String(), part)
+			}
+		})
+	}
+}
+
+func TestExportFile(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	todos := []entity.Todo{
+		{ID: 7, Title: "Water plants", Tags: []string{"home"}, DueDate: &entity.Date{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)}},
+		{ID: 8, Title: "Pay rent", Tags: []string{}, Completed: true},
+	}
+	stream := func(todos []entity.Todo) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			fn := args.Get(3).(func(entity.Todo) error)
+			for _, todo := range todos {
+				assert.NoError(t, fn(todo))
+			}
+		}
+	}
+
+	testCases := []struct {
+		name                string
+		queryParams         string
+		prepareTodoService  func(serviceMock *mocks.TodoService)
+		expectedHTTPStatus  int
+		expectedContentType string
+		expectedBody        []string
+	}{
+		{
+			name:        "json by default",
+			queryParams: "?tags=home",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Stream", mock.Anything, userID, entity.Filters{Tags: []string{"home"}}, mock.Anything).
+					Run(stream(todos[:1])).Return(nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        []string{"[\n{\"id\":7,", "\"title\":\"Water plants\"", "\n]\n"},
+		},
+		{
+			name:        "markdown",
+			queryParams: "?format=markdown",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Stream", mock.Anything, userID, entity.Filters{}, mock.Anything).
+					Run(stream(todos)).Return(nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "text/markdown; charset=utf-8",
+			expectedBody:        []string{"- [ ] Water plants\n- [x] Pay rent\n"},
+		},
+		{
+			name:        "empty csv",
+			queryParams: "?format=csv",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Stream", mock.Anything, userID, entity.Filters{}, mock.Anything).Return(nil)
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        []string{"id,title,description,tags,due_date,completed"},
+		},
+		{
+			name:               "invalid format",
+			queryParams:        "?format=xlsx",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       []string{`"message":"Invalid format parameter"`},
+		},
+		{
+			name:               "invalid filter",
+			queryParams:        "?parent_id=abc",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedBody:       []string{`"message":"Invalid parent_id parameter"`},
+		},
+		{
+			name: "project not found",
+			prepareTodoService: func(serviceMock *mocks.TodoService) {
+				serviceMock.On("Stream", mock.Anything, userID, entity.Filters{}, mock.Anything).
+					Return(entity.ErrProjectNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedBody:       []string{`"message":"Project not found"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todoServiceMock := mocks.NewTodoService(t)
+			if tc.prepareTodoService != nil {
+				tc.prepareTodoService(todoServiceMock)
+			}
+
+			handler := NewHandler(todoServiceMock, logger)
+
+			req, err := http.NewRequest("GET", "/todos/export"+tc.queryParams, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			handler.ExportFile(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			if tc.expectedContentType != "" {
+				assert.Equal(t, tc.expectedContentType, rr.Header().Get("Content-Type"))
+				assert.Regexp(t, `^attachment; filename="todos-\d{4}-\d{2}-\d{2}\.\w+"$`, rr.Header().Get("Content-Disposition"))
+			}
+			for _, part := range tc.expectedBody {
+				assert.Contains(t, rr.Body.String(), part)
+			}
+		})
+	}
+}
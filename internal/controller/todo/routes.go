@@ -5,9 +5,18 @@ import (
 )
 
 func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/shared-with-me", h.GetSharedWithMe)
+	r.Get("/agenda", h.GetAgenda)
+	r.Get("/export", h.ExportFile)
 	r.Get("/{id}", h.Get)
 	r.Get("/", h.GetAll)
 	r.Delete("/{id}", h.Delete)
 	r.Post("/", h.Create)
+	r.Post("/bulk", h.Bulk)
+	r.Post("/import", h.Import)
+	r.Post("/quick", h.QuickAdd)
 	r.Put("/", h.Update)
+	r.Put("/{id}/assignee", h.Assign)
+	r.Put("/{id}/completion", h.Complete)
+	r.Get("/{id}/history", h.GetHistory)
 }
@@ -0,0 +1,77 @@
+package undo
+
+import (
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	service service.UndoService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.UndoService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// Undo reverts a recent todo change
+// @Summary Undo a todo change
+// @Description Reverts the change an undo token was returned for. Each token works once, only for a short time after the change, and only if the todo was not changed again since.
+// @Tags undo
+// @Accept json
+// @Produce json
+// @Param token path string true "Undo token"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UndoResponse "Successfully undo"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid undo token"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 409 {object} swagger.ErrorResponse "Todo was changed since"
+// @Failure 410 {object} swagger.ErrorResponse "Undo token expired or already used"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /undo/{token} [post]
+func (h *Handler) Undo(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "undo_handler", "Undo")
+	logger.Debug("Attempting to undo todo change")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	token, err := uuid.Parse(chi.URLParam(r, "token"))
+	if err != nil {
+		logger.Warn("Invalid undo token")
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid undo token", nil)
+		return
+	}
+
+	if err := h.service.Undo(r.Context(), userID, token); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUndoNotFound):
+			logger.Warn("Undo token expired or already used")
+			entity.SendResponse[any](w, http.StatusGone, true, "Undo token expired or already used", nil)
+		case errors.Is(err, entity.ErrUndoConflict):
+			logger.Warn("Todo was changed since")
+			entity.SendResponse[any](w, http.StatusConflict, true, "Todo was changed since, it can no longer be undone", nil)
+		case errors.Is(err, entity.ErrForbidden):
+			logger.Warn("Permission denied")
+			entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+		default:
+			logger.Error("Failed to undo todo change", "error", err)
+			entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		}
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully undo", nil)
+	logger.Info("Successfully undid todo change")
+}
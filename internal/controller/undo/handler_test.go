@@ -0,0 +1,102 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	token := uuid.MustParse("6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10")
+
+	testCases := []struct {
+		name               string
+		inputToken         string
+		prepareUndoService func(serviceMock *mocks.UndoService)
+		expectedHTTPStatus int
+		expectedResponse   string
+	}{
+		{
+			name:       "successful undo",
+			inputToken: token.String(),
+			prepareUndoService: func(serviceMock *mocks.UndoService) {
+				serviceMock.On("Undo", mock.Anything, userID, token).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully undo"}`,
+		},
+		{
+			name:               "malformed token",
+			inputToken:         "not-a-token",
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid undo token"}`,
+		},
+		{
+			name:       "expired token",
+			inputToken: token.String(),
+			prepareUndoService: func(serviceMock *mocks.UndoService) {
+				serviceMock.On("Undo", mock.Anything, userID, token).Return(entity.ErrUndoNotFound)
+			},
+			expectedHTTPStatus: http.StatusGone,
+			expectedResponse:   `{"code":410,"error":true,"message":"Undo token expired or already used"}`,
+		},
+		{
+			name:       "todo changed since",
+			inputToken: token.String(),
+			prepareUndoService: func(serviceMock *mocks.UndoService) {
+				serviceMock.On("Undo", mock.Anything, userID, token).Return(entity.ErrUndoConflict)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   `{"code":409,"error":true,"message":"Todo was changed since, it can no longer be undone"}`,
+		},
+		{
+			name:       "internal server error",
+			inputToken: token.String(),
+			prepareUndoService: func(serviceMock *mocks.UndoService) {
+				serviceMock.On("Undo", mock.Anything, userID, token).Return(errors.New("database error"))
+			},
+			expectedHTTPStatus: http.StatusInternalServerError,
+			expectedResponse:   `{"code":500,"error":true,"message":"Something went wrong, please try again later"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			undoServiceMock := mocks.NewUndoService(t)
+			if tc.prepareUndoService != nil {
+				tc.prepareUndoService(undoServiceMock)
+			}
+
+			handler := NewHandler(undoServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/undo", func(r chi.Router) {
+				RegisterRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/undo/"+tc.inputToken, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
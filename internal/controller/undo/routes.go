@@ -0,0 +1,7 @@
+package undo
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Post("/{token}", h.Undo)
+}
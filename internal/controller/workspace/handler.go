@@ -0,0 +1,580 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type Handler struct {
+	service service.WorkspaceService
+	logger  *slog.Logger
+}
+
+func NewHandler(service service.WorkspaceService, logger *slog.Logger) *Handler {
+	return &Handler{service: service, logger: logger}
+}
+
+// GetAll lists the workspaces of the user
+// @Summary List workspaces
+// @Description Lists the workspaces the authenticated user is a member of, with their role in each.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListWorkspaceResponse "Successfully fetch"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "GetAll")
+	logger.Debug("Attempting to fetch workspaces")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	workspaces, err := h.service.GetAll(r.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to get workspaces", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", workspaces)
+	logger.Info("Successfully fetched workspaces", "count", len(workspaces))
+}
+
+// Create adds a new workspace
+// @Summary Create a workspace
+// @Description Creates a new workspace. The authenticated user becomes its first admin.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspace body swagger.WorkspaceRequest true "Workspace data"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateWorkspaceResponse "Workspace successfully created"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "Create")
+	logger.Debug("Attempting to create workspace")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	var workspace entity.Workspace
+	if err := utils.DecodeJSONStruct(r, &workspace); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := workspace.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	id, err := h.service.Create(r.Context(), userID, workspace)
+	if err != nil {
+		logger.Error("Failed to create workspace", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", map[string]int{
+		"id": id,
+	})
+	logger.Info("Successfully created workspace", "workspace_id", id)
+}
+
+// Get retrieves a workspace by ID
+// @Summary Get a workspace
+// @Description Retrieves a workspace the authenticated user is a member of.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.GetWorkspaceResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "Get")
+	logger.Debug("Attempting to fetch workspace")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id)
+	workspace, err := h.service.Get(r.Context(), userID, id)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", workspace)
+	logger.Info("Successfully fetched workspace")
+}
+
+// Delete removes a workspace
+// @Summary Delete a workspace
+// @Description Deletes a workspace together with all of its todos, projects, members and invitations. Admins only.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.DeleteResponse "Successfully delete"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "Delete")
+	logger.Debug("Attempting to delete workspace")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id)
+	if err := h.service.Delete(r.Context(), userID, id); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully delete", nil)
+	logger.Info("Successfully deleted workspace")
+}
+
+// GetMembers lists the members of a workspace
+// @Summary List workspace members
+// @Description Lists the members of a workspace and their roles.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListMemberResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID}/members [get]
+func (h *Handler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "GetMembers")
+	logger.Debug("Attempting to fetch workspace members")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id)
+	members, err := h.service.GetMembers(r.Context(), userID, id)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", members)
+	logger.Info("Successfully fetched workspace members", "count", len(members))
+}
+
+// UpdateMemberRole changes the role of a member
+// @Summary Change a member's role
+// @Description Changes the role of a workspace member. Admins only. The last admin cannot be demoted.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Param userID path string true "User ID"
+// @Param role body swagger.MemberRoleRequest true "New role"
+// @Security BearerAuth
+// @Success 200 {object} swagger.UpdateResponse "Successfully update"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data, validation error or last admin"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace or member not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID}/members/{userID} [put]
+func (h *Handler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "UpdateMemberRole")
+	logger.Debug("Attempting to update member role")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	memberStr := chi.URLParam(r, "userID")
+	memberID, err := uuid.Parse(memberStr)
+	if err != nil {
+		logger.Warn("Invalid user id", "user_id", memberStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid user ID", nil)
+		return
+	}
+
+	var request entity.MemberRoleRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id, "member_id", memberID)
+	if err := h.service.UpdateMemberRole(r.Context(), userID, id, memberID, request.Role); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully update", nil)
+	logger.Info("Successfully updated member role", "role", request.Role)
+}
+
+// RemoveMember removes a member from a workspace
+// @Summary Remove a member
+// @Description Removes a member from a workspace. Admins can remove anyone; every member can remove themselves to leave. The last admin cannot leave.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Param userID path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.RemoveResponse "Successfully remove"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid ID or last admin"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace or member not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID}/members/{userID} [delete]
+func (h *Handler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "RemoveMember")
+	logger.Debug("Attempting to remove member")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	memberStr := chi.URLParam(r, "userID")
+	memberID, err := uuid.Parse(memberStr)
+	if err != nil {
+		logger.Warn("Invalid user id", "user_id", memberStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid user ID", nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id, "member_id", memberID)
+	if err := h.service.RemoveMember(r.Context(), userID, id, memberID); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully remove", nil)
+	logger.Info("Successfully removed member")
+}
+
+// CreateInvitation creates an invitation link
+// @Summary Invite to a workspace
+// @Description Creates a single-use invitation token granting the given role. The token is only returned once. Admins only.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Param invitation body swagger.InvitationRequest true "Invitation data"
+// @Security BearerAuth
+// @Success 201 {object} swagger.CreateInvitationResponse "Successfully create"
+// @Failure 400 {object} swagger.ErrorResponse "Invalid request data or validation error"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID}/invitations [post]
+func (h *Handler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "CreateInvitation")
+	logger.Debug("Attempting to create invitation")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	var request entity.InvitationRequest
+	if err := utils.DecodeJSONStruct(r, &request); err != nil {
+		logger.Warn("Failed to decode json", "error", err)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, err.Error(), nil)
+		return
+	}
+
+	if validationErrors := request.Validate(); validationErrors != nil {
+		msg := fmt.Sprintf("Validation error: %s", strings.Join(validationErrors, ";"))
+		logger.Warn(msg)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, msg, nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id)
+	invitation, err := h.service.CreateInvitation(r.Context(), userID, id, request)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusCreated, false, "Successfully create", invitation)
+	logger.Info("Successfully created invitation", "invitation_id", invitation.ID)
+}
+
+// GetInvitations lists pending invitations
+// @Summary List invitations
+// @Description Lists the pending, unexpired invitations of a workspace. Tokens are not included. Admins only.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.ListInvitationResponse "Successfully fetch"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID}/invitations [get]
+func (h *Handler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "GetInvitations")
+	logger.Debug("Attempting to fetch invitations")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id)
+	invitations, err := h.service.GetInvitations(r.Context(), userID, id)
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully fetch", invitations)
+	logger.Info("Successfully fetched invitations", "count", len(invitations))
+}
+
+// RevokeInvitation deletes a pending invitation
+// @Summary Revoke an invitation
+// @Description Revokes a pending invitation so its token can no longer be accepted. Admins only.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspaceID path int true "Workspace ID"
+// @Param invitationID path int true "Invitation ID"
+// @Security BearerAuth
+// @Success 200 {object} swagger.RevokeResponse "Successfully revoke"
+// @Failure 400 {object} swagger.InvalidIDResponse "Invalid ID"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 403 {object} swagger.ForbiddenResponse "Permission denied"
+// @Failure 404 {object} swagger.NotFoundResponse "Workspace or invitation not found"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /workspaces/{workspaceID}/invitations/{invitationID} [delete]
+func (h *Handler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "RevokeInvitation")
+	logger.Debug("Attempting to revoke invitation")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	idStr := chi.URLParam(r, "workspaceID")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		logger.Warn("Invalid id", "workspace_id", idStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	invitationStr := chi.URLParam(r, "invitationID")
+	invitationID, err := strconv.Atoi(invitationStr)
+	if err != nil {
+		logger.Warn("Invalid invitation id", "invitation_id", invitationStr)
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid ID", nil)
+		return
+	}
+
+	logger = logger.With("workspace_id", id, "invitation_id", invitationID)
+	if err := h.service.RevokeInvitation(r.Context(), userID, id, invitationID); err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse[any](w, http.StatusOK, false, "Successfully revoke", nil)
+	logger.Info("Successfully revoked invitation")
+}
+
+// AcceptInvitation joins a workspace
+// @Summary Accept an invitation
+// @Description Joins the workspace an invitation token belongs to, with the role the invitation grants. Each token can be used once.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param token path string true "Invitation token"
+// @Security BearerAuth
+// @Success 200 {object} swagger.AcceptInvitationResponse "Successfully accept"
+// @Failure 401 {object} swagger.UnauthorizedResponse "User not authenticated or invalid token"
+// @Failure 404 {object} swagger.NotFoundResponse "Invitation not found or expired"
+// @Failure 409 {object} swagger.ErrorResponse "Already a member"
+// @Failure 500 {object} swagger.ServerErrorResponse "Internal server error"
+// @Router /invitations/{token}/accept [post]
+func (h *Handler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	logger := utils.SetupLogger(r.Context(), h.logger, "workspace_handler", "AcceptInvitation")
+	logger.Debug("Attempting to accept invitation")
+
+	userID, ok := r.Context().Value("id").(uuid.UUID)
+	if !ok {
+		logger.Error("Id not found in context")
+		entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+		return
+	}
+
+	workspace, err := h.service.AcceptInvitation(r.Context(), userID, chi.URLParam(r, "token"))
+	if err != nil {
+		h.sendError(w, logger, err)
+		return
+	}
+
+	entity.SendResponse(w, http.StatusOK, false, "Successfully accept", workspace)
+	logger.Info("Successfully accepted invitation", "workspace_id", workspace.ID)
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrWorkspaceNotFound):
+		logger.Warn("Workspace not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+	case errors.Is(err, entity.ErrMemberNotFound):
+		logger.Warn("Member not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Member not found", nil)
+	case errors.Is(err, entity.ErrInvitationNotFound):
+		logger.Warn("Invitation not found")
+		entity.SendResponse[any](w, http.StatusNotFound, true, "Invitation not found or expired", nil)
+	case errors.Is(err, entity.ErrAlreadyMember):
+		logger.Warn("User is already a member")
+		entity.SendResponse[any](w, http.StatusConflict, true, "Already a member of this workspace", nil)
+	case errors.Is(err, entity.ErrLastAdmin):
+		logger.Warn("Attempt to remove the last admin")
+		entity.SendResponse[any](w, http.StatusBadRequest, true, "Workspace must keep at least one admin", nil)
+	case errors.Is(err, entity.ErrForbidden):
+		logger.Warn("Permission denied")
+		entity.SendResponse[any](w, http.StatusForbidden, true, "Permission denied", nil)
+	default:
+		logger.Error("Failed to process workspace request", "error", err)
+		entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+	}
+}
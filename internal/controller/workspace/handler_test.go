@@ -0,0 +1,194 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUpdateMemberRole(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+	memberID := uuid.New()
+
+	testCases := []struct {
+		name                    string
+		inputWorkspaceID        string
+		inputMemberID           string
+		inputRequest            string
+		prepareWorkspaceService func(serviceMock *mocks.WorkspaceService)
+		expectedHTTPStatus      int
+		expectedResponse        string
+	}{
+		{
+			name:             "successful update",
+			inputWorkspaceID: "5",
+			inputMemberID:    memberID.String(),
+			inputRequest:     `{"role":"guest"}`,
+			prepareWorkspaceService: func(serviceMock *mocks.WorkspaceService) {
+				serviceMock.On("UpdateMemberRole", mock.Anything, userID, 5, memberID, entity.WorkspaceRoleGuest).Return(nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully update"}`,
+		},
+		{
+			name:               "invalid workspace id",
+			inputWorkspaceID:   "abc",
+			inputMemberID:      memberID.String(),
+			inputRequest:       `{"role":"guest"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Invalid ID"}`,
+		},
+		{
+			name:               "invalid role",
+			inputWorkspaceID:   "5",
+			inputMemberID:      memberID.String(),
+			inputRequest:       `{"role":"owner"}`,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Validation error: Field 'role' must be one of: admin member guest"}`,
+		},
+		{
+			name:             "caller is not an admin",
+			inputWorkspaceID: "5",
+			inputMemberID:    memberID.String(),
+			inputRequest:     `{"role":"admin"}`,
+			prepareWorkspaceService: func(serviceMock *mocks.WorkspaceService) {
+				serviceMock.On("UpdateMemberRole", mock.Anything, userID, 5, memberID, entity.WorkspaceRoleAdmin).
+					Return(entity.ErrForbidden)
+			},
+			expectedHTTPStatus: http.StatusForbidden,
+			expectedResponse:   `{"code":403,"error":true,"message":"Permission denied"}`,
+		},
+		{
+			name:             "demote last admin",
+			inputWorkspaceID: "5",
+			inputMemberID:    memberID.String(),
+			inputRequest:     `{"role":"member"}`,
+			prepareWorkspaceService: func(serviceMock *mocks.WorkspaceService) {
+				serviceMock.On("UpdateMemberRole", mock.Anything, userID, 5, memberID, entity.WorkspaceRoleMember).
+					Return(entity.ErrLastAdmin)
+			},
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedResponse:   `{"code":400,"error":true,"message":"Workspace must keep at least one admin"}`,
+		},
+		{
+			name:             "not a member of the workspace",
+			inputWorkspaceID: "5",
+			inputMemberID:    memberID.String(),
+			inputRequest:     `{"role":"member"}`,
+			prepareWorkspaceService: func(serviceMock *mocks.WorkspaceService) {
+				serviceMock.On("UpdateMemberRole", mock.Anything, userID, 5, memberID, entity.WorkspaceRoleMember).
+					Return(entity.ErrWorkspaceNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Workspace not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			workspaceServiceMock := mocks.NewWorkspaceService(t)
+			if tc.prepareWorkspaceService != nil {
+				tc.prepareWorkspaceService(workspaceServiceMock)
+			}
+
+			handler := NewHandler(workspaceServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/workspaces/{workspaceID}", func(r chi.Router) {
+				RegisterWorkspaceRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("PUT", "/workspaces/"+tc.inputWorkspaceID+"/members/"+tc.inputMemberID,
+				bytes.NewBufferString(tc.inputRequest))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	userID := uuid.New()
+
+	testCases := []struct {
+		name                    string
+		prepareWorkspaceService func(serviceMock *mocks.WorkspaceService)
+		expectedHTTPStatus      int
+		expectedResponse        string
+	}{
+		{
+			name: "successful accept",
+			prepareWorkspaceService: func(serviceMock *mocks.WorkspaceService) {
+				serviceMock.On("AcceptInvitation", mock.Anything, userID, "invite-token").
+					Return(entity.Workspace{ID: 5, Name: "Acme Inc.", Role: entity.WorkspaceRoleMember}, nil)
+			},
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponse:   `{"code":200,"error":false,"message":"Successfully accept","data":{"id":5,"name":"Acme Inc.","role":"member"}}`,
+		},
+		{
+			name: "expired or used invitation",
+			prepareWorkspaceService: func(serviceMock *mocks.WorkspaceService) {
+				serviceMock.On("AcceptInvitation", mock.Anything, userID, "invite-token").
+					Return(entity.Workspace{}, entity.ErrInvitationNotFound)
+			},
+			expectedHTTPStatus: http.StatusNotFound,
+			expectedResponse:   `{"code":404,"error":true,"message":"Invitation not found or expired"}`,
+		},
+		{
+			name: "already a member",
+			prepareWorkspaceService: func(serviceMock *mocks.WorkspaceService) {
+				serviceMock.On("AcceptInvitation", mock.Anything, userID, "invite-token").
+					Return(entity.Workspace{}, entity.ErrAlreadyMember)
+			},
+			expectedHTTPStatus: http.StatusConflict,
+			expectedResponse:   `{"code":409,"error":true,"message":"Already a member of this workspace"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			workspaceServiceMock := mocks.NewWorkspaceService(t)
+			if tc.prepareWorkspaceService != nil {
+				tc.prepareWorkspaceService(workspaceServiceMock)
+			}
+
+			handler := NewHandler(workspaceServiceMock, logger)
+
+			r := chi.NewRouter()
+			r.Route("/invitations", func(r chi.Router) {
+				RegisterInvitationRoutes(r, handler)
+			})
+
+			req, err := http.NewRequest("POST", "/invitations/invite-token/accept", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "id", userID))
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
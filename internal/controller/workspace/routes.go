@@ -0,0 +1,25 @@
+package workspace
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterRoutes(r chi.Router, h *Handler) {
+	r.Get("/", h.GetAll)
+	r.Post("/", h.Create)
+}
+
+// RegisterWorkspaceRoutes registers the routes of a single workspace. It expects to be
+// mounted on a router with a {workspaceID} path parameter.
+func RegisterWorkspaceRoutes(r chi.Router, h *Handler) {
+	r.Get("/", h.Get)
+	r.Delete("/", h.Delete)
+	r.Get("/members", h.GetMembers)
+	r.Put("/members/{userID}", h.UpdateMemberRole)
+	r.Delete("/members/{userID}", h.RemoveMember)
+	r.Get("/invitations", h.GetInvitations)
+	r.Post("/invitations", h.CreateInvitation)
+	r.Delete("/invitations/{invitationID}", h.RevokeInvitation)
+}
+
+func RegisterInvitationRoutes(r chi.Router, h *Handler) {
+	r.Post("/{token}/accept", h.AcceptInvitation)
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// AccountExport is everything stored about a user, handed out on a data export.
+type AccountExport struct {
+	Profile       Profile
+	Todos         []Todo
+	RefreshTokens []RefreshTokenInfo
+	Comments      []Comment
+	History       []TodoHistory
+	Sessions      []Session
+	TimeEntries   []TimeEntry
+	Templates     []Template
+	Tags          []Tag
+	ExportedAt    time.Time
+}
+
+// RefreshTokenInfo describes a refresh token without revealing it.
+type RefreshTokenInfo struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AccountDeletion confirms the deletion of an account with its password.
+type AccountDeletion struct {
+	Password string `json:"password" validate:"required"`
+}
+
+func (a *AccountDeletion) Validate() []string {
+	return validateStruct(a)
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultAgendaDays = 7
+	maxAgendaDays     = 92
+)
+
+// AgendaRequest selects the days of an agenda, both inclusive. Filters work as they
+// do when listing todos.
+type AgendaRequest struct {
+	From    *Date
+	To      *Date
+	Filters Filters
+}
+
+// Range resolves the days of the agenda. Without From the agenda starts today, and
+// without To it covers a week.
+func (r AgendaRequest) Range(today Date) (Date, Date, error) {
+	from := today
+	if r.From != nil {
+		from = *r.From
+	}
+	to := Date{from.AddDate(0, 0, defaultAgendaDays-1)}
+	if r.To != nil {
+		to = *r.To
+	}
+
+	if to.Before(from.Time) {
+		return Date{}, Date{}, &ValidationError{Messages: []string{"Field 'to' must not be before 'from'"}}
+	}
+	if to.Sub(from.Time) >= maxAgendaDays*24*time.Hour {
+		return Date{}, Date{}, &ValidationError{
+			Messages: []string{fmt.Sprintf("Agenda must not cover more than %d days", maxAgendaDays)},
+		}
+	}
+	return from, to, nil
+}
+
+// AgendaItem is a todo on a day of the agenda. Occurrence is the day the todo or
+// this occurrence of a recurring todo is due, which is earlier than the day it is
+// listed on for overdue todos.
+type AgendaItem struct {
+	Todo
+	Occurrence Date `json:"occurrence"`
+	Overdue    bool `json:"overdue,omitempty"`
+}
+
+type AgendaDay struct {
+	Date  Date         `json:"date"`
+	Todos []AgendaItem `json:"todos"`
+}
+
+type Agenda struct {
+	From     Date        `json:"from"`
+	To       Date        `json:"to"`
+	Today    Date        `json:"today"`
+	Timezone string      `json:"timezone"`
+	Days     []AgendaDay `json:"days"`
+}
+
+// BuildAgenda places todos on the days from from to to, including days without
+// any. Completed todos stay on their due date. Open todos due before today are
+// listed on today as overdue, and recurring todos are listed on each of their
+// occurrences from today on.
+func BuildAgenda(todos []Todo, from, to, today Date) []AgendaDay {
+	var days []AgendaDay
+	index := make(map[string]int)
+	for date := from.Time; !date.After(to.Time); date = date.AddDate(0, 0, 1) {
+		index[date.Format(time.DateOnly)] = len(days)
+		days = append(days, AgendaDay{Date: Date{date}, Todos: []AgendaItem{}})
+	}
+	add := func(day Date, item AgendaItem) {
+		if i, ok := index[day.Format(time.DateOnly)]; ok {
+			days[i].Todos = append(days[i].Todos, item)
+		}
+	}
+
+	for _, todo := range todos {
+		if todo.DueDate == nil {
+			continue
+		}
+		due := *todo.DueDate
+		if todo.Completed {
+			add(due, AgendaItem{Todo: todo, Occurrence: due})
+			continue
+		}
+
+		start := from
+		if start.Before(today.Time) {
+			start = today
+		}
+		if due.Before(today.Time) {
+			// Completing the overdue todo also covers today's occurrence.
+			add(today, AgendaItem{Todo: todo, Occurrence: due, Overdue: true})
+			if !start.After(today.Time) {
+				start = Date{today.AddDate(0, 0, 1)}
+			}
+		}
+		occurrences := []Date{due}
+		if todo.Recurrence != nil {
+			occurrences = todo.Recurrence.Occurrences(due, start, to)
+		}
+		for _, date := range occurrences {
+			if !date.Before(start.Time) {
+				add(date, AgendaItem{Todo: todo, Occurrence: date})
+			}
+		}
+	}
+	return days
+}
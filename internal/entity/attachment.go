@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+type Attachment struct {
+	ID          int        `json:"id"`
+	TodoID      int        `json:"todo_id"`
+	UploaderID  *uuid.UUID `json:"uploader_id,omitempty"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	Checksum    string     `json:"checksum"`
+	StorageKey  string     `json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// AttachmentUpload is a file received from the client before it is stored.
+type AttachmentUpload struct {
+	FileName string
+	Size     int64
+	Body     io.Reader
+}
+
+// AttachmentLimits restricts what can be uploaded.
+type AttachmentLimits struct {
+	MaxSize             int64
+	AllowedContentTypes []string
+}
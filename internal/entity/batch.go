@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"encoding/json"
+)
+
+// BatchRequest pipelines several API calls in one HTTP request.
+type BatchRequest struct {
+	Requests []SubRequest `json:"requests" validate:"required,min=1"`
+}
+
+// SubRequest is one call of a batch. Path is relative to the API base path, for
+// example "/todos/12", and may carry a query string. Body is passed on as is.
+type SubRequest struct {
+	Method  string            `json:"method" validate:"required,oneof=GET POST PUT PATCH DELETE"`
+	Path    string            `json:"path" validate:"required,startswith=/"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// SubResponse is the answer to one call of a batch. Body holds the usual response
+// envelope of the endpoint, or a JSON string for responses that are not JSON.
+type SubResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+func (b *BatchRequest) Validate() []string {
+	return validateStruct(b)
+}
+
+func (s *SubRequest) Validate() []string {
+	return validateStruct(s)
+}
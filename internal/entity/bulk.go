@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"strings"
+)
+
+const MaxBulkOperations = 500
+
+type BulkMode string
+
+const (
+	// BulkAtomic applies every operation or none of them.
+	BulkAtomic BulkMode = "atomic"
+	// BulkBestEffort applies every operation that succeeds and reports the others.
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+type BulkOperationType string
+
+const (
+	BulkCreate   BulkOperationType = "create"
+	BulkUpdate   BulkOperationType = "update"
+	BulkDelete   BulkOperationType = "delete"
+	BulkComplete BulkOperationType = "complete"
+	BulkRetag    BulkOperationType = "retag"
+	BulkMove     BulkOperationType = "move"
+)
+
+// BulkOperation is one change of a bulk request. Which fields are used depends on Op:
+// every operation but create takes ID, create and update take Todo, and the others
+// their own fields. Move puts
+// the todo into ProjectID, or takes it out of its project when ProjectID is null.
+type BulkOperation struct {
+	Op         BulkOperationType `json:"op" validate:"required,oneof=create update delete complete retag move"`
+	ID         int               `json:"id,omitempty"`
+	Todo       *Todo             `json:"todo,omitempty"`
+	Completed  *bool             `json:"completed,omitempty"`
+	AddTags    []string          `json:"add_tags,omitempty"`
+	RemoveTags []string          `json:"remove_tags,omitempty"`
+	ProjectID  *int              `json:"project_id,omitempty"`
+}
+
+type BulkRequest struct {
+	Mode       BulkMode        `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Operations []BulkOperation `json:"operations" validate:"required,min=1,max=500"`
+}
+
+// BulkOutcome is the result of one operation of a bulk request. ID is the todo the
+// operation applied to, including the one it created.
+type BulkOutcome struct {
+	Index int
+	Op    BulkOperationType
+	ID    int
+	Err   error
+}
+
+// InvalidOperationError reports a bulk operation that is missing fields it needs.
+type InvalidOperationError struct {
+	Messages []string
+}
+
+func (e *InvalidOperationError) Error() string {
+	return strings.Join(e.Messages, ";")
+}
+
+func (r *BulkRequest) Validate() []string {
+	return validateStruct(r)
+}
+
+// Validate checks the operation on its own, before it is applied. The todo of
+// create and update is validated along with the other fields.
+func (o *BulkOperation) Validate() error {
+	messages := validateStruct(o)
+	if (o.Op == BulkCreate || o.Op == BulkUpdate) && o.Todo == nil {
+		messages = append(messages, "Field 'todo' is required")
+	}
+	if o.Op != BulkCreate && o.ID == 0 {
+		messages = append(messages, "Field 'id' is required")
+	}
+	if o.Op == BulkComplete && o.Completed == nil {
+		messages = append(messages, "Field 'completed' is required")
+	}
+	if o.Op == BulkRetag && len(o.AddTags) == 0 && len(o.RemoveTags) == 0 {
+		messages = append(messages, "Field 'add_tags' or 'remove_tags' is required")
+	}
+
+	if messages != nil {
+		return &InvalidOperationError{Messages: messages}
+	}
+	return nil
+}
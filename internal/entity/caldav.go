@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const syncTokenPrefix = "http://go-todo-api/ns/sync/"
+
+// CalDAVObject is the name and UID a CalDAV client chose for a todo it created.
+type CalDAVObject struct {
+	TodoID int
+	Name   string
+	UID    string
+}
+
+// CalDAVResource is a todo served as a calendar object resource.
+type CalDAVResource struct {
+	Name string
+	UID  string
+	Todo Todo
+}
+
+// ETag changes whenever the todo is written, since every write touches UpdatedAt.
+func (r CalDAVResource) ETag() string {
+	var version int64
+	if r.Todo.UpdatedAt != nil {
+		version = r.Todo.UpdatedAt.UnixNano()
+	}
+	return fmt.Sprintf(`"%d-%d"`, r.Todo.ID, version)
+}
+
+// CalDAVChange is a change to a todo after a sync token. Name is only known for
+// todos that left the collection.
+type CalDAVChange struct {
+	TodoID  int
+	Name    string
+	Deleted bool
+}
+
+// SyncToken is the position in the change log of a user's todos. Clients see it
+// as a URI.
+type SyncToken int64
+
+func (t SyncToken) String() string {
+	return syncTokenPrefix + strconv.FormatInt(int64(t), 10)
+}
+
+func ParseSyncToken(s string) (SyncToken, error) {
+	value, ok := strings.CutPrefix(strings.TrimSpace(s), syncTokenPrefix)
+	if !ok {
+		return 0, ErrInvalidSyncToken
+	}
+	token, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || token < 0 {
+		return 0, ErrInvalidSyncToken
+	}
+	return SyncToken(token), nil
+}
+
+// CalDAVCollection is the calendar collection of a user's personal todos.
+type CalDAVCollection struct {
+	SyncToken SyncToken
+	Resources []CalDAVResource
+}
+
+// CalDAVSync lists what changed in a collection after a sync token.
+type CalDAVSync struct {
+	SyncToken SyncToken
+	Changed   []CalDAVResource
+	Deleted   []string
+}
+
+// Preconditions are the If-Match and If-None-Match headers of a request writing a
+// resource.
+type Preconditions struct {
+	IfMatch     string
+	IfNoneMatch string
+}
+
+// Check reports ErrPreconditionFailed unless the resource, identified by etag if it
+// exists, is in the state the client expects.
+func (p Preconditions) Check(etag string, exists bool) error {
+	if p.IfMatch != "" && (!exists || (p.IfMatch != "*" && !containsETag(p.IfMatch, etag))) {
+		return ErrPreconditionFailed
+	}
+	if p.IfNoneMatch != "" && exists && (p.IfNoneMatch == "*" || containsETag(p.IfNoneMatch, etag)) {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+func containsETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"regexp"
+	"time"
+)
+
+const NotificationMentioned NotificationType = "mentioned"
+
+type Comment struct {
+	ID        int        `json:"id"`
+	TodoID    int        `json:"todo_id"`
+	AuthorID  uuid.UUID  `json:"author_id"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	Deleted   bool       `json:"deleted,omitempty"`
+}
+
+type CommentRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+func (c *CommentRequest) Validate() []string {
+	return validateStruct(c)
+}
+
+// mentionPattern matches @username where the @ is not part of a word or an email
+// address. Usernames follow the same rules as registration.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([a-zA-Z0-9_]{3,20})\b`)
+
+// ParseMentions returns the distinct usernames mentioned in a comment body, in the
+// order they first appear.
+func ParseMentions(body string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			usernames = append(usernames, match[1])
+		}
+	}
+	return usernames
+}
@@ -3,10 +3,90 @@ package entity
 import "errors"
 
 var (
-	ErrUserNotFound   = errors.New("user not found")
-	ErrUsernameExists = errors.New("username already exists")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrUsernameExists     = errors.New("username already exists")
+	ErrWrongPassword      = errors.New("wrong password")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrTooManyResetTokens = errors.New("too many password reset tokens")
+	ErrTokenReused        = errors.New("refresh token reused")
+	ErrSessionNotFound    = errors.New("session not found")
 )
 
 var (
 	ErrTodoNotFound = errors.New("todo not found")
 )
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+)
+
+var (
+	ErrForbidden          = errors.New("permission denied")
+	ErrShareNotFound      = errors.New("share not found")
+	ErrInvalidShareTarget = errors.New("cannot share with the owner")
+)
+
+var (
+	ErrWorkspaceNotFound  = errors.New("workspace not found")
+	ErrWorkspaceMismatch  = errors.New("project belongs to a different workspace")
+	ErrInvitationNotFound = errors.New("invitation not found or expired")
+	ErrMemberNotFound     = errors.New("workspace member not found")
+	ErrAlreadyMember      = errors.New("user is already a workspace member")
+	ErrLastAdmin          = errors.New("workspace must keep at least one admin")
+)
+
+var (
+	ErrInvalidAssignee      = errors.New("assignee cannot see the todo")
+	ErrParentMismatch       = errors.New("parent todo belongs to a different workspace")
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+)
+
+var (
+	ErrAttachmentNotFound     = errors.New("attachment not found")
+	ErrAttachmentTooLarge     = errors.New("attachment is too large")
+	ErrUnsupportedContentType = errors.New("unsupported attachment content type")
+)
+
+var (
+	ErrUndoNotFound = errors.New("undo token not found or expired")
+	ErrUndoConflict = errors.New("todo was modified after the change")
+)
+
+var (
+	ErrBulkAborted = errors.New("operation was not applied because another operation failed")
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("tag already exists")
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+var (
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+	ErrTimerRunning      = errors.New("another timer is already running")
+	ErrTimerNotRunning   = errors.New("no timer is running")
+)
+
+var (
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+)
+
+var (
+	ErrCalDAVObjectNotFound = errors.New("calendar object not found")
+	ErrInvalidSyncToken     = errors.New("invalid sync token")
+	ErrPreconditionFailed   = errors.New("resource was changed by someone else")
+	ErrInvalidCalendarData  = errors.New("invalid calendar data")
+)
+
+var (
+	ErrInvalidImport = errors.New("invalid import")
+	ErrInvalidTodo   = errors.New("invalid todo")
+)
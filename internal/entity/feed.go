@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// CalendarFeed is a secret URL serving the todos of a user as iCalendar. The token
+// is only returned when the feed is created.
+type CalendarFeed struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Token      string     `json:"token,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+type CalendarFeedRequest struct {
+	Name string `json:"name" validate:"max=100"`
+}
+
+func (r *CalendarFeedRequest) Validate() []string {
+	return validateStruct(r)
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+type HistoryOperation string
+
+const (
+	HistoryCreated  HistoryOperation = "created"
+	HistoryUpdated  HistoryOperation = "updated"
+	HistoryAssigned HistoryOperation = "assigned"
+	HistoryDeleted  HistoryOperation = "deleted"
+)
+
+// FieldChange is the value of a single todo field before and after a change.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// TodoHistory is one entry of a todo's change log.
+type TodoHistory struct {
+	ID        int              `json:"id"`
+	TodoID    int              `json:"todo_id"`
+	ActorID   *uuid.UUID       `json:"actor_id"`
+	Actor     string           `json:"actor,omitempty"`
+	Operation HistoryOperation `json:"operation"`
+	Changes   []FieldChange    `json:"changes"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// DiffTodos lists the user-editable fields that differ between two versions of a
+// todo. Pass a zero Todo as before to describe a newly created todo, or as after
+// to describe a deleted one.
+func DiffTodos(before, after Todo) []FieldChange {
+	changes := []FieldChange{}
+	add := func(field string, changed bool, before, after any) {
+		if changed {
+			changes = append(changes, FieldChange{Field: field, Before: before, After: after})
+		}
+	}
+
+	add("title", before.Title != after.Title, before.Title, after.Title)
+	add("description", before.Description != after.Description, before.Description, after.Description)
+	add("tags", !slices.Equal(before.Tags, after.Tags), before.Tags, after.Tags)
+	add("due_date", !equalPtr(before.DueDate, after.DueDate, func(a, b Date) bool { return a.Equal(b.Time) }),
+		before.DueDate, after.DueDate)
+	add("project_id", !equalPtr(before.ProjectID, after.ProjectID, eq[int]), before.ProjectID, after.ProjectID)
+	add("parent_id", !equalPtr(before.ParentID, after.ParentID, eq[int]), before.ParentID, after.ParentID)
+	add("checklist", !slices.Equal(before.Checklist, after.Checklist), before.Checklist, after.Checklist)
+	add("estimate_minutes", !equalPtr(before.EstimateMinutes, after.EstimateMinutes, eq[int]),
+		before.EstimateMinutes, after.EstimateMinutes)
+	add("recurrence", !equalPtr(before.Recurrence, after.Recurrence, equalRecurrence), before.Recurrence, after.Recurrence)
+	add("completed", before.Completed != after.Completed, before.Completed, after.Completed)
+	add("assignee_id", !equalPtr(before.AssigneeID, after.AssigneeID, eq[uuid.UUID]), before.AssigneeID, after.AssigneeID)
+	return changes
+}
+
+func eq[T comparable](a, b T) bool {
+	return a == b
+}
+
+func equalPtr[T any](a, b *T, equal func(a, b T) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return equal(*a, *b)
+}
+
+func equalRecurrence(a, b Recurrence) bool {
+	return a.Frequency == b.Frequency && a.step() == b.step() &&
+		equalPtr(a.Until, b.Until, func(a, b Date) bool { return a.Equal(b.Time) })
+}
@@ -0,0 +1,58 @@
+package entity
+
+// MaxImportItems is the most todos a single import may contain.
+const MaxImportItems = 5000
+
+type ImportFormat string
+
+const (
+	ImportCSV      ImportFormat = "csv"
+	ImportJSON     ImportFormat = "json"
+	ImportTodoTxt  ImportFormat = "todotxt"
+	ImportMarkdown ImportFormat = "markdown"
+)
+
+// ImportItem is a todo read from an import file. Line is where it was found: the
+// line of the file, or the position in the array for JSON. Parent is the index of
+// the item it is a subtask of, and Project the name of the project it belongs to.
+type ImportItem struct {
+	Line    int
+	Todo    Todo
+	Project string
+	Parent  *int
+}
+
+// ImportLineError lists what is wrong with one line of an import file.
+type ImportLineError struct {
+	Line   int      `json:"line"`
+	Errors []string `json:"errors"`
+}
+
+// ImportRequest is a parsed import file. Errors are the lines that could not be
+// read; an import with errors is never applied.
+type ImportRequest struct {
+	Items       []ImportItem
+	Errors      []ImportLineError
+	WorkspaceID *int
+	DryRun      bool
+}
+
+// ImportResult is what an import did, or would do on a dry run.
+type ImportResult struct {
+	DryRun   bool              `json:"dry_run"`
+	Total    int               `json:"total"`
+	Imported int               `json:"imported"`
+	IDs      []int             `json:"ids,omitempty"`
+	Errors   []ImportLineError `json:"errors,omitempty"`
+	*Undo
+}
+
+type ExportFormat string
+
+const (
+	ExportJSON     ExportFormat = "json"
+	ExportNDJSON   ExportFormat = "ndjson"
+	ExportCSV      ExportFormat = "csv"
+	ExportMarkdown ExportFormat = "markdown"
+	ExportTodoTxt  ExportFormat = "todotxt"
+)
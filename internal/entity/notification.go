@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type NotificationType string
+
+const (
+	NotificationAssigned NotificationType = "assigned"
+)
+
+type Notification struct {
+	ID        int              `json:"id"`
+	UserID    uuid.UUID        `json:"-"`
+	Type      NotificationType `json:"type"`
+	TodoID    *int             `json:"todo_id,omitempty"`
+	ActorID   *uuid.UUID       `json:"actor_id,omitempty"`
+	Message   string           `json:"message"`
+	Read      bool             `json:"read"`
+	CreatedAt time.Time        `json:"created_at"`
+}
@@ -9,3 +9,14 @@ type Pagination struct {
 	Offset int
 	Limit  int
 }
+
+// Cursor pages through a list ordered by ID, starting after the item with ID After.
+type Cursor struct {
+	After int
+	Limit int
+}
+
+type CursorPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+// PasswordChange sets a new password, confirmed with the current one.
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,passwordstrength"`
+}
+
+func (p *PasswordChange) Validate() []string {
+	return validateStruct(p)
+}
+
+// PasswordForgot asks for a password reset link for the account.
+type PasswordForgot struct {
+	Username string `json:"username" validate:"required"`
+}
+
+func (p *PasswordForgot) Validate() []string {
+	return validateStruct(p)
+}
+
+// PasswordReset sets a new password with a token from a password reset link.
+type PasswordReset struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,passwordstrength"`
+}
+
+func (p *PasswordReset) Validate() []string {
+	return validateStruct(p)
+}
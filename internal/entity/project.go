@@ -0,0 +1,12 @@
+package entity
+
+type Project struct {
+	ID          int    `json:"id" validate:"omitempty"`
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+	WorkspaceID *int   `json:"workspace_id,omitempty"`
+}
+
+func (p *Project) Validate() []string {
+	return validateStruct(p)
+}
@@ -0,0 +1,22 @@
+package entity
+
+// QuickAddRequest creates a todo from a line of free text. On a preview the todo
+// is only parsed, not saved.
+type QuickAddRequest struct {
+	Text        string `json:"text" validate:"required,max=500"`
+	WorkspaceID *int   `json:"-"`
+	Preview     bool   `json:"-"`
+}
+
+func (q *QuickAddRequest) Validate() []string {
+	return validateStruct(q)
+}
+
+// QuickAddResult is the todo read from the text and, unless it was a preview, the
+// id it was saved under.
+type QuickAddResult struct {
+	Preview bool `json:"preview"`
+	Todo    Todo `json:"todo"`
+	ID      int  `json:"id,omitempty"`
+	*Undo
+}
@@ -0,0 +1,160 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurDaily   RecurrenceFrequency = "daily"
+	RecurWeekly  RecurrenceFrequency = "weekly"
+	RecurMonthly RecurrenceFrequency = "monthly"
+	RecurYearly  RecurrenceFrequency = "yearly"
+)
+
+// Recurrence repeats a todo every Interval days, weeks, months or years starting at
+// its due date, up to and including Until. Completing a recurring todo moves it to
+// its next occurrence instead, until the series ends.
+//
+// Start is the due date the series began on and is set by the server. Occurrences
+// are counted from it rather than from the current due date, which completions move
+// and short months clamp.
+type Recurrence struct {
+	Frequency RecurrenceFrequency `json:"frequency" validate:"required,oneof=daily weekly monthly yearly"`
+	Interval  int                 `json:"interval,omitempty" validate:"omitempty,min=1,max=365"`
+	Until     *Date               `json:"until,omitempty"`
+	Start     *Date               `json:"start,omitempty"`
+}
+
+func (r *Recurrence) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return fmt.Errorf("cannot scan %T into Recurrence", v)
+	}
+}
+
+func (r Recurrence) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r Recurrence) step() int {
+	return max(r.Interval, 1)
+}
+
+// start returns the day the series of a todo due on due began. Series stored
+// before Start was recorded begin on the due date.
+func (r Recurrence) start(due Date) Date {
+	if r.Start != nil && !r.Start.After(due.Time) {
+		return *r.Start
+	}
+	return due
+}
+
+// Anchor sets the start of the series of a todo due on due. An edit that keeps the
+// todo on an occurrence of its previous series keeps that series' start.
+func (r *Recurrence) Anchor(due Date, previous *Recurrence) {
+	if previous != nil && previous.Start != nil && previous.Frequency == r.Frequency &&
+		previous.step() == r.step() && r.occursOn(previous.Start.Time, due.Time) {
+		start := *previous.Start
+		r.Start = &start
+		return
+	}
+	r.Start = &due
+}
+
+func (r Recurrence) occursOn(start, day time.Time) bool {
+	if day.Before(start) {
+		return false
+	}
+	for n := r.firstIndex(start, day); ; n++ {
+		if date := r.occurrence(start, n); !date.Before(day) {
+			return date.Equal(day)
+		}
+	}
+}
+
+// occurrence returns the nth occurrence of the series starting at start. Monthly
+// and yearly occurrences on a day the month does not have fall on its last day.
+func (r Recurrence) occurrence(start time.Time, n int) time.Time {
+	switch r.Frequency {
+	case RecurDaily:
+		return start.AddDate(0, 0, n*r.step())
+	case RecurWeekly:
+		return start.AddDate(0, 0, 7*n*r.step())
+	case RecurMonthly:
+		return addMonths(start, n*r.step())
+	default:
+		return addMonths(start, 12*n*r.step())
+	}
+}
+
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// firstIndex returns an occurrence index at or before the first occurrence on or
+// after from, so long series do not have to be walked from the start.
+func (r Recurrence) firstIndex(start, from time.Time) int {
+	if !from.After(start) {
+		return 0
+	}
+	switch r.Frequency {
+	case RecurDaily:
+		return int(from.Sub(start).Hours()/24) / r.step()
+	case RecurWeekly:
+		return int(from.Sub(start).Hours()/24) / (7 * r.step())
+	case RecurMonthly:
+		months := (from.Year()-start.Year())*12 + int(from.Month()-start.Month())
+		return max(months/r.step()-1, 0)
+	default:
+		return max((from.Year()-start.Year())/r.step()-1, 0)
+	}
+}
+
+func (r Recurrence) ended(date time.Time) bool {
+	return r.Until != nil && date.After(r.Until.Time)
+}
+
+// Occurrences returns the occurrences of the series of a todo due on due that fall
+// between from and to, both inclusive. None comes before due.
+func (r Recurrence) Occurrences(due, from, to Date) []Date {
+	if from.Before(due.Time) {
+		from = due
+	}
+	start := r.start(due)
+	var dates []Date
+	for n := r.firstIndex(start.Time, from.Time); ; n++ {
+		date := r.occurrence(start.Time, n)
+		if date.After(to.Time) || r.ended(date) {
+			return dates
+		}
+		if !date.Before(from.Time) {
+			dates = append(dates, Date{date})
+		}
+	}
+}
+
+// Next returns the first occurrence of the series of a todo due on due that comes
+// after the given day, or nil if the series ends before.
+func (r Recurrence) Next(due, after Date) *Date {
+	start := r.start(due)
+	for n := r.firstIndex(start.Time, after.Time); ; n++ {
+		date := r.occurrence(start.Time, n)
+		if r.ended(date) {
+			return nil
+		}
+		if date.After(after.Time) {
+			return &Date{date}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Permission string
+
+const (
+	PermissionViewer Permission = "viewer"
+	PermissionEditor Permission = "editor"
+	PermissionOwner  Permission = "owner"
+)
+
+var permissionRanks = map[Permission]int{
+	PermissionViewer: 1,
+	PermissionEditor: 2,
+	PermissionOwner:  3,
+}
+
+// Allows reports whether p grants at least the required permission.
+func (p Permission) Allows(required Permission) bool {
+	return permissionRanks[p] >= permissionRanks[required] && permissionRanks[p] > 0
+}
+
+// Max returns the stronger of two permissions.
+func (p Permission) Max(other Permission) Permission {
+	if permissionRanks[other] > permissionRanks[p] {
+		return other
+	}
+	return p
+}
+
+type ShareResource string
+
+const (
+	ShareResourceTodo    ShareResource = "todo"
+	ShareResourceProject ShareResource = "project"
+)
+
+type Share struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Username   string     `json:"username"`
+	Permission Permission `json:"permission"`
+}
+
+type ShareRequest struct {
+	Username   string     `json:"username" validate:"required"`
+	Permission Permission `json:"permission" validate:"required,oneof=viewer editor owner"`
+}
+
+type SharedTodo struct {
+	Todo
+	Owner      string     `json:"owner"`
+	Permission Permission `json:"permission"`
+}
+
+type SharedProject struct {
+	Project
+	Owner      string     `json:"owner"`
+	Permission Permission `json:"permission"`
+}
+
+func (s *ShareRequest) Validate() []string {
+	return validateStruct(s)
+}
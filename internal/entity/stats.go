@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type StatsInterval string
+
+const (
+	StatsByDay  StatsInterval = "day"
+	StatsByWeek StatsInterval = "week"
+)
+
+// StatsRequest selects the days from From to To, both inclusive, and how to
+// bucket them.
+type StatsRequest struct {
+	From     Date
+	To       Date
+	Interval StatsInterval
+}
+
+func (r *StatsRequest) Validate() []string {
+	var errs []string
+	switch r.Interval {
+	case StatsByDay, StatsByWeek:
+	default:
+		errs = append(errs, "Field 'interval' must be one of: day week")
+	}
+	if r.To.Before(r.From.Time) {
+		errs = append(errs, "Field 'to' must not be before 'from'")
+	} else if r.To.Sub(r.From.Time) >= maxReportDays*24*time.Hour {
+		errs = append(errs, fmt.Sprintf("Statistics must not cover more than %d days", maxReportDays))
+	}
+	return errs
+}
+
+// StatsBucket counts the todos created and completed in the day or week starting
+// at Start. Weeks start on Monday.
+type StatsBucket struct {
+	Start     Date `json:"start"`
+	Created   int  `json:"created"`
+	Completed int  `json:"completed"`
+}
+
+// TagStats counts the todos with a tag that were created or completed in the
+// range, and the ones that are overdue now.
+type TagStats struct {
+	Tag       string `json:"tag"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+	Overdue   int    `json:"overdue"`
+}
+
+// Streak counts consecutive days with at least one completed todo. The current
+// streak is kept alive until the end of the day after the last completion.
+type Streak struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+// StatsSummary describes the todos as they are now, except AverageLeadTimeHours,
+// which covers the todos completed in the range.
+type StatsSummary struct {
+	Open                 int      `json:"open"`
+	Overdue              int      `json:"overdue"`
+	AverageLeadTimeHours *float64 `json:"average_lead_time_hours"`
+}
+
+type Stats struct {
+	From     Date          `json:"from"`
+	To       Date          `json:"to"`
+	Interval StatsInterval `json:"interval"`
+	StatsSummary
+	Buckets []StatsBucket `json:"buckets"`
+	Tags    []TagStats    `json:"tags"`
+	Streak  Streak        `json:"streak"`
+}
@@ -10,10 +10,27 @@ type UserRequest struct {
 }
 
 type TodoRequest struct {
-	Title       string   `json:"title" example:"Buy groceries"`
-	Description string   `json:"description" example:"Get milk, bread, and eggs"`
-	Tags        []string `json:"tags" example:"shopping,urgent"`
-	DueDate     string   `json:"due_date" example:"2025-04-01"`
+	Title           string          `json:"title" example:"Buy groceries"`
+	Description     string          `json:"description" example:"Get milk, bread, and eggs"`
+	Tags            []string        `json:"tags" example:"shopping,urgent"`
+	DueDate         string          `json:"due_date" example:"2025-04-01"`
+	ProjectID       int             `json:"project_id" example:"3"`
+	ParentID        int             `json:"parent_id,omitempty" example:"7"`
+	Checklist       []ChecklistItem `json:"checklist,omitempty"`
+	EstimateMinutes int             `json:"estimate_minutes,omitempty" example:"90"`
+	Recurrence      *Recurrence     `json:"recurrence,omitempty"`
+}
+
+type ChecklistItem struct {
+	Text string `json:"text" example:"Tag the release"`
+	Done bool   `json:"done" example:"false"`
+}
+
+type Recurrence struct {
+	Frequency string `json:"frequency" example:"weekly" enums:"daily,weekly,monthly,yearly"`
+	Interval  int    `json:"interval,omitempty" example:"2"`
+	Until     string `json:"until,omitempty" example:"2025-12-31"`
+	Start     string `json:"start,omitempty" example:"2025-01-31"`
 }
 
 type UserData struct {
@@ -63,6 +80,47 @@ type RefreshResponse struct {
 	Data    tokenResponse `json:"data"`
 }
 
+type LogoutResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Error   bool   `json:"error" example:"false"`
+	Message string `json:"message" example:"Successfully logout"`
+}
+
+type SessionResponse struct {
+	ID          string `json:"id" example:"5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77"`
+	DeviceName  string `json:"device_name" example:"Firefox on Windows"`
+	UserAgent   string `json:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0"`
+	IP          string `json:"ip" example:"203.0.113.7"`
+	AppPassword bool   `json:"app_password" example:"false"`
+	CreatedAt   string `json:"created_at" example:"2025-04-01T09:12:44Z"`
+	LastUsedAt  string `json:"last_used_at" example:"2025-04-02T17:03:10Z"`
+	Current     bool   `json:"current" example:"true"`
+}
+
+type AppPasswordRequest struct {
+	Name string `json:"name" example:"DAVx5 on Pixel 8"`
+}
+
+type AppPassword struct {
+	SessionID string `json:"session_id" example:"5f0c6a43-1e0b-4c8e-9a43-2b1f3c1d9e77"`
+	Name      string `json:"name" example:"DAVx5 on Pixel 8"`
+	Password  string `json:"password" example:"kq3B8cWz1yXo0p7Tn2LrVf5aHs9dJm4E"`
+}
+
+type CreateAppPasswordResponse struct {
+	Code    int         `json:"code" example:"201"`
+	Error   bool        `json:"error" example:"false"`
+	Message string      `json:"message" example:"Successfully create"`
+	Data    AppPassword `json:"data"`
+}
+
+type ListSessionResponse struct {
+	Code    int               `json:"code" example:"200"`
+	Error   bool              `json:"error" example:"false"`
+	Message string            `json:"message" example:"Successfully fetch"`
+	Data    []SessionResponse `json:"data"`
+}
+
 type UnauthorizedResponse struct {
 	Code    int    `json:"code" example:"401"`
 	Error   bool   `json:"error" example:"true"`
@@ -70,18 +128,54 @@ type UnauthorizedResponse struct {
 }
 
 type TodoResponse struct {
-	ID          int      `json:"id" example:"12"`
-	Title       string   `json:"title" example:"Buy groceries"`
-	Description string   `json:"description" example:"Get milk, bread, and eggs"`
-	Tags        []string `json:"tags" example:"shopping,urgent"`
-	DueDate     string   `json:"due_date" example:"2025-04-01"`
+	ID              int             `json:"id" example:"12"`
+	Title           string          `json:"title" example:"Buy groceries"`
+	Description     string          `json:"description" example:"Get milk, bread, and eggs"`
+	Tags            []string        `json:"tags" example:"shopping,urgent"`
+	DueDate         string          `json:"due_date" example:"2025-04-01"`
+	ProjectID       int             `json:"project_id" example:"3"`
+	WorkspaceID     int             `json:"workspace_id" example:"5"`
+	AssigneeID      string          `json:"assignee_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	ParentID        int             `json:"parent_id" example:"7"`
+	Checklist       []ChecklistItem `json:"checklist"`
+	EstimateMinutes int             `json:"estimate_minutes" example:"90"`
+	Recurrence      *Recurrence     `json:"recurrence,omitempty"`
+	Completed       bool            `json:"completed" example:"true"`
+	CompletedAt     string          `json:"completed_at,omitempty" example:"2025-04-01T12:00:00Z"`
+	CreatedAt       string          `json:"created_at" example:"2025-03-28T09:15:00Z"`
+	UpdatedAt       string          `json:"updated_at" example:"2025-04-01T12:00:00Z"`
 }
 
 type CreateTodoResponse struct {
-	Code    int            `json:"code" example:"201"`
-	Error   bool           `json:"error" example:"false"`
-	Message string         `json:"message" example:"Successfully create"`
-	Data    createResponse `json:"data"`
+	Code    int                `json:"code" example:"201"`
+	Error   bool               `json:"error" example:"false"`
+	Message string             `json:"message" example:"Successfully create"`
+	Data    createTodoResponse `json:"data"`
+}
+
+type createTodoResponse struct {
+	Id            int    `json:"id" example:"12"`
+	UndoToken     string `json:"undo_token" example:"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"`
+	UndoExpiresAt string `json:"undo_expires_at" example:"2025-04-01T12:00:30Z"`
+}
+
+type UndoData struct {
+	UndoToken     string `json:"undo_token" example:"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"`
+	UndoExpiresAt string `json:"undo_expires_at" example:"2025-04-01T12:00:30Z"`
+}
+
+type UpdateTodoResponse struct {
+	Code    int      `json:"code" example:"200"`
+	Error   bool     `json:"error" example:"false"`
+	Message string   `json:"message" example:"Successfully update"`
+	Data    UndoData `json:"data"`
+}
+
+type DeleteTodoResponse struct {
+	Code    int      `json:"code" example:"200"`
+	Error   bool     `json:"error" example:"false"`
+	Message string   `json:"message" example:"Successfully delete"`
+	Data    UndoData `json:"data"`
 }
 
 type createResponse struct {
@@ -127,3 +221,772 @@ type InvalidIDResponse struct {
 	Code    int    `json:"code" example:"400"`
 	Message string `json:"message" example:"Invalid ID"`
 }
+
+type ForbiddenResponse struct {
+	Code    int    `json:"code" example:"403"`
+	Error   bool   `json:"error" example:"true"`
+	Message string `json:"message" example:"Permission denied"`
+}
+
+type ProjectRequest struct {
+	ID          int    `json:"id" example:"3"`
+	Name        string `json:"name" example:"Release 2.0"`
+	Description string `json:"description" example:"Everything needed for the next release"`
+}
+
+type ProjectResponse struct {
+	ID          int    `json:"id" example:"3"`
+	Name        string `json:"name" example:"Release 2.0"`
+	Description string `json:"description" example:"Everything needed for the next release"`
+	WorkspaceID int    `json:"workspace_id" example:"5"`
+}
+
+type GetProjectResponse struct {
+	Code    int             `json:"code" example:"200"`
+	Error   bool            `json:"error" example:"false"`
+	Message string          `json:"message" example:"Successfully fetch"`
+	Data    ProjectResponse `json:"data"`
+}
+
+type CreateProjectResponse struct {
+	Code    int            `json:"code" example:"201"`
+	Error   bool           `json:"error" example:"false"`
+	Message string         `json:"message" example:"Successfully create"`
+	Data    createResponse `json:"data"`
+}
+
+type ListProjectResponse struct {
+	Code    int               `json:"code" example:"200"`
+	Error   bool              `json:"error" example:"false"`
+	Message string            `json:"message" example:"Successfully fetch"`
+	Offset  int               `json:"offset" example:"0"`
+	Limit   int               `json:"limit" example:"20"`
+	Count   int               `json:"count" example:"1"`
+	Total   int               `json:"total" example:"1"`
+	Results []ProjectResponse `json:"data"`
+}
+
+type SharedProjectResponse struct {
+	ID          int    `json:"id" example:"3"`
+	Name        string `json:"name" example:"Release 2.0"`
+	Description string `json:"description" example:"Everything needed for the next release"`
+	Owner       string `json:"owner" example:"jane_doe"`
+	Permission  string `json:"permission" example:"editor"`
+}
+
+type ListSharedProjectResponse struct {
+	Code    int                     `json:"code" example:"200"`
+	Error   bool                    `json:"error" example:"false"`
+	Message string                  `json:"message" example:"Successfully fetch"`
+	Offset  int                     `json:"offset" example:"0"`
+	Limit   int                     `json:"limit" example:"20"`
+	Count   int                     `json:"count" example:"1"`
+	Total   int                     `json:"total" example:"1"`
+	Results []SharedProjectResponse `json:"data"`
+}
+
+type SharedTodoResponse struct {
+	ID          int      `json:"id" example:"12"`
+	Title       string   `json:"title" example:"Buy groceries"`
+	Description string   `json:"description" example:"Get milk, bread, and eggs"`
+	Tags        []string `json:"tags" example:"shopping,urgent"`
+	DueDate     string   `json:"due_date" example:"2025-04-01"`
+	ProjectID   int      `json:"project_id" example:"3"`
+	Owner       string   `json:"owner" example:"jane_doe"`
+	Permission  string   `json:"permission" example:"viewer"`
+}
+
+type ListSharedTodoResponse struct {
+	Code    int                  `json:"code" example:"200"`
+	Error   bool                 `json:"error" example:"false"`
+	Message string               `json:"message" example:"Successfully fetch"`
+	Offset  int                  `json:"offset" example:"0"`
+	Limit   int                  `json:"limit" example:"20"`
+	Count   int                  `json:"count" example:"1"`
+	Total   int                  `json:"total" example:"1"`
+	Results []SharedTodoResponse `json:"data"`
+}
+
+type ShareRequest struct {
+	Username   string `json:"username" example:"jane_doe"`
+	Permission string `json:"permission" example:"editor" enums:"viewer,editor,owner"`
+}
+
+type shareData struct {
+	UserID     string `json:"user_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	Username   string `json:"username" example:"jane_doe"`
+	Permission string `json:"permission" example:"editor"`
+}
+
+type ShareResponse struct {
+	Code    int       `json:"code" example:"200"`
+	Error   bool      `json:"error" example:"false"`
+	Message string    `json:"message" example:"Successfully share"`
+	Data    shareData `json:"data"`
+}
+
+type ListShareResponse struct {
+	Code    int         `json:"code" example:"200"`
+	Error   bool        `json:"error" example:"false"`
+	Message string      `json:"message" example:"Successfully fetch"`
+	Data    []shareData `json:"data"`
+}
+
+type RevokeResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Error   bool   `json:"error" example:"false"`
+	Message string `json:"message" example:"Successfully revoke"`
+}
+
+type RemoveResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Error   bool   `json:"error" example:"false"`
+	Message string `json:"message" example:"Successfully remove"`
+}
+
+type WorkspaceRequest struct {
+	Name string `json:"name" example:"Acme Inc."`
+}
+
+type WorkspaceResponse struct {
+	ID   int    `json:"id" example:"5"`
+	Name string `json:"name" example:"Acme Inc."`
+	Role string `json:"role" example:"admin" enums:"admin,member,guest"`
+}
+
+type GetWorkspaceResponse struct {
+	Code    int               `json:"code" example:"200"`
+	Error   bool              `json:"error" example:"false"`
+	Message string            `json:"message" example:"Successfully fetch"`
+	Data    WorkspaceResponse `json:"data"`
+}
+
+type AcceptInvitationResponse struct {
+	Code    int               `json:"code" example:"200"`
+	Error   bool              `json:"error" example:"false"`
+	Message string            `json:"message" example:"Successfully accept"`
+	Data    WorkspaceResponse `json:"data"`
+}
+
+type CreateWorkspaceResponse struct {
+	Code    int            `json:"code" example:"201"`
+	Error   bool           `json:"error" example:"false"`
+	Message string         `json:"message" example:"Successfully create"`
+	Data    createResponse `json:"data"`
+}
+
+type ListWorkspaceResponse struct {
+	Code    int                 `json:"code" example:"200"`
+	Error   bool                `json:"error" example:"false"`
+	Message string              `json:"message" example:"Successfully fetch"`
+	Data    []WorkspaceResponse `json:"data"`
+}
+
+type MemberRoleRequest struct {
+	Role string `json:"role" example:"member" enums:"admin,member,guest"`
+}
+
+type memberData struct {
+	UserID   string `json:"user_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	Username string `json:"username" example:"jane_doe"`
+	Role     string `json:"role" example:"member"`
+	JoinedAt string `json:"joined_at" example:"2025-04-01T12:00:00Z"`
+}
+
+type ListMemberResponse struct {
+	Code    int          `json:"code" example:"200"`
+	Error   bool         `json:"error" example:"false"`
+	Message string       `json:"message" example:"Successfully fetch"`
+	Data    []memberData `json:"data"`
+}
+
+type InvitationRequest struct {
+	Role           string `json:"role" example:"member" enums:"admin,member,guest"`
+	ExpiresInHours int    `json:"expires_in_hours" example:"72"`
+}
+
+type invitationData struct {
+	ID          int    `json:"id" example:"9"`
+	WorkspaceID int    `json:"workspace_id" example:"5"`
+	Role        string `json:"role" example:"member"`
+	ExpiresAt   string `json:"expires_at" example:"2025-04-04T12:00:00Z"`
+}
+
+type createdInvitationData struct {
+	invitationData
+	Token string `json:"token" example:"k3Jx9aP2vQ8mZr7LwN4tYb6HcE1sDf0Ug5Ki2Oa8WqM"`
+}
+
+type CreateInvitationResponse struct {
+	Code    int                   `json:"code" example:"201"`
+	Error   bool                  `json:"error" example:"false"`
+	Message string                `json:"message" example:"Successfully create"`
+	Data    createdInvitationData `json:"data"`
+}
+
+type ListInvitationResponse struct {
+	Code    int              `json:"code" example:"200"`
+	Error   bool             `json:"error" example:"false"`
+	Message string           `json:"message" example:"Successfully fetch"`
+	Data    []invitationData `json:"data"`
+}
+
+type AssigneeRequest struct {
+	AssigneeID *string `json:"assignee_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+}
+
+type NotificationResponse struct {
+	ID        int    `json:"id" example:"42"`
+	Type      string `json:"type" example:"assigned"`
+	TodoID    int    `json:"todo_id" example:"12"`
+	ActorID   string `json:"actor_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	Message   string `json:"message" example:"You were assigned to \"Buy groceries\""`
+	Read      bool   `json:"read" example:"false"`
+	CreatedAt string `json:"created_at" example:"2025-04-01T12:00:00Z"`
+}
+
+type ListNotificationResponse struct {
+	Code    int                    `json:"code" example:"200"`
+	Error   bool                   `json:"error" example:"false"`
+	Message string                 `json:"message" example:"Successfully fetch"`
+	Offset  int                    `json:"offset" example:"0"`
+	Limit   int                    `json:"limit" example:"20"`
+	Count   int                    `json:"count" example:"1"`
+	Total   int                    `json:"total" example:"1"`
+	Results []NotificationResponse `json:"data"`
+}
+
+type CommentRequest struct {
+	Body string `json:"body" example:"Can you pick up oat milk too, @jane_doe?"`
+}
+
+type CommentResponse struct {
+	ID        int    `json:"id" example:"31"`
+	TodoID    int    `json:"todo_id" example:"12"`
+	AuthorID  string `json:"author_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	Author    string `json:"author" example:"john_doe"`
+	Body      string `json:"body" example:"Can you pick up oat milk too, @jane_doe?"`
+	CreatedAt string `json:"created_at" example:"2025-04-01T12:00:00Z"`
+	EditedAt  string `json:"edited_at" example:"2025-04-01T12:05:00Z"`
+	Deleted   bool   `json:"deleted" example:"false"`
+}
+
+type CreateCommentResponse struct {
+	Code    int             `json:"code" example:"201"`
+	Error   bool            `json:"error" example:"false"`
+	Message string          `json:"message" example:"Successfully create"`
+	Data    CommentResponse `json:"data"`
+}
+
+type commentPage struct {
+	Items      []CommentResponse `json:"items"`
+	NextCursor string            `json:"next_cursor" example:"MzE"`
+}
+
+type ListCommentResponse struct {
+	Code    int         `json:"code" example:"200"`
+	Error   bool        `json:"error" example:"false"`
+	Message string      `json:"message" example:"Successfully fetch"`
+	Data    commentPage `json:"data"`
+}
+
+type AttachmentResponse struct {
+	ID          int    `json:"id" example:"7"`
+	TodoID      int    `json:"todo_id" example:"12"`
+	UploaderID  string `json:"uploader_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	FileName    string `json:"file_name" example:"receipt.pdf"`
+	ContentType string `json:"content_type" example:"application/pdf"`
+	Size        int64  `json:"size" example:"48213"`
+	Checksum    string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	CreatedAt   string `json:"created_at" example:"2025-04-01T12:00:00Z"`
+}
+
+type CreateAttachmentResponse struct {
+	Code    int                `json:"code" example:"201"`
+	Error   bool               `json:"error" example:"false"`
+	Message string             `json:"message" example:"Successfully create"`
+	Data    AttachmentResponse `json:"data"`
+}
+
+type ListAttachmentResponse struct {
+	Code    int                  `json:"code" example:"200"`
+	Error   bool                 `json:"error" example:"false"`
+	Message string               `json:"message" example:"Successfully fetch"`
+	Data    []AttachmentResponse `json:"data"`
+}
+
+type FieldChangeResponse struct {
+	Field  string `json:"field" example:"due_date"`
+	Before any    `json:"before" swaggertype:"string" example:"2025-04-01"`
+	After  any    `json:"after" swaggertype:"string" example:"2025-04-08"`
+}
+
+type TodoHistoryResponse struct {
+	ID        int                   `json:"id" example:"42"`
+	TodoID    int                   `json:"todo_id" example:"12"`
+	ActorID   string                `json:"actor_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	Actor     string                `json:"actor" example:"john_doe"`
+	Operation string                `json:"operation" example:"updated" enums:"created,updated,assigned,deleted"`
+	Changes   []FieldChangeResponse `json:"changes"`
+	CreatedAt string                `json:"created_at" example:"2025-04-01T12:00:00Z"`
+}
+
+type todoHistoryPage struct {
+	Items      []TodoHistoryResponse `json:"items"`
+	NextCursor string                `json:"next_cursor" example:"NDI"`
+}
+
+type ListTodoHistoryResponse struct {
+	Code    int             `json:"code" example:"200"`
+	Error   bool            `json:"error" example:"false"`
+	Message string          `json:"message" example:"Successfully fetch"`
+	Data    todoHistoryPage `json:"data"`
+}
+
+type CompletionRequest struct {
+	Completed bool `json:"completed" example:"true"`
+}
+
+type UndoResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Error   bool   `json:"error" example:"false"`
+	Message string `json:"message" example:"Successfully undo"`
+}
+
+type BulkOperation struct {
+	Op         string      `json:"op" example:"retag" enums:"create,update,delete,complete,retag,move"`
+	ID         int         `json:"id,omitempty" example:"12"`
+	Todo       TodoRequest `json:"todo,omitempty"`
+	Completed  bool        `json:"completed,omitempty" example:"true"`
+	AddTags    []string    `json:"add_tags,omitempty" example:"urgent"`
+	RemoveTags []string    `json:"remove_tags,omitempty" example:"someday"`
+	ProjectID  int         `json:"project_id,omitempty" example:"3"`
+}
+
+type BulkRequest struct {
+	Mode       string          `json:"mode" example:"atomic" enums:"atomic,best_effort"`
+	Operations []BulkOperation `json:"operations"`
+}
+
+type BulkResult struct {
+	Index  int    `json:"index" example:"0"`
+	Op     string `json:"op" example:"retag"`
+	ID     int    `json:"id,omitempty" example:"12"`
+	Status int    `json:"status" example:"200"`
+	Error  string `json:"error,omitempty" example:""`
+}
+
+type BulkData struct {
+	Results       []BulkResult `json:"results"`
+	UndoToken     string       `json:"undo_token,omitempty" example:"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"`
+	UndoExpiresAt string       `json:"undo_expires_at,omitempty" example:"2025-04-01T12:00:30Z"`
+}
+
+type BulkResponse struct {
+	Code    int      `json:"code" example:"200"`
+	Error   bool     `json:"error" example:"false"`
+	Message string   `json:"message" example:"Successfully apply"`
+	Data    BulkData `json:"data"`
+}
+
+type BulkFailedResponse struct {
+	Code    int      `json:"code" example:"422"`
+	Error   bool     `json:"error" example:"true"`
+	Message string   `json:"message" example:"Bulk operation failed"`
+	Data    BulkData `json:"data"`
+}
+
+type SubRequest struct {
+	Method  string            `json:"method" example:"PUT" enums:"GET,POST,PUT,PATCH,DELETE"`
+	Path    string            `json:"path" example:"/todos/12/completion"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    map[string]any    `json:"body,omitempty"`
+}
+
+type BatchRequest struct {
+	Requests []SubRequest `json:"requests"`
+}
+
+type SubResponse struct {
+	Status  int               `json:"status" example:"200"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    map[string]any    `json:"body,omitempty"`
+}
+
+type BatchResponse struct {
+	Code    int           `json:"code" example:"200"`
+	Error   bool          `json:"error" example:"false"`
+	Message string        `json:"message" example:"Successfully batch"`
+	Data    []SubResponse `json:"data"`
+}
+
+type TagData struct {
+	Name        string `json:"name" example:"work"`
+	Color       string `json:"color" example:"#1e88e5"`
+	Description string `json:"description" example:"Anything for the day job"`
+	Count       int    `json:"count" example:"14"`
+}
+
+type TagResponse struct {
+	Code    int     `json:"code" example:"200"`
+	Error   bool    `json:"error" example:"false"`
+	Message string  `json:"message" example:"Successfully update"`
+	Data    TagData `json:"data"`
+}
+
+type ListTagResponse struct {
+	Code    int       `json:"code" example:"200"`
+	Error   bool      `json:"error" example:"false"`
+	Message string    `json:"message" example:"Successfully fetch"`
+	Data    []TagData `json:"data"`
+}
+
+type TagUpdateRequest struct {
+	Name        string `json:"name,omitempty" example:"work"`
+	Color       string `json:"color,omitempty" example:"#1e88e5"`
+	Description string `json:"description,omitempty" example:"Anything for the day job"`
+}
+
+type TagMergeRequest struct {
+	Sources []string `json:"sources" example:"wrk,job"`
+	Target  string   `json:"target" example:"work"`
+}
+
+type TemplateTask struct {
+	Title       string         `json:"title" example:"Release {{version}}"`
+	Description string         `json:"description" example:"Ship version {{version}} to production"`
+	Tags        []string       `json:"tags" example:"release"`
+	Checklist   []string       `json:"checklist,omitempty" example:"Bump version to {{version}},Update changelog"`
+	DueOffset   int            `json:"due_offset" example:"7"`
+	Subtasks    []TemplateTask `json:"subtasks,omitempty"`
+}
+
+type TemplateRequest struct {
+	ID          int            `json:"id,omitempty" example:"4"`
+	Name        string         `json:"name" example:"Release checklist"`
+	Title       string         `json:"title" example:"Release {{version}}"`
+	Description string         `json:"description" example:"Ship version {{version}} to production"`
+	Tags        []string       `json:"tags" example:"release"`
+	Checklist   []string       `json:"checklist,omitempty" example:"Bump version to {{version}},Update changelog"`
+	DueOffset   int            `json:"due_offset" example:"7"`
+	Subtasks    []TemplateTask `json:"subtasks,omitempty"`
+}
+
+type TemplateResponse struct {
+	ID          int            `json:"id" example:"4"`
+	Name        string         `json:"name" example:"Release checklist"`
+	Title       string         `json:"title" example:"Release {{version}}"`
+	Description string         `json:"description" example:"Ship version {{version}} to production"`
+	Tags        []string       `json:"tags" example:"release"`
+	Checklist   []string       `json:"checklist,omitempty" example:"Bump version to {{version}},Update changelog"`
+	DueOffset   int            `json:"due_offset" example:"7"`
+	Subtasks    []TemplateTask `json:"subtasks,omitempty"`
+	CreatedAt   string         `json:"created_at" example:"2025-03-01T09:30:00Z"`
+	UpdatedAt   string         `json:"updated_at,omitempty" example:"2025-03-02T10:00:00Z"`
+}
+
+type GetTemplateResponse struct {
+	Code    int              `json:"code" example:"200"`
+	Error   bool             `json:"error" example:"false"`
+	Message string           `json:"message" example:"Successfully fetch"`
+	Data    TemplateResponse `json:"data"`
+}
+
+type CreateTemplateResponse struct {
+	Code    int            `json:"code" example:"201"`
+	Error   bool           `json:"error" example:"false"`
+	Message string         `json:"message" example:"Successfully create"`
+	Data    createResponse `json:"data"`
+}
+
+type ListTemplateResponse struct {
+	Code    int                `json:"code" example:"200"`
+	Error   bool               `json:"error" example:"false"`
+	Message string             `json:"message" example:"Successfully fetch"`
+	Offset  int                `json:"offset" example:"0"`
+	Limit   int                `json:"limit" example:"20"`
+	Count   int                `json:"count" example:"1"`
+	Total   int                `json:"total" example:"1"`
+	Results []TemplateResponse `json:"data"`
+}
+
+type InstantiateRequest struct {
+	Variables map[string]string `json:"variables"`
+	StartDate string            `json:"start_date,omitempty" example:"2025-04-01"`
+	ProjectID int               `json:"project_id,omitempty" example:"3"`
+}
+
+type instantiateData struct {
+	Id            int    `json:"id" example:"12"`
+	Ids           []int  `json:"ids" example:"12,13,14"`
+	UndoToken     string `json:"undo_token" example:"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"`
+	UndoExpiresAt string `json:"undo_expires_at" example:"2025-04-01T12:00:30Z"`
+}
+
+type InstantiateResponse struct {
+	Code    int             `json:"code" example:"201"`
+	Error   bool            `json:"error" example:"false"`
+	Message string          `json:"message" example:"Successfully create"`
+	Data    instantiateData `json:"data"`
+}
+
+type TimeEntryRequest struct {
+	StartedAt string `json:"started_at" example:"2025-04-01T09:00:00Z"`
+	EndedAt   string `json:"ended_at" example:"2025-04-01T10:30:00Z"`
+	Note      string `json:"note,omitempty" example:"Call with the client"`
+}
+
+type TimeEntryData struct {
+	ID        int    `json:"id" example:"31"`
+	TodoID    int    `json:"todo_id" example:"12"`
+	UserID    string `json:"user_id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	StartedAt string `json:"started_at" example:"2025-04-01T09:00:00Z"`
+	EndedAt   string `json:"ended_at,omitempty" example:"2025-04-01T10:30:00Z"`
+	Minutes   int    `json:"minutes" example:"90"`
+	Note      string `json:"note,omitempty" example:"Call with the client"`
+}
+
+type TimeEntryResponse struct {
+	Code    int           `json:"code" example:"200"`
+	Error   bool          `json:"error" example:"false"`
+	Message string        `json:"message" example:"Successfully stop"`
+	Data    TimeEntryData `json:"data"`
+}
+
+type CreateTimeEntryResponse struct {
+	Code    int           `json:"code" example:"201"`
+	Error   bool          `json:"error" example:"false"`
+	Message string        `json:"message" example:"Successfully create"`
+	Data    TimeEntryData `json:"data"`
+}
+
+type ListTimeEntryResponse struct {
+	Code    int             `json:"code" example:"200"`
+	Error   bool            `json:"error" example:"false"`
+	Message string          `json:"message" example:"Successfully fetch"`
+	Offset  int             `json:"offset" example:"0"`
+	Limit   int             `json:"limit" example:"20"`
+	Count   int             `json:"count" example:"1"`
+	Total   int             `json:"total" example:"1"`
+	Results []TimeEntryData `json:"data"`
+}
+
+type TimeReportRow struct {
+	ID              int    `json:"id,omitempty" example:"3"`
+	Name            string `json:"name" example:"Website redesign"`
+	Minutes         int    `json:"minutes" example:"540"`
+	EstimateMinutes int    `json:"estimate_minutes,omitempty" example:"600"`
+}
+
+type TimeReportData struct {
+	From         string          `json:"from" example:"2025-04-01"`
+	To           string          `json:"to" example:"2025-04-30"`
+	GroupBy      string          `json:"group_by" example:"project"`
+	TotalMinutes int             `json:"total_minutes" example:"540"`
+	Rows         []TimeReportRow `json:"rows"`
+}
+
+type TimeReportResponse struct {
+	Code    int            `json:"code" example:"200"`
+	Error   bool           `json:"error" example:"false"`
+	Message string         `json:"message" example:"Successfully fetch"`
+	Data    TimeReportData `json:"data"`
+}
+
+type StatsBucket struct {
+	Start     string `json:"start" example:"2025-03-31"`
+	Created   int    `json:"created" example:"9"`
+	Completed int    `json:"completed" example:"7"`
+}
+
+type TagStats struct {
+	Tag       string `json:"tag" example:"work"`
+	Created   int    `json:"created" example:"5"`
+	Completed int    `json:"completed" example:"4"`
+	Overdue   int    `json:"overdue" example:"1"`
+}
+
+type Streak struct {
+	Current int `json:"current" example:"3"`
+	Longest int `json:"longest" example:"12"`
+}
+
+type StatsData struct {
+	From                 string        `json:"from" example:"2025-03-10"`
+	To                   string        `json:"to" example:"2025-04-02"`
+	Interval             string        `json:"interval" example:"week"`
+	Open                 int           `json:"open" example:"14"`
+	Overdue              int           `json:"overdue" example:"2"`
+	AverageLeadTimeHours float64       `json:"average_lead_time_hours" example:"31.5"`
+	Buckets              []StatsBucket `json:"buckets"`
+	Tags                 []TagStats    `json:"tags"`
+	Streak               Streak        `json:"streak"`
+}
+
+type StatsResponse struct {
+	Code    int       `json:"code" example:"200"`
+	Error   bool      `json:"error" example:"false"`
+	Message string    `json:"message" example:"Successfully fetch"`
+	Data    StatsData `json:"data"`
+}
+
+type AgendaItem struct {
+	TodoResponse
+	Occurrence string `json:"occurrence" example:"2025-04-01"`
+	Overdue    bool   `json:"overdue,omitempty" example:"false"`
+}
+
+type AgendaDay struct {
+	Date  string       `json:"date" example:"2025-04-01"`
+	Todos []AgendaItem `json:"todos"`
+}
+
+type AgendaData struct {
+	From     string      `json:"from" example:"2025-04-01"`
+	To       string      `json:"to" example:"2025-04-07"`
+	Today    string      `json:"today" example:"2025-04-01"`
+	Timezone string      `json:"timezone" example:"Europe/Berlin"`
+	Days     []AgendaDay `json:"days"`
+}
+
+type AgendaResponse struct {
+	Code    int        `json:"code" example:"200"`
+	Error   bool       `json:"error" example:"false"`
+	Message string     `json:"message" example:"Successfully fetch"`
+	Data    AgendaData `json:"data"`
+}
+
+type Profile struct {
+	ID       string `json:"id" example:"818bdf4c-0b94-4dcb-96be-12a31f073ac2"`
+	Username string `json:"username" example:"john_doe"`
+	Timezone string `json:"timezone" example:"Europe/Berlin"`
+	Email    string `json:"email,omitempty" example:"john@example.com"`
+}
+
+type ProfileUpdateRequest struct {
+	Timezone        string `json:"timezone,omitempty" example:"Europe/Berlin"`
+	Email           string `json:"email,omitempty" example:"john@example.com"`
+	CurrentPassword string `json:"current_password,omitempty" example:"Secret123"`
+}
+
+type AccountDeletionRequest struct {
+	Password string `json:"password" example:"Secret123"`
+}
+
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password" example:"Secret123"`
+	NewPassword     string `json:"new_password" example:"Better456"`
+}
+
+type PasswordChangeResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Error   bool   `json:"error" example:"false"`
+	Message string `json:"message" example:"Password successfully changed"`
+}
+
+type PasswordForgotRequest struct {
+	Username string `json:"username" example:"john_doe"`
+}
+
+type PasswordForgotResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Error   bool   `json:"error" example:"false"`
+	Message string `json:"message" example:"If the account exists and has an email address, a password reset link has been sent"`
+}
+
+type PasswordResetRequest struct {
+	Token       string `json:"token" example:"q3v9Xc0bTn8uRk2mW5yLzA1sD4fG7hJ6eP0oI9uY8tR"`
+	NewPassword string `json:"new_password" example:"Better456"`
+}
+
+type PasswordResetResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Error   bool   `json:"error" example:"false"`
+	Message string `json:"message" example:"Password successfully reset"`
+}
+
+type ProfileResponse struct {
+	Code    int     `json:"code" example:"200"`
+	Error   bool    `json:"error" example:"false"`
+	Message string  `json:"message" example:"Successfully fetch"`
+	Data    Profile `json:"data"`
+}
+
+type CalendarFeedRequest struct {
+	Name string `json:"name" example:"Thunderbird"`
+}
+
+type CalendarFeed struct {
+	ID         int    `json:"id" example:"4"`
+	Name       string `json:"name" example:"Thunderbird"`
+	CreatedAt  string `json:"created_at" example:"2025-04-01T12:00:00Z"`
+	LastUsedAt string `json:"last_used_at,omitempty" example:"2025-04-02T06:30:00Z"`
+}
+
+type CreatedCalendarFeed struct {
+	CalendarFeed
+	Token string `json:"token" example:"kQ2m7Zr0c1xW5bT9yV3nH8pL4sD6fA1gJ0eU2iO7qRk"`
+	URL   string `json:"url" example:"https://todo.example.com/api/v1/feeds/kQ2m7Zr0c1xW5bT9yV3nH8pL4sD6fA1gJ0eU2iO7qRk.ics"`
+}
+
+type CreateCalendarFeedResponse struct {
+	Code    int                 `json:"code" example:"201"`
+	Error   bool                `json:"error" example:"false"`
+	Message string              `json:"message" example:"Successfully create"`
+	Data    CreatedCalendarFeed `json:"data"`
+}
+
+type ListCalendarFeedResponse struct {
+	Code    int            `json:"code" example:"200"`
+	Error   bool           `json:"error" example:"false"`
+	Message string         `json:"message" example:"Successfully fetch"`
+	Data    []CalendarFeed `json:"data"`
+}
+
+type ImportLineError struct {
+	Line   int      `json:"line" example:"3"`
+	Errors []string `json:"errors" example:"Field 'title' must be at least 3 characters"`
+}
+
+type ImportData struct {
+	DryRun        bool              `json:"dry_run" example:"false"`
+	Total         int               `json:"total" example:"2"`
+	Imported      int               `json:"imported" example:"2"`
+	IDs           []int             `json:"ids,omitempty" example:"41,42"`
+	Errors        []ImportLineError `json:"errors,omitempty"`
+	UndoToken     string            `json:"undo_token,omitempty" example:"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"`
+	UndoExpiresAt string            `json:"undo_expires_at,omitempty" example:"2025-04-01T12:00:30Z"`
+}
+
+type ImportResponse struct {
+	Code    int        `json:"code" example:"201"`
+	Error   bool       `json:"error" example:"false"`
+	Message string     `json:"message" example:"Successfully import"`
+	Data    ImportData `json:"data"`
+}
+
+type ImportFailedResponse struct {
+	Code    int        `json:"code" example:"422"`
+	Error   bool       `json:"error" example:"true"`
+	Message string     `json:"message" example:"Import failed"`
+	Data    ImportData `json:"data"`
+}
+
+type QuickAddRequest struct {
+	Text string `json:"text" example:"Pay rent every month on the 1st #finance !high"`
+}
+
+type QuickAddData struct {
+	Preview       bool         `json:"preview" example:"false"`
+	Todo          TodoResponse `json:"todo"`
+	ID            int          `json:"id,omitempty" example:"42"`
+	UndoToken     string       `json:"undo_token,omitempty" example:"6f1c2f9e-8a4b-4f7e-9a51-3c0e4d2b7a10"`
+	UndoExpiresAt string       `json:"undo_expires_at,omitempty" example:"2025-04-01T12:00:30Z"`
+}
+
+type QuickAddResponse struct {
+	Code    int          `json:"code" example:"201"`
+	Error   bool         `json:"error" example:"false"`
+	Message string       `json:"message" example:"Successfully create"`
+	Data    QuickAddData `json:"data"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+// Tag describes a tag name used by a user. Count is the number of the user's todos
+// carrying it; a tag with metadata but no todos has a count of zero.
+type Tag struct {
+	Name        string  `json:"name"`
+	Color       *string `json:"color"`
+	Description string  `json:"description"`
+	Count       int     `json:"count"`
+}
+
+// TagUpdate renames a tag or changes its metadata. Fields left out stay as they are.
+type TagUpdate struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Color       *string `json:"color" validate:"omitempty,hexcolor,len=7"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+}
+
+// TagMerge replaces the source tags with the target on every todo.
+type TagMerge struct {
+	Sources []string `json:"sources" validate:"required,min=1,dive,required,max=50"`
+	Target  string   `json:"target" validate:"required,max=50"`
+}
+
+// TagChange is the tags of one todo before and after a rename or merge.
+type TagChange struct {
+	TodoID int
+	Before []string
+	After  []string
+}
+
+func (t *TagUpdate) Validate() []string {
+	return validateStruct(t)
+}
+
+func (m *TagMerge) Validate() []string {
+	return validateStruct(m)
+}
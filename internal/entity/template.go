@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxTemplateDepth = 3
+	maxTemplateTasks = 100
+)
+
+// templateVariable matches a {{name}} placeholder.
+var templateVariable = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// TemplateTask is a todo of a template. Text fields may contain {{name}} variables
+// and the due date is given in days after the start date of the instantiation.
+type TemplateTask struct {
+	Title       string         `json:"title" validate:"required,max=50"`
+	Description string         `json:"description" validate:"required"`
+	Tags        []string       `json:"tags"`
+	Checklist   []string       `json:"checklist,omitempty" validate:"omitempty,max=100,dive,required,max=200"`
+	DueOffset   int            `json:"due_offset" validate:"min=0"`
+	Subtasks    []TemplateTask `json:"subtasks,omitempty" validate:"omitempty,dive"`
+}
+
+// Template is a reusable tree of todos. The root task is stored inline.
+type Template struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" validate:"required,max=100"`
+	TemplateTask
+	UserID    uuid.UUID  `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Instantiation creates todos from a template. StartDate defaults to today, and
+// ProjectID and WorkspaceID apply to every created todo.
+type Instantiation struct {
+	Variables   map[string]string `json:"variables"`
+	StartDate   *Date             `json:"start_date"`
+	ProjectID   *int              `json:"project_id"`
+	WorkspaceID *int              `json:"-"`
+}
+
+// TemplateError reports a template that cannot be saved or instantiated.
+type TemplateError struct {
+	Messages []string
+}
+
+func (e *TemplateError) Error() string {
+	return strings.Join(e.Messages, ";")
+}
+
+// Validate checks the template fields and that the task tree is neither too deep
+// nor too large.
+func (t *Template) Validate() []string {
+	errs := validateStruct(t)
+	depth, count := t.TemplateTask.size()
+	if depth > maxTemplateDepth {
+		errs = append(errs, fmt.Sprintf("Subtasks must not be nested more than %d levels deep", maxTemplateDepth-1))
+	}
+	if count > maxTemplateTasks {
+		errs = append(errs, fmt.Sprintf("Template must not contain more than %d tasks", maxTemplateTasks))
+	}
+	return errs
+}
+
+func (t TemplateTask) size() (depth, count int) {
+	count = 1
+	for _, subtask := range t.Subtasks {
+		d, c := subtask.size()
+		depth = max(depth, d)
+		count += c
+	}
+	return depth + 1, count
+}
+
+// Render turns the task tree into todos, replacing variables and resolving due
+// dates against start. Every variable used must be given, and the resulting todos
+// must be valid.
+func (t TemplateTask) Render(instantiation Instantiation, start Date) (TodoTree, error) {
+	var messages []string
+	missing := map[string]bool{}
+	tree := t.render(instantiation, start, "", missing, &messages)
+
+	var names []string
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("Variable '%s' is not set", name))
+	}
+
+	if messages != nil {
+		return TodoTree{}, &TemplateError{Messages: messages}
+	}
+	return tree, nil
+}
+
+func (t TemplateTask) render(instantiation Instantiation, start Date, path string, missing map[string]bool,
+	messages *[]string) TodoTree {
+	substitute := func(s string) string {
+		return templateVariable.ReplaceAllStringFunc(s, func(match string) string {
+			name := templateVariable.FindStringSubmatch(match)[1]
+			value, ok := instantiation.Variables[name]
+			if !ok {
+				missing[name] = true
+			}
+			return value
+		})
+	}
+
+	dueDate := Date{start.AddDate(0, 0, t.DueOffset)}
+	todo := Todo{
+		Title:       substitute(t.Title),
+		Description: substitute(t.Description),
+		Tags:        []string{},
+		DueDate:     &dueDate,
+		ProjectID:   instantiation.ProjectID,
+		WorkspaceID: instantiation.WorkspaceID,
+	}
+	for _, tag := range t.Tags {
+		todo.Tags = append(todo.Tags, substitute(tag))
+	}
+	for _, item := range t.Checklist {
+		todo.Checklist = append(todo.Checklist, ChecklistItem{Text: substitute(item)})
+	}
+	for _, msg := range todo.Validate() {
+		if path != "" {
+			msg = path + ": " + msg
+		}
+		*messages = append(*messages, msg)
+	}
+
+	tree := TodoTree{Todo: todo}
+	for i, subtask := range t.Subtasks {
+		subtaskPath := fmt.Sprintf("subtasks[%d]", i)
+		if path != "" {
+			subtaskPath = path + "." + subtaskPath
+		}
+		tree.Subtasks = append(tree.Subtasks, subtask.render(instantiation, start, subtaskPath, missing, messages))
+	}
+	return tree
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+const maxTimeEntryDuration = 24 * time.Hour
+
+// maxReportDays limits how long a time report range can be.
+const maxReportDays = 366
+
+type TimeReportGrouping string
+
+const (
+	TimeReportByTodo    TimeReportGrouping = "todo"
+	TimeReportByTag     TimeReportGrouping = "tag"
+	TimeReportByProject TimeReportGrouping = "project"
+)
+
+// TimeEntry is time a user spent on a todo. Entries without EndedAt are running
+// timers, and their Minutes count up to now.
+type TimeEntry struct {
+	ID        int        `json:"id"`
+	TodoID    int        `json:"todo_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Minutes   int        `json:"minutes"`
+	Note      string     `json:"note,omitempty"`
+}
+
+// TimeEntryRequest logs time that was not tracked with a timer.
+type TimeEntryRequest struct {
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	EndedAt   time.Time `json:"ended_at" validate:"required"`
+	Note      string    `json:"note" validate:"max=500"`
+}
+
+func (r *TimeEntryRequest) Validate() []string {
+	errs := validateStruct(r)
+	if errs != nil {
+		return errs
+	}
+	if !r.EndedAt.After(r.StartedAt) {
+		return []string{"Field 'ended_at' must be after 'started_at'"}
+	}
+	if r.EndedAt.Sub(r.StartedAt) > maxTimeEntryDuration {
+		return []string{"Time entry must not be longer than 24 hours"}
+	}
+	if r.EndedAt.After(time.Now()) {
+		return []string{"Field 'ended_at' must not be in the future"}
+	}
+	return nil
+}
+
+// TimeReportRequest selects the entries started between From and To, both
+// inclusive, and how to group them.
+type TimeReportRequest struct {
+	From    Date
+	To      Date
+	GroupBy TimeReportGrouping
+}
+
+func (r *TimeReportRequest) Validate() []string {
+	var errs []string
+	switch r.GroupBy {
+	case TimeReportByTodo, TimeReportByTag, TimeReportByProject:
+	default:
+		errs = append(errs, "Field 'group_by' must be one of: todo tag project")
+	}
+	if r.To.Before(r.From.Time) {
+		errs = append(errs, "Field 'to' must not be before 'from'")
+	} else if r.To.Sub(r.From.Time) >= maxReportDays*24*time.Hour {
+		errs = append(errs, fmt.Sprintf("Report must not cover more than %d days", maxReportDays))
+	}
+	return errs
+}
+
+// TimeReportRow is the time spent on one todo, tag or project. ID is set for todos
+// and projects; the row of todos outside any project has no ID and an empty name.
+type TimeReportRow struct {
+	ID              *int   `json:"id,omitempty"`
+	Name            string `json:"name"`
+	Minutes         int    `json:"minutes"`
+	EstimateMinutes *int   `json:"estimate_minutes,omitempty"`
+}
+
+// TimeReport sums the user's time entries. A todo with several tags counts towards
+// each of them, so tag rows may add up to more than TotalMinutes.
+type TimeReport struct {
+	From         Date               `json:"from"`
+	To           Date               `json:"to"`
+	GroupBy      TimeReportGrouping `json:"group_by"`
+	TotalMinutes int                `json:"total_minutes"`
+	Rows         []TimeReportRow    `json:"rows"`
+}
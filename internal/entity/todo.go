@@ -1,24 +1,91 @@
 package entity
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"reflect"
 	"strings"
+	"time"
 )
 
 type Todo struct {
-	ID          int      `json:"id" validate:"omitempty"`
-	Title       string   `json:"title" validate:"required,min=3"`
-	Description string   `json:"description" validate:"required"`
-	Tags        []string `json:"tags" validate:"required"`
-	DueDate     *Date    `json:"due_date" validate:"required"`
+	ID          int        `json:"id" validate:"omitempty"`
+	Title       string     `json:"title" validate:"required,min=3"`
+	Description string     `json:"description" validate:"required"`
+	Tags        []string   `json:"tags" validate:"required"`
+	DueDate     *Date      `json:"due_date" validate:"required"`
+	ProjectID   *int       `json:"project_id,omitempty"`
+	WorkspaceID *int       `json:"workspace_id,omitempty"`
+	AssigneeID  *uuid.UUID `json:"assignee_id,omitempty"`
+	ParentID    *int       `json:"parent_id,omitempty"`
+	Checklist   Checklist  `json:"checklist,omitempty" validate:"omitempty,max=100,dive"`
+	// EstimateMinutes is how long the todo is expected to take.
+	EstimateMinutes *int        `json:"estimate_minutes,omitempty" validate:"omitempty,gte=0"`
+	Recurrence      *Recurrence `json:"recurrence,omitempty"`
+	Completed       bool        `json:"completed"`
+	CompletedAt     *time.Time  `json:"completed_at,omitempty"`
+	CreatedAt       *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time  `json:"updated_at,omitempty"`
+	OwnerID         uuid.UUID   `json:"-"`
 }
 
 type Filters struct {
-	DueTime *Date
-	Tags    []string
+	DueTime     *Date
+	Tags        []string
+	ProjectID   *int
+	WorkspaceID *int
+	AssigneeID  *uuid.UUID
+	ParentID    *int
+}
+
+// ChecklistItem is one step of a todo's checklist.
+type ChecklistItem struct {
+	Text string `json:"text" validate:"required,max=200"`
+	Done bool   `json:"done"`
+}
+
+// Checklist is stored as a JSON array in the todos table.
+type Checklist []ChecklistItem
+
+func (c *Checklist) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case nil:
+		*c = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Checklist", v)
+	}
+}
+
+func (c Checklist) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c)
+}
+
+// TodoTree is a todo together with the subtasks to create under it.
+type TodoTree struct {
+	Todo     Todo
+	Subtasks []TodoTree
+}
+
+// AssigneeRequest assigns a todo to a user, or unassigns it when AssigneeID is null.
+type AssigneeRequest struct {
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+}
+
+// CompletionRequest marks a todo as done or reopens it.
+type CompletionRequest struct {
+	Completed bool `json:"completed"`
 }
 
 func (t *Todo) Validate() []string {
@@ -43,6 +110,10 @@ func (t *Todo) Validate() []string {
 				msg = fmt.Sprintf("Field '%s' is required", err.Field())
 			case "min":
 				msg = fmt.Sprintf("Filed '%s' must be least %s characters", err.Field(), err.Param())
+			case "oneof":
+				msg = fmt.Sprintf("Field '%s' must be one of: %s", err.Field(), err.Param())
+			case "gte":
+				msg = fmt.Sprintf("Field '%s' must not be negative", err.Field())
 			default:
 				msg = fmt.Sprintf("Field %s failled validation on %s", err.Field(), err.Tag())
 			}
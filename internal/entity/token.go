@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// RefreshToken is a stored refresh token. Tokens rotated from one another share a
+// family; ParentID is the token this one replaced, nil for the first of a login.
+type RefreshToken struct {
+	ID        int
+	UserID    uuid.UUID
+	FamilyID  uuid.UUID
+	ParentID  *int
+	ExpiresAt time.Time
+	RotatedAt *time.Time
+}
+
+// Session is a login of a user on one device. It lasts for as long as the refresh
+// tokens rotated from the login, which share its ID as their family, or, for an app
+// password, until it is revoked.
+type Session struct {
+	ID          uuid.UUID `json:"id"`
+	DeviceName  string    `json:"device_name"`
+	UserAgent   string    `json:"user_agent"`
+	IP          string    `json:"ip"`
+	AppPassword bool      `json:"app_password"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+	// Current marks the session the request listing sessions was made with.
+	Current bool `json:"current"`
+}
+
+// SessionClient describes the client a session is started from.
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+// AppPasswordRequest names the client an app password is created for.
+type AppPasswordRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+func (a *AppPasswordRequest) Validate() []string {
+	return validateStruct(a)
+}
+
+// AppPassword is a newly created app password. The password is only ever shown here;
+// the session it signs in to is listed and revoked like any other.
+type AppPassword struct {
+	SessionID uuid.UUID `json:"session_id"`
+	Name      string    `json:"name"`
+	Password  string    `json:"password"`
+}
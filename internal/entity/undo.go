@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Undo is returned by mutating todo requests. Posting the token to /undo reverts
+// the change until the token expires.
+type Undo struct {
+	Token     uuid.UUID `json:"undo_token"`
+	ExpiresAt time.Time `json:"undo_expires_at"`
+}
+
+// UndoRecord is what the server keeps to revert a change.
+type UndoRecord struct {
+	Token     uuid.UUID
+	UserID    uuid.UUID
+	TodoID    int
+	OwnerID   uuid.UUID
+	Operation HistoryOperation
+	// Before is the todo as it was before the change, or nil if it was created.
+	Before *Todo
+	// UpdatedAt is when the change was made, or nil if the todo was deleted. The
+	// undo is refused once the todo has been modified again.
+	UpdatedAt *time.Time
+	ExpiresAt time.Time
+}
@@ -16,6 +16,34 @@ type User struct {
 	ID           uuid.UUID
 	Username     string
 	PasswordHash string
+	Timezone     string
+	// Email receives password reset links; it is empty until the user sets it.
+	Email string
+}
+
+// Profile is the part of a user shown to the user themselves.
+type Profile struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Timezone string    `json:"timezone"`
+	Email    string    `json:"email,omitempty"`
+}
+
+func (u User) Profile() Profile {
+	return Profile{ID: u.ID, Username: u.Username, Timezone: u.Timezone, Email: u.Email}
+}
+
+// ProfileUpdate changes the settings of a user. Fields left out stay unchanged.
+// Changing the email address needs the current password, as reset links are sent
+// there.
+type ProfileUpdate struct {
+	Timezone        *string `json:"timezone" validate:"omitempty,timezone"`
+	Email           *string `json:"email" validate:"omitempty,max=254,email"`
+	CurrentPassword string  `json:"current_password" validate:"required_with=Email"`
+}
+
+func (u *ProfileUpdate) Validate() []string {
+	return validateStruct(u)
 }
 
 type UserLogin struct {
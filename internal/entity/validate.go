@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+)
+
+// validateStruct runs the struct's validate tags and turns failures into
+// client-facing messages that use the JSON field names.
+func validateStruct(s any) []string {
+	validate := validator.New()
+	if err := validate.RegisterValidation("passwordstrength", passwordStrengthValidation); err != nil {
+		return []string{err.Error()}
+	}
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	err := validate.Struct(s)
+	if err != nil {
+		var validationErrors validator.ValidationErrors
+		errors.As(err, &validationErrors)
+		var errList []string
+		for _, err := range validationErrors {
+			var msg string
+			switch err.Tag() {
+			case "required", "required_with":
+				msg = fmt.Sprintf("Field '%s' is required", err.Field())
+			case "min":
+				msg = fmt.Sprintf("Field '%s' must be at least %s%s", err.Field(), err.Param(), lengthUnit(err))
+			case "max":
+				msg = fmt.Sprintf("Field '%s' must not exceed %s%s", err.Field(), err.Param(), lengthUnit(err))
+			case "oneof":
+				msg = fmt.Sprintf("Field '%s' must be one of: %s", err.Field(), err.Param())
+			case "email":
+				msg = fmt.Sprintf("Field '%s' must be an email address", err.Field())
+			case "passwordstrength":
+				msg = fmt.Sprintf("Field '%s' must contain at least one letter and one digit", err.Field())
+			case "timezone":
+				msg = fmt.Sprintf("Field '%s' must be an IANA time zone such as Europe/Berlin", err.Field())
+			default:
+				msg = fmt.Sprintf("Field %s failed validation on %s", err.Field(), err.Tag())
+			}
+			errList = append(errList, msg)
+		}
+		return errList
+	}
+	return nil
+}
+
+// ValidationError reports input that is only found to be invalid by the service,
+// for example because the check depends on stored data.
+type ValidationError struct {
+	Messages []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Messages, ";")
+}
+
+func lengthUnit(err validator.FieldError) string {
+	if err.Kind() == reflect.String {
+		return " characters"
+	}
+	return ""
+}
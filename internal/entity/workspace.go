@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"
+	WorkspaceRoleMember WorkspaceRole = "member"
+	WorkspaceRoleGuest  WorkspaceRole = "guest"
+)
+
+const (
+	DefaultInvitationExpire = 72 * time.Hour
+	MaxInvitationExpire     = 30 * 24 * time.Hour
+)
+
+// Permission maps a workspace role onto the permission it grants over the
+// todos and projects of the workspace.
+func (r WorkspaceRole) Permission() Permission {
+	switch r {
+	case WorkspaceRoleAdmin:
+		return PermissionOwner
+	case WorkspaceRoleMember:
+		return PermissionEditor
+	case WorkspaceRoleGuest:
+		return PermissionViewer
+	default:
+		return ""
+	}
+}
+
+type Workspace struct {
+	ID   int           `json:"id" validate:"omitempty"`
+	Name string        `json:"name" validate:"required,max=100"`
+	Role WorkspaceRole `json:"role,omitempty" validate:"omitempty"`
+}
+
+// ActiveWorkspace is the workspace selected for the current request.
+type ActiveWorkspace struct {
+	ID   int
+	Role WorkspaceRole
+}
+
+type WorkspaceMember struct {
+	UserID   uuid.UUID     `json:"user_id"`
+	Username string        `json:"username"`
+	Role     WorkspaceRole `json:"role"`
+	JoinedAt time.Time     `json:"joined_at"`
+}
+
+type MemberRoleRequest struct {
+	Role WorkspaceRole `json:"role" validate:"required,oneof=admin member guest"`
+}
+
+type Invitation struct {
+	ID          int           `json:"id"`
+	WorkspaceID int           `json:"workspace_id"`
+	Role        WorkspaceRole `json:"role"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	Token       string        `json:"token,omitempty"`
+}
+
+type InvitationRequest struct {
+	Role           WorkspaceRole `json:"role" validate:"required,oneof=admin member guest"`
+	ExpiresInHours int           `json:"expires_in_hours" validate:"omitempty,min=1,max=720"`
+}
+
+func (w *Workspace) Validate() []string {
+	return validateStruct(w)
+}
+
+func (m *MemberRoleRequest) Validate() []string {
+	return validateStruct(m)
+}
+
+func (i *InvitationRequest) Validate() []string {
+	return validateStruct(i)
+}
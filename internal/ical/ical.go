@@ -0,0 +1,158 @@
+// Package ical writes todos as RFC 5545 iCalendar VTODO components.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	productID    = "-//go-todo-api//Todos//EN"
+	uidDomain    = "go-todo-api"
+	maxLineBytes = 75
+	dateFormat   = "20060102"
+	utcFormat    = "20060102T150405Z"
+)
+
+// UID identifies a todo across exports, so clients update it instead of adding a
+// copy when a feed is refreshed.
+func UID(todoID int) string {
+	return fmt.Sprintf("todo-%d@%s", todoID, uidDomain)
+}
+
+// WriteCalendar writes a calendar named name with one VTODO per todo. stamp is
+// used as DTSTAMP for todos that were never updated.
+func WriteCalendar(w io.Writer, name string, todos []entity.Todo, stamp time.Time) error {
+	cw := &calendarWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + productID)
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	if name != "" {
+		cw.line("X-WR-CALNAME:" + escapeText(name))
+	}
+	for _, todo := range todos {
+		cw.todo(todo, UID(todo.ID), stamp)
+	}
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+// WriteObject writes a calendar object holding a single todo with the given UID,
+// as served for a CalDAV resource.
+func WriteObject(w io.Writer, todo entity.Todo, uid string, stamp time.Time) error {
+	cw := &calendarWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + productID)
+	cw.todo(todo, uid, stamp)
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+type calendarWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (cw *calendarWriter) todo(todo entity.Todo, uid string, stamp time.Time) {
+	if todo.UpdatedAt != nil {
+		stamp = *todo.UpdatedAt
+	}
+
+	cw.line("BEGIN:VTODO")
+	cw.line("UID:" + escapeText(uid))
+	cw.line("DTSTAMP:" + formatUTC(stamp))
+	if todo.CreatedAt != nil {
+		cw.line("CREATED:" + formatUTC(*todo.CreatedAt))
+	}
+	if todo.UpdatedAt != nil {
+		cw.line("LAST-MODIFIED:" + formatUTC(*todo.UpdatedAt))
+	}
+	cw.line("SUMMARY:" + escapeText(todo.Title))
+	if todo.Description != "" {
+		cw.line("DESCRIPTION:" + escapeText(todo.Description))
+	}
+	if todo.DueDate != nil {
+		// A recurrence set starts at DTSTART, so recurring todos repeat their due date there.
+		if todo.Recurrence != nil {
+			cw.line("DTSTART;VALUE=DATE:" + todo.DueDate.Format(dateFormat))
+		}
+		cw.line("DUE;VALUE=DATE:" + todo.DueDate.Format(dateFormat))
+		if todo.Recurrence != nil {
+			cw.line("RRULE:" + rrule(*todo.Recurrence))
+		}
+	}
+	if todo.Completed {
+		cw.line("STATUS:COMPLETED")
+		if todo.CompletedAt != nil {
+			cw.line("COMPLETED:" + formatUTC(*todo.CompletedAt))
+		}
+	} else {
+		cw.line("STATUS:NEEDS-ACTION")
+	}
+	if len(todo.Tags) > 0 {
+		categories := make([]string, len(todo.Tags))
+		for i, tag := range todo.Tags {
+			categories[i] = escapeText(tag)
+		}
+		cw.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+	if todo.ParentID != nil {
+		cw.line("RELATED-TO:" + UID(*todo.ParentID))
+	}
+	cw.line("END:VTODO")
+}
+
+// line writes a content line, folding it after 75 octets without splitting a
+// UTF-8 sequence.
+func (cw *calendarWriter) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	var b strings.Builder
+	limit := maxLineBytes
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxLineBytes - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	_, cw.err = io.WriteString(cw.w, b.String())
+}
+
+func rrule(r entity.Recurrence) string {
+	rule := "FREQ=" + strings.ToUpper(string(r.Frequency))
+	if r.Interval > 1 {
+		rule += fmt.Sprintf(";INTERVAL=%d", r.Interval)
+	}
+	if r.Until != nil {
+		rule += ";UNTIL=" + r.Until.Format(dateFormat)
+	}
+	return rule
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format(utcFormat)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
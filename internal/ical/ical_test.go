@@ -0,0 +1,201 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	stamp := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2025, 3, 30, 8, 15, 0, 0, time.FixedZone("CEST", 2*60*60))
+	completedAt := time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC)
+	parentID := 1
+
+	todos := []entity.Todo{
+		{
+			ID:          7,
+			Title:       "Water plants",
+			Description: "Kitchen, balcony; then\nthe office",
+			Tags:        []string{"home", "weekly,chores"},
+			DueDate:     &entity.Date{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+			Recurrence: &entity.Recurrence{
+				Frequency: entity.RecurWeekly,
+				Interval:  2,
+				Until:     &entity.Date{Time: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+			},
+			UpdatedAt: &updatedAt,
+		},
+		{
+			ID:          8,
+			Title:       "Buy soil",
+			Tags:        []string{},
+			DueDate:     &entity.Date{Time: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)},
+			ParentID:    &parentID,
+			Completed:   true,
+			CompletedAt: &completedAt,
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteCalendar(&buf, "Todos", todos, stamp)
+	assert.NoError(t, err)
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//go-todo-api//Todos//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Todos",
+		"BEGIN:VTODO",
+		"UID:todo-7@go-todo-api",
+		"DTSTAMP:20250330T061500Z",
+		"LAST-MODIFIED:20250330T061500Z",
+		"SUMMARY:Water plants",
+		`DESCRIPTION:Kitchen\, balcony\; then\nthe office`,
+		"DTSTART;VALUE=DATE:20250402",
+		"DUE;VALUE=DATE:20250402",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20251231",
+		"STATUS:NEEDS-ACTION",
+		`CATEGORIES:home,weekly\,chores`,
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:todo-8@go-todo-api",
+		"DTSTAMP:20250401T120000Z",
+		"SUMMARY:Buy soil",
+		"DUE;VALUE=DATE:20250331",
+		"STATUS:COMPLETED",
+		"COMPLETED:20250331T180000Z",
+		"RELATED-TO:todo-1@go-todo-api",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestLineFolding(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &calendarWriter{w: &buf}
+	cw.line("SUMMARY:" + strings.Repeat("ä", 80))
+	assert.NoError(t, cw.err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	assert.Greater(t, len(lines), 1)
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), maxLineBytes)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("ä", 80), unfolded.String())
+}
+
+func TestParseObject(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		expected    entity.Todo
+		expectedUID string
+		expectedErr string
+	}{
+		{
+			name: "todo with folded lines and an alarm",
+			data: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"BEGIN:VTODO",
+				"UID:5b1c6a1e-client",
+				"SUMMARY:Water the plants in the kitchen and on the balcony before the he",
+				" atwave",
+				`DESCRIPTION:Use the green can\, not the red one\nThanks`,
+				"DUE;TZID=Europe/Berlin:20250402T170000",
+				"STATUS:COMPLETED",
+				`CATEGORIES:home,weekly\,chores`,
+				"CATEGORIES:garden",
+				"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=WE;UNTIL=20251231T235959Z",
+				"BEGIN:VALARM",
+				"ACTION:DISPLAY",
+				"DESCRIPTION:Reminder",
+				"END:VALARM",
+				"END:VTODO",
+				"END:VCALENDAR",
+			}, "\r\n"),
+			expected: entity.Todo{
+				Title:       "Water the plants in the kitchen and on the balcony before the heatwave",
+				Description: "Use the green can, not the red one\nThanks",
+				Tags:        []string{"home", "weekly,chores", "garden"},
+				DueDate:     &entity.Date{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+				Completed:   true,
+				Recurrence: &entity.Recurrence{
+					Frequency: entity.RecurWeekly,
+					Interval:  2,
+					Until:     &entity.Date{Time: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			expectedUID: "5b1c6a1e-client",
+		},
+		{
+			name:        "event instead of todo",
+			data:        "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			expectedErr: "invalid calendar data: only VTODO components are supported",
+		},
+		{
+			name:        "missing uid",
+			data:        "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:Nothing\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			expectedErr: "invalid calendar data: VTODO has no UID",
+		},
+		{
+			name:        "unsupported frequency",
+			data:        "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:1\r\nRRULE:FREQ=HOURLY\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			expectedErr: `invalid calendar data: unsupported FREQ "HOURLY"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todo, uid, err := ParseObject([]byte(tc.data))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, todo)
+			assert.Equal(t, tc.expectedUID, uid)
+		})
+	}
+}
+
+func TestWriteObjectRoundTrip(t *testing.T) {
+	todo := entity.Todo{
+		ID:          7,
+		Title:       "Pay rent; then relax",
+		Description: "Line one\nLine two, with \\ backslash",
+		Tags:        []string{"home", "money"},
+		DueDate:     &entity.Date{Time: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)},
+		Recurrence:  &entity.Recurrence{Frequency: entity.RecurMonthly},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteObject(&buf, todo, "client-uid", time.Now()))
+
+	parsed, uid, err := ParseObject(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "client-uid", uid)
+
+	todo.ID = 0
+	assert.Equal(t, todo, parsed)
+}
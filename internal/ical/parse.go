@@ -0,0 +1,232 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+// property is a content line split into its name, parameters and value.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseObject reads the VTODO of a calendar object and returns it as a todo along
+// with its UID. Only the properties the API stores are read. A todo without DUE
+// has no due date, and parts of an RRULE the API cannot express are ignored.
+func ParseObject(data []byte) (entity.Todo, string, error) {
+	props, err := vtodoProperties(unfold(string(data)))
+	if err != nil {
+		return entity.Todo{}, "", err
+	}
+
+	todo := entity.Todo{Tags: []string{}}
+	var uid string
+	for _, prop := range props {
+		switch prop.name {
+		case "UID":
+			uid = unescapeText(prop.value)
+		case "SUMMARY":
+			todo.Title = unescapeText(prop.value)
+		case "DESCRIPTION":
+			todo.Description = unescapeText(prop.value)
+		case "DUE":
+			due, err := parseDate(prop.value)
+			if err != nil {
+				return entity.Todo{}, "", err
+			}
+			todo.DueDate = &due
+		case "STATUS":
+			todo.Completed = strings.EqualFold(prop.value, "COMPLETED")
+		case "CATEGORIES":
+			for _, tag := range splitList(prop.value) {
+				if tag = strings.TrimSpace(unescapeText(tag)); tag != "" {
+					todo.Tags = append(todo.Tags, tag)
+				}
+			}
+		case "RRULE":
+			recurrence, err := parseRRule(prop.value)
+			if err != nil {
+				return entity.Todo{}, "", err
+			}
+			todo.Recurrence = &recurrence
+		}
+	}
+	if uid == "" {
+		return entity.Todo{}, "", fmt.Errorf("%w: VTODO has no UID", entity.ErrInvalidCalendarData)
+	}
+	return todo, uid, nil
+}
+
+// unfold joins folded content lines and drops empty ones.
+func unfold(data string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// vtodoProperties returns the properties of the single VTODO of a calendar, leaving
+// out those of nested components such as VALARM.
+func vtodoProperties(lines []string) ([]property, error) {
+	var props []property
+	var stack []string
+	todos := 0
+	for _, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			if len(stack) == 0 && component != "VCALENDAR" {
+				return nil, fmt.Errorf("%w: expected VCALENDAR", entity.ErrInvalidCalendarData)
+			}
+			if component == "VTODO" {
+				todos++
+			} else if component == "VEVENT" || component == "VJOURNAL" {
+				return nil, fmt.Errorf("%w: only VTODO components are supported", entity.ErrInvalidCalendarData)
+			}
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != strings.ToUpper(prop.value) {
+				return nil, fmt.Errorf("%w: unbalanced END:%s", entity.ErrInvalidCalendarData, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 2 && stack[1] == "VTODO" {
+				props = append(props, prop)
+			}
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: unterminated %s", entity.ErrInvalidCalendarData, stack[len(stack)-1])
+	}
+	if todos != 1 {
+		return nil, fmt.Errorf("%w: expected exactly one VTODO", entity.ErrInvalidCalendarData)
+	}
+	return props, nil
+}
+
+func parseLine(line string) (property, error) {
+	prop := property{params: map[string]string{}}
+	quoted := false
+	start := 0
+	var name string
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';' || c == ':':
+			part := line[start:i]
+			if name == "" {
+				name = part
+			} else if key, value, ok := strings.Cut(part, "="); ok {
+				prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+			start = i + 1
+			if c == ':' {
+				prop.name = strings.ToUpper(name)
+				prop.value = line[i+1:]
+				return prop, nil
+			}
+		}
+	}
+	return property{}, fmt.Errorf("%w: malformed line %q", entity.ErrInvalidCalendarData, line)
+}
+
+// parseDate reads the day of a DATE or DATE-TIME value. The day of a DATE-TIME is
+// taken as written, in whatever time zone the value is given.
+func parseDate(value string) (entity.Date, error) {
+	if len(value) < 8 {
+		return entity.Date{}, fmt.Errorf("%w: invalid date %q", entity.ErrInvalidCalendarData, value)
+	}
+	date, err := time.Parse(dateFormat, value[:8])
+	if err != nil {
+		return entity.Date{}, fmt.Errorf("%w: invalid date %q", entity.ErrInvalidCalendarData, value)
+	}
+	return entity.Date{Time: date}, nil
+}
+
+func parseRRule(value string) (entity.Recurrence, error) {
+	var recurrence entity.Recurrence
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch frequency := entity.RecurrenceFrequency(strings.ToLower(val)); frequency {
+			case entity.RecurDaily, entity.RecurWeekly, entity.RecurMonthly, entity.RecurYearly:
+				recurrence.Frequency = frequency
+			default:
+				return entity.Recurrence{}, fmt.Errorf("%w: unsupported FREQ %q", entity.ErrInvalidCalendarData, val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return entity.Recurrence{}, fmt.Errorf("%w: invalid INTERVAL %q", entity.ErrInvalidCalendarData, val)
+			}
+			recurrence.Interval = interval
+		case "UNTIL":
+			until, err := parseDate(val)
+			if err != nil {
+				return entity.Recurrence{}, err
+			}
+			recurrence.Until = &until
+		}
+	}
+	if recurrence.Frequency == "" {
+		return entity.Recurrence{}, fmt.Errorf("%w: RRULE has no FREQ", entity.ErrInvalidCalendarData)
+	}
+	return recurrence, nil
+}
+
+// splitList splits a value on the commas that are not escaped.
+func splitList(value string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package mailer
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"log/slog"
+)
+
+// LogMailer writes messages to the log instead of sending them, for development.
+// Message bodies may hold secrets such as reset links, so it must not be used in
+// production.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message Message) error {
+	logger := utils.SetupLogger(ctx, m.logger, "log_mailer", "Send")
+	logger.Info("Email not sent, logged instead", "to", message.To, "subject", message.Subject, "body", message.Body)
+	return nil
+}
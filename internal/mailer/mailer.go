@@ -0,0 +1,39 @@
+// Package mailer sends plain-text email, such as password reset links.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/config"
+	"log/slog"
+)
+
+const (
+	driverLog  = "log"
+	driverSMTP = "smtp"
+)
+
+// Message is a plain-text email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages.
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// New creates the mailer selected by the mail driver in the config.
+func New(cfg config.Config, logger *slog.Logger) (Mailer, error) {
+	switch cfg.Mail.Driver {
+	case driverLog, "":
+		return NewLogMailer(logger), nil
+	case driverSMTP:
+		smtp := cfg.Mail.SMTP
+		return NewSMTPMailer(smtp.Host, smtp.Port, smtp.Username, smtp.Password, cfg.Mail.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Mail.Driver)
+	}
+}
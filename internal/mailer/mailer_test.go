@@ -0,0 +1,75 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	var cfg config.Config
+	m, err := New(cfg, logger)
+	require.NoError(t, err)
+	assert.IsType(t, &LogMailer{}, m)
+
+	cfg.Mail.Driver = "smtp"
+	cfg.Mail.From = "Todo API <no-reply@example.com>"
+	cfg.Mail.SMTP.Host = "mail.example.com"
+	cfg.Mail.SMTP.Port = "587"
+	m, err = New(cfg, logger)
+	require.NoError(t, err)
+	require.IsType(t, &SMTPMailer{}, m)
+	assert.Equal(t, "mail.example.com:587", m.(*SMTPMailer).addr)
+	assert.Equal(t, "no-reply@example.com", m.(*SMTPMailer).sender)
+
+	cfg.Mail.Driver = "pigeon"
+	_, err = New(cfg, logger)
+	assert.Error(t, err)
+}
+
+func TestLogMailer(t *testing.T) {
+	var out bytes.Buffer
+	m := NewLogMailer(slog.New(slog.NewTextHandler(&out, nil)))
+
+	err := m.Send(context.Background(), Message{To: "john@example.com", Subject: "Hello", Body: "Reset here"})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "to=john@example.com")
+	assert.Contains(t, out.String(), `body="Reset here"`)
+}
+
+func TestSMTPMailer(t *testing.T) {
+	m := NewSMTPMailer("mail.example.com", "587", "", "", "Todo API <no-reply@example.com>")
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	m.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		return nil
+	}
+
+	err := m.Send(context.Background(), Message{
+		To:      "john@example.com",
+		Subject: "Reset your password\r\nBcc: eve@example.com",
+		Body:    "Hi,\nclick the link.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "mail.example.com:587", gotAddr)
+	assert.Equal(t, "no-reply@example.com", gotFrom)
+	assert.Equal(t, []string{"john@example.com"}, gotTo)
+	assert.Contains(t, string(gotMsg), "From: Todo API <no-reply@example.com>\r\n")
+	assert.Contains(t, string(gotMsg), "Subject: Reset your passwordBcc: eve@example.com\r\n")
+	assert.NotContains(t, string(gotMsg), "\r\nBcc:")
+	assert.Contains(t, string(gotMsg), "\r\n\r\nHi,\r\nclick the link.")
+
+	date := time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)
+	assert.Contains(t, string(m.format(Message{To: "a@example.com"}, date)), "Date: Wed, 02 Apr 2025 09:00:00 +0000\r\n")
+}
@@ -0,0 +1,59 @@
+package mailer
+
+import (
+	"context"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPMailer sends messages through an SMTP server, authenticating with PLAIN
+// auth when a username is set. net/smtp only allows that over TLS or to localhost.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	// from is the From header, such as "Todo API <no-reply@example.com>", and
+	// sender the bare address given to the server.
+	from   string
+	sender string
+	send   func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	sender := from
+	if address, err := mail.ParseAddress(from); err == nil {
+		sender = address.Address
+	}
+	return &SMTPMailer{addr: net.JoinHostPort(host, port), auth: auth, from: from, sender: sender, send: smtp.SendMail}
+}
+
+// Send does not honour ctx, as net/smtp has no way to cancel a delivery.
+func (m *SMTPMailer) Send(_ context.Context, message Message) error {
+	return m.send(m.addr, m.auth, m.sender, []string{message.To}, m.format(message, time.Now()))
+}
+
+// format builds the message with its headers. Header values are stripped of line
+// breaks so that they cannot add headers of their own.
+func (m *SMTPMailer) format(message Message, now time.Time) []byte {
+	header := func(value string) string {
+		return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + header(m.from) + "\r\n")
+	b.WriteString("To: " + header(message.To) + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", header(message.Subject)) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -23,7 +23,7 @@ func AuthMiddleware(tokenService service.TokenService) func(http.Handler) http.H
 				return
 			}
 
-			id, err := tokenService.ValidateAccessToken(parts[1])
+			id, err := tokenService.ValidateAccessToken(r.Context(), parts[1])
 			if err != nil {
 				entity.SendResponse[any](w, http.StatusUnauthorized, true, "Invalid or expired token", nil)
 				return
@@ -34,3 +34,26 @@ func AuthMiddleware(tokenService service.TokenService) func(http.Handler) http.H
 		})
 	}
 }
+
+// BasicAuthMiddleware authenticates with the username and an app password of the
+// user, for clients such as CalDAV apps that cannot obtain a Bearer token. The
+// account password is not accepted, so that every such client has a session that
+// can be revoked. Failures ask the client for credentials again.
+func BasicAuthMiddleware(tokenService service.TokenService, realm string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			if ok {
+				userID, err := tokenService.AuthenticateAppPassword(r.Context(), username, password)
+				if err == nil {
+					ctx := context.WithValue(r.Context(), "id", userID)
+					next.ServeHTTP(w, r.WithContext(ctx))
+					return
+				}
+			}
+
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+realm+`", charset="UTF-8"`)
+			http.Error(w, "Invalid username or password", http.StatusUnauthorized)
+		})
+	}
+}
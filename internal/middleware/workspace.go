@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/service"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils/contextutils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceMiddleware selects the active workspace from the {workspaceID} path parameter
+// or the X-Workspace-ID header and stores it in the request context. Requests without
+// either work on the user's personal space. It must run after AuthMiddleware.
+func WorkspaceMiddleware(workspaceService service.WorkspaceService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idStr := chi.URLParam(r, "workspaceID")
+			if idStr == "" {
+				idStr = r.Header.Get(WorkspaceHeader)
+			}
+			if idStr == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			workspaceID, err := strconv.Atoi(idStr)
+			if err != nil {
+				entity.SendResponse[any](w, http.StatusBadRequest, true, "Invalid workspace ID", nil)
+				return
+			}
+
+			userID, ok := r.Context().Value("id").(uuid.UUID)
+			if !ok {
+				entity.SendResponse[any](w, http.StatusUnauthorized, true, "User not authenticated", nil)
+				return
+			}
+
+			role, err := workspaceService.GetRole(r.Context(), userID, workspaceID)
+			if err != nil {
+				if errors.Is(err, entity.ErrWorkspaceNotFound) {
+					entity.SendResponse[any](w, http.StatusNotFound, true, "Workspace not found", nil)
+					return
+				}
+				entity.SendResponse[any](w, http.StatusInternalServerError, true, entity.ServerFailureMessage, nil)
+				return
+			}
+
+			ctx := contextutils.WithWorkspace(r.Context(), entity.ActiveWorkspace{ID: workspaceID, Role: role})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
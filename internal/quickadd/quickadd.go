@@ -0,0 +1,363 @@
+// Package quickadd turns a line of free text such as "Pay rent every month on the
+// 1st #finance !high" into a todo.
+package quickadd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+// priorities maps the !priority markers to the priority they stand for. Priorities
+// are stored as priority:<level> tags.
+var priorities = map[string]string{
+	"high": "high", "h": "high", "1": "high", "urgent": "high",
+	"medium": "medium", "med": "medium", "m": "medium", "2": "medium",
+	"low": "low", "l": "low", "3": "low",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// shortWeekdays are only read after a word such as "on" or "every", as they are
+// also ordinary words.
+var shortWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "tues": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January, "february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March, "april": time.April, "apr": time.April, "may": time.May,
+	"june": time.June, "jun": time.June, "july": time.July, "jul": time.July, "august": time.August,
+	"aug": time.August, "september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October, "november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var units = map[string]entity.RecurrenceFrequency{
+	"day": entity.RecurDaily, "days": entity.RecurDaily,
+	"week": entity.RecurWeekly, "weeks": entity.RecurWeekly,
+	"month": entity.RecurMonthly, "months": entity.RecurMonthly,
+	"year": entity.RecurYearly, "years": entity.RecurYearly,
+}
+
+var adverbs = map[string]entity.RecurrenceFrequency{
+	"daily": entity.RecurDaily, "weekly": entity.RecurWeekly, "monthly": entity.RecurMonthly,
+	"yearly": entity.RecurYearly, "annually": entity.RecurYearly,
+}
+
+// Parse reads a todo from text. Dates are relative to today, the current day in the
+// user's time zone. Words that are not understood make up the title, which also
+// describes the todo. Todos without a date are due today, recurring ones start
+// on their first occurrence.
+//
+// Understood are:
+//   - #tag for tags and !high, !medium or !low for a priority:<level> tag;
+//   - today, tomorrow, a weekday ("friday" is today or the coming one, "next friday"
+//     never today), "in 3 days", "next week", "on the 1st", "april 30" and
+//     2025-04-30, optionally after "on", "by" or "due";
+//   - "every day", "every 2 weeks", "every other month", "every monday", daily,
+//     weekly, monthly and yearly, optionally ending "until" a date.
+func Parse(text string, today entity.Date) entity.Todo {
+	p := parser{words: strings.Fields(text), today: today.Time}
+	var title []string
+	for p.pos < len(p.words) {
+		if !p.parseWord() {
+			title = append(title, p.words[p.pos])
+			p.pos++
+		}
+	}
+
+	todo := entity.Todo{
+		Title:      strings.Join(title, " "),
+		Tags:       p.tags,
+		Recurrence: p.recurrence,
+	}
+	todo.Description = todo.Title
+	if todo.Tags == nil {
+		todo.Tags = []string{}
+	}
+	due := today.Time
+	if p.due != nil {
+		due = *p.due
+	} else if p.weekday != nil {
+		due = onOrAfter(today.Time, *p.weekday)
+	}
+	todo.DueDate = &entity.Date{Time: due}
+	return todo
+}
+
+type parser struct {
+	words      []string
+	pos        int
+	today      time.Time
+	tags       []string
+	due        *time.Time
+	weekday    *time.Weekday
+	recurrence *entity.Recurrence
+}
+
+// word returns the lower-cased word at offset n from the current one without
+// trailing punctuation, or "" past the end.
+func (p *parser) word(n int) string {
+	if p.pos+n >= len(p.words) {
+		return ""
+	}
+	return strings.TrimRight(strings.ToLower(p.words[p.pos+n]), ",.;")
+}
+
+// parseWord consumes the words starting at the current one if they mean something.
+func (p *parser) parseWord() bool {
+	word := p.word(0)
+	raw := strings.TrimRight(p.words[p.pos], ",.;")
+	switch {
+	case len(raw) > 1 && raw[0] == '#':
+		p.addTag(raw[1:])
+		p.pos++
+		return true
+	case len(word) > 1 && word[0] == '!':
+		level, ok := priorities[word[1:]]
+		if !ok {
+			return false
+		}
+		p.setPriority(level)
+		p.pos++
+		return true
+	case word == "every" || adverbs[word] != "":
+		return p.parseRecurrence()
+	case word == "until" && p.recurrence != nil:
+		p.pos++
+		if date, n, ok := p.parseDate(0, true); ok {
+			p.recurrence.Until = &entity.Date{Time: date}
+			p.pos += n
+			return true
+		}
+		p.pos--
+		return false
+	}
+
+	prefixed := word == "on" || word == "by" || word == "due"
+	start := 0
+	if prefixed {
+		start = 1
+	}
+	date, n, ok := p.parseDate(start, prefixed)
+	if !ok {
+		return false
+	}
+	p.due = &date
+	p.pos += start + n
+	return true
+}
+
+func (p *parser) addTag(tag string) {
+	for _, existing := range p.tags {
+		if existing == tag {
+			return
+		}
+	}
+	p.tags = append(p.tags, tag)
+}
+
+func (p *parser) setPriority(level string) {
+	for i, tag := range p.tags {
+		if strings.HasPrefix(tag, "priority:") {
+			p.tags = append(p.tags[:i], p.tags[i+1:]...)
+			break
+		}
+	}
+	p.addTag("priority:" + level)
+}
+
+// parseRecurrence reads "every ..." or an adverb such as weekly.
+func (p *parser) parseRecurrence() bool {
+	if frequency, ok := adverbs[p.word(0)]; ok {
+		p.recurrence = &entity.Recurrence{Frequency: frequency}
+		p.pos++
+		return true
+	}
+
+	interval, n := 1, 1
+	switch next := p.word(1); {
+	case next == "other":
+		interval, n = 2, 2
+	case isNumber(next):
+		interval, _ = strconv.Atoi(next)
+		n = 2
+	}
+	if frequency, ok := units[p.word(n)]; ok && interval > 0 {
+		p.recurrence = &entity.Recurrence{Frequency: frequency, Interval: interval}
+		if interval == 1 {
+			p.recurrence.Interval = 0
+		}
+		p.pos += n + 1
+		return true
+	}
+	if weekday, ok := p.weekdayAt(1, true); ok && n == 1 {
+		p.recurrence = &entity.Recurrence{Frequency: entity.RecurWeekly}
+		p.weekday = &weekday
+		p.pos += 2
+		return true
+	}
+	return false
+}
+
+// parseDate reads a date starting at offset start and returns it with the number of
+// words it took. Abbreviated weekdays are only read when prefixed.
+func (p *parser) parseDate(start int, prefixed bool) (time.Time, int, bool) {
+	today := p.today
+	word := p.word(start)
+	switch word {
+	case "today", "tonight":
+		return today, 1, true
+	case "tomorrow", "tmrw", "tmr":
+		return today.AddDate(0, 0, 1), 1, true
+	case "next":
+		if weekday, ok := p.weekdayAt(start+1, true); ok {
+			return onOrAfter(today.AddDate(0, 0, 1), weekday), 2, true
+		}
+		switch p.word(start + 1) {
+		case "week":
+			return today.AddDate(0, 0, 7), 2, true
+		case "month":
+			return addMonths(today, 1), 2, true
+		case "year":
+			return addMonths(today, 12), 2, true
+		}
+		return time.Time{}, 0, false
+	case "in":
+		count := p.word(start + 1)
+		amount, err := strconv.Atoi(count)
+		if count == "a" || count == "an" {
+			amount, err = 1, nil
+		}
+		if err != nil || amount < 0 {
+			return time.Time{}, 0, false
+		}
+		switch units[p.word(start+2)] {
+		case entity.RecurDaily:
+			return today.AddDate(0, 0, amount), 3, true
+		case entity.RecurWeekly:
+			return today.AddDate(0, 0, 7*amount), 3, true
+		case entity.RecurMonthly:
+			return addMonths(today, amount), 3, true
+		case entity.RecurYearly:
+			return addMonths(today, 12*amount), 3, true
+		}
+		return time.Time{}, 0, false
+	case "the":
+		if day, ok := ordinal(p.word(start + 1)); ok && prefixed {
+			return nextDayOfMonth(today, day), 2, true
+		}
+		return time.Time{}, 0, false
+	}
+
+	if weekday, ok := p.weekdayAt(start, prefixed); ok {
+		return onOrAfter(today, weekday), 1, true
+	}
+	if date, err := time.Parse(time.DateOnly, word); err == nil {
+		return date, 1, true
+	}
+	if month, ok := months[word]; ok {
+		if day, ok := ordinal(p.word(start + 1)); ok {
+			if date, ok := nextDate(today, month, day); ok {
+				return date, 2, true
+			}
+		}
+	}
+	if day, ok := ordinal(word); ok {
+		if month, ok := months[p.word(start+1)]; ok {
+			if date, ok := nextDate(today, month, day); ok {
+				return date, 2, true
+			}
+		}
+		if prefixed && !isNumber(word) {
+			return nextDayOfMonth(today, day), 1, true
+		}
+	}
+	return time.Time{}, 0, false
+}
+
+func (p *parser) weekdayAt(n int, short bool) (time.Weekday, bool) {
+	word := p.word(n)
+	if weekday, ok := weekdays[word]; ok {
+		return weekday, true
+	}
+	if weekday, ok := weekdays[strings.TrimSuffix(word, "s")]; ok {
+		return weekday, true
+	}
+	if weekday, ok := shortWeekdays[word]; ok && short {
+		return weekday, true
+	}
+	return 0, false
+}
+
+// ordinal reads a day of the month such as 1st, 22nd or 30.
+func ordinal(word string) (int, bool) {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if trimmed, ok := strings.CutSuffix(word, suffix); ok {
+			word = trimmed
+			break
+		}
+	}
+	if !isNumber(word) {
+		return 0, false
+	}
+	day, err := strconv.Atoi(word)
+	if err != nil || day < 1 || day > 31 {
+		return 0, false
+	}
+	return day, true
+}
+
+func isNumber(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// onOrAfter returns the first given weekday on or after day.
+func onOrAfter(day time.Time, weekday time.Weekday) time.Time {
+	return day.AddDate(0, 0, (int(weekday)-int(day.Weekday())+7)%7)
+}
+
+// addMonths moves day by whole months, falling on the last day of months too short
+// to have the same day.
+func addMonths(day time.Time, months int) time.Time {
+	first := time.Date(day.Year(), day.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	return first.AddDate(0, 0, min(day.Day(), first.AddDate(0, 1, -1).Day())-1)
+}
+
+// nextDayOfMonth returns the first day with the given number on or after today,
+// skipping months too short to have it.
+func nextDayOfMonth(today time.Time, day int) time.Time {
+	for i := 0; ; i++ {
+		month := time.Date(today.Year(), today.Month()+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+		if date := month.AddDate(0, 0, day-1); date.Month() == month.Month() && !date.Before(today) {
+			return date
+		}
+	}
+}
+
+// nextDate returns the first given day of the year on or after today. February 29
+// may be years away; days no month has, such as April 31, are never found.
+func nextDate(today time.Time, month time.Month, day int) (time.Time, bool) {
+	for year := today.Year(); year <= today.Year()+8; year++ {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if date.Month() == month && !date.Before(today) {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,99 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) *entity.Date {
+	return &entity.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestParse(t *testing.T) {
+	// A Wednesday.
+	today := *date(2025, 4, 2)
+
+	testCases := []struct {
+		text     string
+		expected entity.Todo
+	}{
+		{
+			text: "Pay rent every month on the 1st #finance !high",
+			expected: entity.Todo{Title: "Pay rent", Tags: []string{"finance", "priority:high"},
+				DueDate: date(2025, 5, 1), Recurrence: &entity.Recurrence{Frequency: entity.RecurMonthly}},
+		},
+		{
+			text:     "Call mom tomorrow",
+			expected: entity.Todo{Title: "Call mom", Tags: []string{}, DueDate: date(2025, 4, 3)},
+		},
+		{
+			text:     "Submit report by friday !low #work",
+			expected: entity.Todo{Title: "Submit report", Tags: []string{"priority:low", "work"}, DueDate: date(2025, 4, 4)},
+		},
+		{
+			text:     "Team lunch next wednesday",
+			expected: entity.Todo{Title: "Team lunch", Tags: []string{}, DueDate: date(2025, 4, 9)},
+		},
+		{
+			text:     "Standup wednesday",
+			expected: entity.Todo{Title: "Standup", Tags: []string{}, DueDate: date(2025, 4, 2)},
+		},
+		{
+			text:     "Renew passport in 3 weeks",
+			expected: entity.Todo{Title: "Renew passport", Tags: []string{}, DueDate: date(2025, 4, 23)},
+		},
+		{
+			text:     "Dentist on apr 30th",
+			expected: entity.Todo{Title: "Dentist", Tags: []string{}, DueDate: date(2025, 4, 30)},
+		},
+		{
+			text:     "Anniversary 14 march",
+			expected: entity.Todo{Title: "Anniversary", Tags: []string{}, DueDate: date(2026, 3, 14)},
+		},
+		{
+			text: "Water plants every other day until 2025-06-30",
+			expected: entity.Todo{Title: "Water plants", Tags: []string{}, DueDate: date(2025, 4, 2),
+				Recurrence: &entity.Recurrence{Frequency: entity.RecurDaily, Interval: 2, Until: date(2025, 6, 30)}},
+		},
+		{
+			text: "Take out trash every mon",
+			expected: entity.Todo{Title: "Take out trash", Tags: []string{}, DueDate: date(2025, 4, 7),
+				Recurrence: &entity.Recurrence{Frequency: entity.RecurWeekly}},
+		},
+		{
+			text: "Review budget monthly !1 !med",
+			expected: entity.Todo{Title: "Review budget", Tags: []string{"priority:medium"}, DueDate: date(2025, 4, 2),
+				Recurrence: &entity.Recurrence{Frequency: entity.RecurMonthly}},
+		},
+		{
+			text:     "Buy sun cream, put it in the bag!",
+			expected: entity.Todo{Title: "Buy sun cream, put it in the bag!", Tags: []string{}, DueDate: date(2025, 4, 2)},
+		},
+		{
+			text:     "Read the 3rd chapter !important on feb 30",
+			expected: entity.Todo{Title: "Read the 3rd chapter !important on feb 30", Tags: []string{}, DueDate: date(2025, 4, 2)},
+		},
+		{
+			text:     "#errands today",
+			expected: entity.Todo{Tags: []string{"errands"}, DueDate: date(2025, 4, 2)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			tc.expected.Description = tc.expected.Title
+			assert.Equal(t, tc.expected, Parse(tc.text, today))
+		})
+	}
+}
+
+func TestParseEndOfMonth(t *testing.T) {
+	todo := Parse("Close books next month", *date(2025, 1, 31))
+	assert.Equal(t, date(2025, 2, 28), todo.DueDate)
+
+	todo = Parse("Pay insurance on the 31st", *date(2025, 4, 2))
+	assert.Equal(t, date(2025, 5, 31), todo.DueDate)
+}
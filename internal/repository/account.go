@@ -0,0 +1,494 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+// ownedTodoIDs selects the todos of the user $1, including deleted ones, which are
+// only known from the history entry recording their creation. Todos the user created
+// that now belong to someone else are left out.
+const ownedTodoIDs = `SELECT id FROM todos WHERE userid = $1
+	UNION SELECT todoid FROM todo_history h WHERE actorid = $1 AND operation = 'created'
+		AND NOT EXISTS (SELECT 1 FROM todos t WHERE t.id = h.todoid)`
+
+// AccountRepository reads and erases everything stored about a user across tables.
+type AccountRepository interface {
+	// Todos lists every todo the user owns, including those in workspaces.
+	Todos(ctx context.Context, userID uuid.UUID) ([]entity.Todo, error)
+	// RefreshTokens lists the refresh tokens in use; rotated ones are left out.
+	RefreshTokens(ctx context.Context, userID uuid.UUID) ([]entity.RefreshTokenInfo, error)
+	// Comments lists the comments the user wrote, including the body of deleted ones.
+	Comments(ctx context.Context, userID uuid.UUID) ([]entity.Comment, error)
+	// History lists the history of the user's todos, deleted ones included, and the
+	// entries of changes the user made to other todos.
+	History(ctx context.Context, userID uuid.UUID) ([]entity.TodoHistory, error)
+	// Sessions lists every session of the user, including ended ones still stored.
+	Sessions(ctx context.Context, userID uuid.UUID) ([]entity.Session, error)
+	// TimeEntries lists the time the user tracked, on any todo.
+	TimeEntries(ctx context.Context, userID uuid.UUID) ([]entity.TimeEntry, error)
+	// Templates lists the user's templates.
+	Templates(ctx context.Context, userID uuid.UUID) ([]entity.Template, error)
+	// Tags lists the user's tags with their metadata.
+	Tags(ctx context.Context, userID uuid.UUID) ([]entity.Tag, error)
+	// StorageKeys returns the blob keys of the attachments on the user's todos.
+	StorageKeys(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// SoleAdminWorkspaces counts the workspaces the user is the only admin of while
+	// other members remain.
+	SoleAdminWorkspaces(ctx context.Context, userID uuid.UUID) (int, error)
+	// HandOverWorkspaces gives the user's workspace todos and projects to the admin who
+	// joined each workspace first and removes the workspaces the user is alone in.
+	HandOverWorkspaces(ctx context.Context, userID uuid.UUID) error
+	// Delete removes the user. Foreign keys remove or detach the rows referring to
+	// the user; the history of the user's todos and the CalDAV change log, which have
+	// none, are erased here. It must run in a transaction.
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type accountRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewAccountRepository(db *sql.DB, logger *slog.Logger) AccountRepository {
+	return &accountRepository{db: db, logger: logger}
+}
+
+func (r *accountRepository) Todos(ctx context.Context, userID uuid.UUID) ([]entity.Todo, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "Todos")
+	logger.Debug("Attempting to fetch account todos")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+todoColumns+` FROM todos t WHERE t.userid = $1 ORDER BY t.id`, userID)
+	if err != nil {
+		logger.Error("Failed to query todos", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Todo
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			logger.Error("Failed to scan todo row", "error", err)
+			return nil, err
+		}
+		all = append(all, todo)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account todos", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) RefreshTokens(ctx context.Context, userID uuid.UUID) ([]entity.RefreshTokenInfo, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "RefreshTokens")
+	logger.Debug("Attempting to fetch account refresh tokens")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id, createdat, expirydate FROM refresh_tokens WHERE userid = $1 AND rotatedat IS NULL ORDER BY id`, userID)
+	if err != nil {
+		logger.Error("Failed to query refresh tokens", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.RefreshTokenInfo
+	for rows.Next() {
+		var token entity.RefreshTokenInfo
+		if err := rows.Scan(&token.ID, &token.CreatedAt, &token.ExpiresAt); err != nil {
+			logger.Error("Failed to scan refresh token row", "error", err)
+			return nil, err
+		}
+		all = append(all, token)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account refresh tokens", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) Comments(ctx context.Context, userID uuid.UUID) ([]entity.Comment, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "Comments")
+	logger.Debug("Attempting to fetch account comments")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT c.id, c.todoid, c.authorid, u.username, c.body, c.createdat, c.editedat, c.deletedat IS NOT NULL
+		FROM todo_comments c JOIN users u ON u.id = c.authorid
+		WHERE c.authorid = $1 ORDER BY c.id`,
+		userID,
+	)
+	if err != nil {
+		logger.Error("Failed to query comments", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			logger.Error("Failed to scan comment row", "error", err)
+			return nil, err
+		}
+		all = append(all, comment)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account comments", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) History(ctx context.Context, userID uuid.UUID) ([]entity.TodoHistory, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "History")
+	logger.Debug("Attempting to fetch account history")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT h.id, h.todoid, h.actorid, COALESCE(u.username, ''), h.operation, h.changes, h.createdat
+		FROM todo_history h LEFT JOIN users u ON u.id = h.actorid
+		WHERE h.actorid = $1 OR h.todoid IN (`+ownedTodoIDs+`) ORDER BY h.id`,
+		userID,
+	)
+	if err != nil {
+		logger.Error("Failed to query todo history", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.TodoHistory
+	for rows.Next() {
+		var entry entity.TodoHistory
+		var changes []byte
+		err := rows.Scan(&entry.ID, &entry.TodoID, &entry.ActorID, &entry.Actor, &entry.Operation, &changes, &entry.CreatedAt)
+		if err != nil {
+			logger.Error("Failed to scan todo history row", "error", err)
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			logger.Error("Failed to unmarshal changes", "error", err)
+			return nil, err
+		}
+		all = append(all, entry)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account history", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) Sessions(ctx context.Context, userID uuid.UUID) ([]entity.Session, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "Sessions")
+	logger.Debug("Attempting to fetch account sessions")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT s.id, s.devicename, s.useragent, s.ip, s.apppasswordhash IS NOT NULL, s.createdat, s.lastusedat
+		FROM sessions s WHERE s.userid = $1 ORDER BY s.createdat, s.id`,
+		userID,
+	)
+	if err != nil {
+		logger.Error("Failed to query sessions", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Session
+	for rows.Next() {
+		var session entity.Session
+		err := rows.Scan(&session.ID, &session.DeviceName, &session.UserAgent, &session.IP, &session.AppPassword,
+			&session.CreatedAt, &session.LastUsedAt)
+		if err != nil {
+			logger.Error("Failed to scan session row", "error", err)
+			return nil, err
+		}
+		all = append(all, session)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account sessions", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) TimeEntries(ctx context.Context, userID uuid.UUID) ([]entity.TimeEntry, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "TimeEntries")
+	logger.Debug("Attempting to fetch account time entries")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+timeEntryColumns+` FROM time_entries e WHERE e.userid = $1 ORDER BY e.id`, userID)
+	if err != nil {
+		logger.Error("Failed to query time entries", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			logger.Error("Failed to scan time entry row", "error", err)
+			return nil, err
+		}
+		all = append(all, entry)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account time entries", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) Templates(ctx context.Context, userID uuid.UUID) ([]entity.Template, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "Templates")
+	logger.Debug("Attempting to fetch account templates")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+templateColumns+` FROM templates t WHERE t.userid = $1 ORDER BY t.id`, userID)
+	if err != nil {
+		logger.Error("Failed to query templates", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Template
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			logger.Error("Failed to scan template row", "error", err)
+			return nil, err
+		}
+		all = append(all, template)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account templates", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) Tags(ctx context.Context, userID uuid.UUID) ([]entity.Tag, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "Tags")
+	logger.Debug("Attempting to fetch account tags")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(tagQuery, "", ""), userID)
+	if err != nil {
+		logger.Error("Failed to query tags", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			logger.Error("Failed to scan tag row", "error", err)
+			return nil, err
+		}
+		all = append(all, tag)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account tags", "count", len(all))
+	return all, nil
+}
+
+func (r *accountRepository) StorageKeys(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "StorageKeys")
+	logger.Debug("Attempting to fetch account storage keys")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT a.storagekey FROM attachments a JOIN todos t ON t.id = a.todoid WHERE t.userid = $1`, userID)
+	if err != nil {
+		logger.Error("Failed to query attachment storage keys", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			logger.Error("Failed to scan storage key", "error", err)
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched account storage keys", "count", len(keys))
+	return keys, nil
+}
+
+func (r *accountRepository) SoleAdminWorkspaces(ctx context.Context, userID uuid.UUID) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "SoleAdminWorkspaces")
+
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM workspace_members m
+		WHERE m.userid = $1 AND m.role = $2
+		AND NOT EXISTS (SELECT 1 FROM workspace_members o
+			WHERE o.workspaceid = m.workspaceid AND o.userid <> $1 AND o.role = $2)
+		AND EXISTS (SELECT 1 FROM workspace_members o WHERE o.workspaceid = m.workspaceid AND o.userid <> $1)`,
+		userID, entity.WorkspaceRoleAdmin,
+	).Scan(&count)
+	if err != nil {
+		logger.Error("Failed to count sole admin workspaces", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *accountRepository) HandOverWorkspaces(ctx context.Context, userID uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "HandOverWorkspaces")
+	logger.Debug("Attempting to hand over workspace data")
+
+	db := conn(ctx, r.db)
+	// Nobody else would see what is left in these workspaces.
+	_, err := db.ExecContext(ctx,
+		`DELETE FROM workspaces w
+		WHERE EXISTS (SELECT 1 FROM workspace_members m WHERE m.workspaceid = w.id AND m.userid = $1)
+		AND NOT EXISTS (SELECT 1 FROM workspace_members o WHERE o.workspaceid = w.id AND o.userid <> $1)`,
+		userID,
+	)
+	if err != nil {
+		logger.Error("Failed to delete single member workspaces", "error", err)
+		return err
+	}
+
+	for _, table := range []string{"projects", "todos"} {
+		_, err := db.ExecContext(ctx,
+			`UPDATE `+table+` x SET userid = (
+				SELECT o.userid FROM workspace_members o
+				WHERE o.workspaceid = x.workspaceid AND o.userid <> $1 AND o.role = $2
+				ORDER BY o.joinedat, o.userid LIMIT 1)
+			WHERE x.userid = $1 AND x.workspaceid IS NOT NULL`,
+			userID, entity.WorkspaceRoleAdmin,
+		)
+		if err != nil {
+			logger.Error("Failed to hand over workspace "+table, "error", err)
+			return err
+		}
+	}
+
+	logger.Info("Successfully handed over workspace data")
+	return nil
+}
+
+func (r *accountRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "account_repository", "Delete")
+	logger.Debug("Attempting to delete account")
+
+	db := conn(ctx, r.db)
+	// todo_history is append-only unless the transaction announces an erasure.
+	if _, err := db.ExecContext(ctx, `SELECT set_config('todo.erasing', 'on', true)`); err != nil {
+		logger.Error("Failed to allow history erasure", "error", err)
+		return err
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM todo_history WHERE todoid IN (`+ownedTodoIDs+`)`, userID); err != nil {
+		logger.Error("Failed to delete todo history", "error", err)
+		return err
+	}
+
+	res, err := db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		logger.Error("Failed to delete user", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("User not found")
+		return entity.ErrUserNotFound
+	}
+
+	// Deleting the user's todos logged their removal for CalDAV clients that will
+	// never sync again.
+	if _, err := db.ExecContext(ctx, `DELETE FROM caldav_changes WHERE userid = $1`, userID); err != nil {
+		logger.Error("Failed to delete CalDAV changes", "error", err)
+		return err
+	}
+	if _, err := db.ExecContext(ctx, `SELECT set_config('todo.erasing', 'off', true)`); err != nil {
+		logger.Error("Failed to end history erasure", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully deleted account")
+	return nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"log/slog"
+)
+
+type AttachmentRepository interface {
+	Get(ctx context.Context, id int) (entity.Attachment, error)
+	Create(ctx context.Context, attachment entity.Attachment) (int, error)
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context, todoID int) ([]entity.Attachment, error)
+	GetStorageKeys(ctx context.Context, todoID int) ([]string, error)
+}
+
+const attachmentColumns = `a.id, a.todoid, a.uploaderid, a.filename, a.contenttype, a.size, a.checksum, a.storagekey, a.createdat`
+
+type attachmentRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewAttachmentRepository(db *sql.DB, logger *slog.Logger) AttachmentRepository {
+	return &attachmentRepository{db: db, logger: logger}
+}
+
+func scanAttachment(row rowScanner) (entity.Attachment, error) {
+	var attachment entity.Attachment
+	err := row.Scan(&attachment.ID, &attachment.TodoID, &attachment.UploaderID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.Checksum, &attachment.StorageKey, &attachment.CreatedAt)
+	return attachment, err
+}
+
+func (r *attachmentRepository) Get(ctx context.Context, id int) (entity.Attachment, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "attachment_repository", "Get", "attachment_id", id)
+	logger.Debug("Attempting to fetch attachment")
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+attachmentColumns+` FROM attachments a WHERE a.id = $1`, id)
+	attachment, err := scanAttachment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Attachment not found")
+			return entity.Attachment{}, entity.ErrAttachmentNotFound
+		}
+		logger.Error("Failed to scan attachment row", "error", err)
+		return entity.Attachment{}, err
+	}
+
+	logger.Info("Successfully fetched attachment")
+	return attachment, nil
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment entity.Attachment) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "attachment_repository", "Create", "todo_id", attachment.TodoID)
+	logger.Debug("Attempting to create attachment", "file_name", attachment.FileName)
+
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO attachments(todoid, uploaderid, filename, contenttype, size, checksum, storagekey)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		attachment.TodoID,
+		attachment.UploaderID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.StorageKey,
+	).Scan(&id)
+	if err != nil {
+		logger.Error("Failed to insert attachment into database", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully created attachment", "attachment_id", id)
+	return id, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "attachment_repository", "Delete", "attachment_id", id)
+	logger.Debug("Attempting to delete attachment")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM attachments WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No attachment found to delete")
+		return entity.ErrAttachmentNotFound
+	}
+
+	logger.Info("Successfully deleted attachment")
+	return nil
+}
+
+func (r *attachmentRepository) GetAll(ctx context.Context, todoID int) ([]entity.Attachment, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "attachment_repository", "GetAll", "todo_id", todoID)
+	logger.Debug("Attempting to fetch attachments")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+attachmentColumns+` FROM attachments a WHERE a.todoid = $1 ORDER BY a.id`, todoID)
+	if err != nil {
+		logger.Error("Failed to query attachments", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			logger.Error("Failed to scan attachment row", "error", err)
+			return nil, err
+		}
+		all = append(all, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched attachments")
+	return all, nil
+}
+
+// GetStorageKeys returns the blob keys of a todo's attachments so the blobs can be
+// removed once the rows are gone.
+func (r *attachmentRepository) GetStorageKeys(ctx context.Context, todoID int) ([]string, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "attachment_repository", "GetStorageKeys", "todo_id", todoID)
+	logger.Debug("Attempting to fetch attachment storage keys")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT storagekey FROM attachments WHERE todoid = $1`, todoID)
+	if err != nil {
+		logger.Error("Failed to query attachment storage keys", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			logger.Error("Failed to scan storage key", "error", err)
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched attachment storage keys", "count", len(keys))
+	return keys, nil
+}
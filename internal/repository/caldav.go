@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type CalDAVRepository interface {
+	// GetObjects returns the client chosen names of the user's todos by todo ID.
+	GetObjects(ctx context.Context, userID uuid.UUID) (map[int]entity.CalDAVObject, error)
+	GetByName(ctx context.Context, userID uuid.UUID, name string) (entity.CalDAVObject, error)
+	GetByTodo(ctx context.Context, todoID int) (entity.CalDAVObject, error)
+	Create(ctx context.Context, userID uuid.UUID, object entity.CalDAVObject) error
+	SyncToken(ctx context.Context, userID uuid.UUID) (entity.SyncToken, error)
+	// Changes returns the todos changed after the token, once per todo and, for
+	// todos that left the collection, once per name they left it under.
+	Changes(ctx context.Context, userID uuid.UUID, since entity.SyncToken) ([]entity.CalDAVChange, error)
+}
+
+type calDAVRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewCalDAVRepository(db *sql.DB, logger *slog.Logger) CalDAVRepository {
+	return &calDAVRepository{db: db, logger: logger}
+}
+
+func (r *calDAVRepository) GetObjects(ctx context.Context, userID uuid.UUID) (map[int]entity.CalDAVObject, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "caldav_repository", "GetObjects")
+	logger.Debug("Attempting to fetch calendar objects")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT todoid, name, uid FROM caldav_objects WHERE userid = $1`, userID)
+	if err != nil {
+		logger.Error("Failed to query calendar objects", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	objects := make(map[int]entity.CalDAVObject)
+	for rows.Next() {
+		var object entity.CalDAVObject
+		if err := rows.Scan(&object.TodoID, &object.Name, &object.UID); err != nil {
+			logger.Error("Failed to scan calendar object row", "error", err)
+			return nil, err
+		}
+		objects[object.TodoID] = object
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched calendar objects", "count", len(objects))
+	return objects, nil
+}
+
+func (r *calDAVRepository) GetByName(ctx context.Context, userID uuid.UUID, name string) (entity.CalDAVObject, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "caldav_repository", "GetByName")
+	logger.Debug("Attempting to fetch calendar object", "name", name)
+
+	return r.get(ctx, logger, `SELECT todoid, name, uid FROM caldav_objects WHERE userid = $1 AND name = $2`, userID, name)
+}
+
+func (r *calDAVRepository) GetByTodo(ctx context.Context, todoID int) (entity.CalDAVObject, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "caldav_repository", "GetByTodo", "todo_id", todoID)
+	logger.Debug("Attempting to fetch calendar object")
+
+	return r.get(ctx, logger, `SELECT todoid, name, uid FROM caldav_objects WHERE todoid = $1`, todoID)
+}
+
+func (r *calDAVRepository) get(ctx context.Context, logger *slog.Logger, query string, args ...any) (entity.CalDAVObject, error) {
+	var object entity.CalDAVObject
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&object.TodoID, &object.Name, &object.UID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Debug("Calendar object not found")
+			return entity.CalDAVObject{}, entity.ErrCalDAVObjectNotFound
+		}
+		logger.Error("Failed to scan calendar object row", "error", err)
+		return entity.CalDAVObject{}, err
+	}
+	return object, nil
+}
+
+func (r *calDAVRepository) Create(ctx context.Context, userID uuid.UUID, object entity.CalDAVObject) error {
+	logger := utils.SetupLogger(ctx, r.logger, "caldav_repository", "Create", "todo_id", object.TodoID)
+	logger.Debug("Attempting to create calendar object", "name", object.Name)
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO caldav_objects (todoid, userid, name, uid) VALUES ($1, $2, $3, $4)`,
+		object.TodoID, userID, object.Name, object.UID,
+	)
+	if err != nil {
+		logger.Error("Failed to insert calendar object", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully created calendar object")
+	return nil
+}
+
+func (r *calDAVRepository) SyncToken(ctx context.Context, userID uuid.UUID) (entity.SyncToken, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "caldav_repository", "SyncToken")
+	logger.Debug("Attempting to fetch sync token")
+
+	var token entity.SyncToken
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(id), 0) FROM caldav_changes WHERE userid = $1`, userID).Scan(&token)
+	if err != nil {
+		logger.Error("Failed to query sync token", "error", err)
+		return 0, err
+	}
+	return token, nil
+}
+
+func (r *calDAVRepository) Changes(ctx context.Context, userID uuid.UUID, since entity.SyncToken) ([]entity.CalDAVChange, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "caldav_repository", "Changes")
+	logger.Debug("Attempting to fetch changes", "since", since)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT DISTINCT todoid, COALESCE(name, ''), deleted FROM caldav_changes WHERE userid = $1 AND id > $2`,
+		userID, since,
+	)
+	if err != nil {
+		logger.Error("Failed to query changes", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var changes []entity.CalDAVChange
+	for rows.Next() {
+		var change entity.CalDAVChange
+		if err := rows.Scan(&change.TodoID, &change.Name, &change.Deleted); err != nil {
+			logger.Error("Failed to scan change row", "error", err)
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched changes", "count", len(changes))
+	return changes, nil
+}
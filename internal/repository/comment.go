@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"log/slog"
+)
+
+type CommentRepository interface {
+	Get(ctx context.Context, id int) (entity.Comment, error)
+	Create(ctx context.Context, comment entity.Comment) (int, error)
+	Update(ctx context.Context, id int, body string) error
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context, todoID int, cursor entity.Cursor) ([]entity.Comment, error)
+}
+
+// Deleted comments keep their place in the thread but their body is never returned.
+const commentColumns = `c.id, c.todoid, c.authorid, u.username,
+	CASE WHEN c.deletedat IS NULL THEN c.body ELSE '' END, c.createdat, c.editedat, c.deletedat IS NOT NULL`
+
+type commentRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewCommentRepository(db *sql.DB, logger *slog.Logger) CommentRepository {
+	return &commentRepository{db: db, logger: logger}
+}
+
+func scanComment(row rowScanner) (entity.Comment, error) {
+	var comment entity.Comment
+	err := row.Scan(&comment.ID, &comment.TodoID, &comment.AuthorID, &comment.Author,
+		&comment.Body, &comment.CreatedAt, &comment.EditedAt, &comment.Deleted)
+	return comment, err
+}
+
+// Get returns a comment that has not been deleted.
+func (r *commentRepository) Get(ctx context.Context, id int) (entity.Comment, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "comment_repository", "Get", "comment_id", id)
+	logger.Debug("Attempting to fetch comment")
+
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+commentColumns+` FROM todo_comments c JOIN users u ON u.id = c.authorid
+		WHERE c.id = $1 AND c.deletedat IS NULL`,
+		id,
+	)
+	comment, err := scanComment(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Comment not found")
+			return entity.Comment{}, entity.ErrCommentNotFound
+		}
+		logger.Error("Failed to scan comment row", "error", err)
+		return entity.Comment{}, err
+	}
+
+	logger.Info("Successfully fetched comment")
+	return comment, nil
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment entity.Comment) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "comment_repository", "Create", "todo_id", comment.TodoID)
+	logger.Debug("Attempting to create comment")
+
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO todo_comments(todoid, authorid, body) VALUES ($1, $2, $3) RETURNING id`,
+		comment.TodoID,
+		comment.AuthorID,
+		comment.Body,
+	).Scan(&id)
+	if err != nil {
+		logger.Error("Failed to insert comment into database", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully created comment", "comment_id", id)
+	return id, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, id int, body string) error {
+	logger := utils.SetupLogger(ctx, r.logger, "comment_repository", "Update", "comment_id", id)
+	logger.Debug("Attempting to update comment")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE todo_comments SET body = $1, editedat = CURRENT_TIMESTAMP WHERE id = $2 AND deletedat IS NULL`,
+		body, id,
+	)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No comment found to update")
+		return entity.ErrCommentNotFound
+	}
+
+	logger.Info("Successfully updated comment")
+	return nil
+}
+
+// Delete soft deletes a comment so replies around it still make sense.
+func (r *commentRepository) Delete(ctx context.Context, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "comment_repository", "Delete", "comment_id", id)
+	logger.Debug("Attempting to delete comment")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE todo_comments SET deletedat = CURRENT_TIMESTAMP WHERE id = $1 AND deletedat IS NULL`, id)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No comment found to delete")
+		return entity.ErrCommentNotFound
+	}
+
+	logger.Info("Successfully deleted comment")
+	return nil
+}
+
+// GetAll returns up to cursor.Limit comments of a todo, oldest first, that come after
+// the cursor.
+func (r *commentRepository) GetAll(ctx context.Context, todoID int, cursor entity.Cursor) ([]entity.Comment, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "comment_repository", "GetAll", "todo_id", todoID)
+	logger.Debug("Attempting to fetch comments", "after", cursor.After, "limit", cursor.Limit)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+commentColumns+` FROM todo_comments c JOIN users u ON u.id = c.authorid
+		WHERE c.todoid = $1 AND c.id > $2 ORDER BY c.id LIMIT $3`,
+		todoID, cursor.After, cursor.Limit,
+	)
+	if err != nil {
+		logger.Error("Failed to query comments", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			logger.Error("Failed to scan comment row", "error", err)
+			return nil, err
+		}
+		all = append(all, comment)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched comments")
+	return all, nil
+}
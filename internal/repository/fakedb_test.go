@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver is an in-memory stand-in for Postgres that understands just the
+// queries these tests run. Rows written in a transaction are visible only on its
+// connection until it commits, as they are in Postgres.
+type fakeDriver struct {
+	mu        sync.Mutex
+	nextID    int
+	todos     map[string]string
+	revokedAt map[string]time.Time
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+type fakeConn struct {
+	driver  *fakeDriver
+	pending map[string]string
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.pending = map[string]string{}
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	for id, owner := range c.pending {
+		c.driver.todos[id] = owner
+	}
+	c.pending = nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.pending = nil
+	return nil
+}
+
+// CheckNamedValue accepts every argument as is; the fake never looks at the types.
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "INSERT INTO todos"):
+		c.driver.nextID++
+		id, owner := fmt.Sprint(c.driver.nextID), fmt.Sprint(args[len(args)-1].Value)
+		if c.pending != nil {
+			c.pending[id] = owner
+		} else {
+			c.driver.todos[id] = owner
+		}
+		return &fakeRows{columns: []string{"id"}, values: [][]driver.Value{{int64(c.driver.nextID)}}}, nil
+	case strings.HasPrefix(query, "SELECT t.userid,"):
+		id := fmt.Sprint(args[0].Value)
+		owner, ok := c.pending[id]
+		if !ok {
+			owner, ok = c.driver.todos[id]
+		}
+		rows := &fakeRows{columns: []string{"userid", "todo_share", "project_share", "project_owner", "workspace_role"}}
+		if ok {
+			rows.values = [][]driver.Value{{owner, nil, nil, nil, nil}}
+		}
+		return rows, nil
+	case strings.HasPrefix(query, "SELECT EXISTS (SELECT 1 FROM revoked_access_tokens"):
+		var revokedAt driver.Value
+		if t, ok := c.driver.revokedAt[fmt.Sprint(args[1].Value)]; ok {
+			revokedAt = t
+		}
+		return &fakeRows{columns: []string{"revoked", "tokensrevokedat"}, values: [][]driver.Value{{false, revokedAt}}}, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+
+	if strings.HasPrefix(query, "UPDATE users SET tokensrevokedat") {
+		c.driver.revokedAt[fmt.Sprint(args[1].Value)] = args[0].Value.(time.Time)
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+var fakeDrivers atomic.Int32
+
+func newFakeDB(t *testing.T) *sql.DB {
+	name := fmt.Sprintf("fake-%d", fakeDrivers.Add(1))
+	sql.Register(name, &fakeDriver{todos: map[string]string{}, revokedAt: map[string]time.Time{}})
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type CalendarFeedRepository interface {
+	GetAll(ctx context.Context, userID uuid.UUID) ([]entity.CalendarFeed, error)
+	Create(ctx context.Context, userID uuid.UUID, name, tokenHash string) (entity.CalendarFeed, error)
+	Delete(ctx context.Context, userID uuid.UUID, id int) error
+	// Use returns the owner of the feed with the token hash and records the access.
+	Use(ctx context.Context, tokenHash string) (uuid.UUID, error)
+}
+
+type calendarFeedRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewCalendarFeedRepository(db *sql.DB, logger *slog.Logger) CalendarFeedRepository {
+	return &calendarFeedRepository{db: db, logger: logger}
+}
+
+func (r *calendarFeedRepository) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.CalendarFeed, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "calendar_feed_repository", "GetAll")
+	logger.Debug("Attempting to fetch calendar feeds")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id, name, createdat, lastusedat FROM calendar_feeds WHERE userid = $1 ORDER BY id`, userID)
+	if err != nil {
+		logger.Error("Failed to query calendar feeds", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	feeds := []entity.CalendarFeed{}
+	for rows.Next() {
+		var feed entity.CalendarFeed
+		if err := rows.Scan(&feed.ID, &feed.Name, &feed.CreatedAt, &feed.LastUsedAt); err != nil {
+			logger.Error("Failed to scan calendar feed row", "error", err)
+			return nil, err
+		}
+		feeds = append(feeds, feed)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched calendar feeds", "count", len(feeds))
+	return feeds, nil
+}
+
+func (r *calendarFeedRepository) Create(ctx context.Context, userID uuid.UUID, name, tokenHash string) (entity.CalendarFeed, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "calendar_feed_repository", "Create")
+	logger.Debug("Attempting to create calendar feed")
+
+	feed := entity.CalendarFeed{Name: name}
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO calendar_feeds (userid, name, tokenhash) VALUES ($1, $2, $3) RETURNING id, createdat`,
+		userID, name, tokenHash,
+	).Scan(&feed.ID, &feed.CreatedAt)
+	if err != nil {
+		logger.Error("Failed to insert calendar feed", "error", err)
+		return entity.CalendarFeed{}, err
+	}
+
+	logger.Info("Successfully created calendar feed", "feed_id", feed.ID)
+	return feed, nil
+}
+
+func (r *calendarFeedRepository) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "calendar_feed_repository", "Delete", "feed_id", id)
+	logger.Debug("Attempting to delete calendar feed")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM calendar_feeds WHERE id = $1 AND userid = $2`, id, userID)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("Calendar feed not found")
+		return entity.ErrCalendarFeedNotFound
+	}
+
+	logger.Info("Successfully deleted calendar feed")
+	return nil
+}
+
+func (r *calendarFeedRepository) Use(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "calendar_feed_repository", "Use")
+	logger.Debug("Attempting to resolve calendar feed")
+
+	var userID uuid.UUID
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE calendar_feeds SET lastusedat = CURRENT_TIMESTAMP WHERE tokenhash = $1 RETURNING userid`,
+		tokenHash,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Calendar feed not found")
+			return uuid.Nil, entity.ErrCalendarFeedNotFound
+		}
+		logger.Error("Failed to update calendar feed", "error", err)
+		return uuid.Nil, err
+	}
+
+	logger.Info("Successfully resolved calendar feed")
+	return userID, nil
+}
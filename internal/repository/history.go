@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"log/slog"
+)
+
+// HistoryRepository stores the change log of todos. Entries are never changed once
+// written, so there is no update or delete.
+type HistoryRepository interface {
+	Create(ctx context.Context, entry entity.TodoHistory) error
+	GetAll(ctx context.Context, todoID int, cursor entity.Cursor) ([]entity.TodoHistory, error)
+}
+
+type historyRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewHistoryRepository(db *sql.DB, logger *slog.Logger) HistoryRepository {
+	return &historyRepository{db: db, logger: logger}
+}
+
+func (r *historyRepository) Create(ctx context.Context, entry entity.TodoHistory) error {
+	logger := utils.SetupLogger(ctx, r.logger, "history_repository", "Create",
+		"todo_id", entry.TodoID, "operation", entry.Operation)
+	logger.Debug("Attempting to record todo history")
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		logger.Error("Failed to marshal changes", "error", err)
+		return err
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO todo_history(todoid, actorid, operation, changes) VALUES ($1, $2, $3, $4)`,
+		entry.TodoID, entry.ActorID, entry.Operation, changes,
+	)
+	if err != nil {
+		logger.Error("Failed to insert todo history", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully recorded todo history")
+	return nil
+}
+
+// GetAll returns up to cursor.Limit history entries of a todo, oldest first, that
+// come after the cursor.
+func (r *historyRepository) GetAll(ctx context.Context, todoID int, cursor entity.Cursor) ([]entity.TodoHistory, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "history_repository", "GetAll", "todo_id", todoID)
+	logger.Debug("Attempting to fetch todo history", "after", cursor.After, "limit", cursor.Limit)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT h.id, h.todoid, h.actorid, COALESCE(u.username, ''), h.operation, h.changes, h.createdat
+		FROM todo_history h LEFT JOIN users u ON u.id = h.actorid
+		WHERE h.todoid = $1 AND h.id > $2 ORDER BY h.id LIMIT $3`,
+		todoID, cursor.After, cursor.Limit,
+	)
+	if err != nil {
+		logger.Error("Failed to query todo history", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.TodoHistory
+	for rows.Next() {
+		var entry entity.TodoHistory
+		var changes []byte
+		err := rows.Scan(&entry.ID, &entry.TodoID, &entry.ActorID, &entry.Actor, &entry.Operation, &changes, &entry.CreatedAt)
+		if err != nil {
+			logger.Error("Failed to scan todo history row", "error", err)
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			logger.Error("Failed to unmarshal changes", "error", err)
+			return nil, err
+		}
+		all = append(all, entry)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched todo history")
+	return all, nil
+}
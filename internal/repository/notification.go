@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification entity.Notification) (int, error)
+	GetAll(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination entity.Pagination) ([]entity.Notification, int, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, id int) error
+}
+
+type notificationRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewNotificationRepository(db *sql.DB, logger *slog.Logger) NotificationRepository {
+	return &notificationRepository{db: db, logger: logger}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification entity.Notification) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "notification_repository", "Create", "type", notification.Type)
+	logger.Debug("Attempting to create notification")
+
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO notifications(userid, type, todoid, actorid, message) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		notification.UserID,
+		notification.Type,
+		notification.TodoID,
+		notification.ActorID,
+		notification.Message,
+	).Scan(&id)
+	if err != nil {
+		logger.Error("Failed to insert notification into database", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully created notification", "notification_id", id)
+	return id, nil
+}
+
+func (r *notificationRepository) GetAll(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination entity.Pagination) ([]entity.Notification, int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "notification_repository", "GetAll", "unread_only", unreadOnly)
+	logger.Debug("Attempting to fetch notifications", "limit", pagination.Limit, "offset", pagination.Offset)
+
+	where := ` FROM notifications n WHERE n.userid = $1`
+	if unreadOnly {
+		where += ` AND n.readat IS NULL`
+	}
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*)`+where, userID).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to count notifications", "error", err)
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT n.id, n.type, n.todoid, n.actorid, n.message, n.readat IS NOT NULL, n.createdat`+
+			where+` ORDER BY n.id DESC LIMIT $2 OFFSET $3`,
+		userID, pagination.Limit, pagination.Offset,
+	)
+	if err != nil {
+		logger.Error("Failed to query notifications", "error", err)
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Notification
+	for rows.Next() {
+		notification := entity.Notification{UserID: userID}
+		err := rows.Scan(&notification.ID, &notification.Type, &notification.TodoID, &notification.ActorID,
+			&notification.Message, &notification.Read, &notification.CreatedAt)
+		if err != nil {
+			logger.Error("Failed to scan notification row", "error", err)
+			return nil, 0, err
+		}
+		all = append(all, notification)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Successfully fetched notifications")
+	return all, total, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID uuid.UUID, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "notification_repository", "MarkRead", "notification_id", id)
+	logger.Debug("Attempting to mark notification as read")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE notifications SET readat = COALESCE(readat, CURRENT_TIMESTAMP) WHERE id = $1 AND userid = $2`,
+		id, userID,
+	)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No notification found to mark as read")
+		return entity.ErrNotificationNotFound
+	}
+
+	logger.Info("Successfully marked notification as read")
+	return nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// PasswordResetRepository stores the hashes of password reset tokens.
+type PasswordResetRepository interface {
+	// Create stores a token unless the user already holds limit unexpired tokens, in
+	// which case it returns entity.ErrTooManyResetTokens.
+	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time, limit int) error
+	// Use consumes an unexpired token and returns the user it was issued to.
+	Use(ctx context.Context, tokenHash string) (uuid.UUID, error)
+	// DeleteUserTokens removes every reset token of the user.
+	DeleteUserTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+type passwordResetRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewPasswordResetRepository(db *sql.DB, logger *slog.Logger) PasswordResetRepository {
+	return &passwordResetRepository{db: db, logger: logger}
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time,
+	limit int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "password_reset_repository", "Create")
+	logger.Debug("Attempting to create password reset token")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO password_reset_tokens (userid, tokenhash, expiresat) SELECT $1, $2, $3
+			WHERE (SELECT COUNT(*) FROM password_reset_tokens WHERE userid = $1 AND expiresat > NOW()) < $4`,
+		userID, tokenHash, expiresAt, limit,
+	)
+	if err != nil {
+		logger.Error("Failed to insert password reset token", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("Too many unexpired password reset tokens")
+		return entity.ErrTooManyResetTokens
+	}
+
+	logger.Info("Successfully created password reset token")
+	return nil
+}
+
+func (r *passwordResetRepository) Use(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "password_reset_repository", "Use")
+	logger.Debug("Attempting to use password reset token")
+
+	var userID uuid.UUID
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"DELETE FROM password_reset_tokens WHERE tokenhash = $1 AND expiresat > NOW() RETURNING userid",
+		tokenHash,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Password reset token not found or expired")
+			return uuid.Nil, entity.ErrInvalidToken
+		}
+		logger.Error("Failed to delete password reset token", "error", err)
+		return uuid.Nil, err
+	}
+
+	logger.Info("Successfully used password reset token")
+	return userID, nil
+}
+
+func (r *passwordResetRepository) DeleteUserTokens(ctx context.Context, userID uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "password_reset_repository", "DeleteUserTokens")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM password_reset_tokens WHERE userid = $1", userID)
+	if err != nil {
+		logger.Error("Failed to delete password reset tokens", "error", err)
+		return err
+	}
+	return nil
+}
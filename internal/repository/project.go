@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type ProjectRepository interface {
+	Get(ctx context.Context, id int) (entity.Project, error)
+	Create(ctx context.Context, userID uuid.UUID, project entity.Project) (int, error)
+	Update(ctx context.Context, project entity.Project) error
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context, userID uuid.UUID, workspaceID *int, pagination entity.Pagination) ([]entity.Project, int, error)
+	GetSharedWith(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedProject, int, error)
+}
+
+const projectColumns = `p.id, p.name, COALESCE(p.description, ''), p.workspaceid`
+
+type projectRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewProjectRepository(db *sql.DB, logger *slog.Logger) ProjectRepository {
+	return &projectRepository{db: db, logger: logger}
+}
+
+func (r *projectRepository) Get(ctx context.Context, id int) (entity.Project, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "project_repository", "Get", "project_id", id)
+	logger.Debug("Attempting to fetch project")
+
+	var project entity.Project
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+projectColumns+` FROM projects p WHERE p.id = $1`, id).
+		Scan(&project.ID, &project.Name, &project.Description, &project.WorkspaceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Project not found")
+			return entity.Project{}, entity.ErrProjectNotFound
+		}
+		logger.Error("Failed to scan project row", "error", err)
+		return entity.Project{}, err
+	}
+
+	logger.Info("Successfully fetched project")
+	return project, nil
+}
+
+func (r *projectRepository) Create(ctx context.Context, userID uuid.UUID, project entity.Project) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "project_repository", "Create")
+	logger.Debug("Attempting to create project", "name", project.Name)
+
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO projects(name, description, workspaceid, userid) VALUES ($1, $2, $3, $4) RETURNING id`,
+		project.Name,
+		project.Description,
+		project.WorkspaceID,
+		userID,
+	).Scan(&id)
+	if err != nil {
+		logger.Error("Failed to insert project into database", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully created project", "project_id", id)
+	return id, nil
+}
+
+func (r *projectRepository) Update(ctx context.Context, project entity.Project) error {
+	logger := utils.SetupLogger(ctx, r.logger, "project_repository", "Update", "project_id", project.ID)
+	logger.Debug("Attempting to update project")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE projects SET name = $1, description = $2 WHERE id = $3`,
+		project.Name,
+		project.Description,
+		project.ID,
+	)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No project found to update")
+		return entity.ErrProjectNotFound
+	}
+
+	logger.Info("Successfully updated project")
+	return nil
+}
+
+func (r *projectRepository) Delete(ctx context.Context, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "project_repository", "Delete", "project_id", id)
+	logger.Debug("Attempting to delete project")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No project found to delete")
+		return entity.ErrProjectNotFound
+	}
+
+	logger.Info("Successfully deleted project")
+	return nil
+}
+
+func (r *projectRepository) GetAll(ctx context.Context, userID uuid.UUID, workspaceID *int, pagination entity.Pagination) ([]entity.Project, int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "project_repository", "GetAll")
+	logger.Debug("Attempting to fetch projects", "limit", pagination.Limit, "offset", pagination.Offset)
+
+	whereClause := ` WHERE p.userid = $1 AND p.workspaceid IS NULL`
+	var scope any = userID
+	if workspaceID != nil {
+		logger = logger.With("workspace_id", *workspaceID)
+		whereClause = ` WHERE p.workspaceid = $1`
+		scope = *workspaceID
+	}
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM projects p`+whereClause, scope).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to count projects", "error", err)
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+projectColumns+` FROM projects p`+whereClause+` ORDER BY p.id LIMIT $2 OFFSET $3`,
+		scope, pagination.Limit, pagination.Offset,
+	)
+	if err != nil {
+		logger.Error("Failed to query projects", "error", err)
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.Project
+	for rows.Next() {
+		var project entity.Project
+		if err := rows.Scan(&project.ID, &project.Name, &project.Description, &project.WorkspaceID); err != nil {
+			logger.Error("Failed to scan project row", "error", err)
+			return nil, 0, err
+		}
+		all = append(all, project)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Successfully fetched projects")
+	return all, total, nil
+}
+
+func (r *projectRepository) GetSharedWith(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedProject, int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "project_repository", "GetSharedWith")
+	logger.Debug("Attempting to fetch shared projects", "limit", pagination.Limit, "offset", pagination.Offset)
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM project_shares WHERE userid = $1`, userID).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to count shared projects", "error", err)
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+projectColumns+`, u.username, ps.permission
+			FROM project_shares ps
+			JOIN projects p ON p.id = ps.projectid
+			JOIN users u ON u.id = p.userid
+			WHERE ps.userid = $1 ORDER BY p.id LIMIT $2 OFFSET $3`,
+		userID, pagination.Limit, pagination.Offset,
+	)
+	if err != nil {
+		logger.Error("Failed to query shared projects", "error", err)
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.SharedProject
+	for rows.Next() {
+		var shared entity.SharedProject
+		if err := rows.Scan(&shared.ID, &shared.Name, &shared.Description, &shared.WorkspaceID, &shared.Owner, &shared.Permission); err != nil {
+			logger.Error("Failed to scan shared project row", "error", err)
+			return nil, 0, err
+		}
+		all = append(all, shared)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Successfully fetched shared projects")
+	return all, total, nil
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type ShareRepository interface {
+	TodoPermission(ctx context.Context, userID uuid.UUID, todoID int) (entity.Permission, error)
+	ProjectPermission(ctx context.Context, userID uuid.UUID, projectID int) (entity.Permission, error)
+	GetOwner(ctx context.Context, resource entity.ShareResource, id int) (uuid.UUID, error)
+	GetShares(ctx context.Context, resource entity.ShareResource, id int) ([]entity.Share, error)
+	SaveShare(ctx context.Context, resource entity.ShareResource, id int, userID uuid.UUID, permission entity.Permission) error
+	DeleteShare(ctx context.Context, resource entity.ShareResource, id int, userID uuid.UUID) error
+}
+
+type shareRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewShareRepository(db *sql.DB, logger *slog.Logger) ShareRepository {
+	return &shareRepository{db: db, logger: logger}
+}
+
+// shareTables maps a shared resource to its owning table, its ACL table and
+// the ACL column referencing the resource.
+func shareTables(resource entity.ShareResource) (string, string, string) {
+	if resource == entity.ShareResourceProject {
+		return "projects", "project_shares", "projectid"
+	}
+	return "todos", "todo_shares", "todoid"
+}
+
+func notFoundError(resource entity.ShareResource) error {
+	if resource == entity.ShareResourceProject {
+		return entity.ErrProjectNotFound
+	}
+	return entity.ErrTodoNotFound
+}
+
+// TodoPermission returns the strongest permission the user holds on a todo through
+// ownership, a direct share, the project the todo belongs to, or membership of its
+// workspace. An empty permission means the user has no access at all.
+func (r *shareRepository) TodoPermission(ctx context.Context, userID uuid.UUID, todoID int) (entity.Permission, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "share_repository", "TodoPermission", "todo_id", todoID)
+	logger.Debug("Attempting to resolve todo permission")
+
+	var ownerID uuid.UUID
+	var todoShare, projectShare sql.NullString
+	var projectOwner uuid.NullUUID
+	var workspaceRole sql.NullString
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT t.userid,
+			(SELECT ts.permission FROM todo_shares ts WHERE ts.todoid = t.id AND ts.userid = $2),
+			(SELECT ps.permission FROM project_shares ps WHERE ps.projectid = t.projectid AND ps.userid = $2),
+			(SELECT p.userid FROM projects p WHERE p.id = t.projectid),
+			(SELECT wm.role FROM workspace_members wm WHERE wm.workspaceid = t.workspaceid AND wm.userid = $2)
+		FROM todos t WHERE t.id = $1`,
+		todoID, userID,
+	).Scan(&ownerID, &todoShare, &projectShare, &projectOwner, &workspaceRole)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Todo not found")
+			return "", entity.ErrTodoNotFound
+		}
+		logger.Error("Failed to resolve todo permission", "error", err)
+		return "", err
+	}
+
+	var permission entity.Permission
+	if ownerID == userID || (projectOwner.Valid && projectOwner.UUID == userID) {
+		permission = entity.PermissionOwner
+	}
+	permission = permission.Max(entity.Permission(todoShare.String))
+	permission = permission.Max(entity.Permission(projectShare.String))
+	permission = permission.Max(entity.WorkspaceRole(workspaceRole.String).Permission())
+
+	logger.Debug("Resolved todo permission", "permission", permission)
+	return permission, nil
+}
+
+func (r *shareRepository) ProjectPermission(ctx context.Context, userID uuid.UUID, projectID int) (entity.Permission, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "share_repository", "ProjectPermission", "project_id", projectID)
+	logger.Debug("Attempting to resolve project permission")
+
+	var ownerID uuid.UUID
+	var projectShare, workspaceRole sql.NullString
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT p.userid,
+			(SELECT ps.permission FROM project_shares ps WHERE ps.projectid = p.id AND ps.userid = $2),
+			(SELECT wm.role FROM workspace_members wm WHERE wm.workspaceid = p.workspaceid AND wm.userid = $2)
+		FROM projects p WHERE p.id = $1`,
+		projectID, userID,
+	).Scan(&ownerID, &projectShare, &workspaceRole)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Project not found")
+			return "", entity.ErrProjectNotFound
+		}
+		logger.Error("Failed to resolve project permission", "error", err)
+		return "", err
+	}
+
+	var permission entity.Permission
+	if ownerID == userID {
+		permission = entity.PermissionOwner
+	}
+	permission = permission.Max(entity.Permission(projectShare.String))
+	permission = permission.Max(entity.WorkspaceRole(workspaceRole.String).Permission())
+
+	logger.Debug("Resolved project permission", "permission", permission)
+	return permission, nil
+}
+
+func (r *shareRepository) GetOwner(ctx context.Context, resource entity.ShareResource, id int) (uuid.UUID, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "share_repository", "GetOwner", "resource", resource, "resource_id", id)
+	logger.Debug("Attempting to fetch resource owner")
+
+	table, _, _ := shareTables(resource)
+	var ownerID uuid.UUID
+	err := conn(ctx, r.db).QueryRowContext(ctx, fmt.Sprintf(`SELECT userid FROM %s WHERE id = $1`, table), id).Scan(&ownerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Resource not found")
+			return uuid.Nil, notFoundError(resource)
+		}
+		logger.Error("Failed to fetch resource owner", "error", err)
+		return uuid.Nil, err
+	}
+	return ownerID, nil
+}
+
+func (r *shareRepository) GetShares(ctx context.Context, resource entity.ShareResource, id int) ([]entity.Share, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "share_repository", "GetShares", "resource", resource, "resource_id", id)
+	logger.Debug("Attempting to fetch shares")
+
+	_, aclTable, column := shareTables(resource)
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		fmt.Sprintf(`SELECT u.id, u.username, s.permission FROM %s s JOIN users u ON u.id = s.userid
+			WHERE s.%s = $1 ORDER BY s.createdat`, aclTable, column),
+		id,
+	)
+	if err != nil {
+		logger.Error("Failed to query shares", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	shares := []entity.Share{}
+	for rows.Next() {
+		var share entity.Share
+		if err := rows.Scan(&share.UserID, &share.Username, &share.Permission); err != nil {
+			logger.Error("Failed to scan share row", "error", err)
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched shares")
+	return shares, nil
+}
+
+func (r *shareRepository) SaveShare(ctx context.Context, resource entity.ShareResource, id int, userID uuid.UUID, permission entity.Permission) error {
+	logger := utils.SetupLogger(ctx, r.logger, "share_repository", "SaveShare", "resource", resource, "resource_id", id)
+	logger.Debug("Attempting to save share", "permission", permission)
+
+	_, aclTable, column := shareTables(resource)
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, userid, permission) VALUES ($1, $2, $3)
+			ON CONFLICT (%[2]s, userid) DO UPDATE SET permission = EXCLUDED.permission`, aclTable, column),
+		id, userID, permission,
+	)
+	if err != nil {
+		logger.Error("Failed to save share", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully saved share")
+	return nil
+}
+
+func (r *shareRepository) DeleteShare(ctx context.Context, resource entity.ShareResource, id int, userID uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "share_repository", "DeleteShare", "resource", resource, "resource_id", id)
+	logger.Debug("Attempting to delete share")
+
+	_, aclTable, column := shareTables(resource)
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND userid = $2`, aclTable, column),
+		id, userID,
+	)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No share found to delete")
+		return entity.ErrShareNotFound
+	}
+
+	logger.Info("Successfully deleted share")
+	return nil
+}
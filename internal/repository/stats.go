@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// StatsRepository aggregates the todos a user owns. Ranges are [from, to), and days
+// are days in the IANA time zone timezone.
+type StatsRepository interface {
+	Buckets(ctx context.Context, userID uuid.UUID, from, to time.Time, interval entity.StatsInterval,
+		timezone string) ([]entity.StatsBucket, error)
+	Summary(ctx context.Context, userID uuid.UUID, from, to time.Time, today time.Time) (entity.StatsSummary, error)
+	Tags(ctx context.Context, userID uuid.UUID, from, to time.Time, today time.Time) ([]entity.TagStats, error)
+	Streak(ctx context.Context, userID uuid.UUID, today time.Time, timezone string) (entity.Streak, error)
+}
+
+type statsRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewStatsRepository(db *sql.DB, logger *slog.Logger) StatsRepository {
+	return &statsRepository{db: db, logger: logger}
+}
+
+// Buckets returns one bucket per day or week of the range, including empty ones.
+func (r *statsRepository) Buckets(ctx context.Context, userID uuid.UUID, from, to time.Time,
+	interval entity.StatsInterval, timezone string) ([]entity.StatsBucket, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "stats_repository", "Buckets", "interval", interval)
+	logger.Debug("Attempting to count todos per bucket", "from", from, "to", to)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`WITH buckets AS (
+			SELECT generate_series(date_trunc($4::text, $2::timestamptz AT TIME ZONE $5),
+				$3::timestamptz AT TIME ZONE $5 - interval '1 day', ('1 ' || $4::text)::interval) AS start
+		), created AS (
+			SELECT date_trunc($4::text, createdat AT TIME ZONE $5) AS start, COUNT(*) AS n FROM todos
+			WHERE userid = $1 AND createdat >= $2 AND createdat < $3 GROUP BY 1
+		), completed AS (
+			SELECT date_trunc($4::text, completedat AT TIME ZONE $5) AS start, COUNT(*) AS n FROM todos
+			WHERE userid = $1 AND completedat >= $2 AND completedat < $3 GROUP BY 1
+		)
+		SELECT b.start::date, COALESCE(c.n, 0), COALESCE(d.n, 0)
+		FROM buckets b LEFT JOIN created c ON c.start = b.start LEFT JOIN completed d ON d.start = b.start
+		ORDER BY b.start`,
+		userID, from, to, string(interval), timezone,
+	)
+	if err != nil {
+		logger.Error("Failed to query buckets", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var buckets []entity.StatsBucket
+	for rows.Next() {
+		var bucket entity.StatsBucket
+		if err := rows.Scan(&bucket.Start, &bucket.Created, &bucket.Completed); err != nil {
+			logger.Error("Failed to scan bucket row", "error", err)
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully counted todos per bucket")
+	return buckets, nil
+}
+
+// Summary counts the open and overdue todos and averages the time from creation to
+// completion of the todos completed in the range.
+func (r *statsRepository) Summary(ctx context.Context, userID uuid.UUID, from, to time.Time,
+	today time.Time) (entity.StatsSummary, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "stats_repository", "Summary")
+	logger.Debug("Attempting to summarize todos", "from", from, "to", to)
+
+	var summary entity.StatsSummary
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FILTER (WHERE NOT completed),
+			COUNT(*) FILTER (WHERE NOT completed AND duetime < $4::date),
+			ROUND((AVG(EXTRACT(EPOCH FROM completedat - createdat))
+				FILTER (WHERE completedat >= $2 AND completedat < $3) / 3600)::numeric, 1)::float8
+		FROM todos WHERE userid = $1`,
+		userID, from, to, today,
+	).Scan(&summary.Open, &summary.Overdue, &summary.AverageLeadTimeHours)
+	if err != nil {
+		logger.Error("Failed to summarize todos", "error", err)
+		return entity.StatsSummary{}, err
+	}
+
+	logger.Info("Successfully summarized todos")
+	return summary, nil
+}
+
+// Tags counts per tag the todos created and completed in the range and the ones
+// overdue today. Tags without any of those are left out.
+func (r *statsRepository) Tags(ctx context.Context, userID uuid.UUID, from, to time.Time,
+	today time.Time) ([]entity.TagStats, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "stats_repository", "Tags")
+	logger.Debug("Attempting to count todos per tag", "from", from, "to", to)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT tag,
+			COUNT(*) FILTER (WHERE t.createdat >= $2 AND t.createdat < $3),
+			COUNT(*) FILTER (WHERE t.completedat >= $2 AND t.completedat < $3),
+			COUNT(*) FILTER (WHERE NOT t.completed AND t.duetime < $4::date)
+		FROM todos t CROSS JOIN LATERAL unnest(t.tags) AS tag
+		WHERE t.userid = $1 AND ((t.createdat >= $2 AND t.createdat < $3)
+			OR (t.completedat >= $2 AND t.completedat < $3)
+			OR (NOT t.completed AND t.duetime < $4::date))
+		GROUP BY tag ORDER BY 2 DESC, tag`,
+		userID, from, to, today,
+	)
+	if err != nil {
+		logger.Error("Failed to query tag stats", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var tags []entity.TagStats
+	for rows.Next() {
+		var tag entity.TagStats
+		if err := rows.Scan(&tag.Tag, &tag.Created, &tag.Completed, &tag.Overdue); err != nil {
+			logger.Error("Failed to scan tag stats row", "error", err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully counted todos per tag")
+	return tags, nil
+}
+
+// Streak finds runs of consecutive days with completed todos. Days minus their rank
+// are equal within a run, which groups each run together.
+func (r *statsRepository) Streak(ctx context.Context, userID uuid.UUID, today time.Time,
+	timezone string) (entity.Streak, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "stats_repository", "Streak")
+	logger.Debug("Attempting to compute completion streaks")
+
+	var streak entity.Streak
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`WITH days AS (
+			SELECT DISTINCT (completedat AT TIME ZONE $3)::date AS day FROM todos
+			WHERE userid = $1 AND completedat IS NOT NULL
+		), runs AS (
+			SELECT MAX(day) AS last_day, COUNT(*) AS length
+			FROM (SELECT day, day - (ROW_NUMBER() OVER (ORDER BY day))::int AS run FROM days) d
+			GROUP BY run
+		)
+		SELECT COALESCE(MAX(length) FILTER (WHERE last_day >= $2::date - 1), 0), COALESCE(MAX(length), 0) FROM runs`,
+		userID, today, timezone,
+	).Scan(&streak.Current, &streak.Longest)
+	if err != nil {
+		logger.Error("Failed to compute streaks", "error", err)
+		return entity.Streak{}, err
+	}
+
+	logger.Info("Successfully computed completion streaks")
+	return streak, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"log/slog"
+)
+
+// TagRepository reads tag usage from the todos of a user and stores the metadata
+// the user set for tag names.
+type TagRepository interface {
+	Get(ctx context.Context, userID uuid.UUID, name string) (entity.Tag, error)
+	GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Tag, error)
+	SaveMetadata(ctx context.Context, userID uuid.UUID, tag entity.Tag) error
+	RenameMetadata(ctx context.Context, userID uuid.UUID, from, to string) error
+	DeleteMetadata(ctx context.Context, userID uuid.UUID, names []string) error
+	Replace(ctx context.Context, userID uuid.UUID, sources []string, target string) ([]entity.TagChange, error)
+}
+
+// tagQuery combines the tags used on the user's todos with those that only have
+// metadata. %s is an extra condition on the tag name, applied to both sides.
+const tagQuery = `SELECT COALESCE(u.name, m.name), m.color, COALESCE(m.description, ''), COALESCE(u.count, 0)
+	FROM (
+		SELECT tag AS name, COUNT(DISTINCT t.id) AS count FROM todos t, unnest(t.tags) AS tag
+		WHERE t.userid = $1 %[1]s GROUP BY tag
+	) u
+	FULL JOIN (SELECT name, color, description FROM tags WHERE userid = $1 %[2]s) m ON m.name = u.name
+	ORDER BY 1`
+
+type tagRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewTagRepository(db *sql.DB, logger *slog.Logger) TagRepository {
+	return &tagRepository{db: db, logger: logger}
+}
+
+func scanTag(row rowScanner) (entity.Tag, error) {
+	var tag entity.Tag
+	err := row.Scan(&tag.Name, &tag.Color, &tag.Description, &tag.Count)
+	return tag, err
+}
+
+func (r *tagRepository) Get(ctx context.Context, userID uuid.UUID, name string) (entity.Tag, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "tag_repository", "Get", "tag", name)
+	logger.Debug("Attempting to fetch tag")
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, fmt.Sprintf(tagQuery, "AND tag = $2", "AND name = $2"), userID, name)
+	tag, err := scanTag(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Tag not found")
+			return entity.Tag{}, entity.ErrTagNotFound
+		}
+		logger.Error("Failed to scan tag row", "error", err)
+		return entity.Tag{}, err
+	}
+
+	logger.Info("Successfully fetched tag")
+	return tag, nil
+}
+
+// GetAll returns every tag of the user ordered by name.
+func (r *tagRepository) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Tag, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "tag_repository", "GetAll")
+	logger.Debug("Attempting to fetch tags")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(tagQuery, "", ""), userID)
+	if err != nil {
+		logger.Error("Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	tags := []entity.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			logger.Error("Failed to scan tag row", "error", err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched tags", "count", len(tags))
+	return tags, nil
+}
+
+func (r *tagRepository) SaveMetadata(ctx context.Context, userID uuid.UUID, tag entity.Tag) error {
+	logger := utils.SetupLogger(ctx, r.logger, "tag_repository", "SaveMetadata", "tag", tag.Name)
+	logger.Debug("Attempting to save tag metadata")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO tags(userid, name, color, description) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (userid, name) DO UPDATE SET color = EXCLUDED.color, description = EXCLUDED.description`,
+		userID, tag.Name, tag.Color, tag.Description,
+	)
+	if err != nil {
+		logger.Error("Failed to save tag metadata", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully saved tag metadata")
+	return nil
+}
+
+// RenameMetadata moves the metadata of a tag to a new name. A tag without metadata
+// is left alone.
+func (r *tagRepository) RenameMetadata(ctx context.Context, userID uuid.UUID, from, to string) error {
+	logger := utils.SetupLogger(ctx, r.logger, "tag_repository", "RenameMetadata", "from", from, "to", to)
+	logger.Debug("Attempting to rename tag metadata")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE tags SET name = $3 WHERE userid = $1 AND name = $2`, userID, from, to)
+	if err != nil {
+		logger.Error("Failed to rename tag metadata", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully renamed tag metadata")
+	return nil
+}
+
+func (r *tagRepository) DeleteMetadata(ctx context.Context, userID uuid.UUID, names []string) error {
+	logger := utils.SetupLogger(ctx, r.logger, "tag_repository", "DeleteMetadata", "tags", names)
+	logger.Debug("Attempting to delete tag metadata")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM tags WHERE userid = $1 AND name = ANY($2)`, userID, pq.Array(names))
+	if err != nil {
+		logger.Error("Failed to delete tag metadata", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully deleted tag metadata")
+	return nil
+}
+
+// Replace swaps the source tags for the target on every todo of the user that has
+// one of them. The target takes the place of the first tag it replaces and appears
+// only once. It returns the tags of each changed todo before and after.
+func (r *tagRepository) Replace(ctx context.Context, userID uuid.UUID, sources []string, target string) ([]entity.TagChange, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "tag_repository", "Replace", "sources", sources, "target", target)
+	logger.Debug("Attempting to replace tags")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`WITH affected AS (
+			SELECT id, tags FROM todos WHERE userid = $1 AND tags && $2 FOR UPDATE
+		)
+		UPDATE todos t SET tags = ARRAY(
+			SELECT s.tag FROM (
+				SELECT CASE WHEN u.tag = ANY($2) THEN $3 ELSE u.tag END AS tag, u.ord
+				FROM unnest(a.tags) WITH ORDINALITY AS u(tag, ord)
+			) s GROUP BY s.tag ORDER BY MIN(s.ord)
+		)
+		FROM affected a WHERE t.id = a.id
+		RETURNING t.id, a.tags, t.tags`,
+		userID, pq.Array(sources), target,
+	)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var changes []entity.TagChange
+	for rows.Next() {
+		var change entity.TagChange
+		if err := rows.Scan(&change.TodoID, pq.Array(&change.Before), pq.Array(&change.After)); err != nil {
+			logger.Error("Failed to scan tag change", "error", err)
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully replaced tags", "todos", len(changes))
+	return changes, nil
+}
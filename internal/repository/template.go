@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type TemplateRepository interface {
+	Get(ctx context.Context, id int) (entity.Template, error)
+	GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.Template, int, error)
+	Create(ctx context.Context, userID uuid.UUID, template entity.Template) (int, error)
+	Update(ctx context.Context, template entity.Template) error
+	Delete(ctx context.Context, id int) error
+}
+
+const templateColumns = `t.id, t.name, t.body, t.userid, t.createdat, t.updatedat`
+
+type templateRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewTemplateRepository(db *sql.DB, logger *slog.Logger) TemplateRepository {
+	return &templateRepository{db: db, logger: logger}
+}
+
+func scanTemplate(row rowScanner) (entity.Template, error) {
+	var template entity.Template
+	var body []byte
+	err := row.Scan(&template.ID, &template.Name, &body, &template.UserID, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return entity.Template{}, err
+	}
+	if err := json.Unmarshal(body, &template.TemplateTask); err != nil {
+		return entity.Template{}, err
+	}
+	return template, nil
+}
+
+func (r *templateRepository) Get(ctx context.Context, id int) (entity.Template, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "template_repository", "Get", "template_id", id)
+	logger.Debug("Attempting to fetch template")
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+templateColumns+` FROM todo_templates t WHERE t.id = $1`, id)
+	template, err := scanTemplate(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Template not found")
+			return entity.Template{}, entity.ErrTemplateNotFound
+		}
+		logger.Error("Failed to scan template row", "error", err)
+		return entity.Template{}, err
+	}
+
+	logger.Info("Successfully fetched template")
+	return template, nil
+}
+
+func (r *templateRepository) GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.Template, int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "template_repository", "GetAll")
+	logger.Debug("Attempting to fetch templates", "limit", pagination.Limit, "offset", pagination.Offset)
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM todo_templates WHERE userid = $1`, userID).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to count templates", "error", err)
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+templateColumns+` FROM todo_templates t WHERE t.userid = $1 ORDER BY t.id LIMIT $2 OFFSET $3`,
+		userID, pagination.Limit, pagination.Offset,
+	)
+	if err != nil {
+		logger.Error("Failed to query templates", "error", err)
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var templates []entity.Template
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			logger.Error("Failed to scan template row", "error", err)
+			return nil, 0, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Successfully fetched templates")
+	return templates, total, nil
+}
+
+func (r *templateRepository) Create(ctx context.Context, userID uuid.UUID, template entity.Template) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "template_repository", "Create")
+	logger.Debug("Attempting to create template", "name", template.Name)
+
+	body, err := json.Marshal(template.TemplateTask)
+	if err != nil {
+		logger.Error("Failed to marshal template body", "error", err)
+		return 0, err
+	}
+
+	var id int
+	err = conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO todo_templates(userid, name, body) VALUES ($1, $2, $3) RETURNING id`,
+		userID, template.Name, body,
+	).Scan(&id)
+	if err != nil {
+		logger.Error("Failed to insert template into database", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully created template", "template_id", id)
+	return id, nil
+}
+
+func (r *templateRepository) Update(ctx context.Context, template entity.Template) error {
+	logger := utils.SetupLogger(ctx, r.logger, "template_repository", "Update", "template_id", template.ID)
+	logger.Debug("Attempting to update template")
+
+	body, err := json.Marshal(template.TemplateTask)
+	if err != nil {
+		logger.Error("Failed to marshal template body", "error", err)
+		return err
+	}
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE todo_templates SET name = $1, body = $2, updatedat = CURRENT_TIMESTAMP WHERE id = $3`,
+		template.Name, body, template.ID,
+	)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No template found to update")
+		return entity.ErrTemplateNotFound
+	}
+
+	logger.Info("Successfully updated template")
+	return nil
+}
+
+func (r *templateRepository) Delete(ctx context.Context, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "template_repository", "Delete", "template_id", id)
+	logger.Debug("Attempting to delete template")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM todo_templates WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No template found to delete")
+		return entity.ErrTemplateNotFound
+	}
+
+	logger.Info("Successfully deleted template")
+	return nil
+}
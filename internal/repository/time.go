@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"log/slog"
+	"time"
+)
+
+type TimeEntryRepository interface {
+	Get(ctx context.Context, id int) (entity.TimeEntry, error)
+	GetRunning(ctx context.Context, userID uuid.UUID) (entity.TimeEntry, error)
+	GetAll(ctx context.Context, todoID int, pagination entity.Pagination) ([]entity.TimeEntry, int, error)
+	Create(ctx context.Context, entry entity.TimeEntry) (int, error)
+	Stop(ctx context.Context, userID uuid.UUID, todoID int) (int, error)
+	Delete(ctx context.Context, id int) error
+	Report(ctx context.Context, userID uuid.UUID, from, to time.Time, groupBy entity.TimeReportGrouping) ([]entity.TimeReportRow, int, error)
+}
+
+// Running timers count up to the current time.
+const timeEntryColumns = `e.id, e.todoid, e.userid, e.startedat, e.endedat,
+	FLOOR(EXTRACT(EPOCH FROM COALESCE(e.endedat, CURRENT_TIMESTAMP) - e.startedat) / 60)::int, e.note`
+
+// timeReportEntries sums the seconds $1 spent on each todo in entries started in
+// [$2, $3).
+const timeReportEntries = `WITH todo_time AS (
+		SELECT t.id, t.title, t.tags, t.projectid, t.estimateminutes,
+			SUM(EXTRACT(EPOCH FROM COALESCE(e.endedat, CURRENT_TIMESTAMP) - e.startedat))::bigint AS seconds
+		FROM time_entries e JOIN todos t ON t.id = e.todoid
+		WHERE e.userid = $1 AND e.startedat >= $2 AND e.startedat < $3
+		GROUP BY t.id
+	)`
+
+var timeReportQueries = map[entity.TimeReportGrouping]string{
+	entity.TimeReportByTodo: timeReportEntries + `
+		SELECT tt.id, tt.title, tt.seconds, tt.estimateminutes FROM todo_time tt ORDER BY tt.seconds DESC, tt.id`,
+	entity.TimeReportByTag: timeReportEntries + `
+		SELECT NULL::int, tag, SUM(tt.seconds)::bigint, SUM(tt.estimateminutes)::int
+		FROM todo_time tt CROSS JOIN LATERAL unnest(tt.tags) AS tag
+		GROUP BY tag ORDER BY 3 DESC, tag`,
+	entity.TimeReportByProject: timeReportEntries + `
+		SELECT p.id, COALESCE(p.name, ''), SUM(tt.seconds)::bigint, SUM(tt.estimateminutes)::int
+		FROM todo_time tt LEFT JOIN projects p ON p.id = tt.projectid
+		GROUP BY p.id, p.name ORDER BY 3 DESC, p.id NULLS LAST`,
+}
+
+type timeEntryRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewTimeEntryRepository(db *sql.DB, logger *slog.Logger) TimeEntryRepository {
+	return &timeEntryRepository{db: db, logger: logger}
+}
+
+func scanTimeEntry(row rowScanner) (entity.TimeEntry, error) {
+	var entry entity.TimeEntry
+	err := row.Scan(&entry.ID, &entry.TodoID, &entry.UserID, &entry.StartedAt, &entry.EndedAt, &entry.Minutes, &entry.Note)
+	return entry, err
+}
+
+func (r *timeEntryRepository) Get(ctx context.Context, id int) (entity.TimeEntry, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "time_entry_repository", "Get", "time_entry_id", id)
+	logger.Debug("Attempting to fetch time entry")
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+timeEntryColumns+` FROM time_entries e WHERE e.id = $1`, id)
+	entry, err := scanTimeEntry(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Time entry not found")
+			return entity.TimeEntry{}, entity.ErrTimeEntryNotFound
+		}
+		logger.Error("Failed to scan time entry row", "error", err)
+		return entity.TimeEntry{}, err
+	}
+
+	logger.Info("Successfully fetched time entry")
+	return entry, nil
+}
+
+func (r *timeEntryRepository) GetRunning(ctx context.Context, userID uuid.UUID) (entity.TimeEntry, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "time_entry_repository", "GetRunning")
+	logger.Debug("Attempting to fetch running timer")
+
+	row := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+timeEntryColumns+` FROM time_entries e WHERE e.userid = $1 AND e.endedat IS NULL`, userID)
+	entry, err := scanTimeEntry(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Debug("No timer is running")
+			return entity.TimeEntry{}, entity.ErrTimerNotRunning
+		}
+		logger.Error("Failed to scan time entry row", "error", err)
+		return entity.TimeEntry{}, err
+	}
+
+	logger.Info("Successfully fetched running timer", "time_entry_id", entry.ID)
+	return entry, nil
+}
+
+// GetAll returns the time entries of a todo, most recent first.
+func (r *timeEntryRepository) GetAll(ctx context.Context, todoID int, pagination entity.Pagination) ([]entity.TimeEntry, int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "time_entry_repository", "GetAll", "todo_id", todoID)
+	logger.Debug("Attempting to fetch time entries", "limit", pagination.Limit, "offset", pagination.Offset)
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM time_entries WHERE todoid = $1`, todoID).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to count time entries", "error", err)
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+timeEntryColumns+` FROM time_entries e WHERE e.todoid = $1
+		ORDER BY e.startedat DESC, e.id DESC LIMIT $2 OFFSET $3`,
+		todoID, pagination.Limit, pagination.Offset,
+	)
+	if err != nil {
+		logger.Error("Failed to query time entries", "error", err)
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var entries []entity.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			logger.Error("Failed to scan time entry row", "error", err)
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Successfully fetched time entries")
+	return entries, total, nil
+}
+
+// Create adds a time entry. An entry without EndedAt starts a timer, which fails
+// with ErrTimerRunning if the user already has one.
+func (r *timeEntryRepository) Create(ctx context.Context, entry entity.TimeEntry) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "time_entry_repository", "Create", "todo_id", entry.TodoID)
+	logger.Debug("Attempting to create time entry")
+
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO time_entries(todoid, userid, startedat, endedat, note) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		entry.TodoID,
+		entry.UserID,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Note,
+	).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			logger.Warn("Timer already running")
+			return 0, entity.ErrTimerRunning
+		}
+		logger.Error("Failed to insert time entry into database", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully created time entry", "time_entry_id", id)
+	return id, nil
+}
+
+// Stop ends the user's running timer on the todo and returns its ID.
+func (r *timeEntryRepository) Stop(ctx context.Context, userID uuid.UUID, todoID int) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "time_entry_repository", "Stop", "todo_id", todoID)
+	logger.Debug("Attempting to stop timer")
+
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE time_entries SET endedat = GREATEST(CURRENT_TIMESTAMP, startedat)
+		WHERE userid = $1 AND todoid = $2 AND endedat IS NULL RETURNING id`,
+		userID, todoID,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("No timer is running on the todo")
+			return 0, entity.ErrTimerNotRunning
+		}
+		logger.Error("Failed to execute stop query", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully stopped timer", "time_entry_id", id)
+	return id, nil
+}
+
+func (r *timeEntryRepository) Delete(ctx context.Context, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "time_entry_repository", "Delete", "time_entry_id", id)
+	logger.Debug("Attempting to delete time entry")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM time_entries WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No time entry found to delete")
+		return entity.ErrTimeEntryNotFound
+	}
+
+	logger.Info("Successfully deleted time entry")
+	return nil
+}
+
+// Report sums the time the user spent in entries started in [from, to), grouped as
+// requested, and returns the rows together with the total in minutes.
+func (r *timeEntryRepository) Report(ctx context.Context, userID uuid.UUID, from, to time.Time,
+	groupBy entity.TimeReportGrouping) ([]entity.TimeReportRow, int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "time_entry_repository", "Report", "group_by", groupBy)
+	logger.Debug("Attempting to build time report", "from", from, "to", to)
+
+	query, ok := timeReportQueries[groupBy]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown time report grouping %q", groupBy)
+	}
+
+	var total int64
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		timeReportEntries+` SELECT COALESCE(SUM(seconds), 0)::bigint FROM todo_time`,
+		userID, from, to,
+	).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to sum time entries", "error", err)
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		logger.Error("Failed to query time report", "error", err)
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var report []entity.TimeReportRow
+	for rows.Next() {
+		var row entity.TimeReportRow
+		var seconds int64
+		if err := rows.Scan(&row.ID, &row.Name, &seconds, &row.EstimateMinutes); err != nil {
+			logger.Error("Failed to scan time report row", "error", err)
+			return nil, 0, err
+		}
+		row.Minutes = int(seconds / 60)
+		report = append(report, row)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Successfully built time report")
+	return report, int(total / 60), nil
+}
@@ -9,17 +9,70 @@ import (
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"log/slog"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/GlebMoskalev/go-todo-api/internal/entity"
 )
 
 type TodoRepository interface {
-	Get(ctx context.Context, userID uuid.UUID, id int) (entity.Todo, error)
+	Get(ctx context.Context, id int) (entity.Todo, error)
 	Create(ctx context.Context, userID uuid.UUID, todo entity.Todo) (int, error)
-	Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) error
-	Delete(ctx context.Context, userID uuid.UUID, id int) error
+	// CreateBatch inserts the todos with a single statement and returns their ids in
+	// the same order.
+	CreateBatch(ctx context.Context, userID uuid.UUID, todos []entity.Todo) ([]int, error)
+	Update(ctx context.Context, todo entity.Todo) error
+	Delete(ctx context.Context, id int) error
 	GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination, filters entity.Filters) ([]entity.Todo, int, error)
+	GetAgenda(ctx context.Context, userID uuid.UUID, filters entity.Filters, from, to entity.Date) ([]entity.Todo, error)
+	// Export lists every todo matching the filters, without pagination.
+	Export(ctx context.Context, userID uuid.UUID, filters entity.Filters) ([]entity.Todo, error)
+	// Stream passes every todo matching the filters to fn as it is read, stopping at
+	// the first error fn returns.
+	Stream(ctx context.Context, userID uuid.UUID, filters entity.Filters, fn func(entity.Todo) error) error
+	GetSharedWith(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedTodo, int, error)
+	Assign(ctx context.Context, id int, assigneeID *uuid.UUID) error
+	SetCompleted(ctx context.Context, id int, completed bool) error
+	Restore(ctx context.Context, todo entity.Todo, updatedAt time.Time) error
+	Recreate(ctx context.Context, todo entity.Todo) error
+	DeleteUnchanged(ctx context.Context, id int, updatedAt time.Time) error
+}
+
+const todoColumns = `t.id, t.title, t.description, t.tags, t.duetime, t.projectid, t.workspaceid, t.assigneeid,
+	t.parentid, t.checklist, t.estimateminutes, t.recurrence, t.completed, t.completedat, t.createdat, t.updatedat, t.userid`
+
+// todoAccessCondition matches todos the user referenced by the given placeholder
+// index can see through any of the paths TodoPermission resolves.
+const todoAccessCondition = `(t.userid = $%[1]d
+		OR EXISTS (SELECT 1 FROM todo_shares ts WHERE ts.todoid = t.id AND ts.userid = $%[1]d)
+		OR EXISTS (SELECT 1 FROM projects p WHERE p.id = t.projectid AND p.userid = $%[1]d)
+		OR EXISTS (SELECT 1 FROM project_shares ps WHERE ps.projectid = t.projectid AND ps.userid = $%[1]d)
+		OR EXISTS (SELECT 1 FROM workspace_members wm WHERE wm.workspaceid = t.workspaceid AND wm.userid = $%[1]d))`
+
+// sharedTodosQuery resolves every todo shared with $1, either directly or through
+// a shared project, together with the strongest permission the user holds on it.
+const sharedTodosQuery = `WITH access AS (
+		SELECT ts.todoid, ts.permission FROM todo_shares ts WHERE ts.userid = $1
+		UNION ALL
+		SELECT t.id, ps.permission FROM project_shares ps JOIN todos t ON t.projectid = ps.projectid WHERE ps.userid = $1
+	), ranked AS (
+		SELECT todoid, MAX(CASE permission WHEN 'owner' THEN 3 WHEN 'editor' THEN 2 ELSE 1 END) AS rank
+		FROM access GROUP BY todoid
+	)`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTodo(row rowScanner, extra ...any) (entity.Todo, error) {
+	var todo entity.Todo
+	dest := append([]any{
+		&todo.ID, &todo.Title, &todo.Description, pq.Array(&todo.Tags), &todo.DueDate, &todo.ProjectID, &todo.WorkspaceID,
+		&todo.AssigneeID, &todo.ParentID, &todo.Checklist, &todo.EstimateMinutes, &todo.Recurrence, &todo.Completed, &todo.CompletedAt, &todo.CreatedAt, &todo.UpdatedAt, &todo.OwnerID,
+	}, extra...)
+	err := row.Scan(dest...)
+	return todo, err
 }
 
 type todoRepository struct {
@@ -31,17 +84,14 @@ func NewTodoRepository(db *sql.DB, logger *slog.Logger) TodoRepository {
 	return &todoRepository{db: db, logger: logger}
 }
 
-func (r *todoRepository) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Todo, error) {
+func (r *todoRepository) Get(ctx context.Context, id int) (entity.Todo, error) {
 	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Get", "todo_id", id)
 	logger.Debug("Attempting to fetching todo")
 
-	row := r.db.QueryRowContext(ctx,
-		`SELECT t.id, t.title, t.tags, t.description, t.duetime
-			 	FROM todos t JOIN users u ON t.userid = u.id WHERE t.id = $1 and u.id = $2`,
-		id, userID)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+todoColumns+` FROM todos t WHERE t.id = $1`, id)
 
-	var todo entity.Todo
-	if err := row.Scan(&todo.ID, &todo.Title, pq.Array(&todo.Tags), &todo.Description, &todo.DueDate); err != nil {
+	todo, err := scanTodo(row)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			logger.Warn("Todo not found")
 			return entity.Todo{}, entity.ErrTodoNotFound
@@ -54,13 +104,11 @@ func (r *todoRepository) Get(ctx context.Context, userID uuid.UUID, id int) (ent
 	return todo, nil
 }
 
-func (r *todoRepository) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+func (r *todoRepository) Delete(ctx context.Context, id int) error {
 	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Delete", "todo_id", id)
 	logger.Debug("Attempting to delete todo")
 
-	res, err := r.db.ExecContext(ctx,
-		`DELETE FROM todos t USING users u WHERE t.userid = u.id AND t.id = $1 AND u.id = $2`,
-		id, userID)
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM todos WHERE id = $1`, id)
 	if err != nil {
 		logger.Error("Failed to execute delete query", "error", err)
 		return err
@@ -85,13 +133,19 @@ func (r *todoRepository) Create(ctx context.Context, userID uuid.UUID, todo enti
 	logger.Debug("Attempting to create todo", "title", todo.Title)
 
 	var id int
-	err := r.db.QueryRowContext(ctx,
-		`INSERT INTO todos(title, description, tags, duetime, userid) SELECT $1, $2, $3, $4, id FROM users WHERE id = $5 Returning id`,
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO todos(title, description, tags, duetime, projectid, workspaceid, parentid, checklist, estimateminutes, recurrence, userid) SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, id FROM users WHERE id = $11 Returning id`,
 
 		todo.Title,
 		todo.Description,
 		pq.Array(todo.Tags),
 		todo.DueDate,
+		todo.ProjectID,
+		todo.WorkspaceID,
+		todo.ParentID,
+		todo.Checklist,
+		todo.EstimateMinutes,
+		todo.Recurrence,
 		userID,
 	).Scan(&id)
 	if err != nil {
@@ -103,18 +157,76 @@ func (r *todoRepository) Create(ctx context.Context, userID uuid.UUID, todo enti
 	return id, nil
 }
 
-func (r *todoRepository) Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) error {
+func (r *todoRepository) CreateBatch(ctx context.Context, userID uuid.UUID, todos []entity.Todo) ([]int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "CreateBatch")
+	logger.Debug("Attempting to create todos", "count", len(todos))
+
+	const columns = 11
+	values := make([]string, 0, len(todos))
+	args := make([]any, 0, len(todos)*columns)
+	for i, todo := range todos {
+		placeholders := make([]string, columns)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*columns+j+1)
+		}
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+		args = append(args, todo.Title, todo.Description, pq.Array(todo.Tags), todo.DueDate, todo.ProjectID,
+			todo.WorkspaceID, todo.ParentID, todo.Checklist, todo.EstimateMinutes, todo.Recurrence, userID)
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`INSERT INTO todos(title, description, tags, duetime, projectid, workspaceid, parentid, checklist, estimateminutes, recurrence, userid) VALUES `+
+			strings.Join(values, ", ")+` RETURNING id`,
+		args...,
+	)
+	if err != nil {
+		logger.Error("Failed to insert todos into database", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	ids := make([]int, 0, len(todos))
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			logger.Error("Failed to scan todo id", "error", err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+	// The rows take their ids from the sequence in the order they are listed, while
+	// RETURNING does not promise any order.
+	sort.Ints(ids)
+
+	logger.Info("Successfully created todos", "count", len(ids))
+	return ids, nil
+}
+
+func (r *todoRepository) Update(ctx context.Context, todo entity.Todo) error {
 	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Update")
 	logger.Debug("Attempting to update todo", "todo_id", todo.ID)
 
-	res, err := r.db.ExecContext(ctx,
-		`UPDATE todos t SET title = $1, description = $2, tags = $3, duetime = $4 FROM users u WHERE t.userid = u.id AND t.id =$5 AND u.id =$6`,
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE todos SET title = $1, description = $2, tags = $3, duetime = $4, projectid = $5, checklist = $6,
+		estimateminutes = $7, recurrence = $8 WHERE id = $9`,
 		todo.Title,
 		todo.Description,
 		pq.Array(todo.Tags),
 		todo.DueDate,
+		todo.ProjectID,
+		todo.Checklist,
+		todo.EstimateMinutes,
+		todo.Recurrence,
 		todo.ID,
-		userID,
 	)
 	if err != nil {
 		logger.Error("Failed to execute update query", "error", err)
@@ -142,45 +254,45 @@ func (r *todoRepository) GetAll(ctx context.Context, userID uuid.UUID, paginatio
 	if filters.Tags != nil {
 		logger = logger.With("tags", filters.Tags)
 	}
-	logger.Debug("Attempting to fetching todos", "limit", pagination.Limit, "offset", pagination.Offset)
-
-	var conditions []string
-	var args []any
-	argIndex := 1
-
-	conditions = append(conditions, fmt.Sprintf("u.id = $%d", argIndex))
-	args = append(args, userID)
-	argIndex++
-
-	if filters.DueTime != nil {
-		conditions = append(conditions, fmt.Sprintf("duetime = $%d", argIndex))
-		args = append(args, *filters.DueTime)
-		argIndex++
+	if filters.ProjectID != nil {
+		logger = logger.With("project_id", *filters.ProjectID)
 	}
-	if filters.Tags != nil {
-		conditions = append(conditions, fmt.Sprintf("tags && $%d", argIndex))
-		args = append(args, pq.Array(filters.Tags))
-		argIndex++
+	if filters.WorkspaceID != nil {
+		logger = logger.With("workspace_id", *filters.WorkspaceID)
+	}
+	if filters.AssigneeID != nil {
+		logger = logger.With("assignee_id", *filters.AssigneeID)
 	}
+	if filters.ParentID != nil {
+		logger = logger.With("parent_id", *filters.ParentID)
+	}
+	logger.Debug("Attempting to fetching todos", "limit", pagination.Limit, "offset", pagination.Offset)
+
+	conditions, args := todoFilterConditions(userID, filters)
+	argIndex := len(args) + 1
 
 	whereClause := ""
 	if len(conditions) > 0 {
 		whereClause += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	countQuery := `SELECT COUNT(*) FROM todos t JOIN users u ON t.userid = u.id` + whereClause
+	countQuery := `SELECT COUNT(*) FROM todos t` + whereClause
 	logger.Debug("Executing count query", "query", countQuery, "args", args)
 
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err := conn(ctx, r.db).QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to count todos", "error", err)
+		return nil, 0, err
+	}
 
-	query := `SELECT t.id, t.title, t.description, t.tags, t.duetime FROM todos t JOIN users u ON t.userid = u.id ` + whereClause
+	query := `SELECT ` + todoColumns + ` FROM todos t` + whereClause + ` ORDER BY t.id`
 	logger.Debug("Executing query", "query", query, "args", args)
 
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
 	args = append(args, pagination.Limit, pagination.Offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 
 	if err != nil {
 		logger.Error("Failed to query todos", "error", err)
@@ -196,18 +308,391 @@ func (r *todoRepository) GetAll(ctx context.Context, userID uuid.UUID, paginatio
 
 	var all []entity.Todo
 	for rows.Next() {
-		var todo entity.Todo
-		if err := rows.Scan(&todo.ID, &todo.Title, &todo.Description, pq.Array(&todo.Tags), &todo.DueDate); err != nil {
-			r.logger.Error("Failed to scan todo row", "error", err)
+		todo, err := scanTodo(rows)
+		if err != nil {
+			logger.Error("Failed to scan todo row", "error", err)
 			return nil, 0, err
 		}
 		all = append(all, todo)
 	}
 	if err := rows.Err(); err != nil {
-		r.logger.Error("Error occurred during rows iteration", "error", err)
+		logger.Error("Error occurred during rows iteration", "error", err)
 		return nil, 0, err
 	}
 
 	logger.Info("Successfully fetching todos")
 	return all, total, nil
 }
+
+// GetAgenda returns the todos matching the filters that are due up to to and are
+// either due from from on or still open, ordered by due date.
+func (r *todoRepository) GetAgenda(ctx context.Context, userID uuid.UUID, filters entity.Filters, from, to entity.Date) ([]entity.Todo, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "GetAgenda")
+	logger.Debug("Attempting to fetch agenda todos", "from", from, "to", to)
+
+	conditions, args := todoFilterConditions(userID, filters)
+	conditions = append(conditions, fmt.Sprintf("t.duetime <= $%d AND (t.duetime >= $%d OR NOT t.completed)",
+		len(args)+1, len(args)+2))
+	args = append(args, to, from)
+
+	query := `SELECT ` + todoColumns + ` FROM todos t WHERE ` + strings.Join(conditions, " AND ") +
+		` ORDER BY t.duetime, t.id`
+	all, err := r.queryTodos(ctx, logger, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched agenda todos", "count", len(all))
+	return all, nil
+}
+
+func (r *todoRepository) Export(ctx context.Context, userID uuid.UUID, filters entity.Filters) ([]entity.Todo, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Export")
+	logger.Debug("Attempting to fetch todos for export")
+
+	conditions, args := todoFilterConditions(userID, filters)
+	query := `SELECT ` + todoColumns + ` FROM todos t WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY t.id`
+	all, err := r.queryTodos(ctx, logger, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched todos for export", "count", len(all))
+	return all, nil
+}
+
+func (r *todoRepository) Stream(ctx context.Context, userID uuid.UUID, filters entity.Filters, fn func(entity.Todo) error) error {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Stream")
+	logger.Debug("Attempting to stream todos")
+
+	conditions, args := todoFilterConditions(userID, filters)
+	query := `SELECT ` + todoColumns + ` FROM todos t WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY t.id`
+	count := 0
+	err := r.eachTodo(ctx, logger, query, args, func(todo entity.Todo) error {
+		count++
+		return fn(todo)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Successfully streamed todos", "count", count)
+	return nil
+}
+
+// queryTodos runs a query selecting todoColumns and scans every row.
+func (r *todoRepository) queryTodos(ctx context.Context, logger *slog.Logger, query string, args []any) ([]entity.Todo, error) {
+	var all []entity.Todo
+	err := r.eachTodo(ctx, logger, query, args, func(todo entity.Todo) error {
+		all = append(all, todo)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// eachTodo runs a query selecting todoColumns and passes each row to fn as soon as
+// it is scanned.
+func (r *todoRepository) eachTodo(ctx context.Context, logger *slog.Logger, query string, args []any,
+	fn func(entity.Todo) error) error {
+	logger.Debug("Executing query", "query", query, "args", args)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("Failed to query todos", "error", err)
+		return err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			logger.Error("Failed to scan todo row", "error", err)
+			return err
+		}
+		if err := fn(todo); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return err
+	}
+	return nil
+}
+
+// todoFilterConditions builds the WHERE conditions shared by the queries that list
+// todos, numbering their placeholders from $1.
+func todoFilterConditions(userID uuid.UUID, filters entity.Filters) ([]string, []any) {
+	var conditions []string
+	var args []any
+	argIndex := 1
+
+	// Access to a parent todo, project or workspace is checked by the service, so
+	// listing one returns every todo in it rather than only the ones the user owns.
+	// Given together, they all apply. Without any of them, the todos assigned to the
+	// user are listed wherever they live, as long as the user can still see them, or
+	// otherwise the user's personal todos.
+	scoped := false
+	if filters.ParentID != nil {
+		conditions = append(conditions, fmt.Sprintf("t.parentid = $%d", argIndex))
+		args = append(args, *filters.ParentID)
+		argIndex++
+		scoped = true
+	}
+	if filters.ProjectID != nil {
+		conditions = append(conditions, fmt.Sprintf("t.projectid = $%d", argIndex))
+		args = append(args, *filters.ProjectID)
+		argIndex++
+		scoped = true
+	}
+	if filters.WorkspaceID != nil {
+		conditions = append(conditions, fmt.Sprintf("t.workspaceid = $%d", argIndex))
+		args = append(args, *filters.WorkspaceID)
+		argIndex++
+		scoped = true
+	}
+
+	assigneeFilter := filters.AssigneeID != nil
+	if !scoped {
+		if assigneeFilter && *filters.AssigneeID == userID {
+			conditions = append(conditions, fmt.Sprintf("t.assigneeid = $%d AND "+todoAccessCondition, argIndex))
+			assigneeFilter = false
+		} else {
+			conditions = append(conditions, fmt.Sprintf("t.userid = $%d AND t.workspaceid IS NULL", argIndex))
+		}
+		args = append(args, userID)
+		argIndex++
+	}
+
+	if assigneeFilter {
+		conditions = append(conditions, fmt.Sprintf("t.assigneeid = $%d", argIndex))
+		args = append(args, *filters.AssigneeID)
+		argIndex++
+	}
+
+	if filters.DueTime != nil {
+		conditions = append(conditions, fmt.Sprintf("duetime = $%d", argIndex))
+		args = append(args, *filters.DueTime)
+		argIndex++
+	}
+	if filters.Tags != nil {
+		conditions = append(conditions, fmt.Sprintf("tags && $%d", argIndex))
+		args = append(args, pq.Array(filters.Tags))
+	}
+	return conditions, args
+}
+
+func (r *todoRepository) Assign(ctx context.Context, id int, assigneeID *uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Assign", "todo_id", id)
+	logger.Debug("Attempting to assign todo", "assignee_id", assigneeID)
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE todos SET assigneeid = $1 WHERE id = $2`, assigneeID, id)
+	if err != nil {
+		logger.Error("Failed to execute assign query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No todo found to assign")
+		return entity.ErrTodoNotFound
+	}
+
+	logger.Info("Successfully assigned todo")
+	return nil
+}
+
+func (r *todoRepository) GetSharedWith(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedTodo, int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "GetSharedWith")
+	logger.Debug("Attempting to fetch shared todos", "limit", pagination.Limit, "offset", pagination.Offset)
+
+	from := ` FROM ranked r JOIN todos t ON t.id = r.todoid JOIN users u ON u.id = t.userid WHERE t.userid <> $1`
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, sharedTodosQuery+` SELECT COUNT(*)`+from, userID).Scan(&total)
+	if err != nil {
+		logger.Error("Failed to count shared todos", "error", err)
+		return nil, 0, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		sharedTodosQuery+` SELECT `+todoColumns+`, u.username,
+			CASE r.rank WHEN 3 THEN 'owner' WHEN 2 THEN 'editor' ELSE 'viewer' END`+
+			from+` ORDER BY t.id LIMIT $2 OFFSET $3`,
+		userID, pagination.Limit, pagination.Offset,
+	)
+	if err != nil {
+		logger.Error("Failed to query shared todos", "error", err)
+		return nil, 0, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var all []entity.SharedTodo
+	for rows.Next() {
+		var shared entity.SharedTodo
+		todo, err := scanTodo(rows, &shared.Owner, &shared.Permission)
+		if err != nil {
+			logger.Error("Failed to scan shared todo row", "error", err)
+			return nil, 0, err
+		}
+		shared.Todo = todo
+		all = append(all, shared)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Successfully fetched shared todos")
+	return all, total, nil
+}
+
+func (r *todoRepository) SetCompleted(ctx context.Context, id int, completed bool) error {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "SetCompleted", "todo_id", id)
+	logger.Debug("Attempting to set todo completion", "completed", completed)
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE todos SET completed = $1 WHERE id = $2`, completed, id)
+	if err != nil {
+		logger.Error("Failed to execute completion query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No todo found to complete")
+		return entity.ErrTodoNotFound
+	}
+
+	logger.Info("Successfully set todo completion")
+	return nil
+}
+
+// Restore writes back every editable field of a todo, provided it was not modified
+// after updatedAt.
+func (r *todoRepository) Restore(ctx context.Context, todo entity.Todo, updatedAt time.Time) error {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Restore", "todo_id", todo.ID)
+	logger.Debug("Attempting to restore todo")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE todos SET title = $1, description = $2, tags = $3, duetime = $4, projectid = $5, assigneeid = $6,
+		checklist = $7, estimateminutes = $8, recurrence = $9, completed = $10, completedat = $11
+		WHERE id = $12 AND updatedat = $13`,
+		todo.Title,
+		todo.Description,
+		pq.Array(todo.Tags),
+		todo.DueDate,
+		todo.ProjectID,
+		todo.AssigneeID,
+		todo.Checklist,
+		todo.EstimateMinutes,
+		todo.Recurrence,
+		todo.Completed,
+		todo.CompletedAt,
+		todo.ID,
+		updatedAt,
+	)
+	if err != nil {
+		logger.Error("Failed to execute restore query", "error", err)
+		return mapUndoError(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("Todo was modified or deleted since")
+		return entity.ErrUndoConflict
+	}
+
+	logger.Info("Successfully restored todo")
+	return nil
+}
+
+// Recreate inserts a deleted todo again under its original ID.
+func (r *todoRepository) Recreate(ctx context.Context, todo entity.Todo) error {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "Recreate", "todo_id", todo.ID)
+	logger.Debug("Attempting to recreate todo")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO todos(id, title, description, tags, duetime, projectid, workspaceid, assigneeid, parentid, checklist,
+		estimateminutes, recurrence, completed, completedat, createdat, userid)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, COALESCE($15, CURRENT_TIMESTAMP), $16)`,
+		todo.ID,
+		todo.Title,
+		todo.Description,
+		pq.Array(todo.Tags),
+		todo.DueDate,
+		todo.ProjectID,
+		todo.WorkspaceID,
+		todo.AssigneeID,
+		todo.ParentID,
+		todo.Checklist,
+		todo.EstimateMinutes,
+		todo.Recurrence,
+		todo.Completed,
+		todo.CompletedAt,
+		todo.CreatedAt,
+		todo.OwnerID,
+	)
+	if err != nil {
+		logger.Error("Failed to insert todo into database", "error", err)
+		return mapUndoError(err)
+	}
+
+	logger.Info("Successfully recreated todo")
+	return nil
+}
+
+// DeleteUnchanged deletes a todo, provided it was not modified after updatedAt.
+func (r *todoRepository) DeleteUnchanged(ctx context.Context, id int, updatedAt time.Time) error {
+	logger := utils.SetupLogger(ctx, r.logger, "todo_repository", "DeleteUnchanged", "todo_id", id)
+	logger.Debug("Attempting to delete todo")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM todos WHERE id = $1 AND updatedat = $2`, id, updatedAt)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("Todo was modified or deleted since")
+		return entity.ErrUndoConflict
+	}
+
+	logger.Info("Successfully deleted todo")
+	return nil
+}
+
+// mapUndoError reports a restored todo that no longer fits the database, such as
+// one whose project, workspace or owner was deleted meanwhile, as a conflict.
+func mapUndoError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && (pqErr.Code == "23503" || pqErr.Code == "23505") {
+		return entity.ErrUndoConflict
+	}
+	return err
+}
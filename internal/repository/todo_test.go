@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoFilterConditions(t *testing.T) {
+	userID := uuid.New()
+	parentID, projectID, workspaceID := 1, 2, 3
+
+	testCases := []struct {
+		name               string
+		filters            entity.Filters
+		expectedConditions []string
+		expectedArgs       []any
+	}{
+		{
+			name:               "personal todos",
+			expectedConditions: []string{"t.userid = $1 AND t.workspaceid IS NULL"},
+			expectedArgs:       []any{userID},
+		},
+		{
+			name:               "assigned to me",
+			filters:            entity.Filters{AssigneeID: &userID},
+			expectedConditions: []string{fmt.Sprintf("t.assigneeid = $%d AND "+todoAccessCondition, 1)},
+			expectedArgs:       []any{userID},
+		},
+		{
+			name:               "parent and project",
+			filters:            entity.Filters{ParentID: &parentID, ProjectID: &projectID},
+			expectedConditions: []string{"t.parentid = $1", "t.projectid = $2"},
+			expectedArgs:       []any{parentID, projectID},
+		},
+		{
+			name:               "parent, workspace and assigned to me",
+			filters:            entity.Filters{ParentID: &parentID, WorkspaceID: &workspaceID, AssigneeID: &userID},
+			expectedConditions: []string{"t.parentid = $1", "t.workspaceid = $2", "t.assigneeid = $3"},
+			expectedArgs:       []any{parentID, workspaceID, userID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions, args := todoFilterConditions(userID, tc.filters)
+			assert.Equal(t, tc.expectedConditions, conditions)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
 	"github.com/GlebMoskalev/go-todo-api/internal/utils"
 	"github.com/google/uuid"
 	"log/slog"
@@ -10,9 +12,47 @@ import (
 )
 
 type TokenRepository interface {
-	SaveRefreshToken(ctx context.Context, userID uuid.UUID, token string, expiry time.Duration) error
-	ValidateRefreshToken(ctx context.Context, userID uuid.UUID, token string) (bool, error)
-	DeleteRefreshToken(ctx context.Context, refreshToken string) error
+	// SaveRefreshToken stores the hash of a refresh token of the given family.
+	// parentID is the token it replaces, nil for the first token of a login.
+	SaveRefreshToken(ctx context.Context, userID uuid.UUID, tokenHash string, familyID uuid.UUID, parentID *int,
+		expiry time.Duration) error
+	// GetRefreshToken finds an unexpired refresh token of the user by its hash,
+	// rotated or not.
+	GetRefreshToken(ctx context.Context, userID uuid.UUID, tokenHash string) (entity.RefreshToken, error)
+	// MarkRefreshTokenRotated records that the token was replaced. It reports false
+	// if the token had been rotated already.
+	MarkRefreshTokenRotated(ctx context.Context, id int) (bool, error)
+	CreateSession(ctx context.Context, userID uuid.UUID, session entity.Session) error
+	// CreateAppPasswordSession starts a session that signs in with the app password
+	// hashed to passwordHash instead of refresh tokens.
+	CreateAppPasswordSession(ctx context.Context, userID uuid.UUID, session entity.Session, passwordHash string) error
+	// UseAppPassword returns the user the app password was issued to, provided it
+	// belongs to username, and records that its session was just used.
+	UseAppPassword(ctx context.Context, username, passwordHash string) (uuid.UUID, error)
+	// GetSessions lists the sessions of the user, the most recently used first.
+	GetSessions(ctx context.Context, userID uuid.UUID) ([]entity.Session, error)
+	// TouchSession records that the session was just used.
+	TouchSession(ctx context.Context, id uuid.UUID) error
+	// DeleteSession ends a session of the user, revoking its refresh tokens.
+	DeleteSession(ctx context.Context, userID, id uuid.UUID) error
+	// DeleteUserSessions ends every session of the user, revoking every refresh token.
+	DeleteUserSessions(ctx context.Context, userID uuid.UUID) error
+	// DeleteOtherSessions ends every session of the user but keep.
+	DeleteOtherSessions(ctx context.Context, userID, keep uuid.UUID) error
+	// RevokeAccessToken rejects the access token with the given jti until it expires.
+	RevokeAccessToken(ctx context.Context, userID uuid.UUID, jti uuid.UUID, expiresAt time.Time) error
+	// RevokeUserAccessTokens rejects every access token of the user issued before the
+	// second before falls in. Tokens only record whole seconds, so one issued later in
+	// that second stays valid.
+	RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID, before time.Time) error
+	// IsAccessTokenRevoked reports whether the access token was revoked on its own,
+	// with every token of the user or by ending its session. sessionID is nil for
+	// tokens issued before sessions existed.
+	IsAccessTokenRevoked(ctx context.Context, userID, jti, sessionID uuid.UUID, issuedAt time.Time) (bool, error)
+	// DeleteExpired removes the refresh tokens, access token revocations and
+	// password reset tokens of every user that have expired and the login sessions
+	// left without tokens, and returns how many rows it removed.
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 type tokenRepository struct {
@@ -24,49 +64,325 @@ func NewTokenRepository(db *sql.DB, logger *slog.Logger) TokenRepository {
 	return &tokenRepository{db: db, logger: logger}
 }
 
-func (r *tokenRepository) SaveRefreshToken(ctx context.Context, userID uuid.UUID, token string, expiry time.Duration) error {
+func (r *tokenRepository) SaveRefreshToken(ctx context.Context, userID uuid.UUID, tokenHash string, familyID uuid.UUID,
+	parentID *int, expiry time.Duration) error {
 	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "SaveRefreshToken")
 
-	_, err := r.db.ExecContext(ctx,
-		"INSERT INTO refresh_tokens (token, expirydate, userid) VALUES ($1, $2, $3)",
-		token,
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO refresh_tokens (tokenhash, expirydate, userid, familyid, parentid) VALUES ($1, $2, $3, $4, $5)",
+		tokenHash,
 		time.Now().UTC().Add(expiry),
 		userID,
+		familyID,
+		parentID,
 	)
 	if err != nil {
 		logger.Error("Failed to save refresh token", "error", err)
+		return err
 	}
 	return nil
 }
 
-func (r *tokenRepository) ValidateRefreshToken(ctx context.Context, userID uuid.UUID, token string) (bool, error) {
-	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "ValidateRefreshToken")
+func (r *tokenRepository) GetRefreshToken(ctx context.Context, userID uuid.UUID, tokenHash string) (entity.RefreshToken, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "GetRefreshToken")
 
-	_, err := r.db.ExecContext(ctx, "DELETE FROM refresh_tokens WHERE userid = $1 AND expirydate < NOW()", userID)
+	db := conn(ctx, r.db)
+	_, err := db.ExecContext(ctx, "DELETE FROM refresh_tokens WHERE userid = $1 AND expirydate < NOW()", userID)
 	if err != nil {
 		logger.Error("Failed to delete expired refresh tokens", "error", err)
-		return false, err
+		return entity.RefreshToken{}, err
 	}
 
-	var count int
-	err = r.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM refresh_tokens rt JOIN users u ON rt.userid = u.id WHERE u.id = $1 AND rt.token = $2",
-		userID, token,
-	).Scan(&count)
+	var refreshToken entity.RefreshToken
+	var parentID sql.NullInt64
+	var rotatedAt sql.NullTime
+	err = db.QueryRowContext(ctx,
+		"SELECT id, userid, familyid, parentid, expirydate, rotatedat FROM refresh_tokens WHERE userid = $1 AND tokenhash = $2",
+		userID, tokenHash,
+	).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.FamilyID, &parentID, &refreshToken.ExpiresAt, &rotatedAt)
 	if err != nil {
-		logger.Error("Failed to validate refresh token", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Refresh token not found")
+			return entity.RefreshToken{}, entity.ErrInvalidToken
+		}
+		logger.Error("Failed to get refresh token", "error", err)
+		return entity.RefreshToken{}, err
+	}
+	if parentID.Valid {
+		id := int(parentID.Int64)
+		refreshToken.ParentID = &id
+	}
+	if rotatedAt.Valid {
+		refreshToken.RotatedAt = &rotatedAt.Time
+	}
+	return refreshToken, nil
+}
+
+func (r *tokenRepository) MarkRefreshTokenRotated(ctx context.Context, id int) (bool, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "MarkRefreshTokenRotated")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE refresh_tokens SET rotatedat = NOW() WHERE id = $1 AND rotatedat IS NULL", id)
+	if err != nil {
+		logger.Error("Failed to mark refresh token rotated", "error", err)
+		return false, err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
 		return false, err
 	}
-	return count > 0, nil
+	return rowsAffected > 0, nil
+}
+
+func (r *tokenRepository) CreateSession(ctx context.Context, userID uuid.UUID, session entity.Session) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "CreateSession")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO sessions (id, userid, devicename, useragent, ip, createdat, lastusedat)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)`,
+		session.ID, userID, session.DeviceName, session.UserAgent, session.IP, session.CreatedAt,
+	)
+	if err != nil {
+		logger.Error("Failed to create session", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *tokenRepository) CreateAppPasswordSession(ctx context.Context, userID uuid.UUID, session entity.Session,
+	passwordHash string) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "CreateAppPasswordSession")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO sessions (id, userid, devicename, useragent, ip, apppasswordhash, createdat, lastusedat)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		session.ID, userID, session.DeviceName, session.UserAgent, session.IP, passwordHash, session.CreatedAt,
+	)
+	if err != nil {
+		logger.Error("Failed to create app password session", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *tokenRepository) UseAppPassword(ctx context.Context, username, passwordHash string) (uuid.UUID, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "UseAppPassword")
+
+	var userID uuid.UUID
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE sessions s SET lastusedat = NOW() FROM users u
+		WHERE s.apppasswordhash = $1 AND u.id = s.userid AND u.username = $2
+		RETURNING s.userid`,
+		passwordHash, username,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("App password not found")
+			return uuid.Nil, entity.ErrWrongPassword
+		}
+		logger.Error("Failed to use app password", "error", err)
+		return uuid.Nil, err
+	}
+	return userID, nil
+}
+
+func (r *tokenRepository) GetSessions(ctx context.Context, userID uuid.UUID) ([]entity.Session, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "GetSessions")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT s.id, s.devicename, s.useragent, s.ip, s.apppasswordhash IS NOT NULL, s.createdat, s.lastusedat
+		FROM sessions s
+		WHERE s.userid = $1 AND (s.apppasswordhash IS NOT NULL OR EXISTS (
+			SELECT 1 FROM refresh_tokens rt WHERE rt.familyid = s.id AND rt.rotatedat IS NULL AND rt.expirydate >= NOW()
+		))
+		ORDER BY s.lastusedat DESC, s.id`,
+		userID,
+	)
+	if err != nil {
+		logger.Error("Failed to query sessions", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	sessions := []entity.Session{}
+	for rows.Next() {
+		var session entity.Session
+		err := rows.Scan(&session.ID, &session.DeviceName, &session.UserAgent, &session.IP, &session.AppPassword,
+			&session.CreatedAt, &session.LastUsedAt)
+		if err != nil {
+			logger.Error("Failed to scan session row", "error", err)
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *tokenRepository) TouchSession(ctx context.Context, id uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "TouchSession")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE sessions SET lastusedat = NOW() WHERE id = $1", id)
+	if err != nil {
+		logger.Error("Failed to update session", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *tokenRepository) DeleteSession(ctx context.Context, userID, id uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "DeleteSession")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM sessions WHERE id = $1 AND userid = $2", id, userID)
+	if err != nil {
+		logger.Error("Failed to delete session", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("Session not found")
+		return entity.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *tokenRepository) DeleteUserSessions(ctx context.Context, userID uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "DeleteUserSessions")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM sessions WHERE userid = $1", userID)
+	if err != nil {
+		logger.Error("Failed to delete sessions", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *tokenRepository) DeleteOtherSessions(ctx context.Context, userID, keep uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "DeleteOtherSessions")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM sessions WHERE userid = $1 AND id <> $2", userID, keep)
+	if err != nil {
+		logger.Error("Failed to delete sessions", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *tokenRepository) RevokeAccessToken(ctx context.Context, userID uuid.UUID, jti uuid.UUID, expiresAt time.Time) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "RevokeAccessToken")
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM revoked_access_tokens WHERE expiresat < NOW()")
+	if err != nil {
+		logger.Error("Failed to delete expired revoked access tokens", "error", err)
+		return err
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO revoked_access_tokens (jti, userid, expiresat) VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING`,
+		jti, userID, expiresAt,
+	)
+	if err != nil {
+		logger.Error("Failed to revoke access token", "error", err)
+		return err
+	}
+	return nil
 }
 
-func (r *tokenRepository) DeleteRefreshToken(ctx context.Context, refreshToken string) error {
-	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "DeleteRefreshToken")
+func (r *tokenRepository) RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "RevokeUserAccessTokens")
 
-	_, err := r.db.ExecContext(ctx, "DELETE FROM refresh_tokens WHERE token = $1", refreshToken)
+	_, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET tokensrevokedat = $1 WHERE id = $2",
+		before.UTC().Truncate(time.Second), userID)
 	if err != nil {
-		logger.Error("Failed to delete refresh tokens", "error", err)
+		logger.Error("Failed to revoke access tokens", "error", err)
 		return err
 	}
 	return nil
 }
+
+func (r *tokenRepository) IsAccessTokenRevoked(ctx context.Context, userID, jti, sessionID uuid.UUID, issuedAt time.Time) (bool, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "IsAccessTokenRevoked")
+
+	var revoked bool
+	var revokedAt sql.NullTime
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM revoked_access_tokens WHERE jti = $1)
+			OR ($3 <> '00000000-0000-0000-0000-000000000000'::uuid
+				AND NOT EXISTS (SELECT 1 FROM sessions WHERE id = $3 AND userid = $2)),
+			(SELECT tokensrevokedat FROM users WHERE id = $2)`,
+		jti, userID, sessionID,
+	).Scan(&revoked, &revokedAt)
+	if err != nil {
+		logger.Error("Failed to check access token revocation", "error", err)
+		return false, err
+	}
+	// Both times are whole seconds, so a token issued in the second of the revocation
+	// is not after it and stays valid.
+	return revoked || (revokedAt.Valid && revokedAt.Time.After(issuedAt)), nil
+}
+
+func (r *tokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "token_repository", "DeleteExpired")
+
+	db := conn(ctx, r.db)
+	res, err := db.ExecContext(ctx, "DELETE FROM refresh_tokens WHERE expirydate < NOW()")
+	if err != nil {
+		logger.Error("Failed to delete expired refresh tokens", "error", err)
+		return 0, err
+	}
+	refreshTokens, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return 0, err
+	}
+
+	res, err = db.ExecContext(ctx, "DELETE FROM revoked_access_tokens WHERE expiresat < NOW()")
+	if err != nil {
+		logger.Error("Failed to delete expired revoked access tokens", "error", err)
+		return 0, err
+	}
+	accessTokens, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return 0, err
+	}
+
+	res, err = db.ExecContext(ctx, "DELETE FROM password_reset_tokens WHERE expiresat < NOW()")
+	if err != nil {
+		logger.Error("Failed to delete expired password reset tokens", "error", err)
+		return 0, err
+	}
+	resetTokens, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return 0, err
+	}
+
+	res, err = db.ExecContext(ctx,
+		`DELETE FROM sessions s WHERE s.apppasswordhash IS NULL
+			AND NOT EXISTS (SELECT 1 FROM refresh_tokens rt WHERE rt.familyid = s.id)`)
+	if err != nil {
+		logger.Error("Failed to delete sessions without tokens", "error", err)
+		return 0, err
+	}
+	sessions, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return 0, err
+	}
+	return refreshTokens + accessTokens + resetTokens + sessions, nil
+}
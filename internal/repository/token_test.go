@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRepository_RevokeUserAccessTokens(t *testing.T) {
+	db := newFakeDB(t)
+	repo := NewTokenRepository(db, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	userID := uuid.New()
+	logoutAt := time.Date(2025, 3, 14, 12, 0, 0, 700_000_000, time.UTC)
+
+	require.NoError(t, repo.RevokeUserAccessTokens(context.Background(), userID, logoutAt))
+
+	testCases := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{name: "second before the logout", issuedAt: logoutAt.Add(-time.Second), revoked: true},
+		{name: "same second as the logout", issuedAt: logoutAt.Add(200 * time.Millisecond), revoked: false},
+		{name: "after the logout", issuedAt: logoutAt.Add(time.Second), revoked: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Tokens carry their issue time in whole seconds.
+			issuedAt := tc.issuedAt.Truncate(time.Second)
+			revoked, err := repo.IsAccessTokenRevoked(context.Background(), userID, uuid.New(), uuid.Nil, issuedAt)
+			require.NoError(t, err)
+			assert.Equal(t, tc.revoked, revoked)
+		})
+	}
+
+	revoked, err := repo.IsAccessTokenRevoked(context.Background(), uuid.New(), uuid.New(), uuid.Nil, logoutAt.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
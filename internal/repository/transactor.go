@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"log/slog"
+)
+
+// Transactor runs several repository calls in one database transaction.
+type Transactor interface {
+	// WithinTransaction calls fn with a context carrying the transaction. Repositories
+	// that receive this context run their queries in it. The transaction is committed
+	// if fn returns nil and rolled back otherwise. Calls made while a transaction is
+	// already in progress run in a savepoint, so a failing fn only undoes its own work.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// txState is stored in the context of a running transaction. depth counts the
+// savepoints currently open and names the next one.
+type txState struct {
+	tx    *sql.Tx
+	depth int
+}
+
+// querier is the part of *sql.DB and *sql.Tx the repositories use.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction carried by ctx, or db when there is none.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if state, ok := ctx.Value(txKey{}).(txState); ok {
+		return state.tx
+	}
+	return db
+}
+
+type transactor struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewTransactor(db *sql.DB, logger *slog.Logger) Transactor {
+	return &transactor{db: db, logger: logger}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	logger := utils.SetupLogger(ctx, t.logger, "transactor", "WithinTransaction")
+	if state, ok := ctx.Value(txKey{}).(txState); ok {
+		return t.withinSavepoint(ctx, logger, state, fn)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to begin transaction", "error", err)
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, txState{tx: tx})); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error("Failed to rollback transaction", "error", rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Failed to commit transaction", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (t *transactor) withinSavepoint(ctx context.Context, logger *slog.Logger, state txState,
+	fn func(ctx context.Context) error) error {
+	name := fmt.Sprintf("sp_%d", state.depth)
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		logger.Error("Failed to create savepoint", "error", err)
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, txState{tx: state.tx, depth: state.depth + 1})); err != nil {
+		if _, rollbackErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			logger.Error("Failed to rollback to savepoint", "error", rollbackErr)
+		}
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		logger.Error("Failed to release savepoint", "error", err)
+		return err
+	}
+	return nil
+}
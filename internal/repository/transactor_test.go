@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactor_CreateTreeSeesOwnWrites(t *testing.T) {
+	db := newFakeDB(t)
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	todos := NewTodoRepository(db, logger)
+	shares := NewShareRepository(db, logger)
+	tx := NewTransactor(db, logger)
+	userID := uuid.New()
+
+	// Mirrors the todo service building a tree: every subtask's parent is created
+	// and then checked for edit access inside the same transaction.
+	var ids []int
+	err := tx.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		parentID, err := todos.Create(ctx, userID, entity.Todo{Title: "Trip"})
+		if err != nil {
+			return err
+		}
+		ids = append(ids, parentID)
+		for _, title := range []string{"Book flights", "Pack"} {
+			permission, err := shares.TodoPermission(ctx, userID, parentID)
+			if err != nil {
+				return err
+			}
+			if permission != entity.PermissionOwner {
+				return fmt.Errorf("unexpected permission %q", permission)
+			}
+			id, err := todos.Create(ctx, userID, entity.Todo{Title: title, ParentID: &parentID})
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	permission, err := shares.TodoPermission(context.Background(), userID, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, entity.PermissionOwner, permission)
+}
+
+func TestTransactor_RollbackHidesWrites(t *testing.T) {
+	db := newFakeDB(t)
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	todos := NewTodoRepository(db, logger)
+	shares := NewShareRepository(db, logger)
+	tx := NewTransactor(db, logger)
+	userID := uuid.New()
+
+	var id int
+	err := tx.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		var err error
+		id, err = todos.Create(ctx, userID, entity.Todo{Title: "Trip"})
+		if err != nil {
+			return err
+		}
+		return entity.ErrForbidden
+	})
+	require.ErrorIs(t, err, entity.ErrForbidden)
+
+	_, err = shares.TodoPermission(context.Background(), userID, id)
+	assert.ErrorIs(t, err, entity.ErrTodoNotFound)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+type UndoRepository interface {
+	Create(ctx context.Context, record entity.UndoRecord) error
+	Take(ctx context.Context, userID uuid.UUID, token uuid.UUID) ([]entity.UndoRecord, error)
+}
+
+type undoRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewUndoRepository(db *sql.DB, logger *slog.Logger) UndoRepository {
+	return &undoRepository{db: db, logger: logger}
+}
+
+// Create stores an undo token. The user's expired tokens are purged along the way.
+func (r *undoRepository) Create(ctx context.Context, record entity.UndoRecord) error {
+	logger := utils.SetupLogger(ctx, r.logger, "undo_repository", "Create",
+		"todo_id", record.TodoID, "operation", record.Operation)
+	logger.Debug("Attempting to create undo token")
+
+	var before []byte
+	if record.Before != nil {
+		var err error
+		before, err = json.Marshal(record.Before)
+		if err != nil {
+			logger.Error("Failed to marshal before-image", "error", err)
+			return err
+		}
+	}
+
+	db := conn(ctx, r.db)
+	if _, err := db.ExecContext(ctx,
+		`DELETE FROM undo_tokens WHERE userid = $1 AND expiresat < now()`, record.UserID); err != nil {
+		logger.Error("Failed to purge expired undo tokens", "error", err)
+		return err
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO undo_tokens(token, userid, todoid, ownerid, operation, before, updatedat, expiresat)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		record.Token,
+		record.UserID,
+		record.TodoID,
+		record.OwnerID,
+		record.Operation,
+		before,
+		record.UpdatedAt,
+		record.ExpiresAt,
+	)
+	if err != nil {
+		logger.Error("Failed to insert undo token", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully created undo token")
+	return nil
+}
+
+// Take marks the unexpired records of a token as used and returns them in the order
+// the changes were made, so each token works only once.
+func (r *undoRepository) Take(ctx context.Context, userID uuid.UUID, token uuid.UUID) ([]entity.UndoRecord, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "undo_repository", "Take")
+	logger.Debug("Attempting to take undo token")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`WITH taken AS (
+			UPDATE undo_tokens SET usedat = now()
+			WHERE token = $1 AND userid = $2 AND usedat IS NULL AND expiresat > now()
+			RETURNING id, token, userid, todoid, ownerid, operation, before, updatedat, expiresat
+		)
+		SELECT token, userid, todoid, ownerid, operation, before, updatedat, expiresat FROM taken ORDER BY id`,
+		token, userID,
+	)
+	if err != nil {
+		logger.Error("Failed to take undo token", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	var records []entity.UndoRecord
+	for rows.Next() {
+		var record entity.UndoRecord
+		var before []byte
+		err := rows.Scan(&record.Token, &record.UserID, &record.TodoID, &record.OwnerID, &record.Operation,
+			&before, &record.UpdatedAt, &record.ExpiresAt)
+		if err != nil {
+			logger.Error("Failed to scan undo record", "error", err)
+			return nil, err
+		}
+		if before != nil {
+			record.Before = &entity.Todo{}
+			if err := json.Unmarshal(before, record.Before); err != nil {
+				logger.Error("Failed to unmarshal before-image", "error", err)
+				return nil, err
+			}
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		logger.Warn("Undo token not found, used or expired")
+		return nil, entity.ErrUndoNotFound
+	}
+
+	logger.Info("Successfully took undo token", "changes", len(records))
+	return records, nil
+}
@@ -15,6 +15,9 @@ type UserRepository interface {
 	Create(ctx context.Context, user entity.UserLogin) (entity.User, error)
 	Get(ctx context.Context, id uuid.UUID) (entity.User, error)
 	GetByUsername(ctx context.Context, username string) (entity.User, error)
+	UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) error
+	UpdateEmail(ctx context.Context, id uuid.UUID, email string) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
 }
 
 type userRepository struct {
@@ -37,7 +40,7 @@ func (r *userRepository) Create(ctx context.Context, user entity.UserLogin) (ent
 	}
 
 	id := uuid.New()
-	_, err = r.db.ExecContext(ctx, "INSERT INTO users(id, username, passwordhash) VALUES($1, $2, $3)",
+	_, err = conn(ctx, r.db).ExecContext(ctx, "INSERT INTO users(id, username, passwordhash) VALUES($1, $2, $3)",
 		id,
 		user.Username,
 		passwordHash,
@@ -63,10 +66,12 @@ func (r *userRepository) Get(ctx context.Context, id uuid.UUID) (entity.User, er
 	logger.Debug("Attempting to fetch user")
 
 	user := entity.User{}
-	err := r.db.QueryRowContext(ctx, "SELECT id, username, passwordhash FROM users WHERE id=$1", id).Scan(
+	err := conn(ctx, r.db).QueryRowContext(ctx, "SELECT id, username, passwordhash, timezone, COALESCE(email, '') FROM users WHERE id=$1", id).Scan(
 		&user.ID,
 		&user.Username,
 		&user.PasswordHash,
+		&user.Timezone,
+		&user.Email,
 	)
 
 	if err != nil {
@@ -87,11 +92,13 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (en
 	logger.Debug("Attempting to fetch user by username")
 
 	var user entity.User
-	err := r.db.QueryRowContext(ctx, "SELECT id, username, passwordhash FROM users WHERE username=$1",
+	err := conn(ctx, r.db).QueryRowContext(ctx, "SELECT id, username, passwordhash, timezone, COALESCE(email, '') FROM users WHERE username=$1",
 		username,
 	).Scan(&user.ID,
 		&user.Username,
 		&user.PasswordHash,
+		&user.Timezone,
+		&user.Email,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -105,3 +112,72 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (en
 	logger.Info("Successfully fetched user")
 	return user, nil
 }
+
+func (r *userRepository) UpdateTimezone(ctx context.Context, id uuid.UUID, timezone string) error {
+	logger := utils.SetupLogger(ctx, r.logger, "user_repository", "UpdateTimezone")
+	logger.Debug("Attempting to update timezone", "timezone", timezone)
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET timezone = $1 WHERE id = $2", timezone, id)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("User not found")
+		return entity.ErrUserNotFound
+	}
+
+	logger.Info("Successfully updated timezone")
+	return nil
+}
+
+func (r *userRepository) UpdateEmail(ctx context.Context, id uuid.UUID, email string) error {
+	logger := utils.SetupLogger(ctx, r.logger, "user_repository", "UpdateEmail")
+	logger.Debug("Attempting to update email")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET email = $1 WHERE id = $2", email, id)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("User not found")
+		return entity.ErrUserNotFound
+	}
+
+	logger.Info("Successfully updated email")
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	logger := utils.SetupLogger(ctx, r.logger, "user_repository", "UpdatePassword")
+	logger.Debug("Attempting to update password")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE users SET passwordhash = $1 WHERE id = $2", passwordHash, id)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("User not found")
+		return entity.ErrUserNotFound
+	}
+
+	logger.Info("Successfully updated password")
+	return nil
+}
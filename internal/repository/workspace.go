@@ -0,0 +1,440 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"log/slog"
+	"time"
+)
+
+type WorkspaceRepository interface {
+	Create(ctx context.Context, userID uuid.UUID, workspace entity.Workspace) (int, error)
+	Get(ctx context.Context, userID uuid.UUID, id int) (entity.Workspace, error)
+	GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Workspace, error)
+	Delete(ctx context.Context, id int) error
+	GetRole(ctx context.Context, userID uuid.UUID, id int) (entity.WorkspaceRole, error)
+	GetMembers(ctx context.Context, id int) ([]entity.WorkspaceMember, error)
+	AddMember(ctx context.Context, id int, userID uuid.UUID, role entity.WorkspaceRole) error
+	UpdateMemberRole(ctx context.Context, id int, userID uuid.UUID, role entity.WorkspaceRole) error
+	DeleteMember(ctx context.Context, id int, userID uuid.UUID) error
+	CountAdmins(ctx context.Context, id int) (int, error)
+	CreateInvitation(ctx context.Context, userID uuid.UUID, invitation entity.Invitation, tokenHash string) (int, error)
+	GetInvitations(ctx context.Context, id int) ([]entity.Invitation, error)
+	DeleteInvitation(ctx context.Context, id int, invitationID int) error
+	ClaimInvitation(ctx context.Context, userID uuid.UUID, tokenHash string) (entity.Invitation, error)
+}
+
+type workspaceRepository struct {
+	db     *sql.DB
+	tx     Transactor
+	logger *slog.Logger
+}
+
+func NewWorkspaceRepository(db *sql.DB, logger *slog.Logger) WorkspaceRepository {
+	return &workspaceRepository{db: db, tx: NewTransactor(db, logger), logger: logger}
+}
+
+// Create inserts the workspace and makes its creator the first admin.
+func (r *workspaceRepository) Create(ctx context.Context, userID uuid.UUID, workspace entity.Workspace) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "Create")
+	logger.Debug("Attempting to create workspace", "name", workspace.Name)
+
+	var id int
+	err := r.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := conn(ctx, r.db).QueryRowContext(ctx, `INSERT INTO workspaces(name) VALUES ($1) RETURNING id`, workspace.Name).Scan(&id)
+		if err != nil {
+			logger.Error("Failed to insert workspace", "error", err)
+			return err
+		}
+
+		_, err = conn(ctx, r.db).ExecContext(ctx,
+			`INSERT INTO workspace_members(workspaceid, userid, role) VALUES ($1, $2, $3)`,
+			id, userID, entity.WorkspaceRoleAdmin,
+		)
+		if err != nil {
+			logger.Error("Failed to insert workspace admin", "error", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	logger.Info("Successfully created workspace", "workspace_id", id)
+	return id, nil
+}
+
+func (r *workspaceRepository) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Workspace, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "Get", "workspace_id", id)
+	logger.Debug("Attempting to fetch workspace")
+
+	var workspace entity.Workspace
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT w.id, w.name, wm.role FROM workspaces w
+			JOIN workspace_members wm ON wm.workspaceid = w.id
+			WHERE w.id = $1 AND wm.userid = $2`,
+		id, userID,
+	).Scan(&workspace.ID, &workspace.Name, &workspace.Role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("Workspace not found")
+			return entity.Workspace{}, entity.ErrWorkspaceNotFound
+		}
+		logger.Error("Failed to scan workspace row", "error", err)
+		return entity.Workspace{}, err
+	}
+
+	logger.Info("Successfully fetched workspace")
+	return workspace, nil
+}
+
+func (r *workspaceRepository) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Workspace, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "GetAll")
+	logger.Debug("Attempting to fetch workspaces")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT w.id, w.name, wm.role FROM workspaces w
+			JOIN workspace_members wm ON wm.workspaceid = w.id
+			WHERE wm.userid = $1 ORDER BY w.id`,
+		userID,
+	)
+	if err != nil {
+		logger.Error("Failed to query workspaces", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	workspaces := []entity.Workspace{}
+	for rows.Next() {
+		var workspace entity.Workspace
+		if err := rows.Scan(&workspace.ID, &workspace.Name, &workspace.Role); err != nil {
+			logger.Error("Failed to scan workspace row", "error", err)
+			return nil, err
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched workspaces")
+	return workspaces, nil
+}
+
+func (r *workspaceRepository) Delete(ctx context.Context, id int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "Delete", "workspace_id", id)
+	logger.Debug("Attempting to delete workspace")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM workspaces WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No workspace found to delete")
+		return entity.ErrWorkspaceNotFound
+	}
+
+	logger.Info("Successfully deleted workspace")
+	return nil
+}
+
+// GetRole returns the user's role in the workspace, or an empty role if they are not a member.
+func (r *workspaceRepository) GetRole(ctx context.Context, userID uuid.UUID, id int) (entity.WorkspaceRole, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "GetRole", "workspace_id", id)
+	logger.Debug("Attempting to fetch workspace role")
+
+	var role entity.WorkspaceRole
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT role FROM workspace_members WHERE workspaceid = $1 AND userid = $2`,
+		id, userID,
+	).Scan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		logger.Error("Failed to fetch workspace role", "error", err)
+		return "", err
+	}
+	return role, nil
+}
+
+func (r *workspaceRepository) GetMembers(ctx context.Context, id int) ([]entity.WorkspaceMember, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "GetMembers", "workspace_id", id)
+	logger.Debug("Attempting to fetch workspace members")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT u.id, u.username, wm.role, wm.joinedat FROM workspace_members wm
+			JOIN users u ON u.id = wm.userid
+			WHERE wm.workspaceid = $1 ORDER BY wm.joinedat`,
+		id,
+	)
+	if err != nil {
+		logger.Error("Failed to query workspace members", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	members := []entity.WorkspaceMember{}
+	for rows.Next() {
+		var member entity.WorkspaceMember
+		if err := rows.Scan(&member.UserID, &member.Username, &member.Role, &member.JoinedAt); err != nil {
+			logger.Error("Failed to scan workspace member row", "error", err)
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched workspace members")
+	return members, nil
+}
+
+func (r *workspaceRepository) AddMember(ctx context.Context, id int, userID uuid.UUID, role entity.WorkspaceRole) error {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "AddMember", "workspace_id", id)
+	logger.Debug("Attempting to add workspace member", "role", role)
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO workspace_members(workspaceid, userid, role) VALUES ($1, $2, $3)`,
+		id, userID, role,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			logger.Warn("User is already a member")
+			return entity.ErrAlreadyMember
+		}
+		logger.Error("Failed to insert workspace member", "error", err)
+		return err
+	}
+
+	logger.Info("Successfully added workspace member")
+	return nil
+}
+
+func (r *workspaceRepository) UpdateMemberRole(ctx context.Context, id int, userID uuid.UUID, role entity.WorkspaceRole) error {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "UpdateMemberRole", "workspace_id", id)
+	logger.Debug("Attempting to update workspace member role", "role", role)
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE workspace_members SET role = $1 WHERE workspaceid = $2 AND userid = $3`,
+		role, id, userID,
+	)
+	if err != nil {
+		logger.Error("Failed to execute update query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No workspace member found to update")
+		return entity.ErrMemberNotFound
+	}
+
+	logger.Info("Successfully updated workspace member role")
+	return nil
+}
+
+func (r *workspaceRepository) DeleteMember(ctx context.Context, id int, userID uuid.UUID) error {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "DeleteMember", "workspace_id", id)
+	logger.Debug("Attempting to delete workspace member")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM workspace_members WHERE workspaceid = $1 AND userid = $2`,
+		id, userID,
+	)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No workspace member found to delete")
+		return entity.ErrMemberNotFound
+	}
+
+	logger.Info("Successfully deleted workspace member")
+	return nil
+}
+
+func (r *workspaceRepository) CountAdmins(ctx context.Context, id int) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "CountAdmins", "workspace_id", id)
+
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM workspace_members WHERE workspaceid = $1 AND role = $2`,
+		id, entity.WorkspaceRoleAdmin,
+	).Scan(&count)
+	if err != nil {
+		logger.Error("Failed to count workspace admins", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *workspaceRepository) CreateInvitation(ctx context.Context, userID uuid.UUID, invitation entity.Invitation, tokenHash string) (int, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "CreateInvitation", "workspace_id", invitation.WorkspaceID)
+	logger.Debug("Attempting to create invitation", "role", invitation.Role)
+
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO workspace_invitations(workspaceid, tokenhash, role, invitedby, expirydate)
+			VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		invitation.WorkspaceID, tokenHash, invitation.Role, userID, invitation.ExpiresAt,
+	).Scan(&id)
+	if err != nil {
+		logger.Error("Failed to insert invitation", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Successfully created invitation", "invitation_id", id)
+	return id, nil
+}
+
+// GetInvitations returns the invitations of a workspace that can still be accepted.
+func (r *workspaceRepository) GetInvitations(ctx context.Context, id int) ([]entity.Invitation, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "GetInvitations", "workspace_id", id)
+	logger.Debug("Attempting to fetch invitations")
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id, workspaceid, role, expirydate FROM workspace_invitations
+			WHERE workspaceid = $1 AND acceptedat IS NULL AND expirydate > NOW() ORDER BY id`,
+		id,
+	)
+	if err != nil {
+		logger.Error("Failed to query invitations", "error", err)
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logger.Error("Failed to close rows", "error", err)
+		}
+	}(rows)
+
+	invitations := []entity.Invitation{}
+	for rows.Next() {
+		var invitation entity.Invitation
+		if err := rows.Scan(&invitation.ID, &invitation.WorkspaceID, &invitation.Role, &invitation.ExpiresAt); err != nil {
+			logger.Error("Failed to scan invitation row", "error", err)
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("Error occurred during rows iteration", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Successfully fetched invitations")
+	return invitations, nil
+}
+
+func (r *workspaceRepository) DeleteInvitation(ctx context.Context, id int, invitationID int) error {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "DeleteInvitation",
+		"workspace_id", id, "invitation_id", invitationID)
+	logger.Debug("Attempting to delete invitation")
+
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM workspace_invitations WHERE workspaceid = $1 AND id = $2 AND acceptedat IS NULL`,
+		id, invitationID,
+	)
+	if err != nil {
+		logger.Error("Failed to execute delete query", "error", err)
+		return err
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		logger.Error("Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		logger.Warn("No invitation found to delete")
+		return entity.ErrInvitationNotFound
+	}
+
+	logger.Info("Successfully deleted invitation")
+	return nil
+}
+
+// ClaimInvitation marks a pending invitation as accepted by the user and adds them to
+// the workspace. Both steps run in one transaction so an invitation is used at most once.
+func (r *workspaceRepository) ClaimInvitation(ctx context.Context, userID uuid.UUID, tokenHash string) (entity.Invitation, error) {
+	logger := utils.SetupLogger(ctx, r.logger, "workspace_repository", "ClaimInvitation")
+	logger.Debug("Attempting to claim invitation")
+
+	var invitation entity.Invitation
+	err := r.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := conn(ctx, r.db).QueryRowContext(ctx,
+			`UPDATE workspace_invitations SET acceptedat = $1, acceptedby = $2
+				WHERE tokenhash = $3 AND acceptedat IS NULL AND expirydate > $1
+				RETURNING id, workspaceid, role, expirydate`,
+			time.Now().UTC(), userID, tokenHash,
+		).Scan(&invitation.ID, &invitation.WorkspaceID, &invitation.Role, &invitation.ExpiresAt)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				logger.Warn("Invitation not found or expired")
+				return entity.ErrInvitationNotFound
+			}
+			logger.Error("Failed to claim invitation", "error", err)
+			return err
+		}
+
+		res, err := conn(ctx, r.db).ExecContext(ctx,
+			`INSERT INTO workspace_members(workspaceid, userid, role) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+			invitation.WorkspaceID, userID, invitation.Role,
+		)
+		if err != nil {
+			logger.Error("Failed to insert workspace member", "error", err)
+			return err
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			logger.Error("Failed to get rows affected", "error", err)
+			return err
+		}
+		if rowsAffected == 0 {
+			logger.Warn("User is already a member")
+			return entity.ErrAlreadyMember
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Invitation{}, err
+	}
+
+	logger.Info("Successfully claimed invitation", "workspace_id", invitation.WorkspaceID)
+	return invitation, nil
+}
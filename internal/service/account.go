@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/storage"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=AccountService --output=./mocks
+type AccountService interface {
+	// Export collects everything stored about the user.
+	Export(ctx context.Context, userID uuid.UUID) (entity.AccountExport, error)
+	// Delete removes the user and their data once the password is confirmed.
+	Delete(ctx context.Context, userID uuid.UUID, password string) error
+}
+
+type accountService struct {
+	repo      repository.AccountRepository
+	userRepo  repository.UserRepository
+	tokenRepo repository.TokenRepository
+	tx        repository.Transactor
+	remover   blobRemover
+	now       func() time.Time
+}
+
+func NewAccountService(repo repository.AccountRepository, userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository, tx repository.Transactor, blobs storage.BlobStore, logger *slog.Logger) AccountService {
+	return &accountService{
+		repo:      repo,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		tx:        tx,
+		remover:   blobRemover{blobs: blobs, logger: logger},
+		now:       time.Now,
+	}
+}
+
+func (s *accountService) Export(ctx context.Context, userID uuid.UUID) (entity.AccountExport, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return entity.AccountExport{}, err
+	}
+	export := entity.AccountExport{Profile: user.Profile(), ExportedAt: s.now().UTC()}
+
+	if export.Todos, err = s.repo.Todos(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+	if export.RefreshTokens, err = s.repo.RefreshTokens(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+	if export.Comments, err = s.repo.Comments(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+	if export.History, err = s.repo.History(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+	if export.Sessions, err = s.repo.Sessions(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+	if export.TimeEntries, err = s.repo.TimeEntries(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+	if export.Templates, err = s.repo.Templates(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+	if export.Tags, err = s.repo.Tags(ctx, userID); err != nil {
+		return entity.AccountExport{}, err
+	}
+
+	if export.Todos == nil {
+		export.Todos = []entity.Todo{}
+	}
+	if export.RefreshTokens == nil {
+		export.RefreshTokens = []entity.RefreshTokenInfo{}
+	}
+	if export.Comments == nil {
+		export.Comments = []entity.Comment{}
+	}
+	if export.History == nil {
+		export.History = []entity.TodoHistory{}
+	}
+	if export.Sessions == nil {
+		export.Sessions = []entity.Session{}
+	}
+	if export.TimeEntries == nil {
+		export.TimeEntries = []entity.TimeEntry{}
+	}
+	if export.Templates == nil {
+		export.Templates = []entity.Template{}
+	}
+	if export.Tags == nil {
+		export.Tags = []entity.Tag{}
+	}
+	return export, nil
+}
+
+// Delete revokes the user's refresh tokens and removes the account in one
+// transaction. Workspace todos and projects stay with the workspace, handed to
+// another admin; the last admin of a workspace with other members must appoint a
+// successor first. Attachment blobs are removed once it has committed.
+func (s *accountService) Delete(ctx context.Context, userID uuid.UUID, password string) error {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !entity.VerifyPassword(password, user.PasswordHash) {
+		return entity.ErrWrongPassword
+	}
+
+	var keys []string
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		count, err := s.repo.SoleAdminWorkspaces(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return entity.ErrLastAdmin
+		}
+		if err := s.repo.HandOverWorkspaces(ctx, userID); err != nil {
+			return err
+		}
+		if keys, err = s.repo.StorageKeys(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.tokenRepo.DeleteUserSessions(ctx, userID); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+	s.remover.remove(ctx, keys...)
+	return nil
+}
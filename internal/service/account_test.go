@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTransactor runs fn directly; the stubs it is used with keep no state that a
+// rollback would have to undo.
+type stubTransactor struct{}
+
+func (stubTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type stubTokenRepository struct {
+	repository.TokenRepository
+	revoked []uuid.UUID
+}
+
+func (r *stubTokenRepository) DeleteUserSessions(_ context.Context, userID uuid.UUID) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+// stubAccountRepository models one workspace: its admins and the owner of each of
+// its todos. Deleting a user drops the todos they still own, as the foreign key does.
+type stubAccountRepository struct {
+	repository.AccountRepository
+	admins  []uuid.UUID
+	members []uuid.UUID
+	todos   map[int]uuid.UUID
+	deleted []uuid.UUID
+}
+
+func (r *stubAccountRepository) SoleAdminWorkspaces(_ context.Context, userID uuid.UUID) (int, error) {
+	if len(r.admins) == 1 && r.admins[0] == userID && len(r.members) > 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (r *stubAccountRepository) HandOverWorkspaces(_ context.Context, userID uuid.UUID) error {
+	for id, owner := range r.todos {
+		if owner != userID {
+			continue
+		}
+		for _, admin := range r.admins {
+			if admin != userID {
+				r.todos[id] = admin
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (r *stubAccountRepository) StorageKeys(_ context.Context, _ uuid.UUID) ([]string, error) {
+	return nil, nil
+}
+
+func (r *stubAccountRepository) Delete(_ context.Context, userID uuid.UUID) error {
+	for id, owner := range r.todos {
+		if owner == userID {
+			delete(r.todos, id)
+		}
+	}
+	r.deleted = append(r.deleted, userID)
+	return nil
+}
+
+func TestAccountService_DeleteWorkspaceAdmin(t *testing.T) {
+	passwordHash, err := entity.HashPassword("Secret123")
+	require.NoError(t, err)
+	userID, otherAdmin, member := uuid.New(), uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name          string
+		admins        []uuid.UUID
+		expectedError error
+		expectedTodos map[int]uuid.UUID
+	}{
+		{
+			name:          "last admin",
+			admins:        []uuid.UUID{userID},
+			expectedError: entity.ErrLastAdmin,
+			expectedTodos: map[int]uuid.UUID{1: userID, 2: member},
+		},
+		{
+			name:          "another admin takes over the todos",
+			admins:        []uuid.UUID{userID, otherAdmin},
+			expectedTodos: map[int]uuid.UUID{1: otherAdmin, 2: member},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := &stubUserRepository{user: entity.User{ID: userID, Username: "john_doe", PasswordHash: passwordHash}}
+			tokens := &stubTokenRepository{}
+			accounts := &stubAccountRepository{
+				admins:  tc.admins,
+				members: []uuid.UUID{member},
+				todos:   map[int]uuid.UUID{1: userID, 2: member},
+			}
+			s := NewAccountService(accounts, users, tokens, stubTransactor{}, nil,
+				slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+			err := s.Delete(context.Background(), userID, "Secret123")
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedTodos, accounts.todos)
+			if tc.expectedError != nil {
+				assert.Empty(t, accounts.deleted)
+				assert.Empty(t, tokens.revoked)
+			} else {
+				assert.Equal(t, []uuid.UUID{userID}, accounts.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/storage"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+)
+
+// sniffLength is how many bytes http.DetectContentType looks at.
+const sniffLength = 512
+
+//go:generate go run github.com/vektra/mockery/v2 --name=AttachmentService --output=./mocks
+type AttachmentService interface {
+	GetAll(ctx context.Context, userID uuid.UUID, todoID int) ([]entity.Attachment, error)
+	Upload(ctx context.Context, userID uuid.UUID, todoID int, upload entity.AttachmentUpload) (entity.Attachment, error)
+	Download(ctx context.Context, userID uuid.UUID, todoID int, id int) (entity.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error
+}
+
+type attachmentService struct {
+	repo    repository.AttachmentRepository
+	blobs   storage.BlobStore
+	remover blobRemover
+	auth    authorizer
+	limits  entity.AttachmentLimits
+	logger  *slog.Logger
+}
+
+func NewAttachmentService(repo repository.AttachmentRepository, blobs storage.BlobStore,
+	shareRepo repository.ShareRepository, workspaceRepo repository.WorkspaceRepository, limits entity.AttachmentLimits,
+	logger *slog.Logger) AttachmentService {
+	return &attachmentService{
+		repo:    repo,
+		blobs:   blobs,
+		remover: blobRemover{blobs: blobs, logger: logger},
+		auth:    authorizer{shares: shareRepo, workspaces: workspaceRepo},
+		limits:  limits,
+		logger:  logger,
+	}
+}
+
+// getAttachment returns an attachment only if it belongs to the todo in the request path.
+func (s *attachmentService) getAttachment(ctx context.Context, todoID int, id int) (entity.Attachment, error) {
+	attachment, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Attachment{}, err
+	}
+	if attachment.TodoID != todoID {
+		return entity.Attachment{}, entity.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (s *attachmentService) GetAll(ctx context.Context, userID uuid.UUID, todoID int) ([]entity.Attachment, error) {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer); err != nil {
+		return nil, err
+	}
+	attachments, err := s.repo.GetAll(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if attachments == nil {
+		attachments = []entity.Attachment{}
+	}
+	return attachments, nil
+}
+
+// Upload stores the file and records it on the todo. The content type is detected
+// from the file itself rather than trusted from the client.
+func (s *attachmentService) Upload(ctx context.Context, userID uuid.UUID, todoID int, upload entity.AttachmentUpload) (entity.Attachment, error) {
+	logger := utils.SetupLogger(ctx, s.logger, "attachment_service", "Upload", "todo_id", todoID)
+
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionEditor); err != nil {
+		return entity.Attachment{}, err
+	}
+	if upload.Size > s.limits.MaxSize {
+		return entity.Attachment{}, entity.ErrAttachmentTooLarge
+	}
+
+	body := bufio.NewReaderSize(upload.Body, sniffLength)
+	head, err := body.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return entity.Attachment{}, err
+	}
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !slices.Contains(s.limits.AllowedContentTypes, contentType) {
+		return entity.Attachment{}, entity.ErrUnsupportedContentType
+	}
+
+	hash := sha256.New()
+	key := fmt.Sprintf("todos/%d/%s", todoID, uuid.New())
+	if err := s.blobs.Put(ctx, key, io.TeeReader(body, hash), upload.Size, contentType); err != nil {
+		logger.Error("Failed to store attachment blob", "error", err)
+		return entity.Attachment{}, err
+	}
+
+	attachment := entity.Attachment{
+		TodoID:      todoID,
+		UploaderID:  &userID,
+		FileName:    upload.FileName,
+		ContentType: contentType,
+		Size:        upload.Size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	}
+	id, err := s.repo.Create(ctx, attachment)
+	if err != nil {
+		s.remover.remove(ctx, key)
+		return entity.Attachment{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// Download returns the attachment metadata and its content. The caller closes the reader.
+func (s *attachmentService) Download(ctx context.Context, userID uuid.UUID, todoID int, id int) (entity.Attachment, io.ReadCloser, error) {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer); err != nil {
+		return entity.Attachment{}, nil, err
+	}
+	attachment, err := s.getAttachment(ctx, todoID, id)
+	if err != nil {
+		return entity.Attachment{}, nil, err
+	}
+	body, err := s.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return entity.Attachment{}, nil, entity.ErrAttachmentNotFound
+		}
+		return entity.Attachment{}, nil, err
+	}
+	return attachment, body, nil
+}
+
+func (s *attachmentService) Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionEditor); err != nil {
+		return err
+	}
+	attachment, err := s.getAttachment(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.remover.remove(ctx, attachment.StorageKey)
+	return nil
+}
+
+// blobRemover deletes blobs whose rows are already gone. A failure leaves an orphaned
+// blob behind but does not fail the request, so errors are only logged.
+type blobRemover struct {
+	blobs  storage.BlobStore
+	logger *slog.Logger
+}
+
+func (b blobRemover) remove(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := b.blobs.Delete(ctx, key); err != nil {
+			logger := utils.SetupLogger(ctx, b.logger, "blob_remover", "remove", "key", key)
+			logger.Warn("Failed to delete blob", "error", err)
+		}
+	}
+}
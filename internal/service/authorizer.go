@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+)
+
+// authorizer checks access to shared resources. Users without any access get a
+// not-found error so that the existence of other users' data is not revealed.
+type authorizer struct {
+	shares     repository.ShareRepository
+	workspaces repository.WorkspaceRepository
+}
+
+func (a authorizer) requireTodo(ctx context.Context, userID uuid.UUID, todoID int, required entity.Permission) (entity.Permission, error) {
+	permission, err := a.shares.TodoPermission(ctx, userID, todoID)
+	if err != nil {
+		return "", err
+	}
+	if permission == "" {
+		return "", entity.ErrTodoNotFound
+	}
+	if !permission.Allows(required) {
+		return permission, entity.ErrForbidden
+	}
+	return permission, nil
+}
+
+func (a authorizer) requireProject(ctx context.Context, userID uuid.UUID, projectID int, required entity.Permission) (entity.Permission, error) {
+	permission, err := a.shares.ProjectPermission(ctx, userID, projectID)
+	if err != nil {
+		return "", err
+	}
+	if permission == "" {
+		return "", entity.ErrProjectNotFound
+	}
+	if !permission.Allows(required) {
+		return permission, entity.ErrForbidden
+	}
+	return permission, nil
+}
+
+func (a authorizer) requireWorkspace(ctx context.Context, userID uuid.UUID, workspaceID int, required entity.Permission) error {
+	role, err := a.workspaces.GetRole(ctx, userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return entity.ErrWorkspaceNotFound
+	}
+	if !role.Permission().Allows(required) {
+		return entity.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/google/uuid"
+	"slices"
+)
+
+// errBulkAborted rolls back the transaction of an atomic bulk request.
+var errBulkAborted = errors.New("bulk request aborted")
+
+// Bulk applies the operations in order in a single transaction, each in its own
+// savepoint. In atomic mode the first failure rolls back everything; in best-effort
+// mode failed operations are skipped and the rest are kept. Every applied change is
+// undone by the one returned undo token, which is nil if nothing was applied.
+func (s *todoService) Bulk(ctx context.Context, userID uuid.UUID, request entity.BulkRequest) ([]entity.BulkOutcome, *entity.Undo, error) {
+	atomic := request.Mode != entity.BulkBestEffort
+	outcomes := make([]entity.BulkOutcome, len(request.Operations))
+	cs := &changeSet{undo: s.undo.newUndo()}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		failed := false
+		for i, operation := range request.Operations {
+			outcomes[i] = entity.BulkOutcome{Index: i, Op: operation.Op, ID: operation.ID}
+			if failed {
+				continue
+			}
+
+			child := cs.child()
+			err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				id, err := s.bulkOperation(ctx, userID, operation, child)
+				outcomes[i].ID = id
+				return err
+			})
+			if err != nil {
+				outcomes[i].Err = err
+				failed = atomic
+				continue
+			}
+			cs.merge(child)
+		}
+		if failed {
+			return errBulkAborted
+		}
+		return nil
+	})
+
+	if errors.Is(err, errBulkAborted) {
+		for i := range outcomes {
+			if outcomes[i].Err == nil {
+				outcomes[i].Err = entity.ErrBulkAborted
+				if outcomes[i].Op == entity.BulkCreate {
+					outcomes[i].ID = 0
+				}
+			}
+		}
+		return outcomes, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.finish(ctx, cs)
+	if !cs.issued {
+		return outcomes, nil, nil
+	}
+	return outcomes, &cs.undo, nil
+}
+
+// bulkOperation applies one operation and returns the id of the todo it changed.
+func (s *todoService) bulkOperation(ctx context.Context, userID uuid.UUID, operation entity.BulkOperation,
+	cs *changeSet) (int, error) {
+	if err := operation.Validate(); err != nil {
+		return operation.ID, err
+	}
+
+	switch operation.Op {
+	case entity.BulkCreate:
+		return s.create(ctx, userID, *operation.Todo, cs)
+	case entity.BulkUpdate:
+		todo := *operation.Todo
+		todo.ID = operation.ID
+		return operation.ID, s.update(ctx, userID, todo, cs)
+	case entity.BulkDelete:
+		return operation.ID, s.delete(ctx, userID, operation.ID, cs)
+	case entity.BulkComplete:
+		return operation.ID, s.complete(ctx, userID, operation.ID, *operation.Completed, cs)
+	case entity.BulkRetag:
+		existing, err := s.editable(ctx, userID, operation.ID)
+		if err != nil {
+			return operation.ID, err
+		}
+		todo := existing
+		todo.Tags = retag(existing.Tags, operation.AddTags, operation.RemoveTags)
+		return operation.ID, s.save(ctx, userID, existing, todo, cs)
+	default:
+		existing, err := s.editable(ctx, userID, operation.ID)
+		if err != nil {
+			return operation.ID, err
+		}
+		todo := existing
+		todo.ProjectID = operation.ProjectID
+		return operation.ID, s.save(ctx, userID, existing, todo, cs)
+	}
+}
+
+// retag removes and then adds tags, keeping the order of the tags that stay.
+func retag(tags, add, remove []string) []string {
+	result := make([]string, 0, len(tags)+len(add))
+	for _, tag := range tags {
+		if !slices.Contains(remove, tag) {
+			result = append(result, tag)
+		}
+	}
+	for _, tag := range add {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
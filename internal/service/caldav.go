@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/ical"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=CalDAVService --output=./mocks
+type CalDAVService interface {
+	Collection(ctx context.Context, userID uuid.UUID) (entity.CalDAVCollection, error)
+	Get(ctx context.Context, userID uuid.UUID, name string) (entity.CalDAVResource, error)
+	// Put creates or replaces the resource with the todo parsed from it and reports
+	// whether it was created.
+	Put(ctx context.Context, userID uuid.UUID, name string, todo entity.Todo, uid string, preconditions entity.Preconditions) (entity.CalDAVResource, bool, error)
+	Delete(ctx context.Context, userID uuid.UUID, name string, preconditions entity.Preconditions) error
+	Sync(ctx context.Context, userID uuid.UUID, since entity.SyncToken) (entity.CalDAVSync, error)
+}
+
+// calDAVService serves the personal todos of a user as a single CalDAV calendar.
+// Todos are named <id>.ics unless a client created them under a name of its own.
+// Writes go through the todo service, so they are recorded in the history and can
+// be undone like any other change.
+type calDAVService struct {
+	repo  repository.CalDAVRepository
+	todos TodoService
+	tx    repository.Transactor
+}
+
+func NewCalDAVService(repo repository.CalDAVRepository, todos TodoService, tx repository.Transactor) CalDAVService {
+	return &calDAVService{repo: repo, todos: todos, tx: tx}
+}
+
+func (s *calDAVService) Collection(ctx context.Context, userID uuid.UUID) (entity.CalDAVCollection, error) {
+	// The token is read first, so changes made while listing are sent again on the
+	// next sync rather than lost.
+	token, err := s.repo.SyncToken(ctx, userID)
+	if err != nil {
+		return entity.CalDAVCollection{}, err
+	}
+	resources, err := s.resources(ctx, userID)
+	if err != nil {
+		return entity.CalDAVCollection{}, err
+	}
+	return entity.CalDAVCollection{SyncToken: token, Resources: resources}, nil
+}
+
+func (s *calDAVService) resources(ctx context.Context, userID uuid.UUID) ([]entity.CalDAVResource, error) {
+	todos, err := s.todos.Export(ctx, userID, entity.Filters{})
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.repo.GetObjects(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]entity.CalDAVResource, 0, len(todos))
+	for _, todo := range todos {
+		resources = append(resources, resource(todo, objects))
+	}
+	return resources, nil
+}
+
+func resource(todo entity.Todo, objects map[int]entity.CalDAVObject) entity.CalDAVResource {
+	if object, ok := objects[todo.ID]; ok {
+		return entity.CalDAVResource{Name: object.Name, UID: object.UID, Todo: todo}
+	}
+	return entity.CalDAVResource{Name: defaultName(todo.ID), UID: ical.UID(todo.ID), Todo: todo}
+}
+
+func defaultName(todoID int) string {
+	return strconv.Itoa(todoID) + ".ics"
+}
+
+// parseDefaultName returns the todo a name of the form <id>.ics stands for.
+func parseDefaultName(name string) (int, bool) {
+	value, ok := strings.CutSuffix(name, ".ics")
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || defaultName(id) != name {
+		return 0, false
+	}
+	return id, true
+}
+
+func (s *calDAVService) Get(ctx context.Context, userID uuid.UUID, name string) (entity.CalDAVResource, error) {
+	object, err := s.repo.GetByName(ctx, userID, name)
+	if errors.Is(err, entity.ErrCalDAVObjectNotFound) {
+		id, ok := parseDefaultName(name)
+		if !ok {
+			return entity.CalDAVResource{}, entity.ErrCalDAVObjectNotFound
+		}
+		// A todo created by a client is only found under the name the client chose.
+		if _, err := s.repo.GetByTodo(ctx, id); !errors.Is(err, entity.ErrCalDAVObjectNotFound) {
+			if err == nil {
+				err = entity.ErrCalDAVObjectNotFound
+			}
+			return entity.CalDAVResource{}, err
+		}
+		object = entity.CalDAVObject{TodoID: id, Name: name, UID: ical.UID(id)}
+	} else if err != nil {
+		return entity.CalDAVResource{}, err
+	}
+
+	todo, err := s.todos.Get(ctx, userID, object.TodoID)
+	if err != nil {
+		if errors.Is(err, entity.ErrTodoNotFound) || errors.Is(err, entity.ErrForbidden) {
+			return entity.CalDAVResource{}, entity.ErrCalDAVObjectNotFound
+		}
+		return entity.CalDAVResource{}, err
+	}
+	// The calendar only holds the user's personal todos, not the ones shared with
+	// them or kept in a workspace.
+	if todo.OwnerID != userID || todo.WorkspaceID != nil {
+		return entity.CalDAVResource{}, entity.ErrCalDAVObjectNotFound
+	}
+	return entity.CalDAVResource{Name: object.Name, UID: object.UID, Todo: todo}, nil
+}
+
+func (s *calDAVService) Put(ctx context.Context, userID uuid.UUID, name string, todo entity.Todo, uid string, preconditions entity.Preconditions) (entity.CalDAVResource, bool, error) {
+	var created bool
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.Get(ctx, userID, name)
+		if errors.Is(err, entity.ErrCalDAVObjectNotFound) {
+			if err := preconditions.Check("", false); err != nil {
+				return err
+			}
+			created = true
+			return s.create(ctx, userID, name, todo, uid)
+		}
+		if err != nil {
+			return err
+		}
+		if err := preconditions.Check(existing.ETag(), true); err != nil {
+			return err
+		}
+		return s.update(ctx, userID, existing.Todo, todo)
+	})
+	if err != nil {
+		return entity.CalDAVResource{}, false, err
+	}
+
+	resource, err := s.Get(ctx, userID, name)
+	return resource, created, err
+}
+
+func (s *calDAVService) create(ctx context.Context, userID uuid.UUID, name string, todo entity.Todo, uid string) error {
+	// Names of the form <id>.ics are kept for the todos created through the API.
+	if _, ok := parseDefaultName(name); ok {
+		return entity.ErrForbidden
+	}
+	if todo.DueDate == nil {
+		now := time.Now().UTC()
+		todo.DueDate = &entity.Date{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)}
+	}
+
+	id, _, err := s.todos.Create(ctx, userID, todo)
+	if err != nil {
+		return err
+	}
+	if todo.Completed {
+		if _, err := s.todos.Complete(ctx, userID, id, true); err != nil {
+			return err
+		}
+	}
+	return s.repo.Create(ctx, userID, entity.CalDAVObject{TodoID: id, Name: name, UID: uid})
+}
+
+// update copies the fields a VTODO carries onto the todo. The project, checklist
+// and estimate are kept, and so is the due date if the client sent none.
+func (s *calDAVService) update(ctx context.Context, userID uuid.UUID, existing, todo entity.Todo) error {
+	updated := existing
+	updated.Title = todo.Title
+	updated.Description = todo.Description
+	updated.Tags = todo.Tags
+	updated.Recurrence = todo.Recurrence
+	if todo.DueDate != nil {
+		updated.DueDate = todo.DueDate
+	}
+
+	if _, err := s.todos.Update(ctx, userID, updated); err != nil {
+		return err
+	}
+	if todo.Completed != existing.Completed {
+		if _, err := s.todos.Complete(ctx, userID, existing.ID, todo.Completed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *calDAVService) Delete(ctx context.Context, userID uuid.UUID, name string, preconditions entity.Preconditions) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.Get(ctx, userID, name)
+		if err != nil {
+			return err
+		}
+		if err := preconditions.Check(existing.ETag(), true); err != nil {
+			return err
+		}
+		_, err = s.todos.Delete(ctx, userID, existing.Todo.ID)
+		return err
+	})
+}
+
+// Sync lists the resources changed and removed after the token. A client without a
+// token gets every resource.
+func (s *calDAVService) Sync(ctx context.Context, userID uuid.UUID, since entity.SyncToken) (entity.CalDAVSync, error) {
+	token, err := s.repo.SyncToken(ctx, userID)
+	if err != nil {
+		return entity.CalDAVSync{}, err
+	}
+	if since > token {
+		return entity.CalDAVSync{}, entity.ErrInvalidSyncToken
+	}
+	resources, err := s.resources(ctx, userID)
+	if err != nil {
+		return entity.CalDAVSync{}, err
+	}
+	sync := entity.CalDAVSync{SyncToken: token, Changed: []entity.CalDAVResource{}, Deleted: []string{}}
+	if since == 0 {
+		sync.Changed = resources
+		return sync, nil
+	}
+
+	changes, err := s.repo.Changes(ctx, userID, since)
+	if err != nil {
+		return entity.CalDAVSync{}, err
+	}
+	current := make(map[int]entity.CalDAVResource, len(resources))
+	names := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		current[resource.Todo.ID] = resource
+		names[resource.Name] = true
+	}
+
+	changed := make(map[int]bool)
+	deleted := make(map[string]bool)
+	for _, change := range changes {
+		resource, ok := current[change.TodoID]
+		switch {
+		case !change.Deleted && ok && !changed[change.TodoID]:
+			changed[change.TodoID] = true
+			sync.Changed = append(sync.Changed, resource)
+		case change.Deleted && !names[change.Name] && !deleted[change.Name]:
+			deleted[change.Name] = true
+			sync.Deleted = append(sync.Deleted, change.Name)
+		}
+	}
+	return sync, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"log/slog"
+	"time"
+)
+
+// TokenCleaner removes expired tokens in the background. Without it, the expired
+// refresh tokens of a user are only removed once the user presents one again.
+type TokenCleaner struct {
+	tokenRepo repository.TokenRepository
+	interval  time.Duration
+	logger    *slog.Logger
+}
+
+func NewTokenCleaner(tokenRepo repository.TokenRepository, interval time.Duration, logger *slog.Logger) *TokenCleaner {
+	return &TokenCleaner{tokenRepo: tokenRepo, interval: interval, logger: logger}
+}
+
+// Run cleans up right away and then every interval until ctx is done.
+func (c *TokenCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.clean(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *TokenCleaner) clean(ctx context.Context) {
+	logger := utils.SetupLogger(ctx, c.logger, "token_cleaner", "clean")
+
+	deleted, err := c.tokenRepo.DeleteExpired(ctx)
+	if err != nil {
+		logger.Error("Failed to delete expired tokens", "error", err)
+		return
+	}
+	logger.Info("Deleted expired tokens", "count", deleted)
+}
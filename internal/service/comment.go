@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=CommentService --output=./mocks
+type CommentService interface {
+	GetAll(ctx context.Context, userID uuid.UUID, todoID int, cursor entity.Cursor) (entity.CursorPage[entity.Comment], error)
+	Create(ctx context.Context, userID uuid.UUID, todoID int, request entity.CommentRequest) (entity.Comment, error)
+	Update(ctx context.Context, userID uuid.UUID, todoID int, id int, request entity.CommentRequest) error
+	Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error
+}
+
+type commentService struct {
+	repo     repository.CommentRepository
+	todoRepo repository.TodoRepository
+	userRepo repository.UserRepository
+	auth     authorizer
+	notifier notifier
+	logger   *slog.Logger
+}
+
+func NewCommentService(repo repository.CommentRepository, todoRepo repository.TodoRepository,
+	userRepo repository.UserRepository, shareRepo repository.ShareRepository, workspaceRepo repository.WorkspaceRepository,
+	notificationRepo repository.NotificationRepository, logger *slog.Logger) CommentService {
+	return &commentService{
+		repo:     repo,
+		todoRepo: todoRepo,
+		userRepo: userRepo,
+		auth:     authorizer{shares: shareRepo, workspaces: workspaceRepo},
+		notifier: notifier{repo: notificationRepo, logger: logger},
+		logger:   logger,
+	}
+}
+
+// getComment returns a comment only if it belongs to the todo in the request path.
+func (s *commentService) getComment(ctx context.Context, todoID int, id int) (entity.Comment, error) {
+	comment, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Comment{}, err
+	}
+	if comment.TodoID != todoID {
+		return entity.Comment{}, entity.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func (s *commentService) GetAll(ctx context.Context, userID uuid.UUID, todoID int, cursor entity.Cursor) (entity.CursorPage[entity.Comment], error) {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer); err != nil {
+		return entity.CursorPage[entity.Comment]{}, err
+	}
+	return fetchPage(cursor, func(comment entity.Comment) int { return comment.ID },
+		func(cursor entity.Cursor) ([]entity.Comment, error) {
+			return s.repo.GetAll(ctx, todoID, cursor)
+		})
+}
+
+// Create adds a comment. Everyone who can see a todo can discuss it.
+func (s *commentService) Create(ctx context.Context, userID uuid.UUID, todoID int, request entity.CommentRequest) (entity.Comment, error) {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer); err != nil {
+		return entity.Comment{}, err
+	}
+
+	id, err := s.repo.Create(ctx, entity.Comment{TodoID: todoID, AuthorID: userID, Body: request.Body})
+	if err != nil {
+		return entity.Comment{}, err
+	}
+	comment, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Comment{}, err
+	}
+
+	s.notifyMentions(ctx, comment, entity.ParseMentions(comment.Body))
+	return comment, nil
+}
+
+// Update lets authors edit their own comments. Users mentioned for the first time
+// by the edit are notified.
+func (s *commentService) Update(ctx context.Context, userID uuid.UUID, todoID int, id int, request entity.CommentRequest) error {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer); err != nil {
+		return err
+	}
+	comment, err := s.getComment(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID {
+		return entity.ErrForbidden
+	}
+
+	if err := s.repo.Update(ctx, id, request.Body); err != nil {
+		return err
+	}
+
+	previous := make(map[string]bool)
+	for _, username := range entity.ParseMentions(comment.Body) {
+		previous[username] = true
+	}
+	var added []string
+	for _, username := range entity.ParseMentions(request.Body) {
+		if !previous[username] {
+			added = append(added, username)
+		}
+	}
+	comment.Body = request.Body
+	s.notifyMentions(ctx, comment, added)
+	return nil
+}
+
+// Delete lets authors delete their own comments and todo owners moderate the thread.
+func (s *commentService) Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error {
+	permission, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer)
+	if err != nil {
+		return err
+	}
+	comment, err := s.getComment(ctx, todoID, id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID && !permission.Allows(entity.PermissionOwner) {
+		return entity.ErrForbidden
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// notifyMentions notifies the mentioned users who can see the todo. Unknown
+// usernames and users without access are skipped so mentions never leak the todo.
+func (s *commentService) notifyMentions(ctx context.Context, comment entity.Comment, usernames []string) {
+	if len(usernames) == 0 {
+		return
+	}
+	logger := utils.SetupLogger(ctx, s.logger, "comment_service", "notifyMentions", "comment_id", comment.ID)
+
+	todo, err := s.todoRepo.Get(ctx, comment.TodoID)
+	if err != nil {
+		logger.Warn("Failed to fetch todo for mention notifications", "error", err)
+		return
+	}
+
+	for _, username := range usernames {
+		user, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			if !errors.Is(err, entity.ErrUserNotFound) {
+				logger.Warn("Failed to resolve mention", "username", username, "error", err)
+			}
+			continue
+		}
+		if user.ID == comment.AuthorID {
+			continue
+		}
+
+		permission, err := s.auth.shares.TodoPermission(ctx, user.ID, comment.TodoID)
+		if err != nil {
+			logger.Warn("Failed to resolve permission of mentioned user", "username", username, "error", err)
+			continue
+		}
+		if permission == "" {
+			continue
+		}
+
+		s.notifier.notify(ctx, entity.Notification{
+			UserID:  user.ID,
+			Type:    entity.NotificationMentioned,
+			TodoID:  &comment.TodoID,
+			ActorID: &comment.AuthorID,
+			Message: fmt.Sprintf("%s mentioned you on %q", comment.Author, todo.Title),
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+)
+
+const calendarFeedTokenBytes = 32
+
+//go:generate go run github.com/vektra/mockery/v2 --name=CalendarFeedService --output=./mocks
+type CalendarFeedService interface {
+	GetAll(ctx context.Context, userID uuid.UUID) ([]entity.CalendarFeed, error)
+	Create(ctx context.Context, userID uuid.UUID, request entity.CalendarFeedRequest) (entity.CalendarFeed, error)
+	Delete(ctx context.Context, userID uuid.UUID, id int) error
+	// Todos returns the todos served by the feed with the token.
+	Todos(ctx context.Context, token string) ([]entity.Todo, error)
+}
+
+// calendarFeedService hands out secret feed tokens. A feed serves the personal
+// todos of its owner for as long as the feed exists.
+type calendarFeedService struct {
+	repo  repository.CalendarFeedRepository
+	todos TodoService
+}
+
+func NewCalendarFeedService(repo repository.CalendarFeedRepository, todos TodoService) CalendarFeedService {
+	return &calendarFeedService{repo: repo, todos: todos}
+}
+
+func (s *calendarFeedService) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.CalendarFeed, error) {
+	return s.repo.GetAll(ctx, userID)
+}
+
+func (s *calendarFeedService) Create(ctx context.Context, userID uuid.UUID, request entity.CalendarFeedRequest) (entity.CalendarFeed, error) {
+	token, err := utils.GenerateToken(calendarFeedTokenBytes)
+	if err != nil {
+		return entity.CalendarFeed{}, err
+	}
+	feed, err := s.repo.Create(ctx, userID, request.Name, utils.HashToken(token))
+	if err != nil {
+		return entity.CalendarFeed{}, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+func (s *calendarFeedService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	return s.repo.Delete(ctx, userID, id)
+}
+
+func (s *calendarFeedService) Todos(ctx context.Context, token string) ([]entity.Todo, error) {
+	userID, err := s.repo.Use(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	return s.todos.Export(ctx, userID, entity.Filters{})
+}
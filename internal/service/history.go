@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+)
+
+// historian appends entries to the change log of todos. An entry must be written
+// in the transaction of the change it describes, so unlike notifications a failure
+// fails the change.
+type historian struct {
+	repo repository.HistoryRepository
+}
+
+// record stores the difference between two versions of a todo. A nil before means
+// the todo was created and a nil after that it was deleted. Changes that leave
+// every field as it was are not recorded.
+func (h historian) record(ctx context.Context, actorID uuid.UUID, todoID int, operation entity.HistoryOperation,
+	before, after *entity.Todo) error {
+	var from, to entity.Todo
+	if before != nil {
+		from = *before
+	}
+	if after != nil {
+		to = *after
+	}
+
+	changes := entity.DiffTodos(from, to)
+	if len(changes) == 0 {
+		return nil
+	}
+	return h.repo.Create(ctx, entity.TodoHistory{
+		TodoID:    todoID,
+		ActorID:   &actorID,
+		Operation: operation,
+		Changes:   changes,
+	})
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+)
+
+// importBatchSize is how many todos are inserted per statement.
+const importBatchSize = 100
+
+// Import validates every todo of an import and, unless it is a dry run or a line
+// has errors, creates them all in a single transaction. Todos without a due date
+// are due today, and projects are looked up by name among the user's projects in
+// the target workspace. Every created todo is undone by the one returned undo token.
+func (s *todoService) Import(ctx context.Context, userID uuid.UUID, request entity.ImportRequest) (entity.ImportResult, error) {
+	result := entity.ImportResult{DryRun: request.DryRun, Total: len(request.Items) + len(request.Errors)}
+	if request.WorkspaceID != nil {
+		if err := s.auth.requireWorkspace(ctx, userID, *request.WorkspaceID, entity.PermissionEditor); err != nil {
+			return entity.ImportResult{}, err
+		}
+	}
+	today, _, err := s.today(ctx, userID)
+	if err != nil {
+		return entity.ImportResult{}, err
+	}
+	projects, err := s.importProjects(ctx, userID, request)
+	if err != nil {
+		return entity.ImportResult{}, err
+	}
+
+	errs := slices.Clone(request.Errors)
+	items := make([]entity.ImportItem, len(request.Items))
+	checked := make(map[int]error)
+	for i, item := range request.Items {
+		todo := item.Todo
+		todo.WorkspaceID = request.WorkspaceID
+		if todo.DueDate == nil {
+			todo.DueDate = &today
+		}
+
+		messages := todo.Validate()
+		if item.Project != "" {
+			if id, ok := projects[strings.ToLower(item.Project)]; ok {
+				todo.ProjectID = &id
+			} else {
+				messages = append(messages, fmt.Sprintf("Project '%s' not found", item.Project))
+			}
+		}
+		if todo.ProjectID != nil {
+			err, ok := checked[*todo.ProjectID]
+			if !ok {
+				err = s.checkProject(ctx, userID, todo.ProjectID, todo.WorkspaceID)
+				checked[*todo.ProjectID] = err
+			}
+			if err != nil {
+				message, ok := importProjectMessage(err, *todo.ProjectID)
+				if !ok {
+					return entity.ImportResult{}, err
+				}
+				messages = append(messages, message)
+			}
+		}
+
+		if messages != nil {
+			errs = append(errs, entity.ImportLineError{Line: item.Line, Errors: messages})
+		}
+		item.Todo = todo
+		items[i] = item
+	}
+
+	depths := make([]int, len(items))
+	for i := range items {
+		depth, j := 0, i
+		for items[j].Parent != nil && depth <= len(items) {
+			j = *items[j].Parent
+			depth++
+		}
+		if depth > len(items) {
+			errs = append(errs, entity.ImportLineError{Line: items[i].Line, Errors: []string{"Subtasks form a cycle"}})
+		}
+		depths[i] = depth
+	}
+
+	slices.SortStableFunc(errs, func(a, b entity.ImportLineError) int { return a.Line - b.Line })
+	result.Errors = errs
+	if len(errs) > 0 || request.DryRun || len(items) == 0 {
+		return result, nil
+	}
+
+	undo, err := s.apply(ctx, func(ctx context.Context, cs *changeSet) error {
+		ids, err := s.importItems(ctx, userID, items, depths, cs)
+		result.IDs = ids
+		return err
+	})
+	if err != nil {
+		return entity.ImportResult{}, err
+	}
+	result.Imported = len(result.IDs)
+	result.Undo = &undo
+	return result, nil
+}
+
+// importItems creates the todos level by level, so that every subtask is inserted
+// after its parent, and returns their ids in the order of the items.
+func (s *todoService) importItems(ctx context.Context, userID uuid.UUID, items []entity.ImportItem, depths []int,
+	cs *changeSet) ([]int, error) {
+	ids := make([]int, len(items))
+	for depth := 0; ; depth++ {
+		var level []int
+		for i := range items {
+			if depths[i] == depth {
+				level = append(level, i)
+			}
+		}
+		if len(level) == 0 {
+			break
+		}
+
+		for start := 0; start < len(level); start += importBatchSize {
+			batch := level[start:min(start+importBatchSize, len(level))]
+			todos := make([]entity.Todo, len(batch))
+			for k, i := range batch {
+				todos[k] = items[i].Todo
+				if parent := items[i].Parent; parent != nil {
+					parentID := ids[*parent]
+					todos[k].ParentID = &parentID
+				}
+			}
+			created, err := s.repo.CreateBatch(ctx, userID, todos)
+			if err != nil {
+				return nil, err
+			}
+			for k, i := range batch {
+				ids[i] = created[k]
+			}
+		}
+	}
+
+	for i, item := range items {
+		if item.Todo.Completed {
+			if err := s.repo.SetCompleted(ctx, ids[i], true); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.commit(ctx, cs, userID, entity.HistoryCreated, nil, ids[i]); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// importProjects maps the lower-cased names of the projects in the import's
+// workspace to their ids. The first project wins when names repeat.
+func (s *todoService) importProjects(ctx context.Context, userID uuid.UUID, request entity.ImportRequest) (map[string]int, error) {
+	projects := make(map[string]int)
+	if !slices.ContainsFunc(request.Items, func(item entity.ImportItem) bool { return item.Project != "" }) {
+		return projects, nil
+	}
+
+	for offset := 0; ; offset += 100 {
+		page, total, err := s.projectRepo.GetAll(ctx, userID, request.WorkspaceID, entity.Pagination{Offset: offset, Limit: 100})
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range page {
+			name := strings.ToLower(project.Name)
+			if _, ok := projects[name]; !ok {
+				projects[name] = project.ID
+			}
+		}
+		if len(page) == 0 || offset+len(page) >= total {
+			return projects, nil
+		}
+	}
+}
+
+func importProjectMessage(err error, projectID int) (string, bool) {
+	switch {
+	case errors.Is(err, entity.ErrProjectNotFound):
+		return fmt.Sprintf("Project %d not found", projectID), true
+	case errors.Is(err, entity.ErrForbidden):
+		return fmt.Sprintf("Permission denied on project %d", projectID), true
+	case errors.Is(err, entity.ErrWorkspaceMismatch):
+		return fmt.Sprintf("Project %d belongs to a different workspace", projectID), true
+	}
+	return "", false
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// AccountService is an autogenerated mock type for the AccountService type
+type AccountService struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, userID, password
+func (_m *AccountService) Delete(ctx context.Context, userID uuid.UUID, password string) error {
+	ret := _m.Called(ctx, userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, userID
+func (_m *AccountService) Export(ctx context.Context, userID uuid.UUID) (entity.AccountExport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 entity.AccountExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (entity.AccountExport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) entity.AccountExport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.AccountExport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAccountService creates a new instance of AccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountService {
+	mock := &AccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
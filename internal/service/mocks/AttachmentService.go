@@ -0,0 +1,146 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// AttachmentService is an autogenerated mock type for the AttachmentService type
+type AttachmentService struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, userID, todoID, id
+func (_m *AttachmentService) Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error {
+	ret := _m.Called(ctx, userID, todoID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r0 = rf(ctx, userID, todoID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Download provides a mock function with given fields: ctx, userID, todoID, id
+func (_m *AttachmentService) Download(ctx context.Context, userID uuid.UUID, todoID int, id int) (entity.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(ctx, userID, todoID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Download")
+	}
+
+	var r0 entity.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) (entity.Attachment, io.ReadCloser, error)); ok {
+		return rf(ctx, userID, todoID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) entity.Attachment); ok {
+		r0 = rf(ctx, userID, todoID, id)
+	} else {
+		r0 = ret.Get(0).(entity.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) io.ReadCloser); ok {
+		r1 = rf(ctx, userID, todoID, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r2 = rf(ctx, userID, todoID, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAll provides a mock function with given fields: ctx, userID, todoID
+func (_m *AttachmentService) GetAll(ctx context.Context, userID uuid.UUID, todoID int) ([]entity.Attachment, error) {
+	ret := _m.Called(ctx, userID, todoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]entity.Attachment, error)); ok {
+		return rf(ctx, userID, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []entity.Attachment); ok {
+		r0 = rf(ctx, userID, todoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upload provides a mock function with given fields: ctx, userID, todoID, upload
+func (_m *AttachmentService) Upload(ctx context.Context, userID uuid.UUID, todoID int, upload entity.AttachmentUpload) (entity.Attachment, error) {
+	ret := _m.Called(ctx, userID, todoID, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 entity.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.AttachmentUpload) (entity.Attachment, error)); ok {
+		return rf(ctx, userID, todoID, upload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.AttachmentUpload) entity.Attachment); ok {
+		r0 = rf(ctx, userID, todoID, upload)
+	} else {
+		r0 = ret.Get(0).(entity.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.AttachmentUpload) error); ok {
+		r1 = rf(ctx, userID, todoID, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentService creates a new instance of AttachmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentService {
+	mock := &AttachmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
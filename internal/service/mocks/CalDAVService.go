@@ -0,0 +1,168 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// CalDAVService is an autogenerated mock type for the CalDAVService type
+type CalDAVService struct {
+	mock.Mock
+}
+
+// Collection provides a mock function with given fields: ctx, userID
+func (_m *CalDAVService) Collection(ctx context.Context, userID uuid.UUID) (entity.CalDAVCollection, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Collection")
+	}
+
+	var r0 entity.CalDAVCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (entity.CalDAVCollection, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) entity.CalDAVCollection); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.CalDAVCollection)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, name, preconditions
+func (_m *CalDAVService) Delete(ctx context.Context, userID uuid.UUID, name string, preconditions entity.Preconditions) error {
+	ret := _m.Called(ctx, userID, name, preconditions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, entity.Preconditions) error); ok {
+		r0 = rf(ctx, userID, name, preconditions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userID, name
+func (_m *CalDAVService) Get(ctx context.Context, userID uuid.UUID, name string) (entity.CalDAVResource, error) {
+	ret := _m.Called(ctx, userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 entity.CalDAVResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (entity.CalDAVResource, error)); ok {
+		return rf(ctx, userID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) entity.CalDAVResource); ok {
+		r0 = rf(ctx, userID, name)
+	} else {
+		r0 = ret.Get(0).(entity.CalDAVResource)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Put provides a mock function with given fields: ctx, userID, name, todo, uid, preconditions
+func (_m *CalDAVService) Put(ctx context.Context, userID uuid.UUID, name string, todo entity.Todo, uid string, preconditions entity.Preconditions) (entity.CalDAVResource, bool, error) {
+	ret := _m.Called(ctx, userID, name, todo, uid, preconditions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 entity.CalDAVResource
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, entity.Todo, string, entity.Preconditions) (entity.CalDAVResource, bool, error)); ok {
+		return rf(ctx, userID, name, todo, uid, preconditions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, entity.Todo, string, entity.Preconditions) entity.CalDAVResource); ok {
+		r0 = rf(ctx, userID, name, todo, uid, preconditions)
+	} else {
+		r0 = ret.Get(0).(entity.CalDAVResource)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, entity.Todo, string, entity.Preconditions) bool); ok {
+		r1 = rf(ctx, userID, name, todo, uid, preconditions)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, string, entity.Todo, string, entity.Preconditions) error); ok {
+		r2 = rf(ctx, userID, name, todo, uid, preconditions)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Sync provides a mock function with given fields: ctx, userID, since
+func (_m *CalDAVService) Sync(ctx context.Context, userID uuid.UUID, since entity.SyncToken) (entity.CalDAVSync, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sync")
+	}
+
+	var r0 entity.CalDAVSync
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.SyncToken) (entity.CalDAVSync, error)); ok {
+		return rf(ctx, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.SyncToken) entity.CalDAVSync); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		r0 = ret.Get(0).(entity.CalDAVSync)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.SyncToken) error); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCalDAVService creates a new instance of CalDAVService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCalDAVService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CalDAVService {
+	mock := &CalDAVService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
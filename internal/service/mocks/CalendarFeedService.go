@@ -0,0 +1,137 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// CalendarFeedService is an autogenerated mock type for the CalendarFeedService type
+type CalendarFeedService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, userID, request
+func (_m *CalendarFeedService) Create(ctx context.Context, userID uuid.UUID, request entity.CalendarFeedRequest) (entity.CalendarFeed, error) {
+	ret := _m.Called(ctx, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 entity.CalendarFeed
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.CalendarFeedRequest) (entity.CalendarFeed, error)); ok {
+		return rf(ctx, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.CalendarFeedRequest) entity.CalendarFeed); ok {
+		r0 = rf(ctx, userID, request)
+	} else {
+		r0 = ret.Get(0).(entity.CalendarFeed)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.CalendarFeedRequest) error); ok {
+		r1 = rf(ctx, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *CalendarFeedService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *CalendarFeedService) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.CalendarFeed, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.CalendarFeed
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]entity.CalendarFeed, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []entity.CalendarFeed); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.CalendarFeed)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Todos provides a mock function with given fields: ctx, token
+func (_m *CalendarFeedService) Todos(ctx context.Context, token string) ([]entity.Todo, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Todos")
+	}
+
+	var r0 []entity.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Todo, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Todo); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCalendarFeedService creates a new instance of CalendarFeedService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCalendarFeedService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CalendarFeedService {
+	mock := &CalendarFeedService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
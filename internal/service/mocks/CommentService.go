@@ -0,0 +1,123 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// CommentService is an autogenerated mock type for the CommentService type
+type CommentService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, userID, todoID, request
+func (_m *CommentService) Create(ctx context.Context, userID uuid.UUID, todoID int, request entity.CommentRequest) (entity.Comment, error) {
+	ret := _m.Called(ctx, userID, todoID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 entity.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.CommentRequest) (entity.Comment, error)); ok {
+		return rf(ctx, userID, todoID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.CommentRequest) entity.Comment); ok {
+		r0 = rf(ctx, userID, todoID, request)
+	} else {
+		r0 = ret.Get(0).(entity.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.CommentRequest) error); ok {
+		r1 = rf(ctx, userID, todoID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, todoID, id
+func (_m *CommentService) Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error {
+	ret := _m.Called(ctx, userID, todoID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r0 = rf(ctx, userID, todoID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID, todoID, cursor
+func (_m *CommentService) GetAll(ctx context.Context, userID uuid.UUID, todoID int, cursor entity.Cursor) (entity.CursorPage[entity.Comment], error) {
+	ret := _m.Called(ctx, userID, todoID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 entity.CursorPage[entity.Comment]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Cursor) (entity.CursorPage[entity.Comment], error)); ok {
+		return rf(ctx, userID, todoID, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Cursor) entity.CursorPage[entity.Comment]); ok {
+		r0 = rf(ctx, userID, todoID, cursor)
+	} else {
+		r0 = ret.Get(0).(entity.CursorPage[entity.Comment])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.Cursor) error); ok {
+		r1 = rf(ctx, userID, todoID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, userID, todoID, id, request
+func (_m *CommentService) Update(ctx context.Context, userID uuid.UUID, todoID int, id int, request entity.CommentRequest) error {
+	ret := _m.Called(ctx, userID, todoID, id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int, entity.CommentRequest) error); ok {
+		r0 = rf(ctx, userID, todoID, id, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCommentService creates a new instance of CommentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommentService {
+	mock := &CommentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// NotificationService is an autogenerated mock type for the NotificationService type
+type NotificationService struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, userID, unreadOnly, pagination
+func (_m *NotificationService) GetAll(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination entity.Pagination) ([]entity.Notification, int, error) {
+	ret := _m.Called(ctx, userID, unreadOnly, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.Notification
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, entity.Pagination) ([]entity.Notification, int, error)); ok {
+		return rf(ctx, userID, unreadOnly, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, entity.Pagination) []entity.Notification); ok {
+		r0 = rf(ctx, userID, unreadOnly, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, entity.Pagination) int); ok {
+		r1 = rf(ctx, userID, unreadOnly, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, bool, entity.Pagination) error); ok {
+		r2 = rf(ctx, userID, unreadOnly, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MarkRead provides a mock function with given fields: ctx, userID, id
+func (_m *NotificationService) MarkRead(ctx context.Context, userID uuid.UUID, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewNotificationService creates a new instance of NotificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotificationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotificationService {
+	mock := &NotificationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
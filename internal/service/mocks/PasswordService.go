@@ -0,0 +1,90 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PasswordService is an autogenerated mock type for the PasswordService type
+type PasswordService struct {
+	mock.Mock
+}
+
+// Change provides a mock function with given fields: ctx, userID, change, accessTokenString
+func (_m *PasswordService) Change(ctx context.Context, userID uuid.UUID, change entity.PasswordChange, accessTokenString string) error {
+	ret := _m.Called(ctx, userID, change, accessTokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Change")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.PasswordChange, string) error); ok {
+		r0 = rf(ctx, userID, change, accessTokenString)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Forgot provides a mock function with given fields: ctx, request
+func (_m *PasswordService) Forgot(ctx context.Context, request entity.PasswordForgot) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Forgot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PasswordForgot) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reset provides a mock function with given fields: ctx, request
+func (_m *PasswordService) Reset(ctx context.Context, request entity.PasswordReset) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PasswordReset) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *PasswordService) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// NewPasswordService creates a new instance of PasswordService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordService {
+	mock := &PasswordService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
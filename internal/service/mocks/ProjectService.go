@@ -0,0 +1,197 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ProjectService is an autogenerated mock type for the ProjectService type
+type ProjectService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, userID, project
+func (_m *ProjectService) Create(ctx context.Context, userID uuid.UUID, project entity.Project) (int, error) {
+	ret := _m.Called(ctx, userID, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Project) (int, error)); ok {
+		return rf(ctx, userID, project)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Project) int); ok {
+		r0 = rf(ctx, userID, project)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Project) error); ok {
+		r1 = rf(ctx, userID, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *ProjectService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userID, id
+func (_m *ProjectService) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Project, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 entity.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (entity.Project, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) entity.Project); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(entity.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, userID, workspaceID, pagination
+func (_m *ProjectService) GetAll(ctx context.Context, userID uuid.UUID, workspaceID *int, pagination entity.Pagination) ([]entity.Project, int, error) {
+	ret := _m.Called(ctx, userID, workspaceID, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.Project
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *int, entity.Pagination) ([]entity.Project, int, error)); ok {
+		return rf(ctx, userID, workspaceID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *int, entity.Pagination) []entity.Project); ok {
+		r0 = rf(ctx, userID, workspaceID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *int, entity.Pagination) int); ok {
+		r1 = rf(ctx, userID, workspaceID, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, *int, entity.Pagination) error); ok {
+		r2 = rf(ctx, userID, workspaceID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSharedWithMe provides a mock function with given fields: ctx, userID, pagination
+func (_m *ProjectService) GetSharedWithMe(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedProject, int, error) {
+	ret := _m.Called(ctx, userID, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedWithMe")
+	}
+
+	var r0 []entity.SharedProject
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) ([]entity.SharedProject, int, error)); ok {
+		return rf(ctx, userID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) []entity.SharedProject); ok {
+		r0 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SharedProject)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Pagination) int); ok {
+		r1 = rf(ctx, userID, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.Pagination) error); ok {
+		r2 = rf(ctx, userID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: ctx, userID, project
+func (_m *ProjectService) Update(ctx context.Context, userID uuid.UUID, project entity.Project) error {
+	ret := _m.Called(ctx, userID, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Project) error); ok {
+		r0 = rf(ctx, userID, project)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewProjectService creates a new instance of ProjectService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProjectService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProjectService {
+	mock := &ProjectService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
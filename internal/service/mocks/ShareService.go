@@ -0,0 +1,107 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ShareService is an autogenerated mock type for the ShareService type
+type ShareService struct {
+	mock.Mock
+}
+
+// GetShares provides a mock function with given fields: ctx, userID, resource, id
+func (_m *ShareService) GetShares(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int) ([]entity.Share, error) {
+	ret := _m.Called(ctx, userID, resource, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShares")
+	}
+
+	var r0 []entity.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ShareResource, int) ([]entity.Share, error)); ok {
+		return rf(ctx, userID, resource, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ShareResource, int) []entity.Share); ok {
+		r0 = rf(ctx, userID, resource, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.ShareResource, int) error); ok {
+		r1 = rf(ctx, userID, resource, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, userID, resource, id, targetID
+func (_m *ShareService) Revoke(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int, targetID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, resource, id, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ShareResource, int, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, resource, id, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Share provides a mock function with given fields: ctx, userID, resource, id, request
+func (_m *ShareService) Share(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int, request entity.ShareRequest) (entity.Share, error) {
+	ret := _m.Called(ctx, userID, resource, id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Share")
+	}
+
+	var r0 entity.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ShareResource, int, entity.ShareRequest) (entity.Share, error)); ok {
+		return rf(ctx, userID, resource, id, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ShareResource, int, entity.ShareRequest) entity.Share); ok {
+		r0 = rf(ctx, userID, resource, id, request)
+	} else {
+		r0 = ret.Get(0).(entity.Share)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.ShareResource, int, entity.ShareRequest) error); ok {
+		r1 = rf(ctx, userID, resource, id, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewShareService creates a new instance of ShareService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShareService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShareService {
+	mock := &ShareService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
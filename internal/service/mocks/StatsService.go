@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// StatsService is an autogenerated mock type for the StatsService type
+type StatsService struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, userID, request
+func (_m *StatsService) Get(ctx context.Context, userID uuid.UUID, request entity.StatsRequest) (entity.Stats, error) {
+	ret := _m.Called(ctx, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 entity.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.StatsRequest) (entity.Stats, error)); ok {
+		return rf(ctx, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.StatsRequest) entity.Stats); ok {
+		r0 = rf(ctx, userID, request)
+	} else {
+		r0 = ret.Get(0).(entity.Stats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.StatsRequest) error); ok {
+		r1 = rf(ctx, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStatsService creates a new instance of StatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatsService {
+	mock := &StatsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,117 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// TagService is an autogenerated mock type for the TagService type
+type TagService struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *TagService) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Tag, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]entity.Tag, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []entity.Tag); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: ctx, userID, merge
+func (_m *TagService) Merge(ctx context.Context, userID uuid.UUID, merge entity.TagMerge) (entity.Tag, error) {
+	ret := _m.Called(ctx, userID, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 entity.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.TagMerge) (entity.Tag, error)); ok {
+		return rf(ctx, userID, merge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.TagMerge) entity.Tag); ok {
+		r0 = rf(ctx, userID, merge)
+	} else {
+		r0 = ret.Get(0).(entity.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.TagMerge) error); ok {
+		r1 = rf(ctx, userID, merge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, userID, name, update
+func (_m *TagService) Update(ctx context.Context, userID uuid.UUID, name string, update entity.TagUpdate) (entity.Tag, error) {
+	ret := _m.Called(ctx, userID, name, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 entity.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, entity.TagUpdate) (entity.Tag, error)); ok {
+		return rf(ctx, userID, name, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, entity.TagUpdate) entity.Tag); ok {
+		r0 = rf(ctx, userID, name, update)
+	} else {
+		r0 = ret.Get(0).(entity.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, entity.TagUpdate) error); ok {
+		r1 = rf(ctx, userID, name, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTagService creates a new instance of TagService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagService {
+	mock := &TagService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,197 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// TemplateService is an autogenerated mock type for the TemplateService type
+type TemplateService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, userID, template
+func (_m *TemplateService) Create(ctx context.Context, userID uuid.UUID, template entity.Template) (int, error) {
+	ret := _m.Called(ctx, userID, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Template) (int, error)); ok {
+		return rf(ctx, userID, template)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Template) int); ok {
+		r0 = rf(ctx, userID, template)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Template) error); ok {
+		r1 = rf(ctx, userID, template)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *TemplateService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userID, id
+func (_m *TemplateService) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Template, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 entity.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (entity.Template, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) entity.Template); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(entity.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, userID, pagination
+func (_m *TemplateService) GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.Template, int, error) {
+	ret := _m.Called(ctx, userID, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.Template
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) ([]entity.Template, int, error)); ok {
+		return rf(ctx, userID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) []entity.Template); ok {
+		r0 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Pagination) int); ok {
+		r1 = rf(ctx, userID, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.Pagination) error); ok {
+		r2 = rf(ctx, userID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Instantiate provides a mock function with given fields: ctx, userID, id, instantiation
+func (_m *TemplateService) Instantiate(ctx context.Context, userID uuid.UUID, id int, instantiation entity.Instantiation) ([]int, entity.Undo, error) {
+	ret := _m.Called(ctx, userID, id, instantiation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Instantiate")
+	}
+
+	var r0 []int
+	var r1 entity.Undo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Instantiation) ([]int, entity.Undo, error)); ok {
+		return rf(ctx, userID, id, instantiation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Instantiation) []int); ok {
+		r0 = rf(ctx, userID, id, instantiation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.Instantiation) entity.Undo); ok {
+		r1 = rf(ctx, userID, id, instantiation)
+	} else {
+		r1 = ret.Get(1).(entity.Undo)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int, entity.Instantiation) error); ok {
+		r2 = rf(ctx, userID, id, instantiation)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: ctx, userID, template
+func (_m *TemplateService) Update(ctx context.Context, userID uuid.UUID, template entity.Template) error {
+	ret := _m.Called(ctx, userID, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Template) error); ok {
+		r0 = rf(ctx, userID, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTemplateService creates a new instance of TemplateService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTemplateService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TemplateService {
+	mock := &TemplateService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,226 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// TimeService is an autogenerated mock type for the TimeService type
+type TimeService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, userID, todoID, request
+func (_m *TimeService) Create(ctx context.Context, userID uuid.UUID, todoID int, request entity.TimeEntryRequest) (entity.TimeEntry, error) {
+	ret := _m.Called(ctx, userID, todoID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 entity.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.TimeEntryRequest) (entity.TimeEntry, error)); ok {
+		return rf(ctx, userID, todoID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.TimeEntryRequest) entity.TimeEntry); ok {
+		r0 = rf(ctx, userID, todoID, request)
+	} else {
+		r0 = ret.Get(0).(entity.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.TimeEntryRequest) error); ok {
+		r1 = rf(ctx, userID, todoID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, todoID, id
+func (_m *TimeService) Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error {
+	ret := _m.Called(ctx, userID, todoID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r0 = rf(ctx, userID, todoID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, userID, todoID, pagination
+func (_m *TimeService) GetAll(ctx context.Context, userID uuid.UUID, todoID int, pagination entity.Pagination) ([]entity.TimeEntry, int, error) {
+	ret := _m.Called(ctx, userID, todoID, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.TimeEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Pagination) ([]entity.TimeEntry, int, error)); ok {
+		return rf(ctx, userID, todoID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Pagination) []entity.TimeEntry); ok {
+		r0 = rf(ctx, userID, todoID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.Pagination) int); ok {
+		r1 = rf(ctx, userID, todoID, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int, entity.Pagination) error); ok {
+		r2 = rf(ctx, userID, todoID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Report provides a mock function with given fields: ctx, userID, request
+func (_m *TimeService) Report(ctx context.Context, userID uuid.UUID, request entity.TimeReportRequest) (entity.TimeReport, error) {
+	ret := _m.Called(ctx, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 entity.TimeReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.TimeReportRequest) (entity.TimeReport, error)); ok {
+		return rf(ctx, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.TimeReportRequest) entity.TimeReport); ok {
+		r0 = rf(ctx, userID, request)
+	} else {
+		r0 = ret.Get(0).(entity.TimeReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.TimeReportRequest) error); ok {
+		r1 = rf(ctx, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Running provides a mock function with given fields: ctx, userID
+func (_m *TimeService) Running(ctx context.Context, userID uuid.UUID) (entity.TimeEntry, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Running")
+	}
+
+	var r0 entity.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (entity.TimeEntry, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) entity.TimeEntry); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Start provides a mock function with given fields: ctx, userID, todoID
+func (_m *TimeService) Start(ctx context.Context, userID uuid.UUID, todoID int) (entity.TimeEntry, error) {
+	ret := _m.Called(ctx, userID, todoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 entity.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (entity.TimeEntry, error)); ok {
+		return rf(ctx, userID, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) entity.TimeEntry); ok {
+		r0 = rf(ctx, userID, todoID)
+	} else {
+		r0 = ret.Get(0).(entity.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Stop provides a mock function with given fields: ctx, userID, todoID
+func (_m *TimeService) Stop(ctx context.Context, userID uuid.UUID, todoID int) (entity.TimeEntry, error) {
+	ret := _m.Called(ctx, userID, todoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 entity.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (entity.TimeEntry, error)); ok {
+		return rf(ctx, userID, todoID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) entity.TimeEntry); ok {
+		r0 = rf(ctx, userID, todoID)
+	} else {
+		r0 = ret.Get(0).(entity.TimeEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, todoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTimeService creates a new instance of TimeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTimeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TimeService {
+	mock := &TimeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -16,8 +16,103 @@ type TodoService struct {
 	mock.Mock
 }
 
+// Assign provides a mock function with given fields: ctx, userID, id, assigneeID
+func (_m *TodoService) Assign(ctx context.Context, userID uuid.UUID, id int, assigneeID *uuid.UUID) (entity.Undo, error) {
+	ret := _m.Called(ctx, userID, id, assigneeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Assign")
+	}
+
+	var r0 entity.Undo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *uuid.UUID) (entity.Undo, error)); ok {
+		return rf(ctx, userID, id, assigneeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *uuid.UUID) entity.Undo); ok {
+		r0 = rf(ctx, userID, id, assigneeID)
+	} else {
+		r0 = ret.Get(0).(entity.Undo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, *uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, id, assigneeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bulk provides a mock function with given fields: ctx, userID, request
+func (_m *TodoService) Bulk(ctx context.Context, userID uuid.UUID, request entity.BulkRequest) ([]entity.BulkOutcome, *entity.Undo, error) {
+	ret := _m.Called(ctx, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bulk")
+	}
+
+	var r0 []entity.BulkOutcome
+	var r1 *entity.Undo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.BulkRequest) ([]entity.BulkOutcome, *entity.Undo, error)); ok {
+		return rf(ctx, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.BulkRequest) []entity.BulkOutcome); ok {
+		r0 = rf(ctx, userID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.BulkOutcome)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.BulkRequest) *entity.Undo); ok {
+		r1 = rf(ctx, userID, request)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Undo)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.BulkRequest) error); ok {
+		r2 = rf(ctx, userID, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Complete provides a mock function with given fields: ctx, userID, id, completed
+func (_m *TodoService) Complete(ctx context.Context, userID uuid.UUID, id int, completed bool) (entity.Undo, error) {
+	ret := _m.Called(ctx, userID, id, completed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 entity.Undo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, bool) (entity.Undo, error)); ok {
+		return rf(ctx, userID, id, completed)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, bool) entity.Undo); ok {
+		r0 = rf(ctx, userID, id, completed)
+	} else {
+		r0 = ret.Get(0).(entity.Undo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, bool) error); ok {
+		r1 = rf(ctx, userID, id, completed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, userID, todo
-func (_m *TodoService) Create(ctx context.Context, userID uuid.UUID, todo entity.Todo) (int, error) {
+func (_m *TodoService) Create(ctx context.Context, userID uuid.UUID, todo entity.Todo) (int, entity.Undo, error) {
 	ret := _m.Called(ctx, userID, todo)
 
 	if len(ret) == 0 {
@@ -25,8 +120,9 @@ func (_m *TodoService) Create(ctx context.Context, userID uuid.UUID, todo entity
 	}
 
 	var r0 int
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Todo) (int, error)); ok {
+	var r1 entity.Undo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Todo) (int, entity.Undo, error)); ok {
 		return rf(ctx, userID, todo)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Todo) int); ok {
@@ -35,31 +131,114 @@ func (_m *TodoService) Create(ctx context.Context, userID uuid.UUID, todo entity
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Todo) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Todo) entity.Undo); ok {
 		r1 = rf(ctx, userID, todo)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(entity.Undo)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.Todo) error); ok {
+		r2 = rf(ctx, userID, todo)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateTree provides a mock function with given fields: ctx, userID, tree
+func (_m *TodoService) CreateTree(ctx context.Context, userID uuid.UUID, tree entity.TodoTree) ([]int, entity.Undo, error) {
+	ret := _m.Called(ctx, userID, tree)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTree")
+	}
+
+	var r0 []int
+	var r1 entity.Undo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.TodoTree) ([]int, entity.Undo, error)); ok {
+		return rf(ctx, userID, tree)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.TodoTree) []int); ok {
+		r0 = rf(ctx, userID, tree)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.TodoTree) entity.Undo); ok {
+		r1 = rf(ctx, userID, tree)
+	} else {
+		r1 = ret.Get(1).(entity.Undo)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.TodoTree) error); ok {
+		r2 = rf(ctx, userID, tree)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // Delete provides a mock function with given fields: ctx, userID, id
-func (_m *TodoService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+func (_m *TodoService) Delete(ctx context.Context, userID uuid.UUID, id int) (entity.Undo, error) {
 	ret := _m.Called(ctx, userID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+	var r0 entity.Undo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (entity.Undo, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) entity.Undo); ok {
 		r0 = rf(ctx, userID, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entity.Undo)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: ctx, userID, filters
+func (_m *TodoService) Export(ctx context.Context, userID uuid.UUID, filters entity.Filters) ([]entity.Todo, error) {
+	ret := _m.Called(ctx, userID, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 []entity.Todo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Filters) ([]entity.Todo, error)); ok {
+		return rf(ctx, userID, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Filters) []entity.Todo); ok {
+		r0 = rf(ctx, userID, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Todo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Filters) error); ok {
+		r1 = rf(ctx, userID, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Get provides a mock function with given fields: ctx, userID, id
@@ -90,6 +269,34 @@ func (_m *TodoService) Get(ctx context.Context, userID uuid.UUID, id int) (entit
 	return r0, r1
 }
 
+// GetAgenda provides a mock function with given fields: ctx, userID, request
+func (_m *TodoService) GetAgenda(ctx context.Context, userID uuid.UUID, request entity.AgendaRequest) (entity.Agenda, error) {
+	ret := _m.Called(ctx, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAgenda")
+	}
+
+	var r0 entity.Agenda
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AgendaRequest) (entity.Agenda, error)); ok {
+		return rf(ctx, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AgendaRequest) entity.Agenda); ok {
+		r0 = rf(ctx, userID, request)
+	} else {
+		r0 = ret.Get(0).(entity.Agenda)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.AgendaRequest) error); ok {
+		r1 = rf(ctx, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: ctx, userID, pagination, filters
 func (_m *TodoService) GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination, filters entity.Filters) ([]entity.Todo, int, error) {
 	ret := _m.Called(ctx, userID, pagination, filters)
@@ -127,22 +334,171 @@ func (_m *TodoService) GetAll(ctx context.Context, userID uuid.UUID, pagination
 	return r0, r1, r2
 }
 
+// GetHistory provides a mock function with given fields: ctx, userID, id, cursor
+func (_m *TodoService) GetHistory(ctx context.Context, userID uuid.UUID, id int, cursor entity.Cursor) (entity.CursorPage[entity.TodoHistory], error) {
+	ret := _m.Called(ctx, userID, id, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 entity.CursorPage[entity.TodoHistory]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Cursor) (entity.CursorPage[entity.TodoHistory], error)); ok {
+		return rf(ctx, userID, id, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.Cursor) entity.CursorPage[entity.TodoHistory]); ok {
+		r0 = rf(ctx, userID, id, cursor)
+	} else {
+		r0 = ret.Get(0).(entity.CursorPage[entity.TodoHistory])
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.Cursor) error); ok {
+		r1 = rf(ctx, userID, id, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSharedWithMe provides a mock function with given fields: ctx, userID, pagination
+func (_m *TodoService) GetSharedWithMe(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedTodo, int, error) {
+	ret := _m.Called(ctx, userID, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedWithMe")
+	}
+
+	var r0 []entity.SharedTodo
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) ([]entity.SharedTodo, int, error)); ok {
+		return rf(ctx, userID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Pagination) []entity.SharedTodo); ok {
+		r0 = rf(ctx, userID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SharedTodo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Pagination) int); ok {
+		r1 = rf(ctx, userID, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.Pagination) error); ok {
+		r2 = rf(ctx, userID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Import provides a mock function with given fields: ctx, userID, request
+func (_m *TodoService) Import(ctx context.Context, userID uuid.UUID, request entity.ImportRequest) (entity.ImportResult, error) {
+	ret := _m.Called(ctx, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 entity.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ImportRequest) (entity.ImportResult, error)); ok {
+		return rf(ctx, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ImportRequest) entity.ImportResult); ok {
+		r0 = rf(ctx, userID, request)
+	} else {
+		r0 = ret.Get(0).(entity.ImportResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.ImportRequest) error); ok {
+		r1 = rf(ctx, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuickAdd provides a mock function with given fields: ctx, userID, request
+func (_m *TodoService) QuickAdd(ctx context.Context, userID uuid.UUID, request entity.QuickAddRequest) (entity.QuickAddResult, error) {
+	ret := _m.Called(ctx, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuickAdd")
+	}
+
+	var r0 entity.QuickAddResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.QuickAddRequest) (entity.QuickAddResult, error)); ok {
+		return rf(ctx, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.QuickAddRequest) entity.QuickAddResult); ok {
+		r0 = rf(ctx, userID, request)
+	} else {
+		r0 = ret.Get(0).(entity.QuickAddResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.QuickAddRequest) error); ok {
+		r1 = rf(ctx, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Stream provides a mock function with given fields: ctx, userID, filters, fn
+func (_m *TodoService) Stream(ctx context.Context, userID uuid.UUID, filters entity.Filters, fn func(entity.Todo) error) error {
+	ret := _m.Called(ctx, userID, filters, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Filters, func(entity.Todo) error) error); ok {
+		r0 = rf(ctx, userID, filters, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, userID, todo
-func (_m *TodoService) Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) error {
+func (_m *TodoService) Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) (entity.Undo, error) {
 	ret := _m.Called(ctx, userID, todo)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Todo) error); ok {
+	var r0 entity.Undo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Todo) (entity.Undo, error)); ok {
+		return rf(ctx, userID, todo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Todo) entity.Undo); ok {
 		r0 = rf(ctx, userID, todo)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entity.Undo)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Todo) error); ok {
+		r1 = rf(ctx, userID, todo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewTodoService creates a new instance of TodoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
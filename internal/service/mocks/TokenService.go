@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -15,9 +16,67 @@ type TokenService struct {
 	mock.Mock
 }
 
-// GenerateTokenPair provides a mock function with given fields: ctx, id
-func (_m *TokenService) GenerateTokenPair(ctx context.Context, id uuid.UUID) (string, string, error) {
-	ret := _m.Called(ctx, id)
+// AuthenticateAppPassword provides a mock function with given fields: ctx, username, password
+func (_m *TokenService) AuthenticateAppPassword(ctx context.Context, username string, password string) (uuid.UUID, error) {
+	ret := _m.Called(ctx, username, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateAppPassword")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (uuid.UUID, error)); ok {
+		return rf(ctx, username, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) uuid.UUID); ok {
+		r0 = rf(ctx, username, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAppPassword provides a mock function with given fields: ctx, userID, request, client
+func (_m *TokenService) CreateAppPassword(ctx context.Context, userID uuid.UUID, request entity.AppPasswordRequest, client entity.SessionClient) (entity.AppPassword, error) {
+	ret := _m.Called(ctx, userID, request, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAppPassword")
+	}
+
+	var r0 entity.AppPassword
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AppPasswordRequest, entity.SessionClient) (entity.AppPassword, error)); ok {
+		return rf(ctx, userID, request, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.AppPasswordRequest, entity.SessionClient) entity.AppPassword); ok {
+		r0 = rf(ctx, userID, request, client)
+	} else {
+		r0 = ret.Get(0).(entity.AppPassword)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.AppPasswordRequest, entity.SessionClient) error); ok {
+		r1 = rf(ctx, userID, request, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateTokenPair provides a mock function with given fields: ctx, id, client
+func (_m *TokenService) GenerateTokenPair(ctx context.Context, id uuid.UUID, client entity.SessionClient) (string, string, error) {
+	ret := _m.Called(ctx, id, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateTokenPair")
@@ -26,23 +85,23 @@ func (_m *TokenService) GenerateTokenPair(ctx context.Context, id uuid.UUID) (st
 	var r0 string
 	var r1 string
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, string, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.SessionClient) (string, string, error)); ok {
+		return rf(ctx, id, client)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.SessionClient) string); ok {
+		r0 = rf(ctx, id, client)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) string); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.SessionClient) string); ok {
+		r1 = rf(ctx, id, client)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID) error); ok {
-		r2 = rf(ctx, id)
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, entity.SessionClient) error); ok {
+		r2 = rf(ctx, id, client)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -50,6 +109,60 @@ func (_m *TokenService) GenerateTokenPair(ctx context.Context, id uuid.UUID) (st
 	return r0, r1, r2
 }
 
+// Logout provides a mock function with given fields: ctx, refreshTokenString, accessTokenString
+func (_m *TokenService) Logout(ctx context.Context, refreshTokenString string, accessTokenString string) error {
+	ret := _m.Called(ctx, refreshTokenString, accessTokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, refreshTokenString, accessTokenString)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogoutAll provides a mock function with given fields: ctx, userID
+func (_m *TokenService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogoutOthers provides a mock function with given fields: ctx, userID, accessTokenString
+func (_m *TokenService) LogoutOthers(ctx context.Context, userID uuid.UUID, accessTokenString string) error {
+	ret := _m.Called(ctx, userID, accessTokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutOthers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, accessTokenString)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RefreshTokens provides a mock function with given fields: ctx, refreshTokenString
 func (_m *TokenService) RefreshTokens(ctx context.Context, refreshTokenString string) (string, string, error) {
 	ret := _m.Called(ctx, refreshTokenString)
@@ -85,9 +198,57 @@ func (_m *TokenService) RefreshTokens(ctx context.Context, refreshTokenString st
 	return r0, r1, r2
 }
 
-// ValidateAccessToken provides a mock function with given fields: tokenString
-func (_m *TokenService) ValidateAccessToken(tokenString string) (uuid.UUID, error) {
-	ret := _m.Called(tokenString)
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *TokenService) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Sessions provides a mock function with given fields: ctx, userID, accessTokenString
+func (_m *TokenService) Sessions(ctx context.Context, userID uuid.UUID, accessTokenString string) ([]entity.Session, error) {
+	ret := _m.Called(ctx, userID, accessTokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []entity.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]entity.Session, error)); ok {
+		return rf(ctx, userID, accessTokenString)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []entity.Session); ok {
+		r0 = rf(ctx, userID, accessTokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, accessTokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateAccessToken provides a mock function with given fields: ctx, tokenString
+func (_m *TokenService) ValidateAccessToken(ctx context.Context, tokenString string) (uuid.UUID, error) {
+	ret := _m.Called(ctx, tokenString)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateAccessToken")
@@ -95,19 +256,19 @@ func (_m *TokenService) ValidateAccessToken(tokenString string) (uuid.UUID, erro
 
 	var r0 uuid.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (uuid.UUID, error)); ok {
-		return rf(tokenString)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, error)); ok {
+		return rf(ctx, tokenString)
 	}
-	if rf, ok := ret.Get(0).(func(string) uuid.UUID); ok {
-		r0 = rf(tokenString)
+	if rf, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
+		r0 = rf(ctx, tokenString)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(uuid.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(tokenString)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenString)
 	} else {
 		r1 = ret.Error(1)
 	}
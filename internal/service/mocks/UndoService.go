@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// UndoService is an autogenerated mock type for the UndoService type
+type UndoService struct {
+	mock.Mock
+}
+
+// Undo provides a mock function with given fields: ctx, userID, token
+func (_m *UndoService) Undo(ctx context.Context, userID uuid.UUID, token uuid.UUID) error {
+	ret := _m.Called(ctx, userID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Undo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewUndoService creates a new instance of UndoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUndoService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UndoService {
+	mock := &UndoService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
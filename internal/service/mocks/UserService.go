@@ -100,6 +100,34 @@ func (_m *UserService) Register(ctx context.Context, user entity.UserLogin) (ent
 	return r0, r1
 }
 
+// UpdateProfile provides a mock function with given fields: ctx, id, update
+func (_m *UserService) UpdateProfile(ctx context.Context, id uuid.UUID, update entity.ProfileUpdate) (entity.User, error) {
+	ret := _m.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ProfileUpdate) (entity.User, error)); ok {
+		return rf(ctx, id, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.ProfileUpdate) entity.User); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.ProfileUpdate) error); ok {
+		r1 = rf(ctx, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserService(t interface {
@@ -0,0 +1,333 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/GlebMoskalev/go-todo-api/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// WorkspaceService is an autogenerated mock type for the WorkspaceService type
+type WorkspaceService struct {
+	mock.Mock
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, userID, token
+func (_m *WorkspaceService) AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (entity.Workspace, error) {
+	ret := _m.Called(ctx, userID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 entity.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (entity.Workspace, error)); ok {
+		return rf(ctx, userID, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) entity.Workspace); ok {
+		r0 = rf(ctx, userID, token)
+	} else {
+		r0 = ret.Get(0).(entity.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, userID, workspace
+func (_m *WorkspaceService) Create(ctx context.Context, userID uuid.UUID, workspace entity.Workspace) (int, error) {
+	ret := _m.Called(ctx, userID, workspace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Workspace) (int, error)); ok {
+		return rf(ctx, userID, workspace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, entity.Workspace) int); ok {
+		r0 = rf(ctx, userID, workspace)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, entity.Workspace) error); ok {
+		r1 = rf(ctx, userID, workspace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, userID, id, request
+func (_m *WorkspaceService) CreateInvitation(ctx context.Context, userID uuid.UUID, id int, request entity.InvitationRequest) (entity.Invitation, error) {
+	ret := _m.Called(ctx, userID, id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 entity.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.InvitationRequest) (entity.Invitation, error)); ok {
+		return rf(ctx, userID, id, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, entity.InvitationRequest) entity.Invitation); ok {
+		r0 = rf(ctx, userID, id, request)
+	} else {
+		r0 = ret.Get(0).(entity.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, entity.InvitationRequest) error); ok {
+		r1 = rf(ctx, userID, id, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *WorkspaceService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, userID, id
+func (_m *WorkspaceService) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Workspace, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 entity.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (entity.Workspace, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) entity.Workspace); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(entity.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, userID
+func (_m *WorkspaceService) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Workspace, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []entity.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]entity.Workspace, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []entity.Workspace); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: ctx, userID, id
+func (_m *WorkspaceService) GetInvitations(ctx context.Context, userID uuid.UUID, id int) ([]entity.Invitation, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitations")
+	}
+
+	var r0 []entity.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]entity.Invitation, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []entity.Invitation); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: ctx, userID, id
+func (_m *WorkspaceService) GetMembers(ctx context.Context, userID uuid.UUID, id int) ([]entity.WorkspaceMember, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 []entity.WorkspaceMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]entity.WorkspaceMember, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []entity.WorkspaceMember); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.WorkspaceMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRole provides a mock function with given fields: ctx, userID, id
+func (_m *WorkspaceService) GetRole(ctx context.Context, userID uuid.UUID, id int) (entity.WorkspaceRole, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRole")
+	}
+
+	var r0 entity.WorkspaceRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (entity.WorkspaceRole, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) entity.WorkspaceRole); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(entity.WorkspaceRole)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, userID, id, memberID
+func (_m *WorkspaceService) RemoveMember(ctx context.Context, userID uuid.UUID, id int, memberID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, id, memberID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, id, memberID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, userID, id, invitationID
+func (_m *WorkspaceService) RevokeInvitation(ctx context.Context, userID uuid.UUID, id int, invitationID int) error {
+	ret := _m.Called(ctx, userID, id, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r0 = rf(ctx, userID, id, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMemberRole provides a mock function with given fields: ctx, userID, id, memberID, role
+func (_m *WorkspaceService) UpdateMemberRole(ctx context.Context, userID uuid.UUID, id int, memberID uuid.UUID, role entity.WorkspaceRole) error {
+	ret := _m.Called(ctx, userID, id, memberID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, uuid.UUID, entity.WorkspaceRole) error); ok {
+		r0 = rf(ctx, userID, id, memberID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWorkspaceService creates a new instance of WorkspaceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkspaceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkspaceService {
+	mock := &WorkspaceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
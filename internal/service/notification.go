@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=NotificationService --output=./mocks
+type NotificationService interface {
+	GetAll(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination entity.Pagination) ([]entity.Notification, int, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, id int) error
+}
+
+type notificationService struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationService(repo repository.NotificationRepository) NotificationService {
+	return &notificationService{repo: repo}
+}
+
+func (s *notificationService) GetAll(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination entity.Pagination) ([]entity.Notification, int, error) {
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+	return s.repo.GetAll(ctx, userID, unreadOnly, pagination)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, userID uuid.UUID, id int) error {
+	return s.repo.MarkRead(ctx, userID, id)
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"log/slog"
+)
+
+// notifier stores notifications on behalf of other services. Failing to notify
+// does not undo the change that triggered it, so errors are only logged.
+type notifier struct {
+	repo   repository.NotificationRepository
+	logger *slog.Logger
+}
+
+func (n notifier) notify(ctx context.Context, notification entity.Notification) {
+	if _, err := n.repo.Create(ctx, notification); err != nil {
+		logger := utils.SetupLogger(ctx, n.logger, "notifier", "notify", "type", notification.Type)
+		logger.Warn("Failed to create notification", "user_id", notification.UserID, "error", err)
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+)
+
+const maxPageLimit = 100
+
+// fetchPage loads one page of a cursor-paginated list. One extra item is requested
+// to tell whether there is a next page.
+func fetchPage[T any](cursor entity.Cursor, id func(T) int, fetch func(entity.Cursor) ([]T, error)) (entity.CursorPage[T], error) {
+	if cursor.Limit > maxPageLimit {
+		cursor.Limit = maxPageLimit
+	}
+
+	limit := cursor.Limit
+	cursor.Limit++
+	items, err := fetch(cursor)
+	if err != nil {
+		return entity.CursorPage[T]{}, err
+	}
+
+	page := entity.CursorPage[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = utils.EncodeCursor(id(page.Items[limit-1]))
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/mailer"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const passwordResetTokenBytes = 32
+
+//go:generate go run github.com/vektra/mockery/v2 --name=PasswordService --output=./mocks
+type PasswordService interface {
+	// Change sets a new password after checking the current one and ends every other
+	// session of the user. The access token names the session to keep.
+	Change(ctx context.Context, userID uuid.UUID, change entity.PasswordChange, accessTokenString string) error
+	// Forgot queues a password reset link to be mailed to the user by Run. It reports
+	// success whether or not the user exists or has an email address, so as not to
+	// tell, and drops the request if the queue is full.
+	Forgot(ctx context.Context, request entity.PasswordForgot) error
+	// Reset sets a new password with a reset token, which is used up, and ends every
+	// session of the user.
+	Reset(ctx context.Context, request entity.PasswordReset) error
+	// Run sends the queued reset links until ctx is done, and then those still queued.
+	Run(ctx context.Context)
+}
+
+// PasswordResetConfig configures password reset links. URL is the page that reads
+// the token from its token query parameter; without it, the bare token is mailed.
+// MaxActive caps the unexpired links a user can hold, so that an account cannot be
+// flooded with emails. Workers links are sent at a time, and at most QueueSize
+// requests wait for them.
+type PasswordResetConfig struct {
+	Expire    time.Duration
+	URL       string
+	MaxActive int
+	Workers   int
+	QueueSize int
+}
+
+// resetRequest is a queued request for a reset link.
+type resetRequest struct {
+	ctx      context.Context
+	username string
+}
+
+type passwordService struct {
+	userRepo  repository.UserRepository
+	resetRepo repository.PasswordResetRepository
+	tokens    TokenService
+	tx        repository.Transactor
+	mailer    mailer.Mailer
+	config    PasswordResetConfig
+	queue     chan resetRequest
+	logger    *slog.Logger
+}
+
+func NewPasswordService(userRepo repository.UserRepository, resetRepo repository.PasswordResetRepository,
+	tokens TokenService, tx repository.Transactor, mailer mailer.Mailer, config PasswordResetConfig,
+	logger *slog.Logger) PasswordService {
+	return &passwordService{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		tokens:    tokens,
+		tx:        tx,
+		mailer:    mailer,
+		config:    config,
+		queue:     make(chan resetRequest, config.QueueSize),
+		logger:    logger,
+	}
+}
+
+func (s *passwordService) Change(ctx context.Context, userID uuid.UUID, change entity.PasswordChange,
+	accessTokenString string) error {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !entity.VerifyPassword(change.CurrentPassword, user.PasswordHash) {
+		return entity.ErrWrongPassword
+	}
+	passwordHash, err := entity.HashPassword(change.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdatePassword(ctx, userID, passwordHash); err != nil {
+			return err
+		}
+		if err := s.resetRepo.DeleteUserTokens(ctx, userID); err != nil {
+			return err
+		}
+		return s.tokens.LogoutOthers(ctx, userID, accessTokenString)
+	})
+}
+
+func (s *passwordService) Forgot(ctx context.Context, request entity.PasswordForgot) error {
+	// Looking up the user and issuing the token happen in the background, so that
+	// the response takes the same time whether or not a link is sent.
+	select {
+	case s.queue <- resetRequest{ctx: context.WithoutCancel(ctx), username: request.Username}:
+	default:
+		logger := utils.SetupLogger(ctx, s.logger, "password_service", "Forgot")
+		logger.Warn("Password reset queue is full, dropping request")
+	}
+	return nil
+}
+
+func (s *passwordService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range max(s.config.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case request := <-s.queue:
+					s.issueResetLink(request.ctx, request.username)
+				case <-ctx.Done():
+					s.drain()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// drain sends the links still queued.
+func (s *passwordService) drain() {
+	for {
+		select {
+		case request := <-s.queue:
+			s.issueResetLink(request.ctx, request.username)
+		default:
+			return
+		}
+	}
+}
+
+func (s *passwordService) issueResetLink(ctx context.Context, username string) {
+	logger := utils.SetupLogger(ctx, s.logger, "password_service", "issueResetLink")
+
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		logger.Info("Password reset requested for unknown user")
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to fetch user", "error", err)
+		return
+	}
+	if user.Email == "" {
+		logger.Info("Password reset requested for user without email address", "user_id", user.ID)
+		return
+	}
+
+	token, err := utils.GenerateToken(passwordResetTokenBytes)
+	if err != nil {
+		logger.Error("Failed to generate password reset token", "error", err)
+		return
+	}
+	expiresAt := time.Now().UTC().Add(s.config.Expire)
+	err = s.resetRepo.Create(ctx, user.ID, utils.HashToken(token), expiresAt, s.config.MaxActive)
+	if errors.Is(err, entity.ErrTooManyResetTokens) {
+		logger.Warn("Password reset link limit reached", "user_id", user.ID)
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to create password reset token", "error", err)
+		return
+	}
+	s.sendResetLink(ctx, user, token)
+}
+
+func (s *passwordService) sendResetLink(ctx context.Context, user entity.User, token string) {
+	logger := utils.SetupLogger(ctx, s.logger, "password_service", "sendResetLink", "user_id", user.ID)
+
+	link := token
+	if s.config.URL != "" {
+		link = s.config.URL + "?token=" + url.QueryEscape(token)
+	}
+	message := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"someone asked to reset the password of your account. Use this link within %d minutes to choose a new one:\n\n"+
+			"%s\n\n"+
+			"If it was not you, ignore this email and your password stays the same.\n",
+			user.Username, int(s.config.Expire.Minutes()), link),
+	}
+	if err := s.mailer.Send(ctx, message); err != nil {
+		logger.Error("Failed to send password reset email", "error", err)
+	}
+}
+
+func (s *passwordService) Reset(ctx context.Context, request entity.PasswordReset) error {
+	passwordHash, err := entity.HashPassword(request.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		userID, err := s.resetRepo.Use(ctx, utils.HashToken(request.Token))
+		if err != nil {
+			return err
+		}
+		if err := s.userRepo.UpdatePassword(ctx, userID, passwordHash); err != nil {
+			return err
+		}
+		if err := s.resetRepo.DeleteUserTokens(ctx, userID); err != nil {
+			return err
+		}
+		return s.tokens.LogoutAll(ctx, userID)
+	})
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/mailer"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubResetRepository counts the tokens created per user and enforces the limit
+// the way the database query does.
+type stubResetRepository struct {
+	repository.PasswordResetRepository
+	tokens map[uuid.UUID]int
+}
+
+func (r *stubResetRepository) Create(_ context.Context, userID uuid.UUID, _ string, _ time.Time, limit int) error {
+	if r.tokens[userID] >= limit {
+		return entity.ErrTooManyResetTokens
+	}
+	r.tokens[userID]++
+	return nil
+}
+
+type recordingMailer struct {
+	messages []mailer.Message
+}
+
+func (m *recordingMailer) Send(_ context.Context, message mailer.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func TestPasswordService_IssueResetLink(t *testing.T) {
+	userID := uuid.New()
+	users := &stubUserRepository{user: entity.User{ID: userID, Username: "john_doe", Email: "john@example.com"}}
+	resets := &stubResetRepository{tokens: map[uuid.UUID]int{}}
+	mail := &recordingMailer{}
+	s := &passwordService{
+		userRepo:  users,
+		resetRepo: resets,
+		mailer:    mail,
+		config:    PasswordResetConfig{Expire: time.Hour, URL: "https://todo.example.com/reset", MaxActive: 2},
+		logger:    slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)),
+	}
+
+	s.issueResetLink(context.Background(), "nobody")
+	assert.Empty(t, resets.tokens)
+	assert.Empty(t, mail.messages)
+
+	for range 3 {
+		s.issueResetLink(context.Background(), "john_doe")
+	}
+	assert.Equal(t, 2, resets.tokens[userID])
+	if assert.Len(t, mail.messages, 2) {
+		assert.Equal(t, "john@example.com", mail.messages[0].To)
+		assert.Contains(t, mail.messages[0].Body, "https://todo.example.com/reset?token=")
+	}
+}
+
+func TestPasswordService_RunDrainsQueue(t *testing.T) {
+	userID := uuid.New()
+	users := &stubUserRepository{user: entity.User{ID: userID, Username: "john_doe", Email: "john@example.com"}}
+	resets := &stubResetRepository{tokens: map[uuid.UUID]int{}}
+	mail := &recordingMailer{}
+	s := NewPasswordService(users, resets, nil, nil, mail,
+		PasswordResetConfig{Expire: time.Hour, MaxActive: 5, Workers: 1, QueueSize: 2},
+		slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	// The third request finds the queue full and is dropped.
+	for range 3 {
+		assert.NoError(t, s.Forgot(context.Background(), entity.PasswordForgot{Username: "john_doe"}))
+	}
+
+	// Run returns only once the queued links have been sent.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Run(ctx)
+	assert.Equal(t, 2, resets.tokens[userID])
+	assert.Len(t, mail.messages, 2)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=ProjectService --output=./mocks
+type ProjectService interface {
+	Get(ctx context.Context, userID uuid.UUID, id int) (entity.Project, error)
+	Create(ctx context.Context, userID uuid.UUID, project entity.Project) (int, error)
+	Update(ctx context.Context, userID uuid.UUID, project entity.Project) error
+	Delete(ctx context.Context, userID uuid.UUID, id int) error
+	GetAll(ctx context.Context, userID uuid.UUID, workspaceID *int, pagination entity.Pagination) ([]entity.Project, int, error)
+	GetSharedWithMe(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedProject, int, error)
+}
+
+type projectService struct {
+	repo repository.ProjectRepository
+	auth authorizer
+}
+
+func NewProjectService(repo repository.ProjectRepository, shareRepo repository.ShareRepository,
+	workspaceRepo repository.WorkspaceRepository) ProjectService {
+	return &projectService{repo: repo, auth: authorizer{shares: shareRepo, workspaces: workspaceRepo}}
+}
+
+func (s *projectService) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Project, error) {
+	if _, err := s.auth.requireProject(ctx, userID, id, entity.PermissionViewer); err != nil {
+		return entity.Project{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+func (s *projectService) Create(ctx context.Context, userID uuid.UUID, project entity.Project) (int, error) {
+	if project.WorkspaceID != nil {
+		if err := s.auth.requireWorkspace(ctx, userID, *project.WorkspaceID, entity.PermissionEditor); err != nil {
+			return 0, err
+		}
+	}
+	return s.repo.Create(ctx, userID, project)
+}
+
+func (s *projectService) Update(ctx context.Context, userID uuid.UUID, project entity.Project) error {
+	if _, err := s.auth.requireProject(ctx, userID, project.ID, entity.PermissionEditor); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, project)
+}
+
+func (s *projectService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	if _, err := s.auth.requireProject(ctx, userID, id, entity.PermissionOwner); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *projectService) GetAll(ctx context.Context, userID uuid.UUID, workspaceID *int, pagination entity.Pagination) ([]entity.Project, int, error) {
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+	if workspaceID != nil {
+		if err := s.auth.requireWorkspace(ctx, userID, *workspaceID, entity.PermissionViewer); err != nil {
+			return nil, 0, err
+		}
+	}
+	return s.repo.GetAll(ctx, userID, workspaceID, pagination)
+}
+
+func (s *projectService) GetSharedWithMe(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedProject, int, error) {
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+	return s.repo.GetSharedWith(ctx, userID, pagination)
+}
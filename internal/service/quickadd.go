@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/quickadd"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// QuickAdd reads a todo from free text, with dates relative to the current day in
+// the user's time zone, and creates it unless it is a preview. A todo that does
+// not validate, such as one whose text is only tags, is reported as ErrInvalidTodo.
+func (s *todoService) QuickAdd(ctx context.Context, userID uuid.UUID, request entity.QuickAddRequest) (entity.QuickAddResult, error) {
+	today, _, err := s.today(ctx, userID)
+	if err != nil {
+		return entity.QuickAddResult{}, err
+	}
+	todo := quickadd.Parse(request.Text, today)
+	todo.WorkspaceID = request.WorkspaceID
+	if messages := todo.Validate(); messages != nil {
+		return entity.QuickAddResult{}, fmt.Errorf("%w: %s", entity.ErrInvalidTodo, strings.Join(messages, ";"))
+	}
+
+	result := entity.QuickAddResult{Preview: request.Preview, Todo: todo}
+	if request.Preview {
+		return result, nil
+	}
+	id, undo, err := s.Create(ctx, userID, todo)
+	if err != nil {
+		return entity.QuickAddResult{}, err
+	}
+	result.ID = id
+	result.Todo.ID = id
+	result.Undo = &undo
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=ShareService --output=./mocks
+type ShareService interface {
+	GetShares(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int) ([]entity.Share, error)
+	Share(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int, request entity.ShareRequest) (entity.Share, error)
+	Revoke(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int, targetID uuid.UUID) error
+}
+
+type shareService struct {
+	repo     repository.ShareRepository
+	userRepo repository.UserRepository
+	auth     authorizer
+	logger   *slog.Logger
+}
+
+func NewShareService(repo repository.ShareRepository, userRepo repository.UserRepository,
+	workspaceRepo repository.WorkspaceRepository, logger *slog.Logger) ShareService {
+	return &shareService{
+		repo:     repo,
+		userRepo: userRepo,
+		auth:     authorizer{shares: repo, workspaces: workspaceRepo},
+		logger:   logger,
+	}
+}
+
+func (s *shareService) require(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int, required entity.Permission) (entity.Permission, error) {
+	if resource == entity.ShareResourceProject {
+		return s.auth.requireProject(ctx, userID, id, required)
+	}
+	return s.auth.requireTodo(ctx, userID, id, required)
+}
+
+func (s *shareService) GetShares(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int) ([]entity.Share, error) {
+	if _, err := s.require(ctx, userID, resource, id, entity.PermissionViewer); err != nil {
+		return nil, err
+	}
+	return s.repo.GetShares(ctx, resource, id)
+}
+
+func (s *shareService) Share(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int, request entity.ShareRequest) (entity.Share, error) {
+	if _, err := s.require(ctx, userID, resource, id, entity.PermissionOwner); err != nil {
+		return entity.Share{}, err
+	}
+
+	target, err := s.userRepo.GetByUsername(ctx, request.Username)
+	if err != nil {
+		return entity.Share{}, err
+	}
+
+	ownerID, err := s.repo.GetOwner(ctx, resource, id)
+	if err != nil {
+		return entity.Share{}, err
+	}
+	if target.ID == ownerID {
+		return entity.Share{}, entity.ErrInvalidShareTarget
+	}
+
+	if err := s.repo.SaveShare(ctx, resource, id, target.ID, request.Permission); err != nil {
+		return entity.Share{}, err
+	}
+
+	return entity.Share{UserID: target.ID, Username: target.Username, Permission: request.Permission}, nil
+}
+
+// Revoke removes a user's access. Owners may revoke anyone; any other user may
+// only remove their own share, which lets them leave a shared todo or project.
+func (s *shareService) Revoke(ctx context.Context, userID uuid.UUID, resource entity.ShareResource, id int, targetID uuid.UUID) error {
+	required := entity.PermissionOwner
+	if targetID == userID {
+		required = entity.PermissionViewer
+	}
+	if _, err := s.require(ctx, userID, resource, id, required); err != nil {
+		return err
+	}
+	return s.repo.DeleteShare(ctx, resource, id, targetID)
+}
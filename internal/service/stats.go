@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=StatsService --output=./mocks
+type StatsService interface {
+	Get(ctx context.Context, userID uuid.UUID, request entity.StatsRequest) (entity.Stats, error)
+}
+
+// statsService reports on the todos a user owns. Days are days in the user's time
+// zone, as in the agenda.
+type statsService struct {
+	repo     repository.StatsRepository
+	userRepo repository.UserRepository
+	now      func() time.Time
+}
+
+func NewStatsService(repo repository.StatsRepository, userRepo repository.UserRepository) StatsService {
+	return &statsService{repo: repo, userRepo: userRepo, now: time.Now}
+}
+
+func (s *statsService) Get(ctx context.Context, userID uuid.UUID, request entity.StatsRequest) (entity.Stats, error) {
+	today, location, err := userToday(ctx, s.userRepo, userID, s.now())
+	if err != nil {
+		return entity.Stats{}, err
+	}
+	from := time.Date(request.From.Year(), request.From.Month(), request.From.Day(), 0, 0, 0, 0, location)
+	to := time.Date(request.To.Year(), request.To.Month(), request.To.Day()+1, 0, 0, 0, 0, location)
+	timezone := location.String()
+
+	buckets, err := s.repo.Buckets(ctx, userID, from, to, request.Interval, timezone)
+	if err != nil {
+		return entity.Stats{}, err
+	}
+	summary, err := s.repo.Summary(ctx, userID, from, to, today.Time)
+	if err != nil {
+		return entity.Stats{}, err
+	}
+	tags, err := s.repo.Tags(ctx, userID, from, to, today.Time)
+	if err != nil {
+		return entity.Stats{}, err
+	}
+	streak, err := s.repo.Streak(ctx, userID, today.Time, timezone)
+	if err != nil {
+		return entity.Stats{}, err
+	}
+
+	if buckets == nil {
+		buckets = []entity.StatsBucket{}
+	}
+	if tags == nil {
+		tags = []entity.TagStats{}
+	}
+	return entity.Stats{
+		From:         request.From,
+		To:           request.To,
+		Interval:     request.Interval,
+		StatsSummary: summary,
+		Buckets:      buckets,
+		Tags:         tags,
+		Streak:       streak,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubStatsRepository records the range, day and time zone it is queried with.
+type stubStatsRepository struct {
+	repository.StatsRepository
+	from, to, today time.Time
+	timezone        string
+}
+
+func (r *stubStatsRepository) Buckets(_ context.Context, _ uuid.UUID, from, to time.Time, _ entity.StatsInterval,
+	timezone string) ([]entity.StatsBucket, error) {
+	r.from, r.to, r.timezone = from, to, timezone
+	return nil, nil
+}
+
+func (r *stubStatsRepository) Summary(_ context.Context, _ uuid.UUID, _, _ time.Time, today time.Time) (entity.StatsSummary, error) {
+	r.today = today
+	return entity.StatsSummary{}, nil
+}
+
+func (r *stubStatsRepository) Tags(context.Context, uuid.UUID, time.Time, time.Time, time.Time) ([]entity.TagStats, error) {
+	return nil, nil
+}
+
+func (r *stubStatsRepository) Streak(context.Context, uuid.UUID, time.Time, string) (entity.Streak, error) {
+	return entity.Streak{}, nil
+}
+
+func TestStatsService_GetUsesUserTimezone(t *testing.T) {
+	userID := uuid.New()
+	stats := &stubStatsRepository{}
+	s := &statsService{
+		repo:     stats,
+		userRepo: &stubUserRepository{user: entity.User{ID: userID, Timezone: "America/New_York"}},
+		// Late in the evening of April 13 in New York, already April 14 in UTC.
+		now: func() time.Time { return time.Date(2025, 4, 14, 2, 30, 0, 0, time.UTC) },
+	}
+
+	_, err := s.Get(context.Background(), userID, entity.StatsRequest{
+		From:     entity.Date{Time: time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC)},
+		To:       entity.Date{Time: time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC)},
+		Interval: entity.StatsByDay,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", stats.timezone)
+	assert.Equal(t, time.Date(2025, 4, 7, 4, 0, 0, 0, time.UTC), stats.from.UTC())
+	assert.Equal(t, time.Date(2025, 4, 14, 4, 0, 0, 0, time.UTC), stats.to.UTC())
+	assert.Equal(t, time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC), stats.today)
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=TagService --output=./mocks
+type TagService interface {
+	GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Tag, error)
+	Update(ctx context.Context, userID uuid.UUID, name string, update entity.TagUpdate) (entity.Tag, error)
+	Merge(ctx context.Context, userID uuid.UUID, merge entity.TagMerge) (entity.Tag, error)
+}
+
+// tagService manages the tags of the todos a user owns. Todos keep their tags as a
+// list of names, so renaming and merging rewrite those lists.
+type tagService struct {
+	repo    repository.TagRepository
+	tx      repository.Transactor
+	history historian
+}
+
+func NewTagService(repo repository.TagRepository, historyRepo repository.HistoryRepository,
+	tx repository.Transactor) TagService {
+	return &tagService{
+		repo:    repo,
+		tx:      tx,
+		history: historian{repo: historyRepo},
+	}
+}
+
+func (s *tagService) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Tag, error) {
+	return s.repo.GetAll(ctx, userID)
+}
+
+// Update renames a tag on all of the user's todos and sets its metadata. Renaming to
+// a tag that already exists is refused, since that is a merge.
+func (s *tagService) Update(ctx context.Context, userID uuid.UUID, name string, update entity.TagUpdate) (entity.Tag, error) {
+	var tag entity.Tag
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		tag, err = s.repo.Get(ctx, userID, name)
+		if err != nil {
+			return err
+		}
+
+		if update.Name != nil && *update.Name != name {
+			_, err := s.repo.Get(ctx, userID, *update.Name)
+			if err == nil {
+				return entity.ErrTagExists
+			}
+			if !errors.Is(err, entity.ErrTagNotFound) {
+				return err
+			}
+			if err := s.replace(ctx, userID, []string{name}, *update.Name); err != nil {
+				return err
+			}
+			if err := s.repo.RenameMetadata(ctx, userID, name, *update.Name); err != nil {
+				return err
+			}
+			tag.Name = *update.Name
+		}
+
+		if update.Color != nil || update.Description != nil {
+			if update.Color != nil {
+				tag.Color = update.Color
+			}
+			if update.Description != nil {
+				tag.Description = *update.Description
+			}
+			if err := s.repo.SaveMetadata(ctx, userID, tag); err != nil {
+				return err
+			}
+		}
+
+		tag, err = s.repo.Get(ctx, userID, tag.Name)
+		return err
+	})
+	return tag, err
+}
+
+// Merge replaces every source tag with the target. The target keeps its own metadata
+// and the metadata of the sources is dropped.
+func (s *tagService) Merge(ctx context.Context, userID uuid.UUID, merge entity.TagMerge) (entity.Tag, error) {
+	var sources []string
+	for _, source := range merge.Sources {
+		if source != merge.Target {
+			sources = append(sources, source)
+		}
+	}
+
+	var tag entity.Tag
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, source := range sources {
+			if _, err := s.repo.Get(ctx, userID, source); err != nil {
+				return err
+			}
+		}
+		if len(sources) > 0 {
+			if err := s.replace(ctx, userID, sources, merge.Target); err != nil {
+				return err
+			}
+			if err := s.repo.DeleteMetadata(ctx, userID, sources); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		tag, err = s.repo.Get(ctx, userID, merge.Target)
+		return err
+	})
+	return tag, err
+}
+
+// replace rewrites the tags and records the change in the history of each todo.
+func (s *tagService) replace(ctx context.Context, userID uuid.UUID, sources []string, target string) error {
+	changes, err := s.repo.Replace(ctx, userID, sources, target)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		before := entity.Todo{ID: change.TodoID, Tags: change.Before}
+		after := entity.Todo{ID: change.TodoID, Tags: change.After}
+		if err := s.history.record(ctx, userID, change.TodoID, entity.HistoryUpdated, &before, &after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=TemplateService --output=./mocks
+type TemplateService interface {
+	Get(ctx context.Context, userID uuid.UUID, id int) (entity.Template, error)
+	GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.Template, int, error)
+	Create(ctx context.Context, userID uuid.UUID, template entity.Template) (int, error)
+	Update(ctx context.Context, userID uuid.UUID, template entity.Template) error
+	Delete(ctx context.Context, userID uuid.UUID, id int) error
+	Instantiate(ctx context.Context, userID uuid.UUID, id int, instantiation entity.Instantiation) ([]int, entity.Undo, error)
+}
+
+// templateService keeps the templates of a user. Templates are private, so the
+// templates of other users are reported as not found.
+type templateService struct {
+	repo  repository.TemplateRepository
+	todos TodoService
+}
+
+func NewTemplateService(repo repository.TemplateRepository, todos TodoService) TemplateService {
+	return &templateService{repo: repo, todos: todos}
+}
+
+func (s *templateService) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Template, error) {
+	template, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Template{}, err
+	}
+	if template.UserID != userID {
+		return entity.Template{}, entity.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (s *templateService) GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.Template, int, error) {
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+	return s.repo.GetAll(ctx, userID, pagination)
+}
+
+func (s *templateService) Create(ctx context.Context, userID uuid.UUID, template entity.Template) (int, error) {
+	return s.repo.Create(ctx, userID, template)
+}
+
+func (s *templateService) Update(ctx context.Context, userID uuid.UUID, template entity.Template) error {
+	if _, err := s.Get(ctx, userID, template.ID); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, template)
+}
+
+func (s *templateService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	if _, err := s.Get(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Instantiate creates the todos of a template in one transaction and returns their
+// ids, the root todo first. The todos are checked and created like any other, so
+// the user needs write access to the project and workspace they go into.
+func (s *templateService) Instantiate(ctx context.Context, userID uuid.UUID, id int,
+	instantiation entity.Instantiation) ([]int, entity.Undo, error) {
+	template, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, entity.Undo{}, err
+	}
+
+	start := entity.Date{Time: time.Now().UTC().Truncate(24 * time.Hour)}
+	if instantiation.StartDate != nil {
+		start = *instantiation.StartDate
+	}
+	tree, err := template.Render(instantiation, start)
+	if err != nil {
+		return nil, entity.Undo{}, err
+	}
+	return s.todos.CreateTree(ctx, userID, tree)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=TimeService --output=./mocks
+type TimeService interface {
+	Start(ctx context.Context, userID uuid.UUID, todoID int) (entity.TimeEntry, error)
+	Stop(ctx context.Context, userID uuid.UUID, todoID int) (entity.TimeEntry, error)
+	Running(ctx context.Context, userID uuid.UUID) (entity.TimeEntry, error)
+	GetAll(ctx context.Context, userID uuid.UUID, todoID int, pagination entity.Pagination) ([]entity.TimeEntry, int, error)
+	Create(ctx context.Context, userID uuid.UUID, todoID int, request entity.TimeEntryRequest) (entity.TimeEntry, error)
+	Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error
+	Report(ctx context.Context, userID uuid.UUID, request entity.TimeReportRequest) (entity.TimeReport, error)
+}
+
+// timeService tracks the time users spend on todos. Logging time needs write access
+// to the todo; everyone who can see it can see the time logged on it.
+type timeService struct {
+	repo repository.TimeEntryRepository
+	auth authorizer
+}
+
+func NewTimeService(repo repository.TimeEntryRepository, shareRepo repository.ShareRepository,
+	workspaceRepo repository.WorkspaceRepository) TimeService {
+	return &timeService{repo: repo, auth: authorizer{shares: shareRepo, workspaces: workspaceRepo}}
+}
+
+// Start starts a timer on the todo. Users have at most one running timer.
+func (s *timeService) Start(ctx context.Context, userID uuid.UUID, todoID int) (entity.TimeEntry, error) {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionEditor); err != nil {
+		return entity.TimeEntry{}, err
+	}
+	id, err := s.repo.Create(ctx, entity.TimeEntry{TodoID: todoID, UserID: userID, StartedAt: time.Now().UTC()})
+	if err != nil {
+		return entity.TimeEntry{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// Stop stops the user's timer on the todo. It works even if the user lost access
+// to the todo while the timer was running.
+func (s *timeService) Stop(ctx context.Context, userID uuid.UUID, todoID int) (entity.TimeEntry, error) {
+	id, err := s.repo.Stop(ctx, userID, todoID)
+	if err != nil {
+		return entity.TimeEntry{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+func (s *timeService) Running(ctx context.Context, userID uuid.UUID) (entity.TimeEntry, error) {
+	return s.repo.GetRunning(ctx, userID)
+}
+
+func (s *timeService) GetAll(ctx context.Context, userID uuid.UUID, todoID int, pagination entity.Pagination) ([]entity.TimeEntry, int, error) {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer); err != nil {
+		return nil, 0, err
+	}
+	if pagination.Limit > maxPageLimit {
+		pagination.Limit = maxPageLimit
+	}
+	return s.repo.GetAll(ctx, todoID, pagination)
+}
+
+func (s *timeService) Create(ctx context.Context, userID uuid.UUID, todoID int, request entity.TimeEntryRequest) (entity.TimeEntry, error) {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionEditor); err != nil {
+		return entity.TimeEntry{}, err
+	}
+	endedAt := request.EndedAt.UTC()
+	id, err := s.repo.Create(ctx, entity.TimeEntry{
+		TodoID:    todoID,
+		UserID:    userID,
+		StartedAt: request.StartedAt.UTC(),
+		EndedAt:   &endedAt,
+		Note:      request.Note,
+	})
+	if err != nil {
+		return entity.TimeEntry{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// Delete lets users delete their own time entries, including a running timer.
+func (s *timeService) Delete(ctx context.Context, userID uuid.UUID, todoID int, id int) error {
+	if _, err := s.auth.requireTodo(ctx, userID, todoID, entity.PermissionViewer); err != nil {
+		return err
+	}
+	entry, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if entry.TodoID != todoID {
+		return entity.ErrTimeEntryNotFound
+	}
+	if entry.UserID != userID {
+		return entity.ErrForbidden
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Report sums the time the user logged in entries started from request.From up to
+// and including request.To.
+func (s *timeService) Report(ctx context.Context, userID uuid.UUID, request entity.TimeReportRequest) (entity.TimeReport, error) {
+	rows, total, err := s.repo.Report(ctx, userID, request.From.Time, request.To.AddDate(0, 0, 1), request.GroupBy)
+	if err != nil {
+		return entity.TimeReport{}, err
+	}
+	if rows == nil {
+		rows = []entity.TimeReportRow{}
+	}
+	return entity.TimeReport{
+		From:         request.From,
+		To:           request.To,
+		GroupBy:      request.GroupBy,
+		TotalMinutes: total,
+		Rows:         rows,
+	}, nil
+}
@@ -2,47 +2,521 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"github.com/GlebMoskalev/go-todo-api/internal/entity"
 	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/storage"
 	"github.com/google/uuid"
+	"log/slog"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2 --name=TodoService --output=./mocks
 type TodoService interface {
 	Get(ctx context.Context, userID uuid.UUID, id int) (entity.Todo, error)
-	Create(ctx context.Context, userID uuid.UUID, todo entity.Todo) (int, error)
-	Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) error
-	Delete(ctx context.Context, userID uuid.UUID, id int) error
+	Create(ctx context.Context, userID uuid.UUID, todo entity.Todo) (int, entity.Undo, error)
+	Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) (entity.Undo, error)
+	Delete(ctx context.Context, userID uuid.UUID, id int) (entity.Undo, error)
 	GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination, filters entity.Filters) ([]entity.Todo, int, error)
+	GetAgenda(ctx context.Context, userID uuid.UUID, request entity.AgendaRequest) (entity.Agenda, error)
+	Export(ctx context.Context, userID uuid.UUID, filters entity.Filters) ([]entity.Todo, error)
+	// Stream passes the todos matching the filters to fn one at a time, without
+	// holding them all in memory.
+	Stream(ctx context.Context, userID uuid.UUID, filters entity.Filters, fn func(entity.Todo) error) error
+	GetSharedWithMe(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedTodo, int, error)
+	Assign(ctx context.Context, userID uuid.UUID, id int, assigneeID *uuid.UUID) (entity.Undo, error)
+	Complete(ctx context.Context, userID uuid.UUID, id int, completed bool) (entity.Undo, error)
+	GetHistory(ctx context.Context, userID uuid.UUID, id int, cursor entity.Cursor) (entity.CursorPage[entity.TodoHistory], error)
+	Bulk(ctx context.Context, userID uuid.UUID, request entity.BulkRequest) ([]entity.BulkOutcome, *entity.Undo, error)
+	CreateTree(ctx context.Context, userID uuid.UUID, tree entity.TodoTree) ([]int, entity.Undo, error)
+	Import(ctx context.Context, userID uuid.UUID, request entity.ImportRequest) (entity.ImportResult, error)
+	QuickAdd(ctx context.Context, userID uuid.UUID, request entity.QuickAddRequest) (entity.QuickAddResult, error)
 }
 
 type todoService struct {
-	repo repository.TodoRepository
+	repo           repository.TodoRepository
+	projectRepo    repository.ProjectRepository
+	userRepo       repository.UserRepository
+	attachmentRepo repository.AttachmentRepository
+	historyRepo    repository.HistoryRepository
+	tx             repository.Transactor
+	auth           authorizer
+	history        historian
+	undo           undoIssuer
+	notifier       notifier
+	remover        blobRemover
 }
 
-func NewTodoService(repo repository.TodoRepository) TodoService {
-	return &todoService{repo: repo}
+func NewTodoService(repo repository.TodoRepository, projectRepo repository.ProjectRepository,
+	userRepo repository.UserRepository, shareRepo repository.ShareRepository, workspaceRepo repository.WorkspaceRepository,
+	notificationRepo repository.NotificationRepository, attachmentRepo repository.AttachmentRepository,
+	historyRepo repository.HistoryRepository, undoRepo repository.UndoRepository, tx repository.Transactor,
+	blobs storage.BlobStore, undoWindow time.Duration, logger *slog.Logger) TodoService {
+	return &todoService{
+		repo:           repo,
+		projectRepo:    projectRepo,
+		userRepo:       userRepo,
+		attachmentRepo: attachmentRepo,
+		historyRepo:    historyRepo,
+		tx:             tx,
+		auth:           authorizer{shares: shareRepo, workspaces: workspaceRepo},
+		history:        historian{repo: historyRepo},
+		undo:           undoIssuer{repo: undoRepo, window: undoWindow},
+		notifier:       notifier{repo: notificationRepo, logger: logger},
+		remover:        blobRemover{blobs: blobs, logger: logger},
+	}
+}
+
+// checkProject verifies the user may add todos to the project and that the project
+// lives in the same workspace as the todo.
+func (s *todoService) checkProject(ctx context.Context, userID uuid.UUID, projectID *int, workspaceID *int) error {
+	if projectID == nil {
+		return nil
+	}
+	if _, err := s.auth.requireProject(ctx, userID, *projectID, entity.PermissionEditor); err != nil {
+		return err
+	}
+	project, err := s.projectRepo.Get(ctx, *projectID)
+	if err != nil {
+		return err
+	}
+	if !sameWorkspace(project.WorkspaceID, workspaceID) {
+		return entity.ErrWorkspaceMismatch
+	}
+	return nil
+}
+
+func sameWorkspace(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
 }
 
 func (s *todoService) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Todo, error) {
-	return s.repo.Get(ctx, userID, id)
+	if _, err := s.auth.requireTodo(ctx, userID, id, entity.PermissionViewer); err != nil {
+		return entity.Todo{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+func (s *todoService) Create(ctx context.Context, userID uuid.UUID, todo entity.Todo) (int, entity.Undo, error) {
+	var id int
+	undo, err := s.apply(ctx, func(ctx context.Context, cs *changeSet) error {
+		var err error
+		id, err = s.create(ctx, userID, todo, cs)
+		return err
+	})
+	return id, undo, err
+}
+
+func (s *todoService) create(ctx context.Context, userID uuid.UUID, todo entity.Todo, cs *changeSet) (int, error) {
+	if todo.WorkspaceID != nil {
+		if err := s.auth.requireWorkspace(ctx, userID, *todo.WorkspaceID, entity.PermissionEditor); err != nil {
+			return 0, err
+		}
+	}
+	if err := s.checkProject(ctx, userID, todo.ProjectID, todo.WorkspaceID); err != nil {
+		return 0, err
+	}
+	if todo.ParentID != nil {
+		parent, err := s.editable(ctx, userID, *todo.ParentID)
+		if err != nil {
+			return 0, err
+		}
+		if !sameWorkspace(parent.WorkspaceID, todo.WorkspaceID) {
+			return 0, entity.ErrParentMismatch
+		}
+	}
+
+	anchorRecurrence(&todo, nil)
+	id, err := s.repo.Create(ctx, userID, todo)
+	if err != nil {
+		return 0, err
+	}
+	return id, s.commit(ctx, cs, userID, entity.HistoryCreated, nil, id)
+}
+
+func (s *todoService) Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) (entity.Undo, error) {
+	return s.apply(ctx, func(ctx context.Context, cs *changeSet) error {
+		return s.update(ctx, userID, todo, cs)
+	})
+}
+
+func (s *todoService) update(ctx context.Context, userID uuid.UUID, todo entity.Todo, cs *changeSet) error {
+	existing, err := s.editable(ctx, userID, todo.ID)
+	if err != nil {
+		return err
+	}
+	todo.WorkspaceID = existing.WorkspaceID
+	todo.AssigneeID = existing.AssigneeID
+	todo.ParentID = existing.ParentID
+	todo.Completed = existing.Completed
+	return s.save(ctx, userID, existing, todo, cs)
+}
+
+// save writes the edited fields of a todo, checking the project it ends up in.
+func (s *todoService) save(ctx context.Context, userID uuid.UUID, existing, todo entity.Todo, cs *changeSet) error {
+	if err := s.checkProject(ctx, userID, todo.ProjectID, todo.WorkspaceID); err != nil {
+		return err
+	}
+	anchorRecurrence(&todo, existing.Recurrence)
+	if err := s.repo.Update(ctx, todo); err != nil {
+		return err
+	}
+	return s.commit(ctx, cs, userID, entity.HistoryUpdated, &existing, todo.ID)
+}
+
+// anchorRecurrence records the day the todo's series starts on. previous is the
+// series the todo was on before the edit, if any.
+func anchorRecurrence(todo *entity.Todo, previous *entity.Recurrence) {
+	if todo.Recurrence == nil {
+		return
+	}
+	recurrence := *todo.Recurrence
+	if todo.DueDate == nil {
+		recurrence.Start = nil
+	} else {
+		recurrence.Anchor(*todo.DueDate, previous)
+	}
+	todo.Recurrence = &recurrence
 }
 
-func (s *todoService) Create(ctx context.Context, userID uuid.UUID, todo entity.Todo) (int, error) {
-	return s.repo.Create(ctx, userID, todo)
+// CreateTree creates a todo and its subtasks, all or nothing, and returns their ids
+// with the todo first. One undo token reverts the whole tree.
+func (s *todoService) CreateTree(ctx context.Context, userID uuid.UUID, tree entity.TodoTree) ([]int, entity.Undo, error) {
+	var ids []int
+	undo, err := s.apply(ctx, func(ctx context.Context, cs *changeSet) error {
+		var err error
+		ids, err = s.createTree(ctx, userID, tree, nil, cs)
+		return err
+	})
+	return ids, undo, err
 }
 
-func (s *todoService) Update(ctx context.Context, userID uuid.UUID, todo entity.Todo) error {
-	return s.repo.Update(ctx, userID, todo)
+func (s *todoService) createTree(ctx context.Context, userID uuid.UUID, tree entity.TodoTree, parentID *int,
+	cs *changeSet) ([]int, error) {
+	todo := tree.Todo
+	todo.ParentID = parentID
+	id, err := s.create(ctx, userID, todo, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{id}
+	for _, subtask := range tree.Subtasks {
+		subtaskIDs, err := s.createTree(ctx, userID, subtask, &id, cs)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, subtaskIDs...)
+	}
+	return ids, nil
 }
 
-func (s *todoService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
-	return s.repo.Delete(ctx, userID, id)
+// Delete removes the todo. Attachment rows go with it, so their blobs are removed
+// afterwards to avoid leaving orphans in storage.
+func (s *todoService) Delete(ctx context.Context, userID uuid.UUID, id int) (entity.Undo, error) {
+	return s.apply(ctx, func(ctx context.Context, cs *changeSet) error {
+		return s.delete(ctx, userID, id, cs)
+	})
+}
+
+func (s *todoService) delete(ctx context.Context, userID uuid.UUID, id int, cs *changeSet) error {
+	if _, err := s.auth.requireTodo(ctx, userID, id, entity.PermissionOwner); err != nil {
+		return err
+	}
+	existing, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	keys, err := s.attachmentRepo.GetStorageKeys(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := s.commit(ctx, cs, userID, entity.HistoryDeleted, &existing, id); err != nil {
+		return err
+	}
+	cs.blobKeys = append(cs.blobKeys, keys...)
+	return nil
 }
 
 func (s *todoService) GetAll(ctx context.Context, userID uuid.UUID, pagination entity.Pagination, filters entity.Filters) ([]entity.Todo, int, error) {
 	if pagination.Limit > 100 {
 		pagination.Limit = 100
 	}
+	if err := s.checkFilters(ctx, userID, filters); err != nil {
+		return nil, 0, err
+	}
 	return s.repo.GetAll(ctx, userID, pagination, filters)
 }
+
+// checkFilters verifies the user can see the parent todo, project or workspace the
+// todos are listed from.
+func (s *todoService) checkFilters(ctx context.Context, userID uuid.UUID, filters entity.Filters) error {
+	if filters.ParentID != nil {
+		if _, err := s.auth.requireTodo(ctx, userID, *filters.ParentID, entity.PermissionViewer); err != nil {
+			return err
+		}
+	}
+	if filters.ProjectID != nil {
+		if _, err := s.auth.requireProject(ctx, userID, *filters.ProjectID, entity.PermissionViewer); err != nil {
+			return err
+		}
+	}
+	if filters.WorkspaceID != nil {
+		if err := s.auth.requireWorkspace(ctx, userID, *filters.WorkspaceID, entity.PermissionViewer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAgenda lists the todos matching the filters per day. Today is the current day
+// in the user's time zone.
+func (s *todoService) GetAgenda(ctx context.Context, userID uuid.UUID, request entity.AgendaRequest) (entity.Agenda, error) {
+	if err := s.checkFilters(ctx, userID, request.Filters); err != nil {
+		return entity.Agenda{}, err
+	}
+	today, location, err := s.today(ctx, userID)
+	if err != nil {
+		return entity.Agenda{}, err
+	}
+	from, to, err := request.Range(today)
+	if err != nil {
+		return entity.Agenda{}, err
+	}
+
+	todos, err := s.repo.GetAgenda(ctx, userID, request.Filters, from, to)
+	if err != nil {
+		return entity.Agenda{}, err
+	}
+	return entity.Agenda{
+		From:     from,
+		To:       to,
+		Today:    today,
+		Timezone: location.String(),
+		Days:     entity.BuildAgenda(todos, from, to, today),
+	}, nil
+}
+
+// Export lists every todo matching the filters, for example to render them as a
+// calendar.
+func (s *todoService) Export(ctx context.Context, userID uuid.UUID, filters entity.Filters) ([]entity.Todo, error) {
+	if err := s.checkFilters(ctx, userID, filters); err != nil {
+		return nil, err
+	}
+	return s.repo.Export(ctx, userID, filters)
+}
+
+func (s *todoService) Stream(ctx context.Context, userID uuid.UUID, filters entity.Filters, fn func(entity.Todo) error) error {
+	if err := s.checkFilters(ctx, userID, filters); err != nil {
+		return err
+	}
+	return s.repo.Stream(ctx, userID, filters, fn)
+}
+
+// today returns the current day in the user's time zone, falling back to UTC if
+// the zone is unknown.
+func (s *todoService) today(ctx context.Context, userID uuid.UUID) (entity.Date, *time.Location, error) {
+	return userToday(ctx, s.userRepo, userID, time.Now())
+}
+
+// userToday returns the day it is at now in the user's time zone, falling back to
+// UTC if the zone is unknown, along with the zone.
+func userToday(ctx context.Context, userRepo repository.UserRepository, userID uuid.UUID,
+	now time.Time) (entity.Date, *time.Location, error) {
+	user, err := userRepo.Get(ctx, userID)
+	if err != nil {
+		return entity.Date{}, nil, err
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	now = now.In(location)
+	return entity.Date{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)}, location, nil
+}
+
+func (s *todoService) GetSharedWithMe(ctx context.Context, userID uuid.UUID, pagination entity.Pagination) ([]entity.SharedTodo, int, error) {
+	if pagination.Limit > 100 {
+		pagination.Limit = 100
+	}
+	return s.repo.GetSharedWith(ctx, userID, pagination)
+}
+
+// Assign sets or clears the assignee of a todo. Only users who can see the todo may
+// be assigned, and the new assignee is notified unless they assigned themselves.
+func (s *todoService) Assign(ctx context.Context, userID uuid.UUID, id int, assigneeID *uuid.UUID) (entity.Undo, error) {
+	return s.apply(ctx, func(ctx context.Context, cs *changeSet) error {
+		return s.assign(ctx, userID, id, assigneeID, cs)
+	})
+}
+
+func (s *todoService) assign(ctx context.Context, userID uuid.UUID, id int, assigneeID *uuid.UUID, cs *changeSet) error {
+	existing, err := s.editable(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if assigneeID != nil {
+		permission, err := s.auth.shares.TodoPermission(ctx, *assigneeID, id)
+		if err != nil {
+			return err
+		}
+		if permission == "" {
+			return entity.ErrInvalidAssignee
+		}
+	}
+
+	if err := s.repo.Assign(ctx, id, assigneeID); err != nil {
+		return err
+	}
+	if err := s.commit(ctx, cs, userID, entity.HistoryAssigned, &existing, id); err != nil {
+		return err
+	}
+
+	reassigned := assigneeID != nil && (existing.AssigneeID == nil || *existing.AssigneeID != *assigneeID)
+	if reassigned && *assigneeID != userID {
+		cs.notifications = append(cs.notifications, entity.Notification{
+			UserID:  *assigneeID,
+			Type:    entity.NotificationAssigned,
+			TodoID:  &id,
+			ActorID: &userID,
+			Message: fmt.Sprintf("You were assigned to %q", existing.Title),
+		})
+	}
+	return nil
+}
+
+// Complete marks a todo as done or reopens it.
+func (s *todoService) Complete(ctx context.Context, userID uuid.UUID, id int, completed bool) (entity.Undo, error) {
+	return s.apply(ctx, func(ctx context.Context, cs *changeSet) error {
+		return s.complete(ctx, userID, id, completed, cs)
+	})
+}
+
+func (s *todoService) complete(ctx context.Context, userID uuid.UUID, id int, completed bool, cs *changeSet) error {
+	existing, err := s.editable(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	// Completing a recurring todo moves it to its next occurrence after today. Only
+	// the last occurrence of a series completes it.
+	if completed && !existing.Completed && existing.Recurrence != nil && existing.DueDate != nil {
+		today, _, err := s.today(ctx, userID)
+		if err != nil {
+			return err
+		}
+		after := *existing.DueDate
+		if after.Before(today.Time) {
+			after = today
+		}
+		if next := existing.Recurrence.Next(*existing.DueDate, after); next != nil {
+			todo := existing
+			// Later completions count from the start of the series, not from the
+			// due date, which a short month may have clamped.
+			anchorRecurrence(&todo, existing.Recurrence)
+			todo.DueDate = next
+			if err := s.repo.Update(ctx, todo); err != nil {
+				return err
+			}
+			return s.commit(ctx, cs, userID, entity.HistoryUpdated, &existing, id)
+		}
+	}
+
+	if err := s.repo.SetCompleted(ctx, id, completed); err != nil {
+		return err
+	}
+	return s.commit(ctx, cs, userID, entity.HistoryUpdated, &existing, id)
+}
+
+// editable returns the todo if the user may edit it.
+func (s *todoService) editable(ctx context.Context, userID uuid.UUID, id int) (entity.Todo, error) {
+	if _, err := s.auth.requireTodo(ctx, userID, id, entity.PermissionEditor); err != nil {
+		return entity.Todo{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// GetHistory returns the change log of a todo, oldest first.
+func (s *todoService) GetHistory(ctx context.Context, userID uuid.UUID, id int, cursor entity.Cursor) (entity.CursorPage[entity.TodoHistory], error) {
+	if _, err := s.auth.requireTodo(ctx, userID, id, entity.PermissionViewer); err != nil {
+		return entity.CursorPage[entity.TodoHistory]{}, err
+	}
+	return fetchPage(cursor, func(entry entity.TodoHistory) int { return entry.ID },
+		func(cursor entity.Cursor) ([]entity.TodoHistory, error) {
+			return s.historyRepo.GetAll(ctx, id, cursor)
+		})
+}
+
+// changeSet collects what a change needs beyond its transaction: the undo token it
+// is issued under, and the blobs to remove and notifications to send once the
+// transaction has committed.
+type changeSet struct {
+	undo          entity.Undo
+	issued        bool
+	blobKeys      []string
+	notifications []entity.Notification
+}
+
+// child starts a change set for part of a larger change. It shares the undo token
+// and is merged back only if that part succeeds.
+func (c *changeSet) child() *changeSet {
+	return &changeSet{undo: c.undo}
+}
+
+func (c *changeSet) merge(other *changeSet) {
+	c.issued = c.issued || other.issued
+	c.blobKeys = append(c.blobKeys, other.blobKeys...)
+	c.notifications = append(c.notifications, other.notifications...)
+}
+
+// apply runs fn in a transaction and, once it has committed, carries out the work
+// collected in the change set.
+func (s *todoService) apply(ctx context.Context, fn func(ctx context.Context, cs *changeSet) error) (entity.Undo, error) {
+	cs := &changeSet{undo: s.undo.newUndo()}
+	if err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return fn(ctx, cs)
+	}); err != nil {
+		return entity.Undo{}, err
+	}
+	s.finish(ctx, cs)
+	return cs.undo, nil
+}
+
+func (s *todoService) finish(ctx context.Context, cs *changeSet) {
+	s.remover.remove(ctx, cs.blobKeys...)
+	for _, notification := range cs.notifications {
+		s.notifier.notify(ctx, notification)
+	}
+}
+
+// commit finishes a change inside its transaction: it records the change in the
+// todo's history and issues an undo for it under the change set's token. before is
+// the todo as it was, or nil if it was just created.
+func (s *todoService) commit(ctx context.Context, cs *changeSet, userID uuid.UUID, operation entity.HistoryOperation,
+	before *entity.Todo, id int) error {
+	var after *entity.Todo
+	if operation != entity.HistoryDeleted {
+		todo, err := s.repo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		after = &todo
+	}
+
+	if err := s.history.record(ctx, userID, id, operation, before, after); err != nil {
+		return err
+	}
+	if err := s.undo.issue(ctx, cs.undo, userID, operation, before, after); err != nil {
+		return err
+	}
+	cs.issued = true
+	return nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTodoRepository keeps a single todo in memory.
+type stubTodoRepository struct {
+	repository.TodoRepository
+	todo entity.Todo
+}
+
+func (r *stubTodoRepository) Get(_ context.Context, id int) (entity.Todo, error) {
+	if id != r.todo.ID {
+		return entity.Todo{}, entity.ErrTodoNotFound
+	}
+	return r.todo, nil
+}
+
+func (r *stubTodoRepository) Update(_ context.Context, todo entity.Todo) error {
+	r.todo = todo
+	return nil
+}
+
+func (r *stubTodoRepository) SetCompleted(_ context.Context, _ int, completed bool) error {
+	r.todo.Completed = completed
+	return nil
+}
+
+type stubShareRepository struct {
+	repository.ShareRepository
+}
+
+func (stubShareRepository) TodoPermission(context.Context, uuid.UUID, int) (entity.Permission, error) {
+	return entity.PermissionOwner, nil
+}
+
+type stubHistoryRepository struct {
+	repository.HistoryRepository
+}
+
+func (stubHistoryRepository) Create(context.Context, entity.TodoHistory) error {
+	return nil
+}
+
+type stubUndoRepository struct {
+	repository.UndoRepository
+}
+
+func (stubUndoRepository) Create(context.Context, entity.UndoRecord) error {
+	return nil
+}
+
+func TestTodoService_CompleteMonthlyOnThe31st(t *testing.T) {
+	userID := uuid.New()
+	// The series lies in the future so that today never moves it.
+	year := time.Now().Year() + 1
+	due := entity.Date{Time: time.Date(year, time.January, 31, 0, 0, 0, 0, time.UTC)}
+	todos := &stubTodoRepository{todo: entity.Todo{
+		ID:         1,
+		Title:      "Pay rent",
+		DueDate:    &due,
+		Recurrence: &entity.Recurrence{Frequency: entity.RecurMonthly},
+	}}
+	s := &todoService{
+		repo:     todos,
+		userRepo: &stubUserRepository{user: entity.User{ID: userID, Timezone: "UTC"}},
+		tx:       stubTransactor{},
+		auth:     authorizer{shares: stubShareRepository{}},
+		history:  historian{repo: stubHistoryRepository{}},
+		undo:     undoIssuer{repo: stubUndoRepository{}, window: time.Minute},
+	}
+
+	lastOfFebruary := time.Date(year, time.March, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, expected := range []time.Time{
+		time.Date(year, time.February, lastOfFebruary, 0, 0, 0, 0, time.UTC),
+		time.Date(year, time.March, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(year, time.April, 30, 0, 0, 0, 0, time.UTC),
+	} {
+		_, err := s.Complete(context.Background(), userID, 1, true)
+		require.NoError(t, err)
+		assert.False(t, todos.todo.Completed)
+		assert.Equal(t, expected, todos.todo.DueDate.Time)
+		assert.Equal(t, due, *todos.todo.Recurrence.Start)
+	}
+}
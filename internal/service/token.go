@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"github.com/GlebMoskalev/go-todo-api/internal/config"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
 	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/useragent"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"log/slog"
@@ -13,56 +16,111 @@ import (
 
 //go:generate go run github.com/vektra/mockery/v2 --name=TokenService --output=./mocks
 type TokenService interface {
-	GenerateTokenPair(ctx context.Context, id uuid.UUID) (string, string, error)
-	ValidateAccessToken(tokenString string) (uuid.UUID, error)
+	// GenerateTokenPair starts a session for the client and issues its first tokens.
+	GenerateTokenPair(ctx context.Context, id uuid.UUID, client entity.SessionClient) (string, string, error)
+	// ValidateAccessToken checks the signature and expiry of an access token and that
+	// it has not been revoked, and returns the user it was issued to.
+	ValidateAccessToken(ctx context.Context, tokenString string) (uuid.UUID, error)
 	ValidateRefreshToken(tokenString string) (uuid.UUID, error)
+	// RefreshTokens rotates a refresh token. Presenting a token that was rotated
+	// already revokes every token descended from the same login and fails with
+	// ErrTokenReused.
 	RefreshTokens(ctx context.Context, refreshTokenString string) (string, string, error)
+	// Logout revokes the refresh tokens of the login a refresh token belongs to and,
+	// if given, the access token used with it.
+	Logout(ctx context.Context, refreshTokenString, accessTokenString string) error
+	// LogoutAll revokes every refresh and access token of the user.
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	// LogoutOthers ends every session of the user but the one the access token
+	// belongs to.
+	LogoutOthers(ctx context.Context, userID uuid.UUID, accessTokenString string) error
+	// Sessions lists the sessions of the user, marking the one the access token
+	// belongs to as current.
+	Sessions(ctx context.Context, userID uuid.UUID, accessTokenString string) ([]entity.Session, error)
+	// RevokeSession ends a session of the user together with its refresh and access
+	// tokens.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	// CreateAppPassword starts a session for a client that signs in with HTTP Basic
+	// auth, such as a CalDAV app, and returns the password it signs in with.
+	CreateAppPassword(ctx context.Context, userID uuid.UUID, request entity.AppPasswordRequest,
+		client entity.SessionClient) (entity.AppPassword, error)
+	// AuthenticateAppPassword returns the user an app password belongs to, or
+	// ErrWrongPassword if it is not one of username's or its session has ended.
+	AuthenticateAppPassword(ctx context.Context, username, password string) (uuid.UUID, error)
 }
 
+const appPasswordBytes = 24
+
 type tokenService struct {
 	userRepo  repository.UserRepository
 	tokenRepo repository.TokenRepository
+	tx        repository.Transactor
 	config    config.Config
 	logger    *slog.Logger
 }
 
 func NewTokenService(userRepo repository.UserRepository, tokenRepo repository.TokenRepository,
-	config config.Config, logger *slog.Logger) TokenService {
+	tx repository.Transactor, config config.Config, logger *slog.Logger) TokenService {
 	return &tokenService{
 		userRepo:  userRepo,
 		tokenRepo: tokenRepo,
+		tx:        tx,
 		config:    config,
 		logger:    logger,
 	}
 }
 
-func (s *tokenService) GenerateTokenPair(ctx context.Context, id uuid.UUID) (string, string, error) {
-	accessToken, err := s.generateAccessToken(id)
-	if err != nil {
-		return "", "", err
+func (s *tokenService) GenerateTokenPair(ctx context.Context, id uuid.UUID, client entity.SessionClient) (string, string, error) {
+	session := entity.Session{
+		ID:         uuid.New(),
+		DeviceName: useragent.DeviceName(client.UserAgent),
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  time.Now().UTC(),
 	}
 
-	refreshToken, err := s.generateRefreshToken(ctx, id)
+	var accessToken, refreshToken string
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tokenRepo.CreateSession(ctx, id, session); err != nil {
+			return err
+		}
+
+		var err error
+		accessToken, err = s.generateAccessToken(id, session.ID)
+		if err != nil {
+			return err
+		}
+		refreshToken, err = s.generateRefreshToken(ctx, id, session.ID, nil)
+		return err
+	})
 	if err != nil {
 		return "", "", err
 	}
-
 	return accessToken, refreshToken, nil
 }
 
-func (s *tokenService) generateAccessToken(id uuid.UUID) (string, error) {
+// generateAccessToken issues an access token of the session; the sid claim lets
+// ending the session revoke it.
+func (s *tokenService) generateAccessToken(id, sessionID uuid.UUID) (string, error) {
+	now := time.Now().UTC()
 	payload := jwt.MapClaims{
 		"id":  id,
-		"exp": time.Now().UTC().Add(time.Duration(s.config.Token.AccessTokenExpire) * time.Minute).Unix(),
+		"sid": sessionID,
+		"jti": uuid.New(),
+		"iat": now.Unix(),
+		"exp": now.Add(time.Duration(s.config.Token.AccessTokenExpire) * time.Minute).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
 	return token.SignedString([]byte(s.config.Token.AccessTokenSecret))
 }
 
-func (s *tokenService) generateRefreshToken(ctx context.Context, id uuid.UUID) (string, error) {
+// generateRefreshToken issues a refresh token of the session, whose ID is the family
+// of its refresh tokens. The jti keeps tokens issued within the same second apart.
+func (s *tokenService) generateRefreshToken(ctx context.Context, id, familyID uuid.UUID, parentID *int) (string, error) {
 	payload := jwt.MapClaims{
 		"id":  id,
+		"jti": uuid.New(),
 		"exp": time.Now().UTC().Add(time.Duration(s.config.Token.RefreshTokenExpire) * time.Minute).Unix(),
 	}
 
@@ -72,7 +130,7 @@ func (s *tokenService) generateRefreshToken(ctx context.Context, id uuid.UUID) (
 		return "", err
 	}
 
-	err = s.tokenRepo.SaveRefreshToken(ctx, id, refreshToken,
+	err = s.tokenRepo.SaveRefreshToken(ctx, id, utils.HashToken(refreshToken), familyID, parentID,
 		time.Duration(s.config.Token.RefreshTokenExpire)*time.Minute)
 	if err != nil {
 		return "", err
@@ -81,19 +139,65 @@ func (s *tokenService) generateRefreshToken(ctx context.Context, id uuid.UUID) (
 	return refreshToken, nil
 }
 
-func (s *tokenService) ValidateAccessToken(tokenString string) (uuid.UUID, error) {
+func (s *tokenService) ValidateAccessToken(ctx context.Context, tokenString string) (uuid.UUID, error) {
+	claims, err := s.parseAccessToken(tokenString)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	revoked, err := s.tokenRepo.IsAccessTokenRevoked(ctx, claims.userID, claims.jti, claims.sessionID, claims.issuedAt)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if revoked {
+		return uuid.Nil, entity.ErrInvalidToken
+	}
+	return claims.userID, nil
+}
+
+// accessClaims are the claims of a valid access token. Tokens issued before access
+// tokens carried a jti have none and count as issued at the epoch; tokens issued
+// before sessions existed have no sid.
+type accessClaims struct {
+	userID    uuid.UUID
+	sessionID uuid.UUID
+	jti       uuid.UUID
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+func (s *tokenService) parseAccessToken(tokenString string) (accessClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.config.Token.AccessTokenSecret), nil
 	})
+	if err != nil {
+		return accessClaims{}, err
+	}
 
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return accessClaims{}, entity.ErrInvalidToken
+	}
+	id, _ := claims["id"].(string)
+	userID, err := uuid.Parse(id)
 	if err != nil {
-		return uuid.Nil, err
+		return accessClaims{}, entity.ErrInvalidToken
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		return uuid.Parse(claims["id"].(string))
+	result := accessClaims{userID: userID, issuedAt: time.Unix(0, 0)}
+	if jti, ok := claims["jti"].(string); ok {
+		result.jti, _ = uuid.Parse(jti)
 	}
-	return uuid.Nil, errors.New("invalid token")
+	if sid, ok := claims["sid"].(string); ok {
+		result.sessionID, _ = uuid.Parse(sid)
+	}
+	if issuedAt, err := claims.GetIssuedAt(); err == nil && issuedAt != nil {
+		result.issuedAt = issuedAt.Time
+	}
+	if expiresAt, err := claims.GetExpirationTime(); err == nil && expiresAt != nil {
+		result.expiresAt = expiresAt.Time
+	}
+	return result, nil
 }
 
 func (s *tokenService) ValidateRefreshToken(tokenString string) (uuid.UUID, error) {
@@ -111,21 +215,163 @@ func (s *tokenService) ValidateRefreshToken(tokenString string) (uuid.UUID, erro
 	return uuid.Nil, errors.New("invalid token")
 }
 
+// RefreshTokens replaces a refresh token with a new one of the same family. The old
+// token is kept as rotated, so that a replay of it can be told apart from a token
+// that was never issued.
 func (s *tokenService) RefreshTokens(ctx context.Context, refreshTokenString string) (string, string, error) {
-	id, err := s.ValidateRefreshToken(refreshTokenString)
+	token, err := s.useRefreshToken(ctx, refreshTokenString)
 	if err != nil {
 		return "", "", err
 	}
 
-	isValid, err := s.tokenRepo.ValidateRefreshToken(ctx, id, refreshTokenString)
-	if err != nil || !isValid {
-		return "", "", errors.New("invalid refresh token")
-	}
+	var accessToken, refreshToken string
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		inUse, err := s.tokenRepo.MarkRefreshTokenRotated(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+		if !inUse {
+			return entity.ErrTokenReused
+		}
+		if err := s.tokenRepo.TouchSession(ctx, token.FamilyID); err != nil {
+			return err
+		}
 
-	err = s.tokenRepo.DeleteRefreshToken(ctx, refreshTokenString)
+		accessToken, err = s.generateAccessToken(token.UserID, token.FamilyID)
+		if err != nil {
+			return err
+		}
+		refreshToken, err = s.generateRefreshToken(ctx, token.UserID, token.FamilyID, &token.ID)
+		return err
+	})
+	if errors.Is(err, entity.ErrTokenReused) {
+		// Another request rotated the token in the meantime.
+		return "", "", s.revokeFamily(ctx, token)
+	}
 	if err != nil {
 		return "", "", err
 	}
+	return accessToken, refreshToken, nil
+}
+
+// useRefreshToken looks up a refresh token presented by a client. A token that was
+// rotated already has been used twice, by the client and by whoever else holds
+// it; its family is revoked so that neither can go on.
+func (s *tokenService) useRefreshToken(ctx context.Context, refreshTokenString string) (entity.RefreshToken, error) {
+	id, err := s.ValidateRefreshToken(refreshTokenString)
+	if err != nil {
+		return entity.RefreshToken{}, entity.ErrInvalidToken
+	}
+	token, err := s.tokenRepo.GetRefreshToken(ctx, id, utils.HashToken(refreshTokenString))
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+	if token.RotatedAt != nil {
+		return entity.RefreshToken{}, s.revokeFamily(ctx, token)
+	}
+	return token, nil
+}
+
+// revokeFamily revokes the family of a reused refresh token and returns
+// ErrTokenReused unless revoking fails.
+func (s *tokenService) revokeFamily(ctx context.Context, token entity.RefreshToken) error {
+	logger := utils.SetupLogger(ctx, s.logger, "token_service", "revokeFamily",
+		"user_id", token.UserID, "family_id", token.FamilyID, "token_id", token.ID)
+	logger.Warn("Security event: refresh token reused, revoking its family", "event", "refresh_token_reuse")
+
+	err := s.tokenRepo.DeleteSession(ctx, token.UserID, token.FamilyID)
+	if err != nil && !errors.Is(err, entity.ErrSessionNotFound) {
+		logger.Error("Failed to revoke refresh token family", "error", err)
+		return err
+	}
+	return entity.ErrTokenReused
+}
+
+// Logout ends the session the refresh token belongs to, revoking its whole family.
+func (s *tokenService) Logout(ctx context.Context, refreshTokenString, accessTokenString string) error {
+	token, err := s.useRefreshToken(ctx, refreshTokenString)
+	if err != nil {
+		return err
+	}
+	err = s.tokenRepo.DeleteSession(ctx, token.UserID, token.FamilyID)
+	if err != nil && !errors.Is(err, entity.ErrSessionNotFound) {
+		return err
+	}
+
+	// An access token that is invalid already, or was issued to someone else, is
+	// left alone: the refresh token is what logging out is about.
+	if accessTokenString == "" {
+		return nil
+	}
+	claims, err := s.parseAccessToken(accessTokenString)
+	if err != nil || claims.userID != token.UserID || claims.jti == uuid.Nil {
+		return nil
+	}
+	return s.tokenRepo.RevokeAccessToken(ctx, token.UserID, claims.jti, claims.expiresAt)
+}
+
+// LogoutAll revokes access tokens by the time they were issued, as they are not
+// stored. Ending the sessions also covers tokens issued earlier in the second of the
+// logout, which the issue time cannot tell apart from tokens issued after it.
+func (s *tokenService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	if err := s.tokenRepo.DeleteUserSessions(ctx, userID); err != nil {
+		return err
+	}
+	return s.tokenRepo.RevokeUserAccessTokens(ctx, userID, time.Now().UTC())
+}
+
+// LogoutOthers ends every session if the access token has none, as tokens issued
+// before sessions existed have no sid. Such a token itself stays valid until it
+// expires.
+func (s *tokenService) LogoutOthers(ctx context.Context, userID uuid.UUID, accessTokenString string) error {
+	var current uuid.UUID
+	if claims, err := s.parseAccessToken(accessTokenString); err == nil && claims.userID == userID {
+		current = claims.sessionID
+	}
+	return s.tokenRepo.DeleteOtherSessions(ctx, userID, current)
+}
+
+func (s *tokenService) Sessions(ctx context.Context, userID uuid.UUID, accessTokenString string) ([]entity.Session, error) {
+	sessions, err := s.tokenRepo.GetSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := s.parseAccessToken(accessTokenString)
+	if err != nil || claims.sessionID == uuid.Nil {
+		return sessions, nil
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == claims.sessionID
+	}
+	return sessions, nil
+}
+
+func (s *tokenService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.tokenRepo.DeleteSession(ctx, userID, sessionID)
+}
+
+func (s *tokenService) CreateAppPassword(ctx context.Context, userID uuid.UUID, request entity.AppPasswordRequest,
+	client entity.SessionClient) (entity.AppPassword, error) {
+	password, err := utils.GenerateToken(appPasswordBytes)
+	if err != nil {
+		return entity.AppPassword{}, err
+	}
+	session := entity.Session{
+		ID:         uuid.New(),
+		DeviceName: request.Name,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := s.tokenRepo.CreateAppPasswordSession(ctx, userID, session, utils.HashToken(password)); err != nil {
+		return entity.AppPassword{}, err
+	}
+	return entity.AppPassword{SessionID: session.ID, Name: request.Name, Password: password}, nil
+}
 
-	return s.GenerateTokenPair(ctx, id)
+// AuthenticateAppPassword looks the password up by its hash. App passwords are
+// random and long enough that guessing them is hopeless, so failed attempts are not
+// counted.
+func (s *tokenService) AuthenticateAppPassword(ctx context.Context, username, password string) (uuid.UUID, error) {
+	return s.tokenRepo.UseAppPassword(ctx, username, utils.HashToken(password))
 }
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=UndoService --output=./mocks
+type UndoService interface {
+	Undo(ctx context.Context, userID uuid.UUID, token uuid.UUID) error
+}
+
+// undoIssuer hands out undo tokens for todo changes. Tokens are stored in the
+// transaction of the change, so a change that is rolled back leaves no token.
+type undoIssuer struct {
+	repo   repository.UndoRepository
+	window time.Duration
+}
+
+// newUndo starts an undo token. Every change issued under it is reverted together.
+func (u undoIssuer) newUndo() entity.Undo {
+	return entity.Undo{
+		Token:     uuid.New(),
+		ExpiresAt: time.Now().Add(u.window).UTC().Truncate(time.Microsecond),
+	}
+}
+
+// issue stores how to revert a change under the given undo token. before and after
+// follow the same rules as for historian.record.
+func (u undoIssuer) issue(ctx context.Context, undo entity.Undo, userID uuid.UUID, operation entity.HistoryOperation,
+	before, after *entity.Todo) error {
+	record := entity.UndoRecord{
+		Token:     undo.Token,
+		UserID:    userID,
+		Operation: operation,
+		Before:    before,
+		ExpiresAt: undo.ExpiresAt,
+	}
+	if after != nil {
+		record.TodoID = after.ID
+		record.OwnerID = after.OwnerID
+		record.UpdatedAt = after.UpdatedAt
+	} else {
+		record.TodoID = before.ID
+		record.OwnerID = before.OwnerID
+	}
+
+	return u.repo.Create(ctx, record)
+}
+
+type undoService struct {
+	repo     repository.UndoRepository
+	todoRepo repository.TodoRepository
+	tx       repository.Transactor
+	auth     authorizer
+	history  historian
+}
+
+func NewUndoService(repo repository.UndoRepository, todoRepo repository.TodoRepository,
+	shareRepo repository.ShareRepository, workspaceRepo repository.WorkspaceRepository,
+	historyRepo repository.HistoryRepository, tx repository.Transactor) UndoService {
+	return &undoService{
+		repo:     repo,
+		todoRepo: todoRepo,
+		tx:       tx,
+		auth:     authorizer{shares: shareRepo, workspaces: workspaceRepo},
+		history:  historian{repo: historyRepo},
+	}
+}
+
+// Undo reverts the changes the token was issued for. The token can be used once, and
+// only if none of the todos was changed again in the meantime. Reverting a delete
+// brings back the todo itself, but not its comments, shares or attachments.
+func (s *undoService) Undo(ctx context.Context, userID uuid.UUID, token uuid.UUID) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		records, err := s.repo.Take(ctx, userID, token)
+		if err != nil {
+			return err
+		}
+		for _, record := range collapse(records) {
+			if err := s.revert(ctx, userID, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// collapse merges the records of each todo into one that takes the todo from its
+// last state straight back to its first.
+func collapse(records []entity.UndoRecord) []entity.UndoRecord {
+	var order []int
+	first := make(map[int]entity.UndoRecord)
+	last := make(map[int]entity.UndoRecord)
+	for _, record := range records {
+		if _, ok := first[record.TodoID]; !ok {
+			first[record.TodoID] = record
+			order = append(order, record.TodoID)
+		}
+		last[record.TodoID] = record
+	}
+
+	var merged []entity.UndoRecord
+	for _, id := range order {
+		record := first[id]
+		record.UpdatedAt = last[id].UpdatedAt
+		created := record.Operation == entity.HistoryCreated
+		deleted := last[id].Operation == entity.HistoryDeleted
+		switch {
+		case created && deleted:
+			continue
+		case created:
+		case deleted:
+			record.Operation = entity.HistoryDeleted
+		default:
+			record.Operation = entity.HistoryUpdated
+		}
+		merged = append(merged, record)
+	}
+	return merged
+}
+
+func (s *undoService) revert(ctx context.Context, userID uuid.UUID, record entity.UndoRecord) error {
+	switch record.Operation {
+	case entity.HistoryCreated:
+		current, err := s.current(ctx, record.TodoID)
+		if err != nil {
+			return err
+		}
+		if err := s.todoRepo.DeleteUnchanged(ctx, record.TodoID, *record.UpdatedAt); err != nil {
+			return err
+		}
+		return s.history.record(ctx, userID, record.TodoID, entity.HistoryDeleted, &current, nil)
+	case entity.HistoryDeleted:
+		before := *record.Before
+		before.ID = record.TodoID
+		before.OwnerID = record.OwnerID
+		if err := s.todoRepo.Recreate(ctx, before); err != nil {
+			return err
+		}
+		return s.history.record(ctx, userID, record.TodoID, entity.HistoryCreated, nil, &before)
+	default:
+		if _, err := s.auth.requireTodo(ctx, userID, record.TodoID, entity.PermissionEditor); err != nil {
+			if errors.Is(err, entity.ErrTodoNotFound) {
+				return entity.ErrUndoConflict
+			}
+			return err
+		}
+		current, err := s.current(ctx, record.TodoID)
+		if err != nil {
+			return err
+		}
+		before := *record.Before
+		before.ID = record.TodoID
+		if err := s.todoRepo.Restore(ctx, before, *record.UpdatedAt); err != nil {
+			return err
+		}
+		return s.history.record(ctx, userID, record.TodoID, entity.HistoryUpdated, &current, &before)
+	}
+}
+
+// current returns the todo an undo applies to. A todo deleted since the change can
+// no longer be reverted.
+func (s *undoService) current(ctx context.Context, id int) (entity.Todo, error) {
+	todo, err := s.todoRepo.Get(ctx, id)
+	if errors.Is(err, entity.ErrTodoNotFound) {
+		return entity.Todo{}, entity.ErrUndoConflict
+	}
+	return todo, err
+}
@@ -13,15 +13,17 @@ type UserService interface {
 	Register(ctx context.Context, user entity.UserLogin) (entity.User, error)
 	GetUser(ctx context.Context, id uuid.UUID) (entity.User, error)
 	GetByUsername(ctx context.Context, username string) (entity.User, error)
+	UpdateProfile(ctx context.Context, id uuid.UUID, update entity.ProfileUpdate) (entity.User, error)
 }
 
 type userService struct {
 	repo   repository.UserRepository
+	tx     repository.Transactor
 	logger *slog.Logger
 }
 
-func NewUserService(repo repository.UserRepository, logger *slog.Logger) UserService {
-	return &userService{repo: repo, logger: logger}
+func NewUserService(repo repository.UserRepository, tx repository.Transactor, logger *slog.Logger) UserService {
+	return &userService{repo: repo, tx: tx, logger: logger}
 }
 
 func (s *userService) Register(ctx context.Context, user entity.UserLogin) (entity.User, error) {
@@ -35,3 +37,30 @@ func (s *userService) GetUser(ctx context.Context, id uuid.UUID) (entity.User, e
 func (s *userService) GetByUsername(ctx context.Context, username string) (entity.User, error) {
 	return s.repo.GetByUsername(ctx, username)
 }
+
+func (s *userService) UpdateProfile(ctx context.Context, id uuid.UUID, update entity.ProfileUpdate) (entity.User, error) {
+	if update.Email != nil {
+		user, err := s.repo.Get(ctx, id)
+		if err != nil {
+			return entity.User{}, err
+		}
+		if !entity.VerifyPassword(update.CurrentPassword, user.PasswordHash) {
+			return entity.User{}, entity.ErrWrongPassword
+		}
+	}
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if update.Timezone != nil {
+			if err := s.repo.UpdateTimezone(ctx, id, *update.Timezone); err != nil {
+				return err
+			}
+		}
+		if update.Email != nil {
+			return s.repo.UpdateEmail(ctx, id, *update.Email)
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.User{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
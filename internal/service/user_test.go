@@ -0,0 +1,145 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubUserRepository keeps a single user in memory. Methods the tests do not
+// need are left to the embedded interface and panic if called.
+type stubUserRepository struct {
+	repository.UserRepository
+	user     entity.User
+	emailErr error
+}
+
+func (r *stubUserRepository) Get(_ context.Context, id uuid.UUID) (entity.User, error) {
+	if id != r.user.ID {
+		return entity.User{}, entity.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+func (r *stubUserRepository) GetByUsername(_ context.Context, username string) (entity.User, error) {
+	if username != r.user.Username {
+		return entity.User{}, entity.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+func (r *stubUserRepository) UpdateEmail(_ context.Context, _ uuid.UUID, email string) error {
+	if r.emailErr != nil {
+		return r.emailErr
+	}
+	r.user.Email = email
+	return nil
+}
+
+func (r *stubUserRepository) UpdateTimezone(_ context.Context, _ uuid.UUID, timezone string) error {
+	r.user.Timezone = timezone
+	return nil
+}
+
+// rollbackTransactor restores the stub user when fn fails, as a rollback would.
+type rollbackTransactor struct {
+	repo *stubUserRepository
+}
+
+func (t rollbackTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := t.repo.user
+	if err := fn(ctx); err != nil {
+		t.repo.user = saved
+		return err
+	}
+	return nil
+}
+
+func TestUserService_UpdateProfileEmail(t *testing.T) {
+	passwordHash, err := entity.HashPassword("Secret123")
+	require.NoError(t, err)
+	userID := uuid.New()
+	email := "attacker@example.com"
+	timezone := "Europe/Berlin"
+
+	testCases := []struct {
+		name          string
+		update        entity.ProfileUpdate
+		expectedError error
+		expectedEmail string
+	}{
+		{
+			name:          "correct password",
+			update:        entity.ProfileUpdate{Email: &email, CurrentPassword: "Secret123"},
+			expectedEmail: email,
+		},
+		{
+			name:          "wrong password",
+			update:        entity.ProfileUpdate{Email: &email, CurrentPassword: "Wrong123", Timezone: &timezone},
+			expectedError: entity.ErrWrongPassword,
+			expectedEmail: "john@example.com",
+		},
+		{
+			name:          "missing password",
+			update:        entity.ProfileUpdate{Email: &email},
+			expectedError: entity.ErrWrongPassword,
+			expectedEmail: "john@example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &stubUserRepository{user: entity.User{
+				ID: userID, Username: "john_doe", PasswordHash: passwordHash, Timezone: "UTC", Email: "john@example.com",
+			}}
+			s := NewUserService(repo, rollbackTransactor{repo: repo}, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+			_, err := s.UpdateProfile(context.Background(), userID, tc.update)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedEmail, repo.user.Email)
+			if tc.expectedError != nil {
+				assert.Equal(t, "UTC", repo.user.Timezone)
+			}
+		})
+	}
+}
+
+func TestUserService_UpdateProfileTimezoneWithoutPassword(t *testing.T) {
+	userID := uuid.New()
+	timezone := "Europe/Berlin"
+	repo := &stubUserRepository{user: entity.User{ID: userID, Username: "john_doe", Timezone: "UTC"}}
+	s := NewUserService(repo, rollbackTransactor{repo: repo}, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	user, err := s.UpdateProfile(context.Background(), userID, entity.ProfileUpdate{Timezone: &timezone})
+	require.NoError(t, err)
+	assert.Equal(t, timezone, user.Timezone)
+}
+
+func TestUserService_UpdateProfileRollsBack(t *testing.T) {
+	passwordHash, err := entity.HashPassword("Secret123")
+	require.NoError(t, err)
+	userID := uuid.New()
+	email := "john.doe@example.com"
+	timezone := "Europe/Berlin"
+	repo := &stubUserRepository{
+		user: entity.User{
+			ID: userID, Username: "john_doe", PasswordHash: passwordHash, Timezone: "UTC", Email: "john@example.com",
+		},
+		emailErr: errors.New("db down"),
+	}
+	s := NewUserService(repo, rollbackTransactor{repo: repo}, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	_, err = s.UpdateProfile(context.Background(), userID,
+		entity.ProfileUpdate{Email: &email, Timezone: &timezone, CurrentPassword: "Secret123"})
+	assert.ErrorIs(t, err, repo.emailErr)
+	assert.Equal(t, "UTC", repo.user.Timezone)
+	assert.Equal(t, "john@example.com", repo.user.Email)
+}
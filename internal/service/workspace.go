@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/GlebMoskalev/go-todo-api/internal/repository"
+	"github.com/GlebMoskalev/go-todo-api/internal/utils"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+const invitationTokenBytes = 32
+
+//go:generate go run github.com/vektra/mockery/v2 --name=WorkspaceService --output=./mocks
+type WorkspaceService interface {
+	Create(ctx context.Context, userID uuid.UUID, workspace entity.Workspace) (int, error)
+	Get(ctx context.Context, userID uuid.UUID, id int) (entity.Workspace, error)
+	GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Workspace, error)
+	Delete(ctx context.Context, userID uuid.UUID, id int) error
+	GetRole(ctx context.Context, userID uuid.UUID, id int) (entity.WorkspaceRole, error)
+	GetMembers(ctx context.Context, userID uuid.UUID, id int) ([]entity.WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, userID uuid.UUID, id int, memberID uuid.UUID, role entity.WorkspaceRole) error
+	RemoveMember(ctx context.Context, userID uuid.UUID, id int, memberID uuid.UUID) error
+	CreateInvitation(ctx context.Context, userID uuid.UUID, id int, request entity.InvitationRequest) (entity.Invitation, error)
+	GetInvitations(ctx context.Context, userID uuid.UUID, id int) ([]entity.Invitation, error)
+	RevokeInvitation(ctx context.Context, userID uuid.UUID, id int, invitationID int) error
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (entity.Workspace, error)
+}
+
+type workspaceService struct {
+	repo   repository.WorkspaceRepository
+	logger *slog.Logger
+}
+
+func NewWorkspaceService(repo repository.WorkspaceRepository, logger *slog.Logger) WorkspaceService {
+	return &workspaceService{repo: repo, logger: logger}
+}
+
+// requireRole returns ErrWorkspaceNotFound for non-members and ErrForbidden when the
+// member's role grants less than the required permission.
+func (s *workspaceService) requireRole(ctx context.Context, userID uuid.UUID, id int, required entity.WorkspaceRole) error {
+	role, err := s.repo.GetRole(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return entity.ErrWorkspaceNotFound
+	}
+	if !role.Permission().Allows(required.Permission()) {
+		return entity.ErrForbidden
+	}
+	return nil
+}
+
+func (s *workspaceService) Create(ctx context.Context, userID uuid.UUID, workspace entity.Workspace) (int, error) {
+	return s.repo.Create(ctx, userID, workspace)
+}
+
+func (s *workspaceService) Get(ctx context.Context, userID uuid.UUID, id int) (entity.Workspace, error) {
+	return s.repo.Get(ctx, userID, id)
+}
+
+func (s *workspaceService) GetAll(ctx context.Context, userID uuid.UUID) ([]entity.Workspace, error) {
+	return s.repo.GetAll(ctx, userID)
+}
+
+func (s *workspaceService) Delete(ctx context.Context, userID uuid.UUID, id int) error {
+	if err := s.requireRole(ctx, userID, id, entity.WorkspaceRoleAdmin); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *workspaceService) GetRole(ctx context.Context, userID uuid.UUID, id int) (entity.WorkspaceRole, error) {
+	role, err := s.repo.GetRole(ctx, userID, id)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		return "", entity.ErrWorkspaceNotFound
+	}
+	return role, nil
+}
+
+func (s *workspaceService) GetMembers(ctx context.Context, userID uuid.UUID, id int) ([]entity.WorkspaceMember, error) {
+	if err := s.requireRole(ctx, userID, id, entity.WorkspaceRoleGuest); err != nil {
+		return nil, err
+	}
+	return s.repo.GetMembers(ctx, id)
+}
+
+func (s *workspaceService) UpdateMemberRole(ctx context.Context, userID uuid.UUID, id int, memberID uuid.UUID, role entity.WorkspaceRole) error {
+	if err := s.requireRole(ctx, userID, id, entity.WorkspaceRoleAdmin); err != nil {
+		return err
+	}
+	if role != entity.WorkspaceRoleAdmin {
+		if err := s.ensureOtherAdmin(ctx, id, memberID); err != nil {
+			return err
+		}
+	}
+	return s.repo.UpdateMemberRole(ctx, id, memberID, role)
+}
+
+// RemoveMember lets admins remove anyone and every member leave on their own.
+func (s *workspaceService) RemoveMember(ctx context.Context, userID uuid.UUID, id int, memberID uuid.UUID) error {
+	required := entity.WorkspaceRoleAdmin
+	if memberID == userID {
+		required = entity.WorkspaceRoleGuest
+	}
+	if err := s.requireRole(ctx, userID, id, required); err != nil {
+		return err
+	}
+	if err := s.ensureOtherAdmin(ctx, id, memberID); err != nil {
+		return err
+	}
+	return s.repo.DeleteMember(ctx, id, memberID)
+}
+
+// ensureOtherAdmin prevents the last admin of a workspace from being demoted or removed.
+func (s *workspaceService) ensureOtherAdmin(ctx context.Context, id int, memberID uuid.UUID) error {
+	role, err := s.repo.GetRole(ctx, memberID, id)
+	if err != nil {
+		return err
+	}
+	if role != entity.WorkspaceRoleAdmin {
+		return nil
+	}
+	admins, err := s.repo.CountAdmins(ctx, id)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return entity.ErrLastAdmin
+	}
+	return nil
+}
+
+func (s *workspaceService) CreateInvitation(ctx context.Context, userID uuid.UUID, id int, request entity.InvitationRequest) (entity.Invitation, error) {
+	if err := s.requireRole(ctx, userID, id, entity.WorkspaceRoleAdmin); err != nil {
+		return entity.Invitation{}, err
+	}
+
+	token, err := utils.GenerateToken(invitationTokenBytes)
+	if err != nil {
+		return entity.Invitation{}, err
+	}
+
+	expire := entity.DefaultInvitationExpire
+	if request.ExpiresInHours > 0 {
+		expire = time.Duration(request.ExpiresInHours) * time.Hour
+	}
+	if expire > entity.MaxInvitationExpire {
+		expire = entity.MaxInvitationExpire
+	}
+
+	invitation := entity.Invitation{
+		WorkspaceID: id,
+		Role:        request.Role,
+		ExpiresAt:   time.Now().UTC().Add(expire),
+	}
+	invitationID, err := s.repo.CreateInvitation(ctx, userID, invitation, utils.HashToken(token))
+	if err != nil {
+		return entity.Invitation{}, err
+	}
+
+	invitation.ID = invitationID
+	invitation.Token = token
+	return invitation, nil
+}
+
+func (s *workspaceService) GetInvitations(ctx context.Context, userID uuid.UUID, id int) ([]entity.Invitation, error) {
+	if err := s.requireRole(ctx, userID, id, entity.WorkspaceRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.repo.GetInvitations(ctx, id)
+}
+
+func (s *workspaceService) RevokeInvitation(ctx context.Context, userID uuid.UUID, id int, invitationID int) error {
+	if err := s.requireRole(ctx, userID, id, entity.WorkspaceRoleAdmin); err != nil {
+		return err
+	}
+	return s.repo.DeleteInvitation(ctx, id, invitationID)
+}
+
+func (s *workspaceService) AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (entity.Workspace, error) {
+	invitation, err := s.repo.ClaimInvitation(ctx, userID, utils.HashToken(token))
+	if err != nil {
+		return entity.Workspace{}, err
+	}
+	return s.repo.Get(ctx, userID, invitation.WorkspaceID)
+}
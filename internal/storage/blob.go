@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GlebMoskalev/go-todo-api/internal/config"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	driverLocal = "local"
+	driverS3    = "s3"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+	ErrInvalidKey   = errors.New("invalid blob key")
+)
+
+// BlobStore stores opaque binary objects under slash-separated keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the blob store selected by the storage driver in the config.
+func New(cfg config.Config) (BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case driverLocal, "":
+		return NewLocalStore(cfg.Storage.Local.Path)
+	case driverS3:
+		s3 := cfg.Storage.S3
+		return NewS3Store(http.DefaultClient, s3.Endpoint, s3.Region, s3.Bucket, s3.AccessKey, s3.SecretKey), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+// validateKey rejects keys that could escape the store's root.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
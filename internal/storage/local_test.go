@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	content := []byte("screenshot bytes")
+
+	require.NoError(t, store.Put(ctx, "todos/12/abc", bytes.NewReader(content), int64(len(content)), "image/png"))
+
+	body, err := store.Get(ctx, "todos/12/abc")
+	require.NoError(t, err)
+	got, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, content, got)
+
+	require.NoError(t, store.Delete(ctx, "todos/12/abc"))
+	require.NoError(t, store.Delete(ctx, "todos/12/abc"))
+	_, err = store.Get(ctx, "todos/12/abc")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+
+	_, err = store.Get(ctx, "todos/../../etc/passwd")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,116 @@
+package todoio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+// parseCSV reads a CSV file whose first row names the columns. It reports whether
+// the file has no description column.
+func parseCSV(data []byte, mapping Mapping) ([]entity.ImportItem, []entity.ImportLineError, bool, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, false, fmt.Errorf("%w: CSV has no header row", entity.ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("%w: %v", entity.ErrInvalidImport, err)
+	}
+
+	columns := make(map[string]int)
+	for _, field := range Fields {
+		name := field
+		if column, ok := mapping[field]; ok {
+			name = column
+		}
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				columns[field] = i
+				break
+			}
+		}
+		if _, ok := columns[field]; !ok && mapping[field] != "" {
+			return nil, nil, false, fmt.Errorf("%w: CSV has no column %q", entity.ErrInvalidImport, name)
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, nil, false, fmt.Errorf("%w: CSV has no title column", entity.ErrInvalidImport)
+	}
+	_, described := columns["description"]
+
+	var items []entity.ImportItem
+	var errs []entity.ImportLineError
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, entity.ImportLineError{Line: parseErr.Line, Errors: []string{parseErr.Err.Error()}})
+				continue
+			}
+			return nil, nil, false, fmt.Errorf("%w: %v", entity.ErrInvalidImport, err)
+		}
+		line, _ := r.FieldPos(0)
+
+		value := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		item := entity.ImportItem{Line: line, Project: value("project")}
+		item.Todo.Title = value("title")
+		item.Todo.Description = value("description")
+		item.Todo.Tags = splitTags(value("tags"))
+
+		var messages []string
+		if due := value("due_date"); due != "" {
+			date, err := parseDate(due)
+			if err != nil {
+				messages = append(messages, "Field 'due_date' must be a date such as 2025-04-30")
+			}
+			item.Todo.DueDate = date
+		}
+		if completed := value("completed"); completed != "" {
+			done, ok := parseBool(completed)
+			if !ok {
+				messages = append(messages, "Field 'completed' must be true or false")
+			}
+			item.Todo.Completed = done
+		}
+
+		if messages != nil {
+			errs = append(errs, entity.ImportLineError{Line: line, Errors: messages})
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, errs, !described, nil
+}
+
+func parseBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "x", "done":
+		return true, true
+	case "false", "no", "n", "0":
+		return false, true
+	}
+	return false, false
+}
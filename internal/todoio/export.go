@@ -0,0 +1,232 @@
+package todoio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+// Writer writes todos one at a time. Nothing is written before the first todo or
+// Close, which finishes the file.
+type Writer interface {
+	Write(todo entity.Todo) error
+	Close() error
+}
+
+// Format describes how an export format is served.
+type Format struct {
+	ContentType string
+	Extension   string
+	NewWriter   func(w io.Writer) Writer
+}
+
+var ExportFormats = map[entity.ExportFormat]Format{
+	entity.ExportJSON:     {ContentType: "application/json", Extension: "json", NewWriter: newJSONWriter},
+	entity.ExportNDJSON:   {ContentType: "application/x-ndjson", Extension: "ndjson", NewWriter: newNDJSONWriter},
+	entity.ExportCSV:      {ContentType: "text/csv; charset=utf-8", Extension: "csv", NewWriter: newCSVWriter},
+	entity.ExportMarkdown: {ContentType: "text/markdown; charset=utf-8", Extension: "md", NewWriter: newMarkdownWriter},
+	entity.ExportTodoTxt:  {ContentType: "text/plain; charset=utf-8", Extension: "txt", NewWriter: newTodoTxtWriter},
+}
+
+// jsonWriter writes a JSON array that the JSON import reads back.
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{w: w}
+}
+
+func (j *jsonWriter) Write(todo entity.Todo) error {
+	data, err := json.Marshal(todo)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if j.count == 0 {
+		separator = "[\n"
+	}
+	j.count++
+	_, err = io.WriteString(j.w, separator+string(data))
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	if j.count == 0 {
+		_, err := io.WriteString(j.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(j.w, "\n]\n")
+	return err
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) Writer {
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}
+}
+
+func (n *ndjsonWriter) Write(todo entity.Todo) error {
+	return n.encoder.Encode(todo)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+var csvHeader = []string{"id", "title", "description", "tags", "due_date", "completed", "completed_at",
+	"project_id", "parent_id", "estimate_minutes", "created_at", "updated_at"}
+
+// csvWriter writes one row per todo under a header whose columns the CSV import
+// matches by name.
+type csvWriter struct {
+	w       *csv.Writer
+	started bool
+}
+
+func newCSVWriter(w io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) header() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) Write(todo entity.Todo) error {
+	if err := c.header(); err != nil {
+		return err
+	}
+	return c.w.Write([]string{
+		strconv.Itoa(todo.ID),
+		todo.Title,
+		todo.Description,
+		strings.Join(todo.Tags, ","),
+		formatDate(todo.DueDate),
+		strconv.FormatBool(todo.Completed),
+		formatTime(todo.CompletedAt),
+		formatInt(todo.ProjectID),
+		formatInt(todo.ParentID),
+		formatInt(todo.EstimateMinutes),
+		formatTime(todo.CreatedAt),
+		formatTime(todo.UpdatedAt),
+	})
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.header(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// markdownWriter writes a flat checklist; only the title and whether the todo is
+// done survive.
+type markdownWriter struct {
+	w io.Writer
+}
+
+func newMarkdownWriter(w io.Writer) Writer {
+	return &markdownWriter{w: w}
+}
+
+func (m *markdownWriter) Write(todo entity.Todo) error {
+	box := "[ ]"
+	if todo.Completed {
+		box = "[x]"
+	}
+	_, err := io.WriteString(m.w, "- "+box+" "+singleLine(todo.Title)+"\n")
+	return err
+}
+
+func (m *markdownWriter) Close() error {
+	return nil
+}
+
+// todoTxtWriter writes todo.txt lines the todo.txt import reads back, apart from
+// the project, which is only known by id. Tags become @contexts and priority:A
+// tags a priority.
+type todoTxtWriter struct {
+	w io.Writer
+}
+
+func newTodoTxtWriter(w io.Writer) Writer {
+	return &todoTxtWriter{w: w}
+}
+
+func (t *todoTxtWriter) Write(todo entity.Todo) error {
+	var words []string
+	var priority string
+	var contexts []string
+	for _, tag := range todo.Tags {
+		if p, ok := strings.CutPrefix(tag, "priority:"); ok && len(p) == 1 && p[0] >= 'A' && p[0] <= 'Z' {
+			priority = p
+			continue
+		}
+		contexts = append(contexts, "@"+strings.Join(strings.Fields(tag), "_"))
+	}
+
+	if todo.Completed {
+		words = append(words, "x")
+		if todo.CompletedAt != nil {
+			words = append(words, todo.CompletedAt.UTC().Format(time.DateOnly))
+		}
+	} else if priority != "" {
+		words = append(words, "("+priority+")")
+	}
+	if todo.CreatedAt != nil && (!todo.Completed || todo.CompletedAt != nil) {
+		words = append(words, todo.CreatedAt.UTC().Format(time.DateOnly))
+	}
+	words = append(words, singleLine(todo.Title))
+	words = append(words, contexts...)
+	if todo.DueDate != nil {
+		words = append(words, "due:"+formatDate(todo.DueDate))
+	}
+	if todo.Completed && priority != "" {
+		words = append(words, "pri:"+priority)
+	}
+
+	_, err := io.WriteString(t.w, strings.Join(words, " ")+"\n")
+	return err
+}
+
+func (t *todoTxtWriter) Close() error {
+	return nil
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func formatDate(date *entity.Date) string {
+	if date == nil {
+		return ""
+	}
+	return date.Time.UTC().Format(time.DateOnly)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
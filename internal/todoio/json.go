@@ -0,0 +1,61 @@
+package todoio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+// parseJSON reads todos as the API returns them: either an array of todos or the
+// response of GET /todos, whose data holds the array. Subtasks stay under their
+// parent when the parent is part of the file; IDs, workspaces and assignees are not
+// carried over.
+func parseJSON(data []byte) ([]entity.ImportItem, []entity.ImportLineError, error) {
+	data = bytes.TrimSpace(data)
+	var raw []json.RawMessage
+	if bytes.HasPrefix(data, []byte("{")) {
+		var response struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", entity.ErrInvalidImport, err)
+		}
+		raw = response.Data
+	} else if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", entity.ErrInvalidImport, err)
+	}
+
+	var items []entity.ImportItem
+	var errs []entity.ImportLineError
+	indexes := make(map[int]int)
+	for i, message := range raw {
+		var todo entity.Todo
+		if err := json.Unmarshal(message, &todo); err != nil {
+			errs = append(errs, entity.ImportLineError{Line: i + 1, Errors: []string{err.Error()}})
+			continue
+		}
+		if todo.ID != 0 {
+			indexes[todo.ID] = len(items)
+		}
+		items = append(items, entity.ImportItem{Line: i + 1, Todo: todo})
+	}
+
+	for i := range items {
+		todo := &items[i].Todo
+		if todo.ParentID != nil {
+			if parent, ok := indexes[*todo.ParentID]; ok && parent != i {
+				items[i].Parent = &parent
+			}
+		}
+		todo.ID = 0
+		todo.ParentID = nil
+		todo.WorkspaceID = nil
+		todo.AssigneeID = nil
+		todo.CompletedAt = nil
+		todo.CreatedAt = nil
+		todo.UpdatedAt = nil
+	}
+	return items, errs, nil
+}
@@ -0,0 +1,44 @@
+package todoio
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+var markdownTask = regexp.MustCompile(`^([ \t]*)(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(.*\S)\s*$`)
+
+// parseMarkdown reads the task list items of a GitHub style Markdown checklist.
+// Items indented under another become its subtasks; all other lines are skipped.
+func parseMarkdown(data string) []entity.ImportItem {
+	type level struct {
+		indent int
+		index  int
+	}
+
+	var items []entity.ImportItem
+	var stack []level
+	for i, line := range strings.Split(data, "\n") {
+		match := markdownTask.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		indent := len(strings.ReplaceAll(match[1], "\t", "    "))
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		item := entity.ImportItem{
+			Line: i + 1,
+			Todo: entity.Todo{Title: match[3], Tags: []string{}, Completed: match[2] != " "},
+		}
+		if len(stack) > 0 {
+			parent := stack[len(stack)-1].index
+			item.Parent = &parent
+		}
+		stack = append(stack, level{indent: indent, index: len(items)})
+		items = append(items, item)
+	}
+	return items
+}
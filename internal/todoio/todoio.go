@@ -0,0 +1,112 @@
+// Package todoio reads and writes todos in the file formats other tools use: CSV,
+// the JSON this API returns, todo.txt and Markdown checklists.
+package todoio
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+// Fields are the todo fields a CSV column can be mapped to.
+var Fields = []string{"title", "description", "tags", "due_date", "completed", "project"}
+
+// Mapping maps todo fields to the CSV columns holding them. A field that is not
+// mapped is read from the column of the same name.
+type Mapping map[string]string
+
+// ParseMapping reads a mapping written as field:column pairs separated by commas,
+// such as "title:Task,due_date:Due date".
+func ParseMapping(s string) (Mapping, error) {
+	mapping := Mapping{}
+	if strings.TrimSpace(s) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		field, column, ok := strings.Cut(pair, ":")
+		field = strings.TrimSpace(field)
+		if !ok || strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("%w: mapping %q is not of the form field:column", entity.ErrInvalidImport, pair)
+		}
+		if !isField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q, must be one of: %s", entity.ErrInvalidImport, field,
+				strings.Join(Fields, ", "))
+		}
+		mapping[field] = strings.TrimSpace(column)
+	}
+	return mapping, nil
+}
+
+func isField(name string) bool {
+	for _, field := range Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse reads the todos of an import file. Lines that cannot be read are returned
+// as errors alongside the items; an error is only returned when the file as a whole
+// cannot be read. Todos without a description, which todo.txt and Markdown have no
+// room for, are described by their title.
+func Parse(format entity.ImportFormat, data []byte, mapping Mapping) ([]entity.ImportItem, []entity.ImportLineError, error) {
+	var (
+		items  []entity.ImportItem
+		errs   []entity.ImportLineError
+		err    error
+		titled bool
+	)
+	switch format {
+	case entity.ImportCSV:
+		items, errs, titled, err = parseCSV(data, mapping)
+	case entity.ImportJSON:
+		items, errs, err = parseJSON(data)
+	case entity.ImportTodoTxt:
+		items, errs = parseTodoTxt(string(data)), nil
+		titled = true
+	case entity.ImportMarkdown:
+		items, errs = parseMarkdown(string(data)), nil
+		titled = true
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown format %q", entity.ErrInvalidImport, format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(items)+len(errs) > entity.MaxImportItems {
+		return nil, nil, fmt.Errorf("%w: more than %d todos", entity.ErrInvalidImport, entity.MaxImportItems)
+	}
+
+	for i := range items {
+		todo := &items[i].Todo
+		if todo.Tags == nil {
+			todo.Tags = []string{}
+		}
+		if titled && todo.Description == "" {
+			todo.Description = todo.Title
+		}
+	}
+	return items, errs, nil
+}
+
+func parseDate(value string) (*entity.Date, error) {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Date{Time: t}, nil
+}
+
+// splitTags splits a list of tags on commas and semicolons, dropping empty ones.
+func splitTags(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
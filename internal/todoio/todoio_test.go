@@ -0,0 +1,236 @@
+package todoio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) *entity.Date {
+	return &entity.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name           string
+		format         entity.ImportFormat
+		data           string
+		mapping        Mapping
+		expectedItems  []entity.ImportItem
+		expectedErrors []entity.ImportLineError
+		expectedErr    string
+	}{
+		{
+			name:   "csv with mapping",
+			format: entity.ImportCSV,
+			data: "\ufeffTask,Notes,Labels,Due,Done,Project\n" +
+				"Pay rent,\"Transfer, then file\",home;money,2025-04-30,no,Household\n" +
+				"\n" +
+				"Call mom,,,2025-13-01,maybe,\n" +
+				"Water plants,Kitchen,,,x,\n",
+			mapping: Mapping{"title": "Task", "description": "Notes", "tags": "Labels", "due_date": "Due", "completed": "Done"},
+			expectedItems: []entity.ImportItem{
+				{Line: 2, Project: "Household", Todo: entity.Todo{Title: "Pay rent", Description: "Transfer, then file",
+					Tags: []string{"home", "money"}, DueDate: date(2025, 4, 30)}},
+				{Line: 5, Todo: entity.Todo{Title: "Water plants", Description: "Kitchen", Tags: []string{}, Completed: true}},
+			},
+			expectedErrors: []entity.ImportLineError{
+				{Line: 4, Errors: []string{"Field 'due_date' must be a date such as 2025-04-30", "Field 'completed' must be true or false"}},
+			},
+		},
+		{
+			name:        "csv without title column",
+			format:      entity.ImportCSV,
+			data:        "name,due_date\nPay rent,2025-04-30\n",
+			expectedErr: "invalid import: CSV has no title column",
+		},
+		{
+			name:        "csv with unknown mapped column",
+			format:      entity.ImportCSV,
+			data:        "title\nPay rent\n",
+			mapping:     Mapping{"due_date": "Deadline"},
+			expectedErr: `invalid import: CSV has no column "Deadline"`,
+		},
+		{
+			name:   "json export",
+			format: entity.ImportJSON,
+			data: `{"code":200,"error":false,"message":"Successfully fetch","data":[` +
+				`{"id":7,"title":"Move","description":"Flat","tags":["home"],"due_date":"2025-05-01","project_id":3,"created_at":"2025-04-01T12:00:00Z"},` +
+				`{"id":8,"title":"Pack books","description":"Boxes","tags":[],"due_date":"2025-04-28","parent_id":7,"completed":true},` +
+				`{"id":9,"title":"Broken","due_date":"tomorrow"},` +
+				`{"id":10,"title":"Orphan","description":"Gone","tags":null,"due_date":"2025-04-28","parent_id":99}]}`,
+			expectedItems: []entity.ImportItem{
+				{Line: 1, Todo: entity.Todo{Title: "Move", Description: "Flat", Tags: []string{"home"}, DueDate: date(2025, 5, 1), ProjectID: intPtr(3)}},
+				{Line: 2, Parent: intPtr(0), Todo: entity.Todo{Title: "Pack books", Description: "Boxes", Tags: []string{},
+					DueDate: date(2025, 4, 28), Completed: true}},
+				{Line: 4, Todo: entity.Todo{Title: "Orphan", Description: "Gone", Tags: []string{}, DueDate: date(2025, 4, 28)}},
+			},
+			expectedErrors: []entity.ImportLineError{
+				{Line: 3, Errors: []string{`parsing time "tomorrow" as "2006-01-02": cannot parse "tomorrow" as "2006"`}},
+			},
+		},
+		{
+			name:        "malformed json",
+			format:      entity.ImportJSON,
+			data:        `[{"title":`,
+			expectedErr: "invalid import: unexpected end of JSON input",
+		},
+		{
+			name:   "todo.txt",
+			format: entity.ImportTodoTxt,
+			data: "(A) 2025-04-01 Call mom +Family @phone due:2025-04-30\n" +
+				"\n" +
+				"x 2025-04-02 2025-04-01 Renew passport +Admin +Travel pri:B url:https://example.com\n" +
+				"Plan trip due:soon\r\n",
+			expectedItems: []entity.ImportItem{
+				{Line: 1, Project: "Family", Todo: entity.Todo{Title: "Call mom", Description: "Call mom",
+					Tags: []string{"priority:A", "phone"}, DueDate: date(2025, 4, 30)}},
+				{Line: 3, Project: "Admin", Todo: entity.Todo{Title: "Renew passport url:https://example.com",
+					Description: "Renew passport url:https://example.com", Tags: []string{"Travel", "priority:B"}, Completed: true}},
+				{Line: 4, Todo: entity.Todo{Title: "Plan trip due:soon", Description: "Plan trip due:soon", Tags: []string{}}},
+			},
+		},
+		{
+			name:   "markdown checklist",
+			format: entity.ImportMarkdown,
+			data: "# Moving\n\n" +
+				"- [ ] Pack\n" +
+				"  - [x] Books\n" +
+				"  - [ ] Kitchen\n" +
+				"    * [ ] Plates\n" +
+				"Some notes\n" +
+				"1. [X] Cancel internet\n",
+			expectedItems: []entity.ImportItem{
+				{Line: 3, Todo: entity.Todo{Title: "Pack", Description: "Pack", Tags: []string{}}},
+				{Line: 4, Parent: intPtr(0), Todo: entity.Todo{Title: "Books", Description: "Books", Tags: []string{}, Completed: true}},
+				{Line: 5, Parent: intPtr(0), Todo: entity.Todo{Title: "Kitchen", Description: "Kitchen", Tags: []string{}}},
+				{Line: 6, Parent: intPtr(2), Todo: entity.Todo{Title: "Plates", Description: "Plates", Tags: []string{}}},
+				{Line: 8, Todo: entity.Todo{Title: "Cancel internet", Description: "Cancel internet", Tags: []string{}, Completed: true}},
+			},
+		},
+		{
+			name:        "unknown format",
+			format:      "xlsx",
+			expectedErr: `invalid import: unknown format "xlsx"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, errs, err := Parse(tc.format, []byte(tc.data), tc.mapping)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				assert.ErrorIs(t, err, entity.ErrInvalidImport)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedItems, items)
+			assert.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping("title:Task, due_date:Due date")
+	assert.NoError(t, err)
+	assert.Equal(t, Mapping{"title": "Task", "due_date": "Due date"}, mapping)
+
+	_, err = ParseMapping("priority:Prio")
+	assert.EqualError(t, err, `invalid import: unknown field "priority", must be one of: title, description, tags, due_date, completed, project`)
+
+	_, err = ParseMapping("title")
+	assert.ErrorIs(t, err, entity.ErrInvalidImport)
+}
+
+func TestExportFormats(t *testing.T) {
+	created := time.Date(2025, 4, 1, 9, 30, 0, 0, time.UTC)
+	completed := time.Date(2025, 4, 3, 18, 0, 0, 0, time.UTC)
+	todos := []entity.Todo{
+		{ID: 7, Title: "Call mom", Description: "Sunday", Tags: []string{"priority:A", "phone call"},
+			DueDate: date(2025, 4, 30), ProjectID: intPtr(3), CreatedAt: &created},
+		{ID: 8, Title: "Renew\npassport", Description: "Admin, travel", Tags: []string{"priority:B"},
+			DueDate: date(2025, 4, 28), ParentID: intPtr(7), Completed: true, CompletedAt: &completed, CreatedAt: &created},
+	}
+
+	testCases := []struct {
+		format   entity.ExportFormat
+		expected string
+		empty    string
+	}{
+		{
+			format: entity.ExportCSV,
+			expected: "id,title,description,tags,due_date,completed,completed_at,project_id,parent_id,estimate_minutes,created_at,updated_at\n" +
+				"7,Call mom,Sunday,\"priority:A,phone call\",2025-04-30,false,,3,,,2025-04-01T09:30:00Z,\n" +
+				"8,\"Renew\npassport\",\"Admin, travel\",priority:B,2025-04-28,true,2025-04-03T18:00:00Z,,7,,2025-04-01T09:30:00Z,\n",
+			empty: "id,title,description,tags,due_date,completed,completed_at,project_id,parent_id,estimate_minutes,created_at,updated_at\n",
+		},
+		{
+			format:   entity.ExportMarkdown,
+			expected: "- [ ] Call mom\n- [x] Renew passport\n",
+		},
+		{
+			format: entity.ExportTodoTxt,
+			expected: "(A) 2025-04-01 Call mom @phone_call due:2025-04-30\n" +
+				"x 2025-04-03 2025-04-01 Renew passport due:2025-04-28 pri:B\n",
+		},
+		{
+			format: entity.ExportNDJSON,
+		},
+		{
+			format: entity.ExportJSON,
+			empty:  "[]\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.format), func(t *testing.T) {
+			format, ok := ExportFormats[tc.format]
+			assert.True(t, ok)
+
+			var buf strings.Builder
+			writer := format.NewWriter(&buf)
+			for _, todo := range todos {
+				assert.NoError(t, writer.Write(todo))
+			}
+			assert.NoError(t, writer.Close())
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, buf.String())
+			}
+
+			var empty strings.Builder
+			assert.NoError(t, format.NewWriter(&empty).Close())
+			assert.Equal(t, tc.empty, empty.String())
+		})
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	todos := []entity.Todo{
+		{ID: 7, Title: "Move", Description: "Flat", Tags: []string{"home"}, DueDate: date(2025, 5, 1)},
+		{ID: 8, Title: "Pack books", Description: "Boxes", Tags: []string{}, DueDate: date(2025, 4, 28), ParentID: intPtr(7), Completed: true},
+	}
+	expected := []entity.ImportItem{
+		{Line: 1, Todo: entity.Todo{Title: "Move", Description: "Flat", Tags: []string{"home"}, DueDate: date(2025, 5, 1)}},
+		{Line: 2, Parent: intPtr(0), Todo: entity.Todo{Title: "Pack books", Description: "Boxes", Tags: []string{},
+			DueDate: date(2025, 4, 28), Completed: true}},
+	}
+
+	var buf strings.Builder
+	writer := ExportFormats[entity.ExportJSON].NewWriter(&buf)
+	for _, todo := range todos {
+		assert.NoError(t, writer.Write(todo))
+	}
+	assert.NoError(t, writer.Close())
+
+	items, errs, err := Parse(entity.ImportJSON, []byte(buf.String()), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+	assert.Equal(t, expected, items)
+}
@@ -0,0 +1,71 @@
+package todoio
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)$`)
+	todoTxtDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+// parseTodoTxt reads the todo.txt format, one todo per line:
+//
+//	x (A) 2025-04-01 Call mom +Family @phone due:2025-04-30
+//
+// The first +project names the project, further ones and @contexts become tags, and
+// the priority becomes a priority:A tag. Completion and creation dates are dropped;
+// other key:value pairs stay part of the title.
+func parseTodoTxt(data string) []entity.ImportItem {
+	var items []entity.ImportItem
+	for i, line := range strings.Split(data, "\n") {
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			continue
+		}
+
+		item := entity.ImportItem{Line: i + 1, Todo: entity.Todo{Tags: []string{}}}
+		if words[0] == "x" {
+			item.Todo.Completed = true
+			words = words[1:]
+		}
+		if len(words) > 0 && todoTxtPriority.MatchString(words[0]) {
+			item.Todo.Tags = append(item.Todo.Tags, "priority:"+words[0][1:2])
+			words = words[1:]
+		}
+		// A completed todo may carry its completion date before its creation date.
+		for n := 0; n < 2 && len(words) > 0 && todoTxtDate.MatchString(words[0]); n++ {
+			words = words[1:]
+		}
+
+		var title []string
+		for _, word := range words {
+			switch {
+			case len(word) > 1 && word[0] == '+':
+				if item.Project == "" {
+					item.Project = word[1:]
+				} else {
+					item.Todo.Tags = append(item.Todo.Tags, word[1:])
+				}
+			case len(word) > 1 && word[0] == '@':
+				item.Todo.Tags = append(item.Todo.Tags, word[1:])
+			case strings.HasPrefix(word, "due:"):
+				if date, err := parseDate(strings.TrimPrefix(word, "due:")); err == nil {
+					item.Todo.DueDate = date
+				} else {
+					title = append(title, word)
+				}
+			case strings.HasPrefix(word, "pri:") && len(word) == 5:
+				item.Todo.Tags = append(item.Todo.Tags, "priority:"+word[4:])
+			default:
+				title = append(title, word)
+			}
+		}
+		item.Todo.Title = strings.Join(title, " ")
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,74 @@
+// Package useragent names the device behind a User-Agent header, such as
+// "Firefox on Windows", for listing where a user is logged in.
+package useragent
+
+import "strings"
+
+// maxProductLength bounds names taken from the header, which clients choose freely.
+const maxProductLength = 50
+
+// family is a browser or operating system, recognised by any of its markers.
+type family struct {
+	name    string
+	markers []string
+}
+
+// browsers are tried in order, as most browsers also claim to be the ones they
+// derive from: Edge and Opera mention Chrome, and Chrome mentions Safari.
+var browsers = []family{
+	{"Edge", []string{"Edg/", "EdgA/", "EdgiOS/", "Edge/"}},
+	{"Opera", []string{"OPR/", "Opera"}},
+	{"Firefox", []string{"Firefox/", "FxiOS/"}},
+	{"Chrome", []string{"Chrome/", "CriOS/"}},
+	{"Safari", []string{"Safari/"}},
+}
+
+// systems are tried in order for the same reason: Android mentions Linux, and
+// iPhones mention Mac OS X.
+var systems = []family{
+	{"iPhone", []string{"iPhone"}},
+	{"iPad", []string{"iPad"}},
+	{"Android", []string{"Android"}},
+	{"Windows", []string{"Windows"}},
+	{"ChromeOS", []string{"CrOS"}},
+	{"macOS", []string{"Macintosh", "Mac OS X"}},
+	{"Linux", []string{"Linux"}},
+}
+
+// DeviceName returns a short name for the device sending userAgent. Clients that
+// are not browsers, such as curl, are named after their product.
+func DeviceName(userAgent string) string {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := match(userAgent, browsers)
+	system := match(userAgent, systems)
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case system != "":
+		return system
+	case browser != "":
+		return browser
+	}
+
+	product, _, _ := strings.Cut(userAgent, " ")
+	product, _, _ = strings.Cut(product, "/")
+	if runes := []rune(product); len(runes) > maxProductLength {
+		return string(runes[:maxProductLength])
+	}
+	return product
+}
+
+func match(userAgent string, candidates []family) string {
+	for _, candidate := range candidates {
+		for _, marker := range candidate.markers {
+			if strings.Contains(userAgent, marker) {
+				return candidate.name
+			}
+		}
+	}
+	return ""
+}
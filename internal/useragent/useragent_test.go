@@ -0,0 +1,65 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceName(t *testing.T) {
+	testCases := []struct {
+		userAgent string
+		expected  string
+	}{
+		{
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			expected:  "Chrome on Windows",
+		},
+		{
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+			expected:  "Edge on Windows",
+		},
+		{
+			userAgent: "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			expected:  "Firefox on Linux",
+		},
+		{
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+			expected:  "Safari on macOS",
+		},
+		{
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			expected:  "Safari on iPhone",
+		},
+		{
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+			expected:  "Chrome on Android",
+		},
+		{
+			userAgent: "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 OPR/109.0.0.0",
+			expected:  "Opera on ChromeOS",
+		},
+		{
+			userAgent: "TodoApp/2.1 (iPad; iOS 17.4)",
+			expected:  "iPad",
+		},
+		{
+			userAgent: "curl/8.5.0",
+			expected:  "curl",
+		},
+		{
+			userAgent: "PostmanRuntime/7.37.3",
+			expected:  "PostmanRuntime",
+		},
+		{
+			userAgent: "  ",
+			expected:  "Unknown device",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DeviceName(tc.userAgent))
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package contextutils
 
-import "context"
+import (
+	"context"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+)
 
 const requestIDContextKey = "request_id"
 
+const workspaceContextKey = "workspace"
+
 func GetRequestId(ctx context.Context) string {
 	if ctx == nil {
 		return ""
@@ -17,3 +22,26 @@ func GetRequestId(ctx context.Context) string {
 func WithRequestId(ctx context.Context, requestId string) context.Context {
 	return context.WithValue(ctx, requestIDContextKey, requestId)
 }
+
+// GetWorkspace returns the workspace selected for the request, if any.
+func GetWorkspace(ctx context.Context) (entity.ActiveWorkspace, bool) {
+	if ctx == nil {
+		return entity.ActiveWorkspace{}, false
+	}
+	workspace, ok := ctx.Value(workspaceContextKey).(entity.ActiveWorkspace)
+	return workspace, ok
+}
+
+// GetWorkspaceID returns the ID of the workspace selected for the request, or nil
+// when the request works on the user's personal space.
+func GetWorkspaceID(ctx context.Context) *int {
+	workspace, ok := GetWorkspace(ctx)
+	if !ok {
+		return nil
+	}
+	return &workspace.ID
+}
+
+func WithWorkspace(ctx context.Context, workspace entity.ActiveWorkspace) context.Context {
+	return context.WithValue(ctx, workspaceContextKey, workspace)
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/GlebMoskalev/go-todo-api/internal/entity"
+	"net/url"
+	"strconv"
+)
+
+// ParsePagination reads the limit and offset query parameters, falling back to defaults.
+// The returned error message is safe to send back to the client.
+func ParsePagination(query url.Values) (entity.Pagination, error) {
+	pagination := entity.Pagination{Offset: entity.DefaultOffset, Limit: entity.DefaultLimit}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return entity.Pagination{}, errors.New("Invalid limit parameter")
+		}
+		pagination.Limit = limit
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			return entity.Pagination{}, errors.New("Invalid offset parameter")
+		}
+		pagination.Offset = offset
+	}
+	return pagination, nil
+}
+
+// ParseCursor reads the limit and cursor query parameters. Cursors are opaque to
+// clients; they only pass back the next_cursor of the previous page.
+func ParseCursor(query url.Values) (entity.Cursor, error) {
+	cursor := entity.Cursor{Limit: entity.DefaultLimit}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return entity.Cursor{}, errors.New("Invalid limit parameter")
+		}
+		cursor.Limit = limit
+	}
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(cursorStr)
+		if err != nil {
+			return entity.Cursor{}, errors.New("Invalid cursor parameter")
+		}
+		after, err := strconv.Atoi(string(raw))
+		if err != nil || after < 0 {
+			return entity.Cursor{}, errors.New("Invalid cursor parameter")
+		}
+		cursor.After = after
+	}
+	return cursor, nil
+}
+
+// EncodeCursor builds the opaque cursor pointing after the item with the given ID.
+func EncodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS project_shares;
+DROP TABLE IF EXISTS todo_shares;
+ALTER TABLE todos DROP COLUMN IF EXISTS ProjectId;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,30 @@
+CREATE TABLE projects
+(
+    ID SERIAL PRIMARY KEY,
+    Name VARCHAR(100) NOT NULL,
+    Description TEXT,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE
+);
+
+ALTER TABLE todos ADD COLUMN ProjectId INT REFERENCES projects(ID) ON DELETE SET NULL;
+
+CREATE TABLE todo_shares
+(
+    TodoId INT NOT NULL REFERENCES todos(ID) ON DELETE CASCADE,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Permission VARCHAR(10) NOT NULL CHECK (Permission IN ('viewer', 'editor', 'owner')),
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (TodoId, UserId)
+);
+
+CREATE TABLE project_shares
+(
+    ProjectId INT NOT NULL REFERENCES projects(ID) ON DELETE CASCADE,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Permission VARCHAR(10) NOT NULL CHECK (Permission IN ('viewer', 'editor', 'owner')),
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ProjectId, UserId)
+);
+
+CREATE INDEX todo_shares_userid_idx ON todo_shares (UserId);
+CREATE INDEX project_shares_userid_idx ON project_shares (UserId);
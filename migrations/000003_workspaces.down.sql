@@ -0,0 +1,5 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS WorkspaceId;
+ALTER TABLE todos DROP COLUMN IF EXISTS WorkspaceId;
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,35 @@
+CREATE TABLE workspaces
+(
+    ID SERIAL PRIMARY KEY,
+    Name VARCHAR(100) NOT NULL,
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE workspace_members
+(
+    WorkspaceId INT NOT NULL REFERENCES workspaces(ID) ON DELETE CASCADE,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Role VARCHAR(10) NOT NULL CHECK (Role IN ('admin', 'member', 'guest')),
+    JoinedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (WorkspaceId, UserId)
+);
+
+CREATE TABLE workspace_invitations
+(
+    ID SERIAL PRIMARY KEY,
+    WorkspaceId INT NOT NULL REFERENCES workspaces(ID) ON DELETE CASCADE,
+    TokenHash TEXT NOT NULL UNIQUE,
+    Role VARCHAR(10) NOT NULL CHECK (Role IN ('admin', 'member', 'guest')),
+    InvitedBy UUID REFERENCES users(ID) ON DELETE CASCADE,
+    ExpiryDate TIMESTAMPTZ NOT NULL,
+    AcceptedAt TIMESTAMPTZ,
+    AcceptedBy UUID REFERENCES users(ID) ON DELETE SET NULL,
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE todos ADD COLUMN WorkspaceId INT REFERENCES workspaces(ID) ON DELETE CASCADE;
+ALTER TABLE projects ADD COLUMN WorkspaceId INT REFERENCES workspaces(ID) ON DELETE CASCADE;
+
+CREATE INDEX workspace_members_userid_idx ON workspace_members (UserId);
+CREATE INDEX todos_workspaceid_idx ON todos (WorkspaceId);
+CREATE INDEX projects_workspaceid_idx ON projects (WorkspaceId);
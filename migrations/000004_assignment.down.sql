@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notifications;
+ALTER TABLE todos DROP COLUMN IF EXISTS AssigneeId;
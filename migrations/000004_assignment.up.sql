@@ -0,0 +1,17 @@
+ALTER TABLE todos ADD COLUMN AssigneeId UUID REFERENCES users(ID) ON DELETE SET NULL;
+
+CREATE INDEX todos_assigneeid_idx ON todos (AssigneeId);
+
+CREATE TABLE notifications
+(
+    ID SERIAL PRIMARY KEY,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Type VARCHAR(20) NOT NULL,
+    TodoId INT REFERENCES todos(ID) ON DELETE CASCADE,
+    ActorId UUID REFERENCES users(ID) ON DELETE SET NULL,
+    Message TEXT NOT NULL,
+    ReadAt TIMESTAMPTZ,
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX notifications_userid_idx ON notifications (UserId, ID DESC);
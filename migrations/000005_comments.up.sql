@@ -0,0 +1,12 @@
+CREATE TABLE todo_comments
+(
+    ID SERIAL PRIMARY KEY,
+    TodoId INT NOT NULL REFERENCES todos(ID) ON DELETE CASCADE,
+    AuthorId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Body TEXT NOT NULL,
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    EditedAt TIMESTAMPTZ,
+    DeletedAt TIMESTAMPTZ
+);
+
+CREATE INDEX todo_comments_todoid_idx ON todo_comments (TodoId, ID);
@@ -0,0 +1,14 @@
+CREATE TABLE attachments
+(
+    ID SERIAL PRIMARY KEY,
+    TodoId INT NOT NULL REFERENCES todos(ID) ON DELETE CASCADE,
+    UploaderId UUID REFERENCES users(ID) ON DELETE SET NULL,
+    FileName VARCHAR(255) NOT NULL,
+    ContentType VARCHAR(100) NOT NULL,
+    Size BIGINT NOT NULL,
+    Checksum CHAR(64) NOT NULL,
+    StorageKey TEXT NOT NULL UNIQUE,
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX attachments_todoid_idx ON attachments (TodoId);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS todo_history;
+DROP FUNCTION IF EXISTS todo_history_immutable();
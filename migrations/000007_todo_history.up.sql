@@ -0,0 +1,29 @@
+-- History outlives the todo it describes, so TodoId is not a foreign key.
+CREATE TABLE todo_history
+(
+    ID BIGSERIAL PRIMARY KEY,
+    TodoId INT NOT NULL,
+    ActorId UUID REFERENCES users(ID) ON DELETE SET NULL,
+    Operation VARCHAR(20) NOT NULL,
+    Changes JSONB NOT NULL DEFAULT '[]',
+    CreatedAt TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX todo_history_todoid_idx ON todo_history (TodoId, ID);
+
+-- Entries are append-only. The only change allowed is clearing the actor when the
+-- user is deleted.
+CREATE FUNCTION todo_history_immutable() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.ActorId IS NULL
+        AND (NEW.ID, NEW.TodoId, NEW.Operation, NEW.Changes, NEW.CreatedAt)
+            IS NOT DISTINCT FROM (OLD.ID, OLD.TodoId, OLD.Operation, OLD.Changes, OLD.CreatedAt) THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'todo_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_history_immutable
+    BEFORE UPDATE OR DELETE ON todo_history
+    FOR EACH ROW EXECUTE FUNCTION todo_history_immutable();
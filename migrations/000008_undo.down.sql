@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS undo_tokens;
+DROP TRIGGER IF EXISTS todos_touch_updatedat ON todos;
+DROP FUNCTION IF EXISTS todos_touch_updatedat();
+ALTER TABLE todos DROP COLUMN IF EXISTS UpdatedAt;
+ALTER TABLE todos DROP COLUMN IF EXISTS Completed;
@@ -0,0 +1,30 @@
+ALTER TABLE todos ADD COLUMN Completed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE todos ADD COLUMN UpdatedAt TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp();
+
+-- UpdatedAt changes on every write, including the ones made by foreign key actions,
+-- so it tells reliably whether a todo was modified since it was last read.
+CREATE FUNCTION todos_touch_updatedat() RETURNS trigger AS $$
+BEGIN
+    NEW.UpdatedAt = clock_timestamp();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todos_touch_updatedat
+    BEFORE UPDATE ON todos
+    FOR EACH ROW EXECUTE FUNCTION todos_touch_updatedat();
+
+CREATE TABLE undo_tokens
+(
+    Token UUID PRIMARY KEY,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    TodoId INT NOT NULL,
+    OwnerId UUID NOT NULL,
+    Operation VARCHAR(20) NOT NULL,
+    Before JSONB,
+    UpdatedAt TIMESTAMPTZ,
+    ExpiresAt TIMESTAMPTZ NOT NULL,
+    UsedAt TIMESTAMPTZ
+);
+
+CREATE INDEX undo_tokens_userid_expiresat_idx ON undo_tokens (UserId, ExpiresAt);
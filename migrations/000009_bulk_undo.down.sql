@@ -0,0 +1,5 @@
+-- Undo tokens are short-lived, dropping them is harmless.
+DELETE FROM undo_tokens;
+DROP INDEX IF EXISTS undo_tokens_token_idx;
+ALTER TABLE undo_tokens DROP COLUMN ID;
+ALTER TABLE undo_tokens ADD PRIMARY KEY (Token);
@@ -0,0 +1,6 @@
+-- A bulk request hands out one undo token for all of its changes, so a token can
+-- now cover several todos.
+ALTER TABLE undo_tokens DROP CONSTRAINT undo_tokens_pkey;
+ALTER TABLE undo_tokens ADD COLUMN ID BIGSERIAL PRIMARY KEY;
+
+CREATE INDEX undo_tokens_token_idx ON undo_tokens (Token);
@@ -0,0 +1,13 @@
+-- Tags stay in the todos.Tags array; this table only holds what a user set up for
+-- a tag name, such as its color.
+CREATE TABLE tags
+(
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Name VARCHAR(50) NOT NULL,
+    Color CHAR(7),
+    Description TEXT NOT NULL DEFAULT '',
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (UserId, Name)
+);
+
+CREATE INDEX todos_tags_idx ON todos USING GIN (Tags);
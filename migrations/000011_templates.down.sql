@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS todo_templates;
+DROP INDEX IF EXISTS todos_parentid_idx;
+ALTER TABLE todos DROP COLUMN IF EXISTS Checklist;
+ALTER TABLE todos DROP COLUMN IF EXISTS ParentId;
@@ -0,0 +1,19 @@
+-- Subtasks point at their parent todo. Deleting the parent keeps its subtasks as
+-- todos of their own.
+ALTER TABLE todos ADD COLUMN ParentId INT REFERENCES todos(ID) ON DELETE SET NULL;
+ALTER TABLE todos ADD COLUMN Checklist JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX todos_parentid_idx ON todos (ParentId);
+
+-- Body holds the task tree of the template as JSON.
+CREATE TABLE todo_templates
+(
+    ID SERIAL PRIMARY KEY,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Name VARCHAR(100) NOT NULL,
+    Body JSONB NOT NULL,
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UpdatedAt TIMESTAMPTZ
+);
+
+CREATE INDEX todo_templates_userid_idx ON todo_templates (UserId, ID);
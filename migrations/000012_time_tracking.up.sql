@@ -0,0 +1,18 @@
+ALTER TABLE todos ADD COLUMN EstimateMinutes INT CHECK (EstimateMinutes >= 0);
+
+-- An entry without EndedAt is a running timer. Each user has at most one.
+CREATE TABLE time_entries
+(
+    ID SERIAL PRIMARY KEY,
+    TodoId INT NOT NULL REFERENCES todos(ID) ON DELETE CASCADE,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    StartedAt TIMESTAMPTZ NOT NULL,
+    EndedAt TIMESTAMPTZ,
+    Note VARCHAR(500) NOT NULL DEFAULT '',
+    CreatedAt TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (EndedAt IS NULL OR EndedAt >= StartedAt)
+);
+
+CREATE UNIQUE INDEX time_entries_running_idx ON time_entries (UserId) WHERE EndedAt IS NULL;
+CREATE INDEX time_entries_todoid_idx ON time_entries (TodoId, StartedAt);
+CREATE INDEX time_entries_userid_idx ON time_entries (UserId, StartedAt);
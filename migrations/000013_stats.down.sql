@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS todos_userid_completedat_idx;
+DROP INDEX IF EXISTS todos_userid_createdat_idx;
+DROP TRIGGER IF EXISTS todos_track_completedat ON todos;
+DROP FUNCTION IF EXISTS todos_track_completedat();
+ALTER TABLE todos DROP COLUMN IF EXISTS CompletedAt;
+ALTER TABLE todos DROP COLUMN IF EXISTS CreatedAt;
@@ -0,0 +1,34 @@
+-- Existing todos get the time of their first history entry, or of their last
+-- change if they have none.
+ALTER TABLE todos ADD COLUMN CreatedAt TIMESTAMPTZ;
+ALTER TABLE todos ADD COLUMN CompletedAt TIMESTAMPTZ;
+
+UPDATE todos t SET
+    CreatedAt = COALESCE((SELECT MIN(h.CreatedAt) FROM todo_history h WHERE h.TodoId = t.ID), t.UpdatedAt),
+    CompletedAt = CASE WHEN t.Completed THEN COALESCE(
+        (SELECT MAX(h.CreatedAt) FROM todo_history h
+         WHERE h.TodoId = t.ID AND h.Changes @> '[{"field": "completed", "after": true}]'),
+        t.UpdatedAt) END;
+
+ALTER TABLE todos ALTER COLUMN CreatedAt SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE todos ALTER COLUMN CreatedAt SET NOT NULL;
+
+-- CompletedAt is set when a todo is completed and cleared when it is reopened.
+-- Writes that give CompletedAt explicitly, such as undo, keep their value.
+CREATE FUNCTION todos_track_completedat() RETURNS trigger AS $$
+BEGIN
+    IF NOT NEW.Completed THEN
+        NEW.CompletedAt = NULL;
+    ELSIF NEW.CompletedAt IS NULL THEN
+        NEW.CompletedAt = CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todos_track_completedat
+    BEFORE INSERT OR UPDATE ON todos
+    FOR EACH ROW EXECUTE FUNCTION todos_track_completedat();
+
+CREATE INDEX todos_userid_createdat_idx ON todos (UserId, CreatedAt);
+CREATE INDEX todos_userid_completedat_idx ON todos (UserId, CompletedAt) WHERE CompletedAt IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todos_userid_duetime_idx;
+ALTER TABLE todos DROP COLUMN IF EXISTS Recurrence;
+ALTER TABLE users DROP COLUMN IF EXISTS Timezone;
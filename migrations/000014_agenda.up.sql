@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN Timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Recurrence holds the repeat rule of a todo as JSON, or NULL for one-off todos.
+ALTER TABLE todos ADD COLUMN Recurrence JSONB;
+
+CREATE INDEX todos_userid_duetime_idx ON todos (UserId, DueTime);
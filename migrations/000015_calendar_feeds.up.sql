@@ -0,0 +1,13 @@
+-- A calendar feed lets calendar clients read a user's todos without a Bearer token.
+-- Only the hash of the feed token is stored.
+CREATE TABLE calendar_feeds
+(
+    ID SERIAL PRIMARY KEY,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Name VARCHAR(100) NOT NULL DEFAULT '',
+    TokenHash TEXT NOT NULL UNIQUE,
+    CreatedAt TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    LastUsedAt TIMESTAMPTZ
+);
+
+CREATE INDEX calendar_feeds_userid_idx ON calendar_feeds (UserId);
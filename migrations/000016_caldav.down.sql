@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS caldav_log_change ON todos;
+DROP TRIGGER IF EXISTS caldav_log_delete ON todos;
+DROP FUNCTION IF EXISTS caldav_log_change();
+DROP TABLE IF EXISTS caldav_changes;
+DROP TABLE IF EXISTS caldav_objects;
@@ -0,0 +1,52 @@
+-- caldav_objects keeps the resource name and iCalendar UID chosen by a CalDAV
+-- client for the todos it created. Other todos are served as <id>.ics.
+CREATE TABLE caldav_objects
+(
+    TodoId INT PRIMARY KEY REFERENCES todos(ID) ON DELETE CASCADE,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    Name TEXT NOT NULL,
+    Uid TEXT NOT NULL,
+    UNIQUE (UserId, Name)
+);
+
+-- caldav_changes logs every change to the personal todos of a user. The newest ID is
+-- the sync token of the user's CalDAV collection, and the entries after a token
+-- are the changes a client has not seen yet. Removed todos keep their resource name.
+-- Deleting a user deletes their todos and logs that, so UserId is not a foreign key.
+CREATE TABLE caldav_changes
+(
+    ID BIGSERIAL PRIMARY KEY,
+    UserId UUID NOT NULL,
+    TodoId INT NOT NULL,
+    Name TEXT,
+    Deleted BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX caldav_changes_userid_id_idx ON caldav_changes (UserId, ID);
+
+CREATE FUNCTION caldav_log_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.WorkspaceId IS NULL AND OLD.UserId IS NOT NULL
+        AND (TG_OP = 'DELETE' OR NEW.WorkspaceId IS NOT NULL OR NEW.UserId IS DISTINCT FROM OLD.UserId) THEN
+        INSERT INTO caldav_changes (UserId, TodoId, Name, Deleted)
+        VALUES (OLD.UserId, OLD.ID,
+                COALESCE((SELECT Name FROM caldav_objects WHERE TodoId = OLD.ID), OLD.ID || '.ics'), TRUE);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.WorkspaceId IS NULL AND NEW.UserId IS NOT NULL THEN
+        INSERT INTO caldav_changes (UserId, TodoId) VALUES (NEW.UserId, NEW.ID);
+    END IF;
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Deletions are logged before the cascade removes the resource name of the todo.
+CREATE TRIGGER caldav_log_delete
+    BEFORE DELETE ON todos
+    FOR EACH ROW EXECUTE FUNCTION caldav_log_change();
+
+CREATE TRIGGER caldav_log_change
+    AFTER INSERT OR UPDATE ON todos
+    FOR EACH ROW EXECUTE FUNCTION caldav_log_change();
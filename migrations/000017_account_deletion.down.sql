@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS todo_history_actorid_idx;
+DROP INDEX IF EXISTS todo_comments_authorid_idx;
+DROP INDEX IF EXISTS refresh_tokens_userid_idx;
+
+CREATE OR REPLACE FUNCTION todo_history_immutable() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.ActorId IS NULL
+        AND (NEW.ID, NEW.TodoId, NEW.Operation, NEW.Changes, NEW.CreatedAt)
+            IS NOT DISTINCT FROM (OLD.ID, OLD.TodoId, OLD.Operation, OLD.Changes, OLD.CreatedAt) THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'todo_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,20 @@
+-- Deleting an account also erases the history of the todos the user owned. The
+-- transaction doing so sets todo.erasing, the only case in which entries may be
+-- deleted.
+CREATE OR REPLACE FUNCTION todo_history_immutable() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.ActorId IS NULL
+        AND (NEW.ID, NEW.TodoId, NEW.Operation, NEW.Changes, NEW.CreatedAt)
+            IS NOT DISTINCT FROM (OLD.ID, OLD.TodoId, OLD.Operation, OLD.Changes, OLD.CreatedAt) THEN
+        RETURN NEW;
+    END IF;
+    IF TG_OP = 'DELETE' AND current_setting('todo.erasing', true) = 'on' THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'todo_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE INDEX refresh_tokens_userid_idx ON refresh_tokens (UserId);
+CREATE INDEX todo_comments_authorid_idx ON todo_comments (AuthorId);
+CREATE INDEX todo_history_actorid_idx ON todo_history (ActorId);
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS TokensRevokedAt;
+DROP TABLE IF EXISTS revoked_access_tokens;
@@ -0,0 +1,14 @@
+-- Access tokens revoked before they expire, such as on logout. A row is only
+-- needed until the token would have expired anyway.
+CREATE TABLE revoked_access_tokens
+(
+    Jti UUID PRIMARY KEY,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    ExpiresAt TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX revoked_access_tokens_expiresat_idx ON revoked_access_tokens (ExpiresAt);
+
+-- Access tokens issued up to TokensRevokedAt are rejected, for logging out
+-- everywhere without knowing every token.
+ALTER TABLE users ADD COLUMN TokensRevokedAt TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS refresh_tokens_familyid_idx;
+DROP INDEX IF EXISTS refresh_tokens_token_idx;
+
+-- Only the tokens still in use survive going back to one token per login.
+DELETE FROM refresh_tokens WHERE RotatedAt IS NOT NULL;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS RotatedAt,
+    DROP COLUMN IF EXISTS ParentId,
+    DROP COLUMN IF EXISTS FamilyId;
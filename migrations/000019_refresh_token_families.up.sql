@@ -0,0 +1,15 @@
+-- Refresh tokens rotated from one another form a family, one per login. A rotated
+-- token is kept until it expires so that presenting it again is recognised as
+-- reuse, upon which the whole family is revoked.
+ALTER TABLE refresh_tokens
+    ADD COLUMN FamilyId UUID,
+    ADD COLUMN ParentId INT REFERENCES refresh_tokens(ID) ON DELETE SET NULL,
+    ADD COLUMN RotatedAt TIMESTAMPTZ;
+
+-- Every token issued so far starts a family of its own.
+UPDATE refresh_tokens SET FamilyId = md5(random()::text || ID::text)::uuid;
+
+ALTER TABLE refresh_tokens ALTER COLUMN FamilyId SET NOT NULL;
+
+CREATE INDEX refresh_tokens_token_idx ON refresh_tokens (TOKEN);
+CREATE INDEX refresh_tokens_familyid_idx ON refresh_tokens (FamilyId);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS refresh_tokens_expirydate_idx;
+DROP INDEX IF EXISTS refresh_tokens_tokenhash_idx;
+
+-- Digests cannot be turned back into tokens; everyone has to log in again.
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens RENAME COLUMN TokenHash TO TOKEN;
+CREATE INDEX refresh_tokens_token_idx ON refresh_tokens (TOKEN);
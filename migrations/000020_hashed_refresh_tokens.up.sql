@@ -0,0 +1,16 @@
+-- Refresh tokens are stored as the hex-encoded SHA-256 digest of the token, like
+-- calendar feed tokens, so that the table alone grants no session.
+ALTER TABLE refresh_tokens RENAME COLUMN TOKEN TO TokenHash;
+
+UPDATE refresh_tokens SET TokenHash = encode(sha256(convert_to(TokenHash, 'UTF8')), 'hex');
+
+-- Tokens issued within the same second used to be equal. Of each set of equal
+-- tokens the one in use, or else the newest, is kept.
+DELETE FROM refresh_tokens a USING refresh_tokens b
+WHERE a.TokenHash = b.TokenHash AND a.ID <> b.ID
+  AND ((a.RotatedAt IS NOT NULL AND b.RotatedAt IS NULL)
+    OR ((a.RotatedAt IS NULL) = (b.RotatedAt IS NULL) AND a.ID < b.ID));
+
+DROP INDEX IF EXISTS refresh_tokens_token_idx;
+CREATE UNIQUE INDEX refresh_tokens_tokenhash_idx ON refresh_tokens (TokenHash);
+CREATE INDEX refresh_tokens_expirydate_idx ON refresh_tokens (ExpiryDate);
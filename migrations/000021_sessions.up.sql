@@ -0,0 +1,25 @@
+-- A session is one login: the family of refresh tokens rotated from it, with the
+-- device it was made from. Revoking a session revokes its tokens.
+CREATE TABLE sessions
+(
+    ID UUID PRIMARY KEY,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    DeviceName VARCHAR(100) NOT NULL DEFAULT '',
+    UserAgent TEXT NOT NULL DEFAULT '',
+    IP VARCHAR(45) NOT NULL DEFAULT '',
+    CreatedAt TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    LastUsedAt TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX sessions_userid_idx ON sessions (UserId);
+
+DELETE FROM refresh_tokens WHERE UserId IS NULL;
+
+-- Logins from before sessions were recorded become sessions without a device.
+INSERT INTO sessions (ID, UserId, CreatedAt, LastUsedAt)
+SELECT FamilyId, (array_agg(UserId))[1], COALESCE(MIN(CreatedAt), NOW()), COALESCE(MAX(CreatedAt), NOW())
+FROM refresh_tokens
+GROUP BY FamilyId;
+
+ALTER TABLE refresh_tokens
+    ADD CONSTRAINT refresh_tokens_familyid_fkey FOREIGN KEY (FamilyId) REFERENCES sessions(ID) ON DELETE CASCADE;
@@ -0,0 +1,16 @@
+-- Password reset links are mailed to this address; users without one cannot
+-- reset a forgotten password.
+ALTER TABLE users ADD COLUMN Email VARCHAR(254);
+
+-- Single-use password reset tokens. Only the hash of a token is stored.
+CREATE TABLE password_reset_tokens
+(
+    ID SERIAL PRIMARY KEY,
+    UserId UUID NOT NULL REFERENCES users(ID) ON DELETE CASCADE,
+    TokenHash TEXT NOT NULL UNIQUE,
+    ExpiresAt TIMESTAMPTZ NOT NULL,
+    CreatedAt TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX password_reset_tokens_userid_idx ON password_reset_tokens (UserId);
+CREATE INDEX password_reset_tokens_expiresat_idx ON password_reset_tokens (ExpiresAt);
@@ -0,0 +1,2 @@
+DELETE FROM sessions WHERE AppPasswordHash IS NOT NULL;
+ALTER TABLE sessions DROP COLUMN IF EXISTS AppPasswordHash;
@@ -0,0 +1,3 @@
+-- An app password signs a client such as a CalDAV app in with HTTP Basic auth. It is
+-- a session of its own, so revoking sessions or logging out everywhere ends it too.
+ALTER TABLE sessions ADD COLUMN AppPasswordHash VARCHAR(64) UNIQUE;